@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/config"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/harvest"
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+func main() {
+	set := flag.String("set", "", "OAI-PMH set to harvest (default from config, e.g. cs)")
+	format := flag.String("format", "", "metadata format: arXiv or arXivRaw (default from config)")
+	from := flag.String("from", "", "backfill from date (YYYY-MM-DD); leaves the watermark untouched")
+	until := flag.String("until", "", "backfill until date (YYYY-MM-DD); leaves the watermark untouched")
+	maxPages := flag.Int("max-pages", 0, "stop after this many pages (0 for no limit)")
+	oaiURL := flag.String("oai-url", "", "OAI-PMH endpoint (default from config)")
+	status := flag.Bool("status", false, "print the stored harvest progress and exit")
+	flag.Parse()
+
+	// Load configuration
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if *set == "" {
+		*set = cfg.Harvest.Set
+	}
+	if *format == "" {
+		*format = cfg.Harvest.MetadataPrefix
+	}
+	if *oaiURL == "" {
+		*oaiURL = cfg.Arxiv.OAIBaseURL
+	}
+
+	req := &harvest.HarvestRequest{
+		Set:            *set,
+		MetadataPrefix: *format,
+		MaxPages:       *maxPages,
+		OnPage: func(p harvest.Progress) {
+			if p.CompleteListSize > 0 {
+				log.Printf("Page %d: %d records stored, %d deleted (list size %d)", p.Pages, p.Records, p.Deleted, p.CompleteListSize)
+			} else {
+				log.Printf("Page %d: %d records stored, %d deleted", p.Pages, p.Records, p.Deleted)
+			}
+		},
+	}
+	if req.From, err = parseDate(*from); err != nil {
+		log.Fatalf("Invalid -from: %v", err)
+	}
+	if req.Until, err = parseDate(*until); err != nil {
+		log.Fatalf("Invalid -until: %v", err)
+	}
+
+	// Initialize database if configured
+	var db database.DB
+	if cfg.Database.Host != "" && cfg.Database.Host != "localhost" {
+		connector, err := database.New(database.Config{
+			Host:         cfg.Database.Host,
+			Port:         cfg.Database.Port,
+			Username:     cfg.Database.Username,
+			Password:     cfg.Database.Password,
+			Database:     cfg.Database.Database,
+			MaxOpenConns: cfg.Database.MaxOpenConns,
+			MaxIdleConns: cfg.Database.MaxIdleConns,
+			MaxLifetime:  cfg.Database.MaxLifetime,
+		})
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		db = connector.DB()
+		defer connector.Close()
+		log.Printf("Connected to MySQL database at %s:%d", cfg.Database.Host, cfg.Database.Port)
+	}
+
+	f := facade.New(facade.Config{
+		ArxivBaseURL:          cfg.Arxiv.BaseURL,
+		HTTPTimeout:           cfg.Arxiv.Timeout,
+		ArxivMaxRetries:       cfg.Arxiv.MaxRetries,
+		ArxivMinInterval:      cfg.Arxiv.MinInterval,
+		ArxivFailureThreshold: cfg.Arxiv.FailureThreshold,
+		ArxivOpenDuration:     cfg.Arxiv.OpenDuration,
+		CacheTTL:              cfg.Cache.TTL,
+		CacheEnabled:          cfg.Cache.Enabled,
+		OAIBaseURL:            *oaiURL,
+		JWTSecret:             cfg.JWT.Secret,
+		JWTExpiresIn:          cfg.JWT.ExpiresIn,
+		UseInMemoryAuth:       db == nil,
+		DB:                    db,
+	})
+
+	// Stop between pages on Ctrl-C; the resumption token is kept for the next run.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *status {
+		s, err := f.Harvester().Status(ctx, *set, *format)
+		if err != nil {
+			log.Fatalf("Failed to read harvest status: %v", err)
+		}
+		if s == nil {
+			log.Printf("Nothing harvested yet for set %q (%s)", *set, *format)
+			return
+		}
+		log.Printf("Set %q (%s): watermark %s, in progress %v, %d records harvested, updated %s",
+			s.Set, s.MetadataPrefix, s.Watermark.Format("2006-01-02"), s.InProgress, s.RecordsHarvested, s.UpdatedAt.Format(time.RFC3339))
+		return
+	}
+
+	log.Printf("Harvesting set %q (%s) from %s", *set, *format, *oaiURL)
+	result, err := f.Harvester().Harvest(ctx, req)
+	if errors.Is(err, context.Canceled) && result != nil {
+		log.Printf("Harvest interrupted after %d pages (%d records)", result.Pages, result.Records)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Harvest failed: %v", err)
+	}
+
+	log.Printf("Harvest finished: %d pages, %d records, %d deleted, complete %v, resumed %v",
+		result.Pages, result.Records, result.Deleted, result.Complete, result.Resumed)
+	if !result.Watermark.IsZero() {
+		log.Printf("Watermark: %s", result.Watermark.Format("2006-01-02"))
+	}
+}
+
+// parseDate parses an optional YYYY-MM-DD flag value.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
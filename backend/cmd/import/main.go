@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/config"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/paperimport"
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+func main() {
+	file := flag.String("file", "arxiv-metadata-oai-snapshot.json", "snapshot to import (.json or .json.gz, - for stdin)")
+	categories := flag.String("categories", "", "comma-separated categories or archives to keep, e.g. cs,stat.ML (default all)")
+	from := flag.String("from", "", "keep papers last revised on or after this date (YYYY-MM-DD)")
+	until := flag.String("until", "", "keep papers last revised on or before this date (YYYY-MM-DD)")
+	batchSize := flag.Int("batch-size", 500, "papers per batch")
+	limit := flag.Int("limit", 0, "stop after importing this many papers (0 for no limit)")
+	flag.Parse()
+
+	// Load configuration
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	started := time.Now()
+	req := &paperimport.ImportRequest{
+		BatchSize: *batchSize,
+		Limit:     *limit,
+		OnBatch: func(p paperimport.Progress) {
+			log.Printf("%d lines read, %d imported, %d filtered, %d invalid (%s)",
+				p.Lines, p.Imported, p.Filtered, p.Invalid, time.Since(started).Round(time.Second))
+		},
+	}
+	if *categories != "" {
+		req.Categories = strings.Split(*categories, ",")
+	}
+	if req.From, err = parseDate(*from); err != nil {
+		log.Fatalf("Invalid -from: %v", err)
+	}
+	if req.Until, err = parseDate(*until); err != nil {
+		log.Fatalf("Invalid -until: %v", err)
+	}
+	if !req.Until.IsZero() {
+		// Include the whole final day.
+		req.Until = req.Until.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	input, err := openSnapshot(*file)
+	if err != nil {
+		log.Fatalf("Failed to open snapshot: %v", err)
+	}
+	defer input.Close()
+
+	// Initialize database if configured
+	var db database.DB
+	if cfg.Database.Host != "" && cfg.Database.Host != "localhost" {
+		connector, err := database.New(database.Config{
+			Host:         cfg.Database.Host,
+			Port:         cfg.Database.Port,
+			Username:     cfg.Database.Username,
+			Password:     cfg.Database.Password,
+			Database:     cfg.Database.Database,
+			MaxOpenConns: cfg.Database.MaxOpenConns,
+			MaxIdleConns: cfg.Database.MaxIdleConns,
+			MaxLifetime:  cfg.Database.MaxLifetime,
+		})
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		db = connector.DB()
+		defer connector.Close()
+		log.Printf("Connected to MySQL database at %s:%d", cfg.Database.Host, cfg.Database.Port)
+	}
+
+	f := facade.New(facade.Config{
+		ArxivBaseURL:    cfg.Arxiv.BaseURL,
+		HTTPTimeout:     cfg.Arxiv.Timeout,
+		CacheTTL:        cfg.Cache.TTL,
+		CacheEnabled:    cfg.Cache.Enabled,
+		JWTSecret:       cfg.JWT.Secret,
+		JWTExpiresIn:    cfg.JWT.ExpiresIn,
+		UseInMemoryAuth: db == nil,
+		DB:              db,
+	})
+
+	// Stop between batches on Ctrl-C; batches already stored are kept.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Importing %s", *file)
+	progress, err := f.Importer().Import(ctx, input, req)
+	if errors.Is(err, context.Canceled) && progress != nil {
+		log.Printf("Import interrupted: %d lines read, %d imported", progress.Lines, progress.Imported)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	log.Printf("Import finished in %s: %d lines read, %d imported, %d filtered, %d invalid",
+		time.Since(started).Round(time.Second), progress.Lines, progress.Imported, progress.Filtered, progress.Invalid)
+}
+
+// openSnapshot opens the snapshot file, decompressing .gz files.
+func openSnapshot(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+
+	gz, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &gzipFile{Reader: gz, file: file}, nil
+}
+
+// gzipFile closes both the gzip stream and the underlying file.
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// parseDate parses an optional YYYY-MM-DD flag value.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
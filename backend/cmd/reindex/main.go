@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/config"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+func main() {
+	indexPath := flag.String("index", "", "snapshot file to write (default search.index_path from config)")
+	flag.Parse()
+
+	// Load configuration
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	path := *indexPath
+	if path == "" {
+		path = cfg.Search.IndexPath
+	}
+	if path == "" {
+		log.Fatal("No index path: set search.index_path or pass -index")
+	}
+
+	// Initialize database if configured
+	var db database.DB
+	if cfg.Database.Host != "" && cfg.Database.Host != "localhost" {
+		connector, err := database.New(database.Config{
+			Host:         cfg.Database.Host,
+			Port:         cfg.Database.Port,
+			Username:     cfg.Database.Username,
+			Password:     cfg.Database.Password,
+			Database:     cfg.Database.Database,
+			MaxOpenConns: cfg.Database.MaxOpenConns,
+			MaxIdleConns: cfg.Database.MaxIdleConns,
+			MaxLifetime:  cfg.Database.MaxLifetime,
+		})
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		db = connector.DB()
+		defer connector.Close()
+		log.Printf("Connected to MySQL database at %s:%d", cfg.Database.Host, cfg.Database.Port)
+	} else {
+		log.Println("No database configured; the index will be empty")
+	}
+
+	f := facade.New(facade.Config{
+		ArxivBaseURL:      cfg.Arxiv.BaseURL,
+		HTTPTimeout:       cfg.Arxiv.Timeout,
+		CacheTTL:          cfg.Cache.TTL,
+		CacheEnabled:      cfg.Cache.Enabled,
+		SearchBackend:     cfg.Search.Backend,
+		SemanticEnabled:   cfg.Search.SemanticEnabled && cfg.Search.VectorIndexPath != "",
+		EmbedderProvider:  cfg.Search.Embedder.Provider,
+		EmbedderDimension: cfg.Search.Embedder.Dimension,
+		EmbedderURL:       cfg.Search.Embedder.URL,
+		EmbedderAPIKey:    cfg.Search.Embedder.APIKey,
+		EmbedderModel:     cfg.Search.Embedder.Model,
+		JWTSecret:         cfg.JWT.Secret,
+		JWTExpiresIn:      cfg.JWT.ExpiresIn,
+		UseInMemoryAuth:   db == nil,
+		DB:                db,
+	})
+
+	// A cancelled rebuild leaves the previous snapshot in place.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	started := time.Now()
+	n, err := f.RebuildSearchIndex(ctx)
+	if errors.Is(err, context.Canceled) {
+		log.Printf("Rebuild interrupted after %d papers; %s was not changed", n, path)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Rebuild failed: %v", err)
+	}
+
+	// Date the snapshot to the start of the rebuild, so a server loading it
+	// also indexes the papers stored while it ran.
+	if err := f.SaveSearchIndex(path); err != nil {
+		log.Fatalf("Failed to save index: %v", err)
+	}
+	if err := os.Chtimes(path, started, started); err != nil {
+		log.Fatalf("Failed to date index: %v", err)
+	}
+	log.Printf("Indexed %d papers in %s; wrote %s (run with the server stopped: it saves its own snapshot on shutdown)",
+		n, time.Since(started).Round(time.Second), path)
+
+	if !cfg.Search.SemanticEnabled || cfg.Search.VectorIndexPath == "" {
+		return
+	}
+	started = time.Now()
+	n, err = f.RebuildVectorIndex(ctx)
+	if errors.Is(err, context.Canceled) {
+		log.Printf("Embedding interrupted after %d papers; %s was not changed", n, cfg.Search.VectorIndexPath)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Embedding failed: %v", err)
+	}
+	if err := f.SaveVectorIndex(cfg.Search.VectorIndexPath); err != nil {
+		log.Fatalf("Failed to save semantic index: %v", err)
+	}
+	if err := os.Chtimes(cfg.Search.VectorIndexPath, started, started); err != nil {
+		log.Fatalf("Failed to date semantic index: %v", err)
+	}
+	log.Printf("Embedded %d papers in %s; wrote %s",
+		n, time.Since(started).Round(time.Second), cfg.Search.VectorIndexPath)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/facade"
+)
+
+// indexSyncSkew is subtracted from the storage time the index is known to be
+// complete up to, covering clock skew between the server and the database.
+const indexSyncSkew = 10 * time.Minute
+
+// syncedIndex is an index kept in step with the paper repository.
+type syncedIndex struct {
+	name    string // Used in log messages
+	load    func(path string) (time.Time, error)
+	save    func(path string) error
+	size    func() int
+	rebuild func(ctx context.Context) (int, error)
+	sync    func(ctx context.Context, since time.Time) (int, error)
+}
+
+// searchIndex is the local full-text search index.
+func searchIndex(f *facade.Facade) syncedIndex {
+	return syncedIndex{
+		name:    "search index",
+		load:    f.LoadSearchIndex,
+		save:    f.SaveSearchIndex,
+		size:    f.SearchIndexSize,
+		rebuild: f.RebuildSearchIndex,
+		sync:    f.SyncSearchIndex,
+	}
+}
+
+// vectorIndex is the semantic index. Syncing it only embeds papers it does
+// not hold yet, since embedding can be slow or billed by the provider.
+func vectorIndex(f *facade.Facade) syncedIndex {
+	return syncedIndex{
+		name:    "semantic index",
+		load:    f.LoadVectorIndex,
+		save:    f.SaveVectorIndex,
+		size:    f.VectorIndexSize,
+		rebuild: f.RebuildVectorIndex,
+		sync:    f.SyncVectorIndex,
+	}
+}
+
+// indexSync keeps an index in step with the paper repository. Papers stored
+// by the server are indexed as they arrive; papers stored by cmd/harvest and
+// cmd/import are picked up every interval.
+type indexSync struct {
+	index    syncedIndex
+	path     string
+	interval time.Duration
+
+	since  time.Time // Storage time the index is complete up to (zero until it is)
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startIndexSync loads the snapshot at path and tops it up with papers stored
+// since it was written, or rebuilds the index from the repository if there is
+// no snapshot. It then indexes newly stored papers every interval (never if
+// interval is not positive).
+func startIndexSync(index syncedIndex, path string, interval time.Duration) *indexSync {
+	s := &indexSync{index: index, path: path, interval: interval, done: make(chan struct{})}
+
+	written, err := index.load(path)
+	if err == nil {
+		log.Printf("Loaded %s with %d papers from %s", index.name, index.size(), path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to load %s, rebuilding: %v", index.name, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.run(ctx, written, err == nil)
+	return s
+}
+
+// run brings the index up to date, then syncs every interval.
+func (s *indexSync) run(ctx context.Context, written time.Time, loaded bool) {
+	defer close(s.done)
+
+	if loaded {
+		s.since = written
+	}
+	if err := s.sync(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("Failed to update %s: %v", s.index.name, err)
+	}
+
+	if s.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := s.sync(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to sync %s: %v", s.index.name, err)
+		}
+	}
+}
+
+// sync indexes the papers stored since the last pass, or rebuilds the index
+// if it has not caught up with the repository yet.
+func (s *indexSync) sync(ctx context.Context) error {
+	started := time.Now()
+	if s.since.IsZero() {
+		n, err := s.index.rebuild(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Rebuilt %s with %d papers", s.index.name, n)
+	} else {
+		n, err := s.index.sync(ctx, s.since.Add(-indexSyncSkew))
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			log.Printf("Added %d papers stored since %s to %s", n, s.since.Format(time.RFC3339), s.index.name)
+		}
+	}
+	s.since = started
+	return nil
+}
+
+// Stop ends the sync loop, indexes the papers stored since its last pass and
+// saves a snapshot dated to that pass, which the next start tops up from.
+// An index that never caught up with the repository is not saved.
+func (s *indexSync) Stop(ctx context.Context) error {
+	s.cancel()
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if s.since.IsZero() || s.path == "" {
+		return nil
+	}
+
+	if err := s.sync(ctx); err != nil {
+		return err
+	}
+	if err := s.index.save(s.path); err != nil {
+		return err
+	}
+	return os.Chtimes(s.path, s.since, s.since)
+}
@@ -1,18 +1,30 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rrlian/papertok/backend/internal/api/handlers"
 	"github.com/rrlian/papertok/backend/internal/api/middleware"
 	"github.com/rrlian/papertok/backend/internal/config"
 	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/alerts"
+	"github.com/rrlian/papertok/backend/internal/features/prewarm"
 	"github.com/rrlian/papertok/backend/internal/infra/database"
+	"golang.org/x/time/rate"
 )
 
+// prewarmTriggerInterval is the least time between forced prewarm refreshes.
+const prewarmTriggerInterval = time.Minute
+
 func main() {
 	// Load configuration
 	configPath := os.Getenv("CONFIG_PATH")
@@ -52,29 +64,112 @@ func main() {
 		defer connector.Close()
 		useInMemoryAuth = false
 		log.Printf("Connected to MySQL database at %s:%d", cfg.Database.Host, cfg.Database.Port)
+
+		if cfg.Database.AutoMigrate {
+			migrator, err := database.NewMigrator(db, database.Migrations())
+			if err != nil {
+				log.Fatalf("Failed to load migrations: %v", err)
+			}
+			applied, err := migrator.Up(context.Background())
+			if err != nil {
+				log.Fatalf("Failed to apply migrations: %v", err)
+			}
+			for _, m := range applied {
+				log.Printf("Applied migration %03d_%s", m.Version, m.Name)
+			}
+		}
 	} else {
 		log.Println("Using in-memory authentication (no database configured)")
 	}
 
 	// Initialize Facade with all dependencies
 	f := facade.New(facade.Config{
-		ArxivBaseURL:   cfg.Arxiv.BaseURL,
-		HTTPTimeout:    cfg.Arxiv.Timeout,
-		CacheTTL:       cfg.Cache.TTL,
-		CacheEnabled:   cfg.Cache.Enabled,
-		JWTSecret:      cfg.JWT.Secret,
-		JWTExpiresIn:   cfg.JWT.ExpiresIn,
-		UseInMemoryAuth: useInMemoryAuth,
-		DB:             db,
+		ArxivBaseURL:          cfg.Arxiv.BaseURL,
+		HTTPTimeout:           cfg.Arxiv.Timeout,
+		ArxivMaxRetries:       cfg.Arxiv.MaxRetries,
+		ArxivMinInterval:      cfg.Arxiv.MinInterval,
+		ArxivFailureThreshold: cfg.Arxiv.FailureThreshold,
+		ArxivOpenDuration:     cfg.Arxiv.OpenDuration,
+		CacheTTL:              cfg.Cache.TTL,
+		CacheEnabled:          cfg.Cache.Enabled,
+		SearchBackend:         cfg.Search.Backend,
+		SemanticEnabled:       cfg.Search.SemanticEnabled,
+		EmbedderProvider:      cfg.Search.Embedder.Provider,
+		EmbedderDimension:     cfg.Search.Embedder.Dimension,
+		EmbedderURL:           cfg.Search.Embedder.URL,
+		EmbedderAPIKey:        cfg.Search.Embedder.APIKey,
+		EmbedderModel:         cfg.Search.Embedder.Model,
+		PrewarmCategories:     cfg.Prewarm.Categories,
+		PrewarmSortOrders:     cfg.Prewarm.SortOrders,
+		PrewarmInterval:       cfg.Prewarm.Interval,
+		PrewarmJitter:         cfg.Prewarm.Jitter,
+		PrewarmLimit:          cfg.Prewarm.Limit,
+		AlertsInterval:        cfg.Alerts.Interval,
+		AlertsLimit:           cfg.Alerts.Limit,
+		AlertsSearches:        cfg.Alerts.SearchesPerCheck,
+		JWTSecret:             cfg.JWT.Secret,
+		JWTExpiresIn:          cfg.JWT.ExpiresIn,
+		UseInMemoryAuth:       useInMemoryAuth,
+		DB:                    db,
 	})
 
+	// Load the local search index snapshot, or rebuild it from stored papers,
+	// and keep indexing papers stored by the CLIs while the server runs.
+	// Papers stored by the server itself are indexed as they arrive.
+	searchSync := startIndexSync(searchIndex(f), cfg.Search.IndexPath, cfg.Search.SyncInterval)
+
+	// Likewise for the semantic index, whose snapshot saves re-embedding every
+	// paper on start, which can be slow or billed by the provider.
+	var vectorSync *indexSync
+	if cfg.Search.SemanticEnabled {
+		vectorSync = startIndexSync(vectorIndex(f), cfg.Search.VectorIndexPath, cfg.Search.SyncInterval)
+	}
+
+	// Trending counts engagement in memory; restore the likes and bookmarks
+	// of the past week from storage so a restart does not reset the rankings.
+	if n, err := f.Trending().Seed(context.Background()); err != nil {
+		log.Printf("Warning: failed to seed trending papers: %v", err)
+	} else {
+		log.Printf("Seeded trending papers with %d likes and bookmarks", n)
+	}
+
+	// Keep popular feed pages warm so readers rarely wait on arXiv.
+	if cfg.Prewarm.Enabled {
+		if cfg.Cache.Enabled && cfg.Prewarm.Interval >= cfg.Cache.TTL {
+			log.Printf("Warning: prewarm interval %s is not below cache TTL %s; warmed pages may expire", cfg.Prewarm.Interval, cfg.Cache.TTL)
+		}
+		if err := f.Prewarmer().Start(); err != nil {
+			log.Fatalf("Failed to start feed pre-warming: %v", err)
+		}
+		log.Printf("Pre-warming %d feed pages every %s", len(f.Prewarmer().Status()), cfg.Prewarm.Interval)
+	}
+
+	// Re-run saved searches in the background and record new matches as alerts.
+	if cfg.Alerts.Enabled {
+		if err := f.Alerter().Start(); err != nil {
+			log.Fatalf("Failed to start saved search alerts: %v", err)
+		}
+		log.Printf("Checking saved searches for alerts every %s", cfg.Alerts.Interval)
+	}
+
 	// Create handlers
 	paperHandler := handlers.NewPaperHandler(f)
 	healthHandler := handlers.NewHealthHandler()
 	authHandler := handlers.NewAuthHandler(f.UserAuth())
+	prewarmHandler := handlers.NewPrewarmHandler(f.Prewarmer())
+	bookmarkHandler := handlers.NewBookmarkHandler(f)
+	likeHandler := handlers.NewLikeHandler(f)
+	historyHandler := handlers.NewHistoryHandler(f)
+	collectionHandler := handlers.NewCollectionHandler(f)
+	noteHandler := handlers.NewNoteHandler(f)
+	searchHistoryHandler := handlers.NewSearchHistoryHandler(f)
+	alertHandler := handlers.NewAlertHandler(f)
+	followHandler := handlers.NewFollowHandler(f)
 
 	// Create router
 	router := gin.Default()
+	// Match on the escaped path so old-style paper IDs (hep-th%2F9901001) stay one segment.
+	router.UseRawPath = true
 
 	// Add middleware
 	router.Use(middleware.Logger())
@@ -101,9 +196,73 @@ func main() {
 	// Paper routes (public for now, can be protected later)
 	api := router.Group("/api/v1")
 	{
-		api.GET("/papers", paperHandler.GetPapers)
-		api.GET("/papers/search", paperHandler.SearchPapers)
-		api.GET("/papers/:id", paperHandler.GetPaperByID)
+		api.GET("/papers", middleware.OptionalAuthMiddleware(f.AuthCore()), paperHandler.GetPapers)
+		api.GET("/papers/search", middleware.OptionalAuthMiddleware(f.AuthCore()), paperHandler.SearchPapers)
+		api.GET("/papers/trending", paperHandler.GetTrendingPapers)
+		api.POST("/papers/batch", paperHandler.BatchGetPapers)
+		api.GET("/papers/:id", middleware.OptionalAuthMiddleware(f.AuthCore()), paperHandler.GetPaperByID)
+		api.GET("/papers/:id/versions", paperHandler.GetPaperVersions)
+		api.GET("/papers/:id/related", paperHandler.GetRelatedPapers)
+		api.POST("/papers/:id/share", middleware.OptionalAuthMiddleware(f.AuthCore()), paperHandler.SharePaper)
+		api.GET("/papers/:id/like", middleware.AuthMiddleware(f.AuthCore()), likeHandler.GetLikeStatus)
+		api.POST("/papers/:id/like", middleware.AuthMiddleware(f.AuthCore()), likeHandler.LikePaper)
+		api.DELETE("/papers/:id/like", middleware.AuthMiddleware(f.AuthCore()), likeHandler.UnlikePaper)
+
+		api.GET("/collections", collectionHandler.ListPublicCollections)
+		api.GET("/collections/:slug", collectionHandler.GetSharedCollection)
+
+		api.GET("/prewarm/jobs", prewarmHandler.GetJobs)
+
+		// Forced refreshes spend the arXiv budget shared by every reader and
+		// can trip its circuit breaker, so they are opt-in and paced globally.
+		if cfg.Prewarm.TriggerEnabled {
+			api.POST("/prewarm/jobs/trigger",
+				middleware.AuthMiddleware(f.AuthCore()),
+				middleware.SimpleRateLimitMiddleware(rate.Every(prewarmTriggerInterval), 1),
+				prewarmHandler.TriggerJobs)
+		}
+	}
+
+	// Signed-in user's data
+	me := router.Group("/api/v1/me")
+	me.Use(middleware.AuthMiddleware(f.AuthCore()))
+	{
+		me.GET("/bookmarks", bookmarkHandler.ListBookmarks)
+		me.POST("/bookmarks", bookmarkHandler.AddBookmark)
+		me.GET("/bookmarks/status", bookmarkHandler.GetBookmarkStatus)
+		me.DELETE("/bookmarks/:id", bookmarkHandler.RemoveBookmark)
+		me.GET("/history", historyHandler.ListHistory)
+		me.POST("/history", historyHandler.RecordView)
+		me.DELETE("/history", historyHandler.ClearHistory)
+		me.DELETE("/history/:id", historyHandler.DeleteHistoryEntry)
+		me.GET("/collections", collectionHandler.ListCollections)
+		me.POST("/collections", collectionHandler.CreateCollection)
+		me.GET("/collections/:id", collectionHandler.GetCollection)
+		me.PATCH("/collections/:id", collectionHandler.UpdateCollection)
+		me.DELETE("/collections/:id", collectionHandler.DeleteCollection)
+		me.POST("/collections/:id/items", collectionHandler.AddCollectionItem)
+		me.PUT("/collections/:id/items", collectionHandler.ReorderCollection)
+		me.PATCH("/collections/:id/items/:paperId", collectionHandler.UpdateCollectionItem)
+		me.DELETE("/collections/:id/items/:paperId", collectionHandler.RemoveCollectionItem)
+		me.GET("/papers/:id/notes", noteHandler.ListNotes)
+		me.POST("/papers/:id/notes", noteHandler.CreateNote)
+		me.GET("/papers/:id/notes/:noteId", noteHandler.GetNote)
+		me.PATCH("/papers/:id/notes/:noteId", noteHandler.UpdateNote)
+		me.DELETE("/papers/:id/notes/:noteId", noteHandler.DeleteNote)
+		me.GET("/notes", noteHandler.SearchNotes)
+		me.GET("/search-history", searchHistoryHandler.ListSearchHistory)
+		me.DELETE("/search-history", searchHistoryHandler.ClearSearchHistory)
+		me.DELETE("/search-history/:id", searchHistoryHandler.DeleteSearchHistoryEntry)
+		me.GET("/saved-searches", searchHistoryHandler.ListSavedSearches)
+		me.POST("/saved-searches", searchHistoryHandler.SaveSearch)
+		me.PATCH("/saved-searches/:id", searchHistoryHandler.RenameSavedSearch)
+		me.DELETE("/saved-searches/:id", searchHistoryHandler.DeleteSavedSearch)
+		me.GET("/saved-searches/:id/results", searchHistoryHandler.RunSavedSearch)
+		me.GET("/alerts", alertHandler.ListAlerts)
+		me.POST("/alerts/read", alertHandler.MarkAlertsRead)
+		me.GET("/following", followHandler.ListFollowing)
+		me.POST("/following", followHandler.Follow)
+		me.DELETE("/following", followHandler.Unfollow)
 	}
 
 	// Start server
@@ -117,9 +276,93 @@ func main() {
 	log.Printf("  GET  /api/v1/auth/profile (requires auth)")
 	log.Printf("  GET  /api/v1/papers")
 	log.Printf("  GET  /api/v1/papers/search")
+	log.Printf("  GET  /api/v1/papers/trending")
+	log.Printf("  POST /api/v1/papers/batch")
 	log.Printf("  GET  /api/v1/papers/:id")
+	log.Printf("  GET  /api/v1/papers/:id/versions")
+	log.Printf("  GET  /api/v1/papers/:id/related")
+	log.Printf("  POST /api/v1/papers/:id/share")
+	log.Printf("  GET  /api/v1/papers/:id/like (requires auth)")
+	log.Printf("  POST /api/v1/papers/:id/like (requires auth)")
+	log.Printf("  DELETE /api/v1/papers/:id/like (requires auth)")
+	log.Printf("  GET  /api/v1/collections")
+	log.Printf("  GET  /api/v1/collections/:slug")
+	log.Printf("  GET  /api/v1/prewarm/jobs")
+	if cfg.Prewarm.TriggerEnabled {
+		log.Printf("  POST /api/v1/prewarm/jobs/trigger (requires auth)")
+	}
+	log.Printf("  GET  /api/v1/me/bookmarks (requires auth)")
+	log.Printf("  POST /api/v1/me/bookmarks (requires auth)")
+	log.Printf("  GET  /api/v1/me/bookmarks/status (requires auth)")
+	log.Printf("  DELETE /api/v1/me/bookmarks/:id (requires auth)")
+	log.Printf("  GET  /api/v1/me/history (requires auth)")
+	log.Printf("  POST /api/v1/me/history (requires auth)")
+	log.Printf("  DELETE /api/v1/me/history (requires auth)")
+	log.Printf("  DELETE /api/v1/me/history/:id (requires auth)")
+	log.Printf("  GET  /api/v1/me/collections (requires auth)")
+	log.Printf("  POST /api/v1/me/collections (requires auth)")
+	log.Printf("  GET  /api/v1/me/collections/:id (requires auth)")
+	log.Printf("  PATCH /api/v1/me/collections/:id (requires auth)")
+	log.Printf("  DELETE /api/v1/me/collections/:id (requires auth)")
+	log.Printf("  POST /api/v1/me/collections/:id/items (requires auth)")
+	log.Printf("  PUT  /api/v1/me/collections/:id/items (requires auth)")
+	log.Printf("  PATCH /api/v1/me/collections/:id/items/:paperId (requires auth)")
+	log.Printf("  DELETE /api/v1/me/collections/:id/items/:paperId (requires auth)")
+	log.Printf("  GET  /api/v1/me/papers/:id/notes (requires auth)")
+	log.Printf("  POST /api/v1/me/papers/:id/notes (requires auth)")
+	log.Printf("  GET  /api/v1/me/papers/:id/notes/:noteId (requires auth)")
+	log.Printf("  PATCH /api/v1/me/papers/:id/notes/:noteId (requires auth)")
+	log.Printf("  DELETE /api/v1/me/papers/:id/notes/:noteId (requires auth)")
+	log.Printf("  GET  /api/v1/me/notes (requires auth)")
+	log.Printf("  GET  /api/v1/me/search-history (requires auth)")
+	log.Printf("  DELETE /api/v1/me/search-history (requires auth)")
+	log.Printf("  DELETE /api/v1/me/search-history/:id (requires auth)")
+	log.Printf("  GET  /api/v1/me/saved-searches (requires auth)")
+	log.Printf("  POST /api/v1/me/saved-searches (requires auth)")
+	log.Printf("  PATCH /api/v1/me/saved-searches/:id (requires auth)")
+	log.Printf("  DELETE /api/v1/me/saved-searches/:id (requires auth)")
+	log.Printf("  GET  /api/v1/me/saved-searches/:id/results (requires auth)")
+	log.Printf("  GET  /api/v1/me/alerts (requires auth)")
+	log.Printf("  POST /api/v1/me/alerts/read (requires auth)")
+	log.Printf("  GET  /api/v1/me/following (requires auth)")
+	log.Printf("  POST /api/v1/me/following (requires auth)")
+	log.Printf("  DELETE /api/v1/me/following (requires auth)")
+
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: router,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	if err := router.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// Wait for a shutdown signal, then let running refreshes and requests finish.
+	<-ctx.Done()
+	log.Println("Shutting down server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := f.Prewarmer().Stop(shutdownCtx); err != nil && !prewarm.IsNotRunning(err) {
+		log.Printf("Failed to stop feed pre-warming: %v", err)
+	}
+	if err := f.Alerter().Stop(shutdownCtx); err != nil && !alerts.IsNotRunning(err) {
+		log.Printf("Failed to stop saved search alerts: %v", err)
+	}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server: %v", err)
+	}
+	if err := searchSync.Stop(shutdownCtx); err != nil {
+		log.Printf("Failed to save search index: %v", err)
+	}
+	if vectorSync != nil {
+		if err := vectorSync.Stop(shutdownCtx); err != nil {
+			log.Printf("Failed to save semantic index: %v", err)
+		}
 	}
 }
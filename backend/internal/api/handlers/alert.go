@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/alerts"
+)
+
+// AlertHandler handles the signed-in user's saved search alerts.
+// All routes sit under /api/v1/me (AuthMiddleware).
+type AlertHandler struct {
+	facade *facade.Facade
+}
+
+// NewAlertHandler creates a new alert handler.
+func NewAlertHandler(f *facade.Facade) *AlertHandler {
+	return &AlertHandler{
+		facade: f,
+	}
+}
+
+// MarkAlertsReadRequest is the body of POST /api/v1/me/alerts/read.
+// Either ids or all must be given.
+type MarkAlertsReadRequest struct {
+	IDs []int64 `json:"ids"`
+	All bool    `json:"all"`
+}
+
+// AlertsResponse represents the response for a page of alerts.
+type AlertsResponse struct {
+	Alerts   []*facade.Alert `json:"alerts"`
+	Total    int             `json:"total"`
+	Unread   int             `json:"unread"`
+	Offset   int             `json:"offset"`
+	PageSize int             `json:"pageSize"`
+}
+
+// MarkAlertsReadResponse represents the response for marking alerts as read.
+type MarkAlertsReadResponse struct {
+	Marked int `json:"marked"`
+}
+
+// ListAlerts handles GET /api/v1/me/alerts.
+// unread=true lists only unread alerts; savedSearchId lists one saved search's.
+func (h *AlertHandler) ListAlerts(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(alerts.DefaultLimit)))
+	if err != nil || limit <= 0 || limit > alerts.MaxLimit {
+		limit = alerts.DefaultLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	unreadOnly, _ := strconv.ParseBool(c.Query("unread"))
+
+	var savedSearchID int64
+	if value := c.Query("savedSearchId"); value != "" {
+		savedSearchID, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || savedSearchID <= 0 {
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid saved search ID", err)
+			return
+		}
+	}
+
+	list, err := h.facade.ListAlerts(c.Request.Context(), &alerts.ListRequest{
+		UserID:        userID,
+		SavedSearchID: savedSearchID,
+		UnreadOnly:    unreadOnly,
+		Offset:        offset,
+		Limit:         limit,
+	})
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to list alerts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: AlertsResponse{
+			Alerts:   list.Alerts,
+			Total:    list.Total,
+			Unread:   list.Unread,
+			Offset:   offset,
+			PageSize: limit,
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// MarkAlertsRead handles POST /api/v1/me/alerts/read.
+func (h *AlertHandler) MarkAlertsRead(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req MarkAlertsReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	var marked int
+	var err error
+	if req.All {
+		marked, err = h.facade.MarkAllAlertsRead(c.Request.Context(), userID)
+	} else {
+		marked, err = h.facade.MarkAlertsRead(c.Request.Context(), userID, req.IDs)
+	}
+	if err != nil {
+		if alerts.IsInvalidIDs(err) {
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", fmt.Sprintf("Give 1 to %d alert IDs, or all", alerts.MaxLimit), err)
+			return
+		}
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to mark alerts read", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      MarkAlertsReadResponse{Marked: marked},
+		Timestamp: time.Now().Unix(),
+	})
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/api/middleware"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/bookmarks"
+)
+
+// BookmarkHandler handles the signed-in user's bookmarks.
+// All routes require AuthMiddleware.
+type BookmarkHandler struct {
+	facade *facade.Facade
+}
+
+// NewBookmarkHandler creates a new bookmark handler.
+func NewBookmarkHandler(f *facade.Facade) *BookmarkHandler {
+	return &BookmarkHandler{
+		facade: f,
+	}
+}
+
+// AddBookmarkRequest is the body of POST /api/v1/me/bookmarks.
+type AddBookmarkRequest struct {
+	PaperID string `json:"paperId" binding:"required"`
+}
+
+// BookmarksResponse represents the response for a page of bookmarks.
+type BookmarksResponse struct {
+	Bookmarks []*facade.Bookmark `json:"bookmarks"`
+	Total     int                `json:"total"`
+	Offset    int                `json:"offset"`
+	PageSize  int                `json:"pageSize"`
+}
+
+// ListBookmarks handles GET /api/v1/me/bookmarks.
+func (h *BookmarkHandler) ListBookmarks(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(bookmarks.DefaultLimit)))
+	if err != nil || limit <= 0 || limit > bookmarks.MaxLimit {
+		limit = bookmarks.DefaultLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	list, err := h.facade.ListBookmarks(c.Request.Context(), &bookmarks.ListRequest{
+		UserID: userID,
+		Offset: offset,
+		Limit:  limit,
+		Sort:   c.DefaultQuery("sort", bookmarks.SortNewest),
+	})
+	if err != nil {
+		if bookmarks.IsInvalidSort(err) {
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid sort, expected newest or oldest", err)
+			return
+		}
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to list bookmarks", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: BookmarksResponse{
+			Bookmarks: list.Bookmarks,
+			Total:     list.Total,
+			Offset:    offset,
+			PageSize:  limit,
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// AddBookmark handles POST /api/v1/me/bookmarks.
+// It responds 201 for a new bookmark and 200 if the paper was already bookmarked.
+func (h *BookmarkHandler) AddBookmark(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req AddBookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	bookmark, created, err := h.facade.AddBookmark(c.Request.Context(), userID, req.PaperID)
+	if err != nil {
+		switch {
+		case bookmarks.IsInvalidID(err):
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid arXiv paper ID", err)
+		case bookmarks.IsPaperNotFound(err):
+			errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Paper not found", err)
+		default:
+			// Bookmarking a paper that is not stored yet fetches it from arXiv first.
+			if !upstreamUnavailable(c, err) {
+				errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to add bookmark", err)
+			}
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, APIResponse{
+		Success:   true,
+		Data:      bookmark,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// RemoveBookmark handles DELETE /api/v1/me/bookmarks/:id.
+func (h *BookmarkHandler) RemoveBookmark(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.facade.RemoveBookmark(c.Request.Context(), userID, c.Param("id")); err != nil {
+		switch {
+		case bookmarks.IsInvalidID(err):
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid arXiv paper ID", err)
+		case bookmarks.IsNotBookmarked(err):
+			errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Paper is not bookmarked", err)
+		default:
+			errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to remove bookmark", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// GetBookmarkStatus handles GET /api/v1/me/bookmarks/status?ids=....
+// The response maps each requested ID to whether it is bookmarked.
+func (h *BookmarkHandler) GetBookmarkStatus(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ids := parseListParam(c, "ids")
+	if len(ids) == 0 {
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "At least one paper ID is required", nil)
+		return
+	}
+
+	status, err := h.facade.BookmarkStatus(c.Request.Context(), userID, ids)
+	if err != nil {
+		switch {
+		case bookmarks.IsInvalidID(err):
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid arXiv paper ID", err)
+		case bookmarks.IsTooManyIDs(err):
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Too many paper IDs", err)
+		default:
+			errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to check bookmarks", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      status,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// requireUserID returns the signed-in user set by AuthMiddleware,
+// writing a 401 response if there is none.
+func requireUserID(c *gin.Context) (int64, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "UNAUTHORIZED",
+				Message: "User not authenticated",
+			},
+			Timestamp: time.Now().Unix(),
+		})
+	}
+	return userID, ok
+}
+
+// errorResponse writes an error response, with err's message as details if
+// err is not nil.
+func errorResponse(c *gin.Context, status int, code, message string, err error) {
+	info := &ErrorInfo{
+		Code:    code,
+		Message: message,
+	}
+	if err != nil {
+		info.Details = err.Error()
+	}
+	c.JSON(status, APIResponse{
+		Success:   false,
+		Error:     info,
+		Timestamp: time.Now().Unix(),
+	})
+}
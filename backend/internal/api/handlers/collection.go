@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/collections"
+)
+
+// CollectionHandler handles paper collections: the signed-in user's own
+// collections under /api/v1/me (AuthMiddleware) and the public, read-only
+// views under /api/v1/collections.
+type CollectionHandler struct {
+	facade *facade.Facade
+}
+
+// NewCollectionHandler creates a new collection handler.
+func NewCollectionHandler(f *facade.Facade) *CollectionHandler {
+	return &CollectionHandler{
+		facade: f,
+	}
+}
+
+// CreateCollectionRequest is the body of POST /api/v1/me/collections.
+type CreateCollectionRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+}
+
+// UpdateCollectionRequest is the body of PATCH /api/v1/me/collections/:id.
+// Omitted fields are left unchanged.
+type UpdateCollectionRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Visibility  *string `json:"visibility"`
+}
+
+// AddCollectionItemRequest is the body of POST /api/v1/me/collections/:id/items.
+type AddCollectionItemRequest struct {
+	PaperID string `json:"paperId" binding:"required"`
+	Note    string `json:"note"`
+}
+
+// UpdateCollectionItemRequest is the body of PATCH /api/v1/me/collections/:id/items/:paperId.
+type UpdateCollectionItemRequest struct {
+	Note string `json:"note"`
+}
+
+// ReorderCollectionRequest is the body of PUT /api/v1/me/collections/:id/items.
+type ReorderCollectionRequest struct {
+	PaperIDs []string `json:"paperIds" binding:"required"`
+}
+
+// CollectionsResponse represents the response for a list of collections.
+type CollectionsResponse struct {
+	Collections []*facade.Collection `json:"collections"`
+	Total       int                  `json:"total"`
+	Offset      int                  `json:"offset,omitempty"`
+	PageSize    int                  `json:"pageSize,omitempty"`
+}
+
+// ListCollections handles GET /api/v1/me/collections.
+func (h *CollectionHandler) ListCollections(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.facade.ListCollections(c.Request.Context(), userID)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to list collections", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      CollectionsResponse{Collections: list, Total: len(list)},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// CreateCollection handles POST /api/v1/me/collections.
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	collection, err := h.facade.CreateCollection(c.Request.Context(), userID, &collections.CreateRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to create collection")
+		return
+	}
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success:   true,
+		Data:      collection,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// GetCollection handles GET /api/v1/me/collections/:id.
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.collectionID(c)
+	if !ok {
+		return
+	}
+
+	collection, err := h.facade.GetCollection(c.Request.Context(), userID, id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      collection,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// UpdateCollection handles PATCH /api/v1/me/collections/:id.
+func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.collectionID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	collection, err := h.facade.UpdateCollection(c.Request.Context(), userID, id, &collections.UpdateRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to update collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      collection,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// DeleteCollection handles DELETE /api/v1/me/collections/:id.
+func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.collectionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.facade.DeleteCollection(c.Request.Context(), userID, id); err != nil {
+		h.handleError(c, err, "Failed to delete collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// AddCollectionItem handles POST /api/v1/me/collections/:id/items.
+// It responds 201 for a new item and 200 if the paper was already in the collection.
+func (h *CollectionHandler) AddCollectionItem(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.collectionID(c)
+	if !ok {
+		return
+	}
+
+	var req AddCollectionItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	item, added, err := h.facade.AddCollectionItem(c.Request.Context(), userID, id, req.PaperID, req.Note)
+	if err != nil {
+		h.handleError(c, err, "Failed to add paper to collection")
+		return
+	}
+
+	status := http.StatusOK
+	if added {
+		status = http.StatusCreated
+	}
+	c.JSON(status, APIResponse{
+		Success:   true,
+		Data:      item,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// ReorderCollection handles PUT /api/v1/me/collections/:id/items.
+// The body lists every paper in the collection in the new order.
+func (h *CollectionHandler) ReorderCollection(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.collectionID(c)
+	if !ok {
+		return
+	}
+
+	var req ReorderCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	collection, err := h.facade.ReorderCollection(c.Request.Context(), userID, id, req.PaperIDs)
+	if err != nil {
+		h.handleError(c, err, "Failed to reorder collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      collection,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// UpdateCollectionItem handles PATCH /api/v1/me/collections/:id/items/:paperId.
+func (h *CollectionHandler) UpdateCollectionItem(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.collectionID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateCollectionItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	item, err := h.facade.UpdateCollectionItem(c.Request.Context(), userID, id, c.Param("paperId"), req.Note)
+	if err != nil {
+		h.handleError(c, err, "Failed to update collection item")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      item,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// RemoveCollectionItem handles DELETE /api/v1/me/collections/:id/items/:paperId.
+func (h *CollectionHandler) RemoveCollectionItem(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.collectionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.facade.RemoveCollectionItem(c.Request.Context(), userID, id, c.Param("paperId")); err != nil {
+		h.handleError(c, err, "Failed to remove paper from collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// ListPublicCollections handles GET /api/v1/collections.
+func (h *CollectionHandler) ListPublicCollections(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(collections.DefaultLimit)))
+	if err != nil || limit <= 0 || limit > collections.MaxLimit {
+		limit = collections.DefaultLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	list, err := h.facade.ListPublicCollections(c.Request.Context(), offset, limit)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to list collections", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: CollectionsResponse{
+			Collections: list.Collections,
+			Total:       list.Total,
+			Offset:      offset,
+			PageSize:    limit,
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// GetSharedCollection handles GET /api/v1/collections/:slug.
+// Unlisted and public collections are readable by anyone with the slug.
+func (h *CollectionHandler) GetSharedCollection(c *gin.Context) {
+	collection, err := h.facade.GetSharedCollection(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		h.handleError(c, err, "Failed to get collection")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      collection,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// collectionID parses the :id path parameter, writing a 400 response if it is invalid.
+func (h *CollectionHandler) collectionID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid collection ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+// handleError maps collection errors to HTTP responses.
+func (h *CollectionHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case collections.IsCollectionNotFound(err):
+		errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Collection not found", err)
+	case collections.IsNotInCollection(err):
+		errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Paper is not in the collection", err)
+	case collections.IsPaperNotFound(err):
+		errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Paper not found", err)
+	case collections.IsInvalidID(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid arXiv paper ID", err)
+	case collections.IsInvalidCollection(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid collection name or description", err)
+	case collections.IsInvalidVisibility(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid visibility, expected private, unlisted or public", err)
+	case collections.IsInvalidNote(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Note is too long", err)
+	case collections.IsInvalidOrder(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Order must list every paper in the collection once", err)
+	case collections.IsTooManyCollections(err):
+		errorResponse(c, http.StatusConflict, "LIMIT_EXCEEDED", "Too many collections", err)
+	case collections.IsCollectionFull(err):
+		errorResponse(c, http.StatusConflict, "LIMIT_EXCEEDED", "Collection is full", err)
+	default:
+		// Adding an unstored paper to a collection fetches it from arXiv.
+		if !upstreamUnavailable(c, err) {
+			errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", message, err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/follows"
+)
+
+// FollowHandler handles the authors and categories the signed-in user follows.
+// All routes sit under /api/v1/me (AuthMiddleware).
+type FollowHandler struct {
+	facade *facade.Facade
+}
+
+// NewFollowHandler creates a new follow handler.
+func NewFollowHandler(f *facade.Facade) *FollowHandler {
+	return &FollowHandler{
+		facade: f,
+	}
+}
+
+// FollowRequest is the body of POST /api/v1/me/following.
+type FollowRequest struct {
+	Kind string `json:"kind" binding:"required"` // "author" or "category"
+	Name string `json:"name" binding:"required"`
+}
+
+// ListFollowing handles GET /api/v1/me/following.
+func (h *FollowHandler) ListFollowing(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	following, err := h.facade.ListFollowing(c.Request.Context(), userID)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to list follows", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      following,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// Follow handles POST /api/v1/me/following.
+// It responds 201 for a new follow and 200 if it was already followed.
+func (h *FollowHandler) Follow(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req FollowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	follow, created, err := h.facade.Follow(c.Request.Context(), userID, req.Kind, req.Name)
+	if err != nil {
+		h.handleError(c, err, "Failed to follow")
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, APIResponse{
+		Success:   true,
+		Data:      follow,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// Unfollow handles DELETE /api/v1/me/following?kind=...&name=....
+func (h *FollowHandler) Unfollow(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.facade.Unfollow(c.Request.Context(), userID, c.Query("kind"), c.Query("name")); err != nil {
+		h.handleError(c, err, "Failed to unfollow")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// handleError maps follows errors to responses.
+func (h *FollowHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case follows.IsInvalidKind(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid kind, expected author or category", err)
+	case follows.IsInvalidName(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid author name or category", err)
+	case follows.IsNotFollowing(err):
+		errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Not following", err)
+	case follows.IsTooManyFollows(err):
+		errorResponse(c, http.StatusConflict, "LIMIT_EXCEEDED", fmt.Sprintf("At most %d authors and categories can be followed", follows.MaxFollows), err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", message, err)
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/history"
+)
+
+// HistoryHandler handles the signed-in user's reading history.
+// All routes require AuthMiddleware.
+type HistoryHandler struct {
+	facade *facade.Facade
+}
+
+// NewHistoryHandler creates a new history handler.
+func NewHistoryHandler(f *facade.Facade) *HistoryHandler {
+	return &HistoryHandler{
+		facade: f,
+	}
+}
+
+// RecordViewRequest is the body of POST /api/v1/me/history.
+// Clients send it when a paper is opened and again as reading progresses;
+// DwellSeconds is the time spent since the previous report.
+type RecordViewRequest struct {
+	PaperID      string  `json:"paperId" binding:"required"`
+	DwellSeconds int     `json:"dwellSeconds"`
+	ScrollDepth  float64 `json:"scrollDepth"`
+	PDFPage      int     `json:"pdfPage"`
+}
+
+// HistoryResponse represents the response for a page of reading history.
+type HistoryResponse struct {
+	Groups   []*facade.HistoryGroup `json:"groups"`
+	Total    int                    `json:"total"`
+	Offset   int                    `json:"offset"`
+	PageSize int                    `json:"pageSize"`
+}
+
+// ClearHistoryResponse represents the response for clearing the reading history.
+type ClearHistoryResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// RecordView handles POST /api/v1/me/history.
+func (h *HistoryHandler) RecordView(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req RecordViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	entry, err := h.facade.RecordView(c.Request.Context(), &history.View{
+		UserID:      userID,
+		PaperID:     req.PaperID,
+		Dwell:       time.Duration(req.DwellSeconds) * time.Second,
+		ScrollDepth: req.ScrollDepth,
+		PDFPage:     req.PDFPage,
+	})
+	if err != nil {
+		switch {
+		case history.IsInvalidID(err):
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid arXiv paper ID", err)
+		case history.IsInvalidView(err):
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid reading progress", err)
+		case history.IsPaperNotFound(err):
+			errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Paper not found", err)
+		default:
+			// The first view of an unstored paper fetches its metadata from arXiv.
+			if !upstreamUnavailable(c, err) {
+				errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to record view", err)
+			}
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      entry,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// ListHistory handles GET /api/v1/me/history.
+// Entries are grouped by the day they were last viewed in the time zone
+// given by tz (an IANA name such as Asia/Shanghai, default UTC).
+func (h *HistoryHandler) ListHistory(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(history.DefaultLimit)))
+	if err != nil || limit <= 0 || limit > history.MaxLimit {
+		limit = history.DefaultLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", "UTC"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid time zone", err)
+		return
+	}
+
+	list, err := h.facade.ListHistory(c.Request.Context(), &history.ListRequest{
+		UserID:   userID,
+		Offset:   offset,
+		Limit:    limit,
+		Location: loc,
+	})
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to list history", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: HistoryResponse{
+			Groups:   list.Groups,
+			Total:    list.Total,
+			Offset:   offset,
+			PageSize: limit,
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// DeleteHistoryEntry handles DELETE /api/v1/me/history/:id.
+func (h *HistoryHandler) DeleteHistoryEntry(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.facade.DeleteHistoryEntry(c.Request.Context(), userID, c.Param("id")); err != nil {
+		switch {
+		case history.IsInvalidID(err):
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid arXiv paper ID", err)
+		case history.IsNotInHistory(err):
+			errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Paper is not in history", err)
+		default:
+			errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to delete history entry", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// ClearHistory handles DELETE /api/v1/me/history.
+func (h *HistoryHandler) ClearHistory(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.facade.ClearHistory(c.Request.Context(), userID)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to clear history", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      ClearHistoryResponse{Deleted: deleted},
+		Timestamp: time.Now().Unix(),
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/likes"
+)
+
+// LikeHandler handles likes of papers.
+// All routes require AuthMiddleware.
+type LikeHandler struct {
+	facade *facade.Facade
+}
+
+// NewLikeHandler creates a new like handler.
+func NewLikeHandler(f *facade.Facade) *LikeHandler {
+	return &LikeHandler{
+		facade: f,
+	}
+}
+
+// GetLikeStatus handles GET /api/v1/papers/:id/like.
+func (h *LikeHandler) GetLikeStatus(c *gin.Context) {
+	h.respond(c, h.facade.GetLikeStatus, "Failed to get like status")
+}
+
+// LikePaper handles POST /api/v1/papers/:id/like.
+// Liking a paper that is already liked succeeds without changing the count.
+func (h *LikeHandler) LikePaper(c *gin.Context) {
+	h.respond(c, h.facade.LikePaper, "Failed to like paper")
+}
+
+// UnlikePaper handles DELETE /api/v1/papers/:id/like.
+// Unliking a paper that is not liked succeeds without changing the count.
+func (h *LikeHandler) UnlikePaper(c *gin.Context) {
+	h.respond(c, h.facade.UnlikePaper, "Failed to unlike paper")
+}
+
+// respond runs a like operation for the signed-in user and the paper in the
+// path, and writes the resulting like state.
+func (h *LikeHandler) respond(c *gin.Context, op func(ctx context.Context, userID int64, paperID string) (*likes.Status, error), message string) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	status, err := op(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		switch {
+		case likes.IsInvalidID(err):
+			errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid arXiv paper ID", err)
+		case likes.IsPaperNotFound(err):
+			errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Paper not found", err)
+		default:
+			// Liking an unstored paper looks it up on arXiv, so an outage there fails the like.
+			if !upstreamUnavailable(c, err) {
+				errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", message, err)
+			}
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      status,
+		Timestamp: time.Now().Unix(),
+	})
+}
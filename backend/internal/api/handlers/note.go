@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/notes"
+)
+
+// NoteHandler handles the signed-in user's notes and highlights on papers.
+// All routes sit under /api/v1/me (AuthMiddleware).
+type NoteHandler struct {
+	facade *facade.Facade
+}
+
+// NewNoteHandler creates a new note handler.
+func NewNoteHandler(f *facade.Facade) *NoteHandler {
+	return &NoteHandler{
+		facade: f,
+	}
+}
+
+// CreateNoteRequest is the body of POST /api/v1/me/papers/:id/notes.
+type CreateNoteRequest struct {
+	Body   string        `json:"body"`
+	Anchor *notes.Anchor `json:"anchor"`
+}
+
+// UpdateNoteRequest is the body of PATCH /api/v1/me/papers/:id/notes/:noteId.
+// Omitted fields are left unchanged; an anchor of null removes the anchor.
+type UpdateNoteRequest struct {
+	Body   *string         `json:"body"`
+	Anchor json.RawMessage `json:"anchor"`
+}
+
+// NotesResponse represents the response for a list of notes.
+type NotesResponse struct {
+	Notes    []*facade.Note `json:"notes"`
+	Total    int            `json:"total"`
+	Offset   int            `json:"offset,omitempty"`
+	PageSize int            `json:"pageSize,omitempty"`
+}
+
+// ListNotes handles GET /api/v1/me/papers/:id/notes.
+func (h *NoteHandler) ListNotes(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.facade.ListNotes(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "Failed to list notes")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      NotesResponse{Notes: list, Total: len(list)},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// CreateNote handles POST /api/v1/me/papers/:id/notes.
+func (h *NoteHandler) CreateNote(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	note, err := h.facade.CreateNote(c.Request.Context(), userID, c.Param("id"), &notes.NoteRequest{
+		Body:   req.Body,
+		Anchor: req.Anchor,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to create note")
+		return
+	}
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success:   true,
+		Data:      note,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// GetNote handles GET /api/v1/me/papers/:id/notes/:noteId.
+func (h *NoteHandler) GetNote(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.noteID(c)
+	if !ok {
+		return
+	}
+
+	note, err := h.facade.GetNote(c.Request.Context(), userID, c.Param("id"), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get note")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      note,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// UpdateNote handles PATCH /api/v1/me/papers/:id/notes/:noteId.
+func (h *NoteHandler) UpdateNote(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.noteID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+	update := &notes.UpdateRequest{Body: req.Body}
+	switch {
+	case len(req.Anchor) == 0:
+		// Anchor omitted: leave it unchanged.
+	case bytes.Equal(req.Anchor, []byte("null")):
+		update.ClearAnchor = true
+	default:
+		update.Anchor = &notes.Anchor{}
+		if err := json.Unmarshal(req.Anchor, update.Anchor); err != nil {
+			errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+			return
+		}
+	}
+
+	note, err := h.facade.UpdateNote(c.Request.Context(), userID, c.Param("id"), id, update)
+	if err != nil {
+		h.handleError(c, err, "Failed to update note")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      note,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// DeleteNote handles DELETE /api/v1/me/papers/:id/notes/:noteId.
+func (h *NoteHandler) DeleteNote(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.noteID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.facade.DeleteNote(c.Request.Context(), userID, c.Param("id"), id); err != nil {
+		h.handleError(c, err, "Failed to delete note")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// SearchNotes handles GET /api/v1/me/notes.
+func (h *NoteHandler) SearchNotes(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(notes.DefaultLimit)))
+	if err != nil || limit <= 0 || limit > notes.MaxLimit {
+		limit = notes.DefaultLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	list, err := h.facade.SearchNotes(c.Request.Context(), &notes.SearchRequest{
+		UserID: userID,
+		Query:  c.Query("q"),
+		Offset: offset,
+		Limit:  limit,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to search notes")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: NotesResponse{
+			Notes:    list.Notes,
+			Total:    list.Total,
+			Offset:   offset,
+			PageSize: limit,
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// noteID parses the :noteId path parameter, writing a 400 response if it is invalid.
+func (h *NoteHandler) noteID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("noteId"), 10, 64)
+	if err != nil || id <= 0 {
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid note ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+// handleError maps note errors to HTTP responses.
+func (h *NoteHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case notes.IsNoteNotFound(err):
+		errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Note not found", err)
+	case notes.IsPaperNotFound(err):
+		errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Paper not found", err)
+	case notes.IsInvalidID(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid arXiv paper ID", err)
+	case notes.IsInvalidNote(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Note body is empty or too long", err)
+	case notes.IsInvalidAnchor(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid note anchor", err)
+	case notes.IsInvalidQuery(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", fmt.Sprintf("Query must have 1 to %d terms", notes.MaxQueryTerms), err)
+	case notes.IsTooManyNotes(err):
+		errorResponse(c, http.StatusConflict, "LIMIT_EXCEEDED", "Too many notes on this paper", err)
+	default:
+		// A note on an unstored paper needs the paper from arXiv first.
+		if !upstreamUnavailable(c, err) {
+			errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", message, err)
+		}
+	}
+}
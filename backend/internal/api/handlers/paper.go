@@ -1,12 +1,21 @@
 package handlers
 
 import (
+	"log"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/api/middleware"
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
 	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/paperfeed"
+	"github.com/rrlian/papertok/backend/internal/features/papersearch"
+	"github.com/rrlian/papertok/backend/internal/features/relatedpapers"
+	"github.com/rrlian/papertok/backend/internal/features/trending"
 )
 
 // APIResponse represents a standard API response.
@@ -26,10 +35,25 @@ type ErrorInfo struct {
 
 // PapersResponse represents the response for papers list.
 type PapersResponse struct {
-	Papers   interface{} `json:"papers"`
-	Total    int         `json:"total"`
-	Page     int         `json:"page"`
-	PageSize int         `json:"pageSize"`
+	Papers     interface{} `json:"papers"`
+	Total      int         `json:"total"`
+	Page       int         `json:"page,omitempty"` // Omitted when the page number is not known (cursor or unaligned offset)
+	PageSize   int         `json:"pageSize"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+
+	RankingScope string `json:"rankingScope,omitempty"` // "page" when papers were reordered within the page only
+}
+
+// TrendingResponse represents the response for trending papers.
+type TrendingResponse struct {
+	Window   string                  `json:"window"`
+	Category string                  `json:"category,omitempty"`
+	Papers   []*facade.TrendingPaper `json:"papers"`
+}
+
+// BatchPapersRequest is the body of POST /api/v1/papers/batch.
+type BatchPapersRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,max=100"`
 }
 
 // PaperHandler handles paper-related requests.
@@ -45,12 +69,25 @@ func NewPaperHandler(f *facade.Facade) *PaperHandler {
 }
 
 // GetPapers handles GET /api/v1/papers.
+// Pages are addressed by offset, or by the nextCursor of the previous page,
+// which stays stable when new papers arrive at the top of the feed.
+// mode=following builds the feed from the signed-in user's followed authors
+// and categories, ranking=personal reorders each page for them, and
+// exclude_seen=true drops papers in their reading history.
 func (h *PaperHandler) GetPapers(c *gin.Context) {
 	// Parse query parameters
-	category := c.DefaultQuery("category", "cs.AI")
+	categories := parseListParam(c, "category")
+	if len(categories) == 0 {
+		categories = []string{"cs.AI"}
+	}
 	limitStr := c.DefaultQuery("limit", "20")
 	offsetStr := c.DefaultQuery("offset", "0")
 	sortBy := c.DefaultQuery("sort_by", "lastUpdatedDate")
+	cursor := c.Query("cursor")
+	mode := c.DefaultQuery("mode", paperfeed.ModeCategories)
+	ranking := c.DefaultQuery("ranking", paperfeed.RankingChronological)
+	excludeSeen, _ := strconv.ParseBool(c.Query("exclude_seen"))
+	userID, _ := middleware.GetUserID(c) // Set by OptionalAuthMiddleware for signed-in users
 
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit <= 0 || limit > 100 {
@@ -63,43 +100,122 @@ func (h *PaperHandler) GetPapers(c *gin.Context) {
 	}
 
 	// Fetch papers via facade
-	papers, err := h.facade.GetPaperFeed(c.Request.Context(), category, limit, offset, sortBy)
+	list, err := h.facade.GetPaperFeed(c.Request.Context(), &paperfeed.FetchRequest{
+		Categories:  categories,
+		Limit:       limit,
+		Offset:      offset,
+		SortBy:      sortBy,
+		Cursor:      cursor,
+		Mode:        mode,
+		Ranking:     ranking,
+		UserID:      userID,
+		ExcludeSeen: excludeSeen,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to fetch papers from arXiv",
-				Details: err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-		})
+		if paperfeed.IsUserRequired(err) {
+			c.JSON(http.StatusUnauthorized, APIResponse{
+				Success: false,
+				Error: &ErrorInfo{
+					Code:    "UNAUTHORIZED",
+					Message: "The following feed, personal ranking and exclude_seen require authentication",
+				},
+				Timestamp: time.Now().Unix(),
+			})
+			return
+		}
+		if paperfeed.IsInvalidMode(err) {
+			h.invalidParams(c, "Invalid mode", err)
+			return
+		}
+		if paperfeed.IsInvalidRanking(err) {
+			h.invalidParams(c, "Invalid ranking", err)
+			return
+		}
+		if paperfeed.IsInvalidCursor(err) {
+			h.invalidParams(c, "Invalid cursor", err)
+			return
+		}
+		if paperfeed.IsInvalidCategory(err) {
+			h.invalidParams(c, "Invalid category", err)
+			return
+		}
+		h.handleError(c, err, "Failed to fetch papers from arXiv")
 		return
 	}
 
+	// The page number is only meaningful for offsets on a page boundary.
+	page := 0
+	if cursor == "" && offset%limit == 0 {
+		page = offset/limit + 1
+	}
+
 	// Return response
 	c.JSON(http.StatusOK, APIResponse{
 		Success: true,
 		Data: PapersResponse{
-			Papers:   papers,
-			Total:    len(papers),
-			Page:     offset/limit + 1,
-			PageSize: limit,
+			Papers:       list.Papers,
+			Total:        list.Total,
+			Page:         page,
+			PageSize:     limit,
+			NextCursor:   list.NextCursor,
+			RankingScope: list.RankingScope,
 		},
 		Timestamp: time.Now().Unix(),
 	})
 }
 
+// searchFieldParams maps fielded query parameters to search fields.
+var searchFieldParams = []struct {
+	param string
+	field string
+}{
+	{"title", papersearch.FieldTitle},
+	{"author", papersearch.FieldAuthor},
+	{"abstract", papersearch.FieldAbstract},
+	{"category", papersearch.FieldCategory},
+	{"id", papersearch.FieldID},
+}
+
 // SearchPapers handles GET /api/v1/papers/search.
+// Besides the free-text "query" parameter it accepts fielded terms
+// (title, author, abstract, category, id; each may be repeated), their
+// exclude_* counterparts, match=all|any, submitted_from/submitted_to
+// dates (YYYY-MM-DD or RFC 3339), backend=arxiv|local to choose between
+// the arXiv API and the local index of stored papers, and
+// mode=keyword|semantic|hybrid to match by keyword, by meaning, or both.
 func (h *PaperHandler) SearchPapers(c *gin.Context) {
 	// Parse query parameters
-	query := c.Query("query")
-	if query == "" {
+	req := &papersearch.SearchRequest{
+		Query:    c.Query("query"),
+		MatchAny: c.Query("match") == "any",
+		Backend:  c.Query("backend"),
+		Mode:     c.Query("mode"),
+	}
+	for _, fp := range searchFieldParams {
+		for _, value := range c.QueryArray(fp.param) {
+			req.Include = append(req.Include, papersearch.Term{Field: fp.field, Value: value})
+		}
+		for _, value := range c.QueryArray("exclude_" + fp.param) {
+			req.Exclude = append(req.Exclude, papersearch.Term{Field: fp.field, Value: value})
+		}
+	}
+
+	var err error
+	if req.SubmittedFrom, err = parseDateParam(c.Query("submitted_from"), false); err != nil {
+		h.invalidParams(c, "Query parameter 'submitted_from' must be YYYY-MM-DD or RFC 3339", err)
+		return
+	}
+	if req.SubmittedTo, err = parseDateParam(c.Query("submitted_to"), true); err != nil {
+		h.invalidParams(c, "Query parameter 'submitted_to' must be YYYY-MM-DD or RFC 3339", err)
+		return
+	}
+
+	if req.Query == "" && len(req.Include) == 0 && req.SubmittedFrom.IsZero() && req.SubmittedTo.IsZero() {
 		c.JSON(http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error: &ErrorInfo{
 				Code:    "INVALID_PARAMS",
-				Message: "Query parameter 'query' is required",
+				Message: "Query parameter 'query' or a fielded search parameter is required",
 			},
 			Timestamp: time.Now().Unix(),
 		})
@@ -111,19 +227,28 @@ func (h *PaperHandler) SearchPapers(c *gin.Context) {
 	if err != nil || limit <= 0 || limit > 100 {
 		limit = 20
 	}
+	req.Limit = limit
 
 	// Search papers via facade
-	papers, err := h.facade.SearchPapers(c.Request.Context(), query, limit)
+	userID, _ := middleware.GetUserID(c) // Set by OptionalAuthMiddleware for signed-in users
+	list, err := h.facade.SearchPapers(c.Request.Context(), userID, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error: &ErrorInfo{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to search papers",
-				Details: err.Error(),
-			},
-			Timestamp: time.Now().Unix(),
-		})
+		if papersearch.IsInvalidQuery(err) {
+			h.invalidParams(c, "Invalid search parameters", err)
+			return
+		}
+		if papersearch.IsSemanticDisabled(err) {
+			c.JSON(http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error: &ErrorInfo{
+					Code:    "SEMANTIC_DISABLED",
+					Message: "Semantic search is not enabled on this server",
+				},
+				Timestamp: time.Now().Unix(),
+			})
+			return
+		}
+		h.handleError(c, err, "Failed to search papers")
 		return
 	}
 
@@ -131,8 +256,8 @@ func (h *PaperHandler) SearchPapers(c *gin.Context) {
 	c.JSON(http.StatusOK, APIResponse{
 		Success: true,
 		Data: PapersResponse{
-			Papers:   papers,
-			Total:    len(papers),
+			Papers:   list.Papers,
+			Total:    list.Total,
 			Page:     1,
 			PageSize: limit,
 		},
@@ -141,6 +266,8 @@ func (h *PaperHandler) SearchPapers(c *gin.Context) {
 }
 
 // GetPaperByID handles GET /api/v1/papers/:id.
+// The ID may carry a version suffix (e.g., 2301.12345v2); old-style IDs
+// such as hep-th/9901001 must have their slash escaped as %2F.
 func (h *PaperHandler) GetPaperByID(c *gin.Context) {
 	paperID := c.Param("id")
 	if paperID == "" {
@@ -158,19 +285,85 @@ func (h *PaperHandler) GetPaperByID(c *gin.Context) {
 	// Get paper by ID via facade
 	paper, err := h.facade.GetPaperByID(c.Request.Context(), paperID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, APIResponse{
+		if papersearch.IsInvalidID(err) {
+			h.invalidParams(c, "Invalid arXiv paper ID", err)
+			return
+		}
+		h.handleError(c, err, "Failed to fetch paper")
+		return
+	}
+
+	if paper == nil {
+		c.JSON(http.StatusNotFound, APIResponse{
 			Success: false,
 			Error: &ErrorInfo{
-				Code:    "INTERNAL_ERROR",
-				Message: "Failed to fetch paper",
-				Details: err.Error(),
+				Code:    "NOT_FOUND",
+				Message: "Paper not found",
 			},
 			Timestamp: time.Now().Unix(),
 		})
 		return
 	}
 
-	if paper == nil {
+	// Count the view towards trending; the ID has already been validated.
+	err = h.facade.RecordEngagement(c.Request.Context(), trending.Signal{
+		PaperID: paper.ID,
+		Kind:    trending.SignalView,
+		Actor:   engagementActor(c),
+	})
+	if err != nil {
+		log.Printf("Failed to record view of %s for trending: %v", paper.ID, err)
+	}
+
+	// Return response
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      paper,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// BatchGetPapers handles POST /api/v1/papers/batch.
+// It returns the requested papers in request order plus the IDs that were not found.
+func (h *PaperHandler) BatchGetPapers(c *gin.Context) {
+	var req BatchPapersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.invalidParams(c, "Body must contain 'ids' with 1 to 100 paper IDs", err)
+		return
+	}
+
+	batch, err := h.facade.GetPapersByIDs(c.Request.Context(), req.IDs)
+	if err != nil {
+		h.handleError(c, err, "Failed to fetch papers")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      batch,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// GetPaperVersions handles GET /api/v1/papers/:id/versions.
+func (h *PaperHandler) GetPaperVersions(c *gin.Context) {
+	paperID := c.Param("id")
+	if paperID == "" {
+		h.invalidParams(c, "Paper ID is required", nil)
+		return
+	}
+
+	versions, err := h.facade.GetPaperVersions(c.Request.Context(), paperID)
+	if err != nil {
+		if papersearch.IsInvalidID(err) {
+			h.invalidParams(c, "Invalid arXiv paper ID", err)
+			return
+		}
+		h.handleError(c, err, "Failed to fetch paper versions")
+		return
+	}
+
+	if len(versions) == 0 {
 		c.JSON(http.StatusNotFound, APIResponse{
 			Success: false,
 			Error: &ErrorInfo{
@@ -182,10 +375,222 @@ func (h *PaperHandler) GetPaperByID(c *gin.Context) {
 		return
 	}
 
-	// Return response
 	c.JSON(http.StatusOK, APIResponse{
 		Success:   true,
-		Data:      paper,
+		Data:      versions,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// GetRelatedPapers handles GET /api/v1/papers/:id/related.
+func (h *PaperHandler) GetRelatedPapers(c *gin.Context) {
+	paperID := c.Param("id")
+	if paperID == "" {
+		h.invalidParams(c, "Paper ID is required", nil)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(relatedpapers.DefaultLimit)))
+	if err != nil || limit <= 0 || limit > relatedpapers.MaxLimit {
+		limit = relatedpapers.DefaultLimit
+	}
+
+	related, err := h.facade.GetRelatedPapers(c.Request.Context(), paperID, limit)
+	if err != nil {
+		if relatedpapers.IsInvalidID(err) || papersearch.IsInvalidID(err) {
+			h.invalidParams(c, "Invalid arXiv paper ID", err)
+			return
+		}
+		h.handleError(c, err, "Failed to find related papers")
+		return
+	}
+
+	if related == nil {
+		c.JSON(http.StatusNotFound, APIResponse{
+			Success: false,
+			Error: &ErrorInfo{
+				Code:    "NOT_FOUND",
+				Message: "Paper not found",
+			},
+			Timestamp: time.Now().Unix(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      related,
 		Timestamp: time.Now().Unix(),
 	})
 }
+
+// GetTrendingPapers handles GET /api/v1/papers/trending.
+// Papers are ranked by views, likes, bookmarks and shares in the window,
+// recent engagement counting more.
+func (h *PaperHandler) GetTrendingPapers(c *gin.Context) {
+	window := c.DefaultQuery("window", trending.Window24h)
+	category := strings.TrimSpace(c.Query("category"))
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(trending.DefaultLimit)))
+	if err != nil || limit <= 0 || limit > trending.MaxLimit {
+		limit = trending.DefaultLimit
+	}
+
+	papers, err := h.facade.GetTrendingPapers(c.Request.Context(), &trending.Request{
+		Window:   window,
+		Category: category,
+		Limit:    limit,
+	})
+	if err != nil {
+		if trending.IsInvalidWindow(err) {
+			h.invalidParams(c, "Invalid window, expected 24h or 7d", err)
+			return
+		}
+		h.handleError(c, err, "Failed to fetch trending papers")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: TrendingResponse{
+			Window:   window,
+			Category: category,
+			Papers:   papers,
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// SharePaper handles POST /api/v1/papers/:id/share.
+// It records that the paper was shared, for trending. Repeated shares by
+// one user or client are counted once per half hour.
+func (h *PaperHandler) SharePaper(c *gin.Context) {
+	err := h.facade.RecordEngagement(c.Request.Context(), trending.Signal{
+		PaperID: c.Param("id"),
+		Kind:    trending.SignalShare,
+		Actor:   engagementActor(c),
+	})
+	if err != nil {
+		if trending.IsInvalidSignal(err) {
+			h.invalidParams(c, "Invalid arXiv paper ID", err)
+			return
+		}
+		if trending.IsPaperNotFound(err) {
+			c.JSON(http.StatusNotFound, APIResponse{
+				Success: false,
+				Error: &ErrorInfo{
+					Code:    "NOT_FOUND",
+					Message: "Paper not found",
+				},
+				Timestamp: time.Now().Unix(),
+			})
+			return
+		}
+		h.handleError(c, err, "Failed to record share")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// engagementActor identifies who engaged with a paper: the signed-in user
+// (set by OptionalAuthMiddleware) or else the client IP.
+func engagementActor(c *gin.Context) string {
+	if userID, ok := middleware.GetUserID(c); ok {
+		return "user:" + strconv.FormatInt(userID, 10)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// handleError writes the error response for a failed paper operation.
+// arXiv being unavailable (circuit open or retries exhausted) maps to 503
+// with a Retry-After header; anything else is a 500.
+func (h *PaperHandler) handleError(c *gin.Context, err error, message string) {
+	if upstreamUnavailable(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, APIResponse{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "INTERNAL_ERROR",
+			Message: message,
+			Details: err.Error(),
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// upstreamUnavailable writes a 503 UPSTREAM_UNAVAILABLE response with a
+// Retry-After header if err means arXiv is unavailable, and reports whether it did.
+func upstreamUnavailable(c *gin.Context, err error) bool {
+	unavailable, ok := arxiv.AsUnavailable(err)
+	if !ok {
+		return false
+	}
+	if unavailable.RetryAfter > 0 {
+		seconds := int(math.Ceil(unavailable.RetryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(seconds))
+	}
+	c.JSON(http.StatusServiceUnavailable, APIResponse{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    "UPSTREAM_UNAVAILABLE",
+			Message: "arXiv is temporarily unavailable, please retry later",
+			Details: err.Error(),
+		},
+		Timestamp: time.Now().Unix(),
+	})
+	return true
+}
+
+// invalidParams writes a 400 INVALID_PARAMS response.
+func (h *PaperHandler) invalidParams(c *gin.Context, message string, err error) {
+	info := &ErrorInfo{
+		Code:    "INVALID_PARAMS",
+		Message: message,
+	}
+	if err != nil {
+		info.Details = err.Error()
+	}
+	c.JSON(http.StatusBadRequest, APIResponse{
+		Success:   false,
+		Error:     info,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// parseListParam collects a repeatable, comma-separated query parameter.
+func parseListParam(c *gin.Context, name string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(name) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// parseDateParam parses a YYYY-MM-DD or RFC 3339 query value.
+// Date-only values are expanded to the end of the day when endOfDay is set.
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Minute)
+	}
+	return t, nil
+}
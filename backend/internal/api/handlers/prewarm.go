@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/features/prewarm"
+)
+
+// PrewarmHandler handles feed pre-warming requests.
+type PrewarmHandler struct {
+	prewarmSvc prewarm.Service
+}
+
+// NewPrewarmHandler creates a new prewarm handler.
+func NewPrewarmHandler(prewarmSvc prewarm.Service) *PrewarmHandler {
+	return &PrewarmHandler{
+		prewarmSvc: prewarmSvc,
+	}
+}
+
+// TriggerPrewarmRequest is the optional body of POST /api/v1/prewarm/jobs/trigger.
+// Empty fields match every job.
+type TriggerPrewarmRequest struct {
+	Category string `json:"category"`
+	SortBy   string `json:"sortBy"`
+}
+
+// GetJobs handles GET /api/v1/prewarm/jobs.
+func (h *PrewarmHandler) GetJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      h.prewarmSvc.Status(),
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// TriggerJobs handles POST /api/v1/prewarm/jobs/trigger.
+func (h *PrewarmHandler) TriggerJobs(c *gin.Context) {
+	var req TriggerPrewarmRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+			return
+		}
+	}
+
+	triggered, err := h.prewarmSvc.Trigger(req.Category, req.SortBy)
+	if err != nil {
+		switch {
+		case prewarm.IsUnknownJob(err):
+			errorResponse(c, http.StatusNotFound, "JOB_NOT_FOUND", "No prewarm job matches the request", err)
+		case prewarm.IsNotRunning(err):
+			errorResponse(c, http.StatusConflict, "PREWARM_DISABLED", "Feed pre-warming is not running", err)
+		default:
+			errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to trigger prewarm jobs", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{
+		Success:   true,
+		Data:      gin.H{"triggered": triggered},
+		Timestamp: time.Now().Unix(),
+	})
+}
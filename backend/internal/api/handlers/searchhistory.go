@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rrlian/papertok/backend/internal/facade"
+	"github.com/rrlian/papertok/backend/internal/features/papersearch"
+	"github.com/rrlian/papertok/backend/internal/features/searchhistory"
+)
+
+// SearchHistoryHandler handles the signed-in user's search history and
+// saved searches. All routes sit under /api/v1/me (AuthMiddleware).
+type SearchHistoryHandler struct {
+	facade *facade.Facade
+}
+
+// NewSearchHistoryHandler creates a new search history handler.
+func NewSearchHistoryHandler(f *facade.Facade) *SearchHistoryHandler {
+	return &SearchHistoryHandler{
+		facade: f,
+	}
+}
+
+// SaveSearchRequest is the body of POST /api/v1/me/saved-searches.
+type SaveSearchRequest struct {
+	HistoryID int64  `json:"historyId" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+}
+
+// RenameSavedSearchRequest is the body of PATCH /api/v1/me/saved-searches/:id.
+type RenameSavedSearchRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// SearchHistoryResponse represents the response for a page of search history.
+type SearchHistoryResponse struct {
+	Entries  []*searchhistory.Entry `json:"entries"`
+	Total    int                    `json:"total"`
+	Offset   int                    `json:"offset"`
+	PageSize int                    `json:"pageSize"`
+}
+
+// ClearSearchHistoryResponse represents the response for clearing the search history.
+type ClearSearchHistoryResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// SavedSearchesResponse represents the response for a list of saved searches.
+type SavedSearchesResponse struct {
+	SavedSearches []*searchhistory.SavedSearch `json:"savedSearches"`
+	Total         int                          `json:"total"`
+}
+
+// SavedSearchResultsResponse represents the response for running a saved search.
+type SavedSearchResultsResponse struct {
+	SavedSearch *searchhistory.SavedSearch `json:"savedSearch"`
+	Papers      []*facade.Paper            `json:"papers"`
+	Total       int                        `json:"total"`
+	PageSize    int                        `json:"pageSize"`
+}
+
+// ListSearchHistory handles GET /api/v1/me/search-history.
+func (h *SearchHistoryHandler) ListSearchHistory(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(searchhistory.DefaultLimit)))
+	if err != nil || limit <= 0 || limit > searchhistory.MaxLimit {
+		limit = searchhistory.DefaultLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	list, err := h.facade.ListSearchHistory(c.Request.Context(), userID, offset, limit)
+	if err != nil {
+		h.handleError(c, err, "Failed to list search history")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: SearchHistoryResponse{
+			Entries:  list.Entries,
+			Total:    list.Total,
+			Offset:   offset,
+			PageSize: limit,
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// DeleteSearchHistoryEntry handles DELETE /api/v1/me/search-history/:id.
+func (h *SearchHistoryHandler) DeleteSearchHistoryEntry(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(c, "Invalid search history entry ID")
+	if !ok {
+		return
+	}
+
+	if err := h.facade.DeleteSearchHistoryEntry(c.Request.Context(), userID, id); err != nil {
+		h.handleError(c, err, "Failed to delete search history entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// ClearSearchHistory handles DELETE /api/v1/me/search-history.
+func (h *SearchHistoryHandler) ClearSearchHistory(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.facade.ClearSearchHistory(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to clear search history")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      ClearSearchHistoryResponse{Deleted: deleted},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// ListSavedSearches handles GET /api/v1/me/saved-searches.
+func (h *SearchHistoryHandler) ListSavedSearches(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.facade.ListSavedSearches(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list saved searches")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      SavedSearchesResponse{SavedSearches: list, Total: len(list)},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// SaveSearch handles POST /api/v1/me/saved-searches.
+func (h *SearchHistoryHandler) SaveSearch(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req SaveSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	saved, err := h.facade.SaveSearch(c.Request.Context(), userID, req.HistoryID, req.Name)
+	if err != nil {
+		h.handleError(c, err, "Failed to save search")
+		return
+	}
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success:   true,
+		Data:      saved,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// RenameSavedSearch handles PATCH /api/v1/me/saved-searches/:id.
+func (h *SearchHistoryHandler) RenameSavedSearch(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(c, "Invalid saved search ID")
+	if !ok {
+		return
+	}
+
+	var req RenameSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid request format", err)
+		return
+	}
+
+	saved, err := h.facade.RenameSavedSearch(c.Request.Context(), userID, id, req.Name)
+	if err != nil {
+		h.handleError(c, err, "Failed to rename saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Data:      saved,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// DeleteSavedSearch handles DELETE /api/v1/me/saved-searches/:id.
+func (h *SearchHistoryHandler) DeleteSavedSearch(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(c, "Invalid saved search ID")
+	if !ok {
+		return
+	}
+
+	if err := h.facade.DeleteSavedSearch(c.Request.Context(), userID, id); err != nil {
+		h.handleError(c, err, "Failed to delete saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success:   true,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// RunSavedSearch handles GET /api/v1/me/saved-searches/:id/results.
+func (h *SearchHistoryHandler) RunSavedSearch(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(c, "Invalid saved search ID")
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	saved, list, err := h.facade.RunSavedSearch(c.Request.Context(), userID, id, limit)
+	if err != nil {
+		h.handleError(c, err, "Failed to run saved search")
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data: SavedSearchResultsResponse{
+			SavedSearch: saved,
+			Papers:      list.Papers,
+			Total:       list.Total,
+			PageSize:    limit,
+		},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// pathID parses the :id path parameter, writing a 400 response if it is invalid.
+func (h *SearchHistoryHandler) pathID(c *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", message, err)
+		return 0, false
+	}
+	return id, true
+}
+
+// handleError maps search history and saved search errors to HTTP responses.
+func (h *SearchHistoryHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case searchhistory.IsEntryNotFound(err):
+		errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Search history entry not found", err)
+	case searchhistory.IsSavedNotFound(err):
+		errorResponse(c, http.StatusNotFound, "NOT_FOUND", "Saved search not found", err)
+	case searchhistory.IsInvalidName(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", fmt.Sprintf("Name must be 1 to %d characters", searchhistory.MaxNameLength), err)
+	case searchhistory.IsNameTaken(err):
+		errorResponse(c, http.StatusConflict, "NAME_TAKEN", "A saved search with this name already exists", err)
+	case searchhistory.IsTooManySaved(err):
+		errorResponse(c, http.StatusConflict, "LIMIT_EXCEEDED", fmt.Sprintf("At most %d saved searches", searchhistory.MaxSaved), err)
+	case papersearch.IsInvalidQuery(err):
+		errorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "Invalid search parameters", err)
+	case papersearch.IsSemanticDisabled(err):
+		errorResponse(c, http.StatusBadRequest, "SEMANTIC_DISABLED", "Semantic search is not enabled on this server", err)
+	default:
+		// Saved searches run against arXiv by default, which may be unavailable.
+		if !upstreamUnavailable(c, err) {
+			errorResponse(c, http.StatusInternalServerError, "INTERNAL_ERROR", message, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -173,8 +174,16 @@ func GetUserID(c *gin.Context) (int64, bool) {
 		return 0, false
 	}
 
-	id, ok := userID.(int64)
-	return id, ok
+	// The ID is stored as a string (see AuthMiddleware), but accept int64 as well.
+	switch id := userID.(type) {
+	case int64:
+		return id, true
+	case string:
+		n, err := strconv.ParseInt(id, 10, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
 }
 
 // GetUsername retrieves the username from the Gin context.
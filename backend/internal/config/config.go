@@ -19,6 +19,10 @@ type Config struct {
 	Cache     CacheConfig     `mapstructure:"cache"`
 	CORS      CORSConfig      `mapstructure:"cors"`
 	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	Harvest   HarvestConfig   `mapstructure:"harvest"`
+	Search    SearchConfig    `mapstructure:"search"`
+	Prewarm   PrewarmConfig   `mapstructure:"prewarm"`
+	Alerts    AlertsConfig    `mapstructure:"alerts"`
 }
 
 // ServerConfig represents server configuration
@@ -38,6 +42,7 @@ type DatabaseConfig struct {
 	MaxOpenConns int           `mapstructure:"max_open_conns"`
 	MaxIdleConns int           `mapstructure:"max_idle_conns"`
 	MaxLifetime  time.Duration `mapstructure:"max_lifetime"`
+	AutoMigrate  bool          `mapstructure:"auto_migrate"` // apply pending migrations at startup
 }
 
 // JWTConfig represents JWT configuration
@@ -48,9 +53,13 @@ type JWTConfig struct {
 
 // ArxivConfig represents arXiv API configuration
 type ArxivConfig struct {
-	BaseURL    string        `mapstructure:"base_url"`
-	Timeout    time.Duration `mapstructure:"timeout"`
-	MaxRetries int           `mapstructure:"max_retries"`
+	BaseURL          string        `mapstructure:"base_url"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+	MaxRetries       int           `mapstructure:"max_retries"`
+	MinInterval      time.Duration `mapstructure:"min_interval"`      // minimum spacing between requests
+	FailureThreshold int           `mapstructure:"failure_threshold"` // consecutive failures before the circuit opens
+	OpenDuration     time.Duration `mapstructure:"open_duration"`     // how long the circuit stays open
+	OAIBaseURL       string        `mapstructure:"oai_base_url"`      // OAI-PMH endpoint used for harvesting
 }
 
 // CacheConfig represents cache configuration
@@ -72,6 +81,51 @@ type RateLimitConfig struct {
 	PerIP    bool `mapstructure:"per_ip"`   // limit per IP or global
 }
 
+// HarvestConfig represents OAI-PMH harvesting configuration
+type HarvestConfig struct {
+	Set            string `mapstructure:"set"`             // OAI-PMH set, e.g. "cs"
+	MetadataPrefix string `mapstructure:"metadata_prefix"` // arXiv or arXivRaw
+}
+
+// SearchConfig represents paper search configuration
+type SearchConfig struct {
+	Backend         string         `mapstructure:"backend"`           // default backend: arxiv or local
+	IndexPath       string         `mapstructure:"index_path"`        // snapshot of the local search index
+	SyncInterval    time.Duration  `mapstructure:"sync_interval"`     // how often to index papers stored by other processes (0 disables)
+	SemanticEnabled bool           `mapstructure:"semantic_enabled"`  // embed stored papers for mode=semantic|hybrid
+	VectorIndexPath string         `mapstructure:"vector_index_path"` // snapshot of the semantic index
+	Embedder        EmbedderConfig `mapstructure:"embedder"`
+}
+
+// EmbedderConfig represents the text embedder used by semantic search
+type EmbedderConfig struct {
+	Provider  string `mapstructure:"provider"`  // hash (local, CPU-only) or http (OpenAI-compatible API)
+	Dimension int    `mapstructure:"dimension"` // vector length; required for http
+	URL       string `mapstructure:"url"`       // http: embeddings endpoint
+	APIKey    string `mapstructure:"api_key"`   // http: bearer token
+	Model     string `mapstructure:"model"`     // http: model name
+}
+
+// PrewarmConfig represents feed pre-warming configuration
+type PrewarmConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	Categories []string      `mapstructure:"categories"`  // categories whose first feed page is kept warm
+	SortOrders []string      `mapstructure:"sort_orders"` // sort orders warmed for each category
+	Interval   time.Duration `mapstructure:"interval"`    // time between refreshes, below cache.ttl
+	Jitter     time.Duration `mapstructure:"jitter"`      // random spread applied to each refresh
+	Limit      int           `mapstructure:"limit"`       // papers per warmed page
+
+	TriggerEnabled bool `mapstructure:"trigger_enabled"` // expose POST /prewarm/jobs/trigger to signed-in users
+}
+
+// AlertsConfig represents saved search alerts configuration
+type AlertsConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	Interval         time.Duration `mapstructure:"interval"`           // time between checks
+	Limit            int           `mapstructure:"limit"`              // matches fetched per saved search and check
+	SearchesPerCheck int           `mapstructure:"searches_per_check"` // saved searches re-run per check, in turn
+}
+
 // Load loads configuration from file
 func Load(configPath string) (*Config, error) {
 	viper.SetConfigFile(configPath)
@@ -111,6 +165,7 @@ func setDefaults() {
 	viper.SetDefault("database.max_open_conns", 25)
 	viper.SetDefault("database.max_idle_conns", 5)
 	viper.SetDefault("database.max_lifetime", "5m")
+	viper.SetDefault("database.auto_migrate", false)
 
 	// JWT defaults (only expires_in, no secret default)
 	viper.SetDefault("jwt.expires_in", "168h") // 7 days
@@ -118,6 +173,34 @@ func setDefaults() {
 	viper.SetDefault("arxiv.base_url", "http://export.arxiv.org/api/query")
 	viper.SetDefault("arxiv.timeout", "10s")
 	viper.SetDefault("arxiv.max_retries", 3)
+	viper.SetDefault("arxiv.min_interval", "3s") // arXiv asks for one request every ~3 seconds
+	viper.SetDefault("arxiv.failure_threshold", 5)
+	viper.SetDefault("arxiv.open_duration", "60s")
+	viper.SetDefault("arxiv.oai_base_url", "https://oaipmh.arxiv.org/oai")
+
+	viper.SetDefault("harvest.set", "cs")
+	viper.SetDefault("harvest.metadata_prefix", "arXiv")
+
+	viper.SetDefault("search.backend", "arxiv")
+	viper.SetDefault("search.index_path", "data/search-index.gob")
+	viper.SetDefault("search.sync_interval", "5m")
+	viper.SetDefault("search.semantic_enabled", true)
+	viper.SetDefault("search.vector_index_path", "data/vector-index.gob")
+	viper.SetDefault("search.embedder.provider", "hash")
+	viper.SetDefault("search.embedder.dimension", 256)
+
+	viper.SetDefault("prewarm.enabled", true)
+	viper.SetDefault("prewarm.categories", []string{"cs.AI", "cs.LG", "cs.CL", "cs.CV"})
+	viper.SetDefault("prewarm.sort_orders", []string{"lastUpdatedDate"})
+	viper.SetDefault("prewarm.interval", "4m") // refresh before the 5-minute cache TTL expires
+	viper.SetDefault("prewarm.jitter", "30s")
+	viper.SetDefault("prewarm.limit", 20)
+	viper.SetDefault("prewarm.trigger_enabled", false)
+
+	viper.SetDefault("alerts.enabled", false)
+	viper.SetDefault("alerts.interval", "1h")
+	viper.SetDefault("alerts.limit", 50)
+	viper.SetDefault("alerts.searches_per_check", 20)
 
 	viper.SetDefault("cache.enabled", true)
 	viper.SetDefault("cache.ttl", "300s") // 5 minutes
@@ -175,6 +258,9 @@ func overrideWithEnvVars(config *Config) {
 	if database := os.Getenv("DB_DATABASE"); database != "" {
 		config.Database.Database = database
 	}
+	if autoMigrate := os.Getenv("DB_AUTO_MIGRATE"); autoMigrate != "" {
+		config.Database.AutoMigrate = strings.ToLower(autoMigrate) == "true"
+	}
 
 	// ArXiv Configuration
 	if baseURL := os.Getenv("ARXIV_BASE_URL"); baseURL != "" {
@@ -190,6 +276,76 @@ func overrideWithEnvVars(config *Config) {
 			config.Arxiv.MaxRetries = r
 		}
 	}
+	if interval := os.Getenv("ARXIV_MIN_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil {
+			config.Arxiv.MinInterval = d
+		}
+	}
+	if oaiBaseURL := os.Getenv("ARXIV_OAI_BASE_URL"); oaiBaseURL != "" {
+		config.Arxiv.OAIBaseURL = oaiBaseURL
+	}
+
+	// Search Configuration
+	if backend := os.Getenv("SEARCH_BACKEND"); backend != "" {
+		config.Search.Backend = backend
+	}
+	if indexPath := os.Getenv("SEARCH_INDEX_PATH"); indexPath != "" {
+		config.Search.IndexPath = indexPath
+	}
+	if interval := os.Getenv("SEARCH_SYNC_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil {
+			config.Search.SyncInterval = d
+		}
+	}
+	if enabled := os.Getenv("SEARCH_SEMANTIC_ENABLED"); enabled != "" {
+		config.Search.SemanticEnabled = strings.ToLower(enabled) == "true"
+	}
+	if vectorIndexPath := os.Getenv("SEARCH_VECTOR_INDEX_PATH"); vectorIndexPath != "" {
+		config.Search.VectorIndexPath = vectorIndexPath
+	}
+	if provider := os.Getenv("EMBEDDER_PROVIDER"); provider != "" {
+		config.Search.Embedder.Provider = provider
+	}
+	if dimension := os.Getenv("EMBEDDER_DIMENSION"); dimension != "" {
+		if d, err := strconv.Atoi(dimension); err == nil {
+			config.Search.Embedder.Dimension = d
+		}
+	}
+	if url := os.Getenv("EMBEDDER_URL"); url != "" {
+		config.Search.Embedder.URL = url
+	}
+	if apiKey := os.Getenv("EMBEDDER_API_KEY"); apiKey != "" {
+		config.Search.Embedder.APIKey = apiKey
+	}
+	if model := os.Getenv("EMBEDDER_MODEL"); model != "" {
+		config.Search.Embedder.Model = model
+	}
+
+	// Prewarm Configuration
+	if enabled := os.Getenv("PREWARM_ENABLED"); enabled != "" {
+		config.Prewarm.Enabled = strings.ToLower(enabled) == "true"
+	}
+	if categories := os.Getenv("PREWARM_CATEGORIES"); categories != "" {
+		config.Prewarm.Categories = strings.Split(categories, ",")
+	}
+	if interval := os.Getenv("PREWARM_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil {
+			config.Prewarm.Interval = d
+		}
+	}
+	if enabled := os.Getenv("PREWARM_TRIGGER_ENABLED"); enabled != "" {
+		config.Prewarm.TriggerEnabled = strings.ToLower(enabled) == "true"
+	}
+
+	// Alerts Configuration
+	if enabled := os.Getenv("ALERTS_ENABLED"); enabled != "" {
+		config.Alerts.Enabled = strings.ToLower(enabled) == "true"
+	}
+	if interval := os.Getenv("ALERTS_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil {
+			config.Alerts.Interval = d
+		}
+	}
 
 	// Cache Configuration
 	if enabled := os.Getenv("CACHE_ENABLED"); enabled != "" {
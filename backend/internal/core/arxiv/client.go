@@ -5,22 +5,31 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 )
 
+// maxIDsPerRequest caps the number of identifiers sent in a single id_list request.
+const maxIDsPerRequest = 100
+
 // Config holds the configuration for the arXiv client.
 type Config struct {
 	BaseURL string
 	Timeout time.Duration
+
+	// Scheduler paces, retries and circuit-breaks requests.
+	// Share one Scheduler between all clients; nil sends requests directly.
+	Scheduler *Scheduler
 }
 
 // Client implements the arXiv Service interface.
 type Client struct {
 	baseURL    string
 	httpClient httpClient
+	scheduler  *Scheduler
 }
 
 // Ensure Client implements Service interface
@@ -31,21 +40,19 @@ func NewClient(cfg Config, client httpClient) *Client {
 	return &Client{
 		baseURL:    cfg.BaseURL,
 		httpClient: client,
+		scheduler:  cfg.Scheduler,
 	}
 }
 
 // FetchByCategory fetches papers from arXiv by category.
-func (c *Client) FetchByCategory(ctx context.Context, req *FetchRequest) ([]*Paper, error) {
+func (c *Client) FetchByCategory(ctx context.Context, req *FetchRequest) (*Result, error) {
 	// Build query parameters
 	params := url.Values{}
 
 	// Build search query
-	var searchQuery string
-	if req.Category == "" {
-		// Use wildcard search to get latest papers
-		searchQuery = "cat:cs.* OR cat:stat.* OR cat:math.*"
-	} else {
-		searchQuery = fmt.Sprintf("cat:%s", req.Category)
+	searchQuery, err := buildCategoryQuery(req)
+	if err != nil {
+		return nil, err
 	}
 	params.Add("search_query", searchQuery)
 	params.Add("sortBy", req.SortBy)
@@ -57,9 +64,9 @@ func (c *Client) FetchByCategory(ctx context.Context, req *FetchRequest) ([]*Pap
 
 	// Make request
 	reqURL := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
-	resp, err := c.httpClient.Get(ctx, reqURL)
+	resp, err := c.get(ctx, reqURL)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+		return nil, fmt.Errorf("%w: %w", ErrFetchFailed, err)
 	}
 	defer resp.Body.Close()
 
@@ -73,23 +80,53 @@ func (c *Client) FetchByCategory(ctx context.Context, req *FetchRequest) ([]*Pap
 		return nil, err
 	}
 
-	return c.convertFeedToPapers(feed), nil
+	return c.convertFeed(feed), nil
+}
+
+// buildCategoryQuery ORs the requested categories and authors together.
+// arXiv merges the matches into one sorted list in which cross-listed
+// papers appear once.
+func buildCategoryQuery(req *FetchRequest) (string, error) {
+	query := NewQuery()
+	for _, category := range append([]string{req.Category}, req.Categories...) {
+		if category != "" {
+			query.Or(FieldCategory, category)
+		}
+	}
+	for _, author := range req.Authors {
+		query.Or(FieldAuthor, author)
+	}
+	if query.IsEmpty() {
+		// Use wildcard search to get latest papers
+		return "cat:cs.* OR cat:stat.* OR cat:math.*", nil
+	}
+	return query.Build()
+}
+
+// Search searches papers by keyword across all fields.
+func (c *Client) Search(ctx context.Context, query string, limit int) (*Result, error) {
+	return c.SearchQuery(ctx, NewQuery().Where(FieldAll, query), limit)
 }
 
-// Search searches papers by keyword.
-func (c *Client) Search(ctx context.Context, query string, limit int) ([]*Paper, error) {
+// SearchQuery searches papers with a structured, fielded query.
+func (c *Client) SearchQuery(ctx context.Context, query *Query, limit int) (*Result, error) {
+	searchQuery, err := query.Build()
+	if err != nil {
+		return nil, err
+	}
+
 	// Build query parameters
 	params := url.Values{}
-	params.Add("search_query", fmt.Sprintf("all:%s", query))
+	params.Add("search_query", searchQuery)
 	params.Add("sortBy", "submittedDate")
 	params.Add("sortOrder", "descending")
 	params.Add("max_results", fmt.Sprintf("%d", limit))
 
 	// Make request
 	reqURL := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
-	resp, err := c.httpClient.Get(ctx, reqURL)
+	resp, err := c.get(ctx, reqURL)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrSearchFailed, err)
+		return nil, fmt.Errorf("%w: %w", ErrSearchFailed, err)
 	}
 	defer resp.Body.Close()
 
@@ -103,22 +140,166 @@ func (c *Client) Search(ctx context.Context, query string, limit int) ([]*Paper,
 		return nil, err
 	}
 
-	return c.convertFeedToPapers(feed), nil
+	return c.convertFeed(feed), nil
 }
 
 // GetByID fetches a single paper by its arXiv ID.
+// The ID may name a specific version (e.g., "2301.12345v2"); otherwise the latest is returned.
 func (c *Client) GetByID(ctx context.Context, id string) (*Paper, error) {
-	// Search by ID
-	papers, err := c.Search(ctx, fmt.Sprintf("id:%s", id), 1)
+	ident, err := ParseIdentifier(id)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.fetchIDList(ctx, []string{ident.String()})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(papers) == 0 {
+	if len(result.Papers) == 0 {
 		return nil, nil
 	}
 
-	return papers[0], nil
+	return result.Papers[0], nil
+}
+
+// GetByIDs fetches several papers in as few requests as possible.
+// Identifiers are sent through id_list in chunks of maxIDsPerRequest.
+// Papers that do not exist are left out; the result is in arXiv's order.
+func (c *Client) GetByIDs(ctx context.Context, ids []string) ([]*Paper, error) {
+	normalized := make([]string, 0, len(ids))
+	for _, id := range ids {
+		ident, err := ParseIdentifier(id)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, ident.String())
+	}
+
+	papers := make([]*Paper, 0, len(normalized))
+	for start := 0; start < len(normalized); start += maxIDsPerRequest {
+		end := start + maxIDsPerRequest
+		if end > len(normalized) {
+			end = len(normalized)
+		}
+
+		result, err := c.fetchIDList(ctx, normalized[start:end])
+		if err != nil {
+			return nil, err
+		}
+		papers = append(papers, result.Papers...)
+	}
+
+	return papers, nil
+}
+
+// GetVersions lists every version of a paper, oldest first.
+// It returns nil when the paper does not exist.
+func (c *Client) GetVersions(ctx context.Context, id string) ([]*Version, error) {
+	ident, err := ParseIdentifier(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The unversioned entry is the latest version; its ID tells how many exist.
+	latest, err := c.fetchIDList(ctx, []string{ident.Base()})
+	if err != nil {
+		return nil, err
+	}
+	if len(latest.Papers) == 0 {
+		return nil, nil
+	}
+	current := latest.Papers[0]
+	if current.Version <= 1 {
+		return []*Version{newVersion(ident, current, 1)}, nil
+	}
+
+	ids := make([]string, current.Version)
+	for i := range ids {
+		ids[i] = ident.WithVersion(i + 1).String()
+	}
+	result, err := c.fetchIDList(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Paper, len(result.Papers))
+	for _, p := range result.Papers {
+		byVersion[p.Version] = p
+	}
+	byVersion[current.Version] = current
+
+	versions := make([]*Version, 0, current.Version)
+	for v := 1; v <= current.Version; v++ {
+		if p, ok := byVersion[v]; ok {
+			versions = append(versions, newVersion(ident, p, v))
+		}
+	}
+	return versions, nil
+}
+
+// fetchIDList fetches the entries for the given identifiers using id_list.
+// arXiv rejects the whole request if it cannot resolve one identifier, so a
+// rejected request is split in half and each half retried, leaving out only
+// the identifiers rejected on their own.
+func (c *Client) fetchIDList(ctx context.Context, ids []string) (*Result, error) {
+	params := url.Values{}
+	params.Add("id_list", strings.Join(ids, ","))
+	params.Add("max_results", fmt.Sprintf("%d", len(ids)))
+
+	reqURL := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
+	resp, err := c.get(ctx, reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFetchFailed, err)
+	}
+	defer resp.Body.Close()
+
+	// arXiv answers 400 for identifiers it cannot resolve.
+	if resp.StatusCode == http.StatusBadRequest {
+		if len(ids) == 1 {
+			return &Result{}, nil
+		}
+		return c.fetchIDListHalves(ctx, ids)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrFetchFailed, resp.StatusCode)
+	}
+
+	feed, err := c.parseFeed(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.convertFeed(feed), nil
+}
+
+// fetchIDListHalves fetches each half of a rejected id_list separately and
+// merges the results.
+func (c *Client) fetchIDListHalves(ctx context.Context, ids []string) (*Result, error) {
+	merged := &Result{}
+	mid := len(ids) / 2
+	for _, half := range [][]string{ids[:mid], ids[mid:]} {
+		result, err := c.fetchIDList(ctx, half)
+		if err != nil {
+			return nil, err
+		}
+		if len(half) == 1 && len(result.Papers) == 0 {
+			log.Printf("arXiv rejected paper ID %s", half[0])
+		}
+		merged.Papers = append(merged.Papers, result.Papers...)
+		merged.TotalResults += result.TotalResults
+	}
+	return merged, nil
+}
+
+// get performs a GET request, through the scheduler when one is configured.
+func (c *Client) get(ctx context.Context, reqURL string) (*http.Response, error) {
+	if c.scheduler == nil {
+		return c.httpClient.Get(ctx, reqURL)
+	}
+	return c.scheduler.Do(ctx, func(ctx context.Context) (*http.Response, error) {
+		return c.httpClient.Get(ctx, reqURL)
+	})
 }
 
 // parseFeed parses the arXiv Atom feed from the response body.
@@ -136,22 +317,56 @@ func (c *Client) parseFeed(body io.Reader) (*Feed, error) {
 	return &feed, nil
 }
 
+// convertFeed converts an arXiv feed to a Result.
+func (c *Client) convertFeed(feed *Feed) *Result {
+	papers := c.convertFeedToPapers(feed)
+
+	// The API omits totalResults on some error pages; never report fewer than we hold.
+	total := feed.TotalResults
+	if total < feed.StartIndex+len(papers) {
+		total = feed.StartIndex + len(papers)
+	}
+
+	return &Result{
+		Papers:       papers,
+		TotalResults: total,
+		StartIndex:   feed.StartIndex,
+	}
+}
+
 // convertFeedToPapers converts arXiv feed entries to Paper structs.
 func (c *Client) convertFeedToPapers(feed *Feed) []*Paper {
 	papers := make([]*Paper, 0, len(feed.Entries))
 
 	for _, entry := range feed.Entries {
-		paperID := extractID(entry.ID)
+		// Error entries (e.g., for a malformed id_list) carry no arXiv identifier.
+		ident, err := ParseIdentifier(entry.ID)
+		if err != nil {
+			continue
+		}
+		paperID := ident.Base()
 		paper := &Paper{
-			ID:      paperID,
-			Title:   cleanText(entry.Title),
-			Summary: cleanText(entry.Summary),
+			ID:         paperID,
+			Version:    ident.Version,
+			Title:      cleanText(entry.Title),
+			Summary:    cleanText(entry.Summary),
+			DOI:        strings.TrimSpace(entry.DOI),
+			JournalRef: cleanText(entry.JournalRef),
+			Comment:    cleanText(entry.Comment),
 		}
 
 		// Parse authors
 		paper.Authors = make([]string, len(entry.Authors))
+		paper.AuthorDetails = make([]AuthorDetail, len(entry.Authors))
 		for i, author := range entry.Authors {
-			paper.Authors[i] = author.Name
+			name := cleanText(author.Name)
+			paper.Authors[i] = name
+			paper.AuthorDetails[i] = AuthorDetail{Name: name}
+			for _, affiliation := range author.Affiliations {
+				if affiliation = cleanText(affiliation); affiliation != "" {
+					paper.AuthorDetails[i].Affiliations = append(paper.AuthorDetails[i].Affiliations, affiliation)
+				}
+			}
 		}
 
 		// Parse dates
@@ -167,7 +382,9 @@ func (c *Client) convertFeedToPapers(feed *Feed) []*Paper {
 		for i, cat := range entry.Categories {
 			paper.Categories[i] = cat.Term
 		}
-		if len(paper.Categories) > 0 {
+		if entry.PrimaryCategory.Term != "" {
+			paper.PrimaryCategory = entry.PrimaryCategory.Term
+		} else if len(paper.Categories) > 0 {
 			paper.PrimaryCategory = paper.Categories[0]
 		}
 
@@ -197,18 +414,20 @@ func parseTime(timeStr string) (time.Time, error) {
 	return time.Parse(time.RFC3339, timeStr)
 }
 
-// extractID extracts arXiv ID from URL.
-func extractID(urlStr string) string {
-	parts := strings.Split(urlStr, "/")
-	if len(parts) > 0 {
-		id := parts[len(parts)-1]
-		// Remove version if present (e.g., v1, v2)
-		if idx := strings.Index(id, "v"); idx > 0 {
-			id = id[:idx]
-		}
-		return id
+// newVersion describes the given version of a paper.
+// The first version is dated by its publication, later ones by their update time.
+func newVersion(ident Identifier, p *Paper, version int) *Version {
+	submitted := p.Updated
+	if version == 1 && !p.Published.IsZero() {
+		submitted = p.Published
+	}
+	return &Version{
+		ID:        ident.WithVersion(version).String(),
+		Version:   version,
+		Submitted: submitted,
+		Title:     p.Title,
+		Comment:   p.Comment,
 	}
-	return urlStr
 }
 
 // cleanText removes extra whitespace from text.
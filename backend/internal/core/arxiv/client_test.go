@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 )
@@ -50,7 +51,7 @@ func TestClient_FetchByCategory(t *testing.T) {
 	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
 
 	// Act
-	papers, err := client.FetchByCategory(context.Background(), &FetchRequest{
+	result, err := client.FetchByCategory(context.Background(), &FetchRequest{
 		Category:   "cs.AI",
 		MaxResults: 10,
 		SortBy:     "lastUpdatedDate",
@@ -58,8 +59,9 @@ func TestClient_FetchByCategory(t *testing.T) {
 
 	// Assert
 	if err != nil {
-		t.Errorf("Expected no error, got: %v", err)
+		t.Fatalf("Expected no error, got: %v", err)
 	}
+	papers := result.Papers
 
 	if len(papers) != 1 {
 		t.Errorf("Expected 1 paper, got: %d", len(papers))
@@ -80,6 +82,76 @@ func TestClient_FetchByCategory(t *testing.T) {
 	}
 }
 
+func TestClient_FetchByCategory_FullMetadata(t *testing.T) {
+	// Arrange
+	xmlResponse := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"
+      xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/"
+      xmlns:arxiv="http://arxiv.org/schemas/atom">
+  <opensearch:totalResults>1342</opensearch:totalResults>
+  <opensearch:startIndex>20</opensearch:startIndex>
+  <opensearch:itemsPerPage>1</opensearch:itemsPerPage>
+  <entry>
+    <id>http://arxiv.org/abs/2301.12345v2</id>
+    <published>2023-01-15T10:00:00Z</published>
+    <updated>2023-02-01T10:00:00Z</updated>
+    <title>Test Paper Title</title>
+    <summary>This is a test summary.</summary>
+    <author>
+      <name>John Doe</name>
+      <arxiv:affiliation>MIT</arxiv:affiliation>
+      <arxiv:affiliation>CSAIL</arxiv:affiliation>
+    </author>
+    <author><name>Jane Smith</name></author>
+    <arxiv:doi>10.1000/xyz123</arxiv:doi>
+    <arxiv:journal_ref>Nature 600, 1-10 (2023)</arxiv:journal_ref>
+    <arxiv:comment>12 pages,
+      3 figures</arxiv:comment>
+    <arxiv:primary_category term="cs.LG" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.AI"/>
+    <category term="cs.LG"/>
+  </entry>
+</feed>`
+
+	mockClient := &mockHTTPClient{
+		response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(xmlResponse)),
+		},
+	}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+	// Act
+	result, err := client.FetchByCategory(context.Background(), &FetchRequest{Category: "cs.LG", MaxResults: 1, Offset: 20})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.TotalResults != 1342 || result.StartIndex != 20 {
+		t.Errorf("Expected total 1342 at index 20, got: %d at %d", result.TotalResults, result.StartIndex)
+	}
+	paper := result.Papers[0]
+	if paper.PrimaryCategory != "cs.LG" {
+		t.Errorf("Expected primary category 'cs.LG', got: %s", paper.PrimaryCategory)
+	}
+	if paper.DOI != "10.1000/xyz123" {
+		t.Errorf("Expected DOI '10.1000/xyz123', got: %s", paper.DOI)
+	}
+	if paper.JournalRef != "Nature 600, 1-10 (2023)" {
+		t.Errorf("Expected journal ref, got: %s", paper.JournalRef)
+	}
+	if paper.Comment != "12 pages, 3 figures" {
+		t.Errorf("Expected comment '12 pages, 3 figures', got: %s", paper.Comment)
+	}
+	if len(paper.AuthorDetails) != 2 || len(paper.AuthorDetails[0].Affiliations) != 2 || paper.AuthorDetails[0].Affiliations[1] != "CSAIL" {
+		t.Errorf("Expected affiliations for the first author, got: %+v", paper.AuthorDetails)
+	}
+	if len(paper.AuthorDetails[1].Affiliations) != 0 {
+		t.Errorf("Expected no affiliations for the second author, got: %v", paper.AuthorDetails[1].Affiliations)
+	}
+}
+
 func TestClient_FetchByCategory_Error(t *testing.T) {
 	// Arrange
 	mockClient := &mockHTTPClient{
@@ -107,38 +179,86 @@ func TestClient_FetchByCategory_Error(t *testing.T) {
 	}
 }
 
-func TestCleanText(t *testing.T) {
+func TestClient_FetchByCategory_Categories(t *testing.T) {
 	tests := []struct {
-		input    string
+		name     string
+		req      *FetchRequest
 		expected string
 	}{
-		{"  hello   world  ", "hello world"},
-		{"line1\nline2\nline3", "line1 line2 line3"},
-		{"  multiple   spaces   ", "multiple spaces"},
+		{
+			name:     "single category",
+			req:      &FetchRequest{Category: "cs.AI"},
+			expected: "cat:cs.AI",
+		},
+		{
+			name:     "several categories and an archive",
+			req:      &FetchRequest{Categories: []string{"cs.LG", "stat.ML", "math.*"}},
+			expected: "(cat:cs.LG OR cat:stat.ML) OR cat:math.*",
+		},
+		{
+			name:     "categories and authors",
+			req:      &FetchRequest{Categories: []string{"cs.LG"}, Authors: []string{"Jonathan Ho", "Song"}},
+			expected: "(cat:cs.LG OR au:\"Jonathan Ho\") OR au:Song",
+		},
+		{
+			name:     "no category",
+			req:      &FetchRequest{},
+			expected: "cat:cs.* OR cat:stat.* OR cat:math.*",
+		},
 	}
 
 	for _, tt := range tests {
-		result := cleanText(tt.input)
-		if result != tt.expected {
-			t.Errorf("cleanText(%q) = %q, expected %q", tt.input, result, tt.expected)
-		}
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockClient := &recordingHTTPClient{}
+			client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+			// Act
+			_, err := client.FetchByCategory(context.Background(), tt.req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			parsed, _ := url.Parse(mockClient.urls[0])
+			if got := parsed.Query().Get("search_query"); got != tt.expected {
+				t.Errorf("Expected search_query %q, got: %q", tt.expected, got)
+			}
+		})
 	}
 }
 
-func TestExtractID(t *testing.T) {
+func TestClient_FetchByCategory_InvalidCategory(t *testing.T) {
+	// Arrange
+	mockClient := &recordingHTTPClient{}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+	// Act
+	_, err := client.FetchByCategory(context.Background(), &FetchRequest{Categories: []string{"cs.LG", "cs.AI OR all:x"}})
+
+	// Assert
+	if !IsInvalidQuery(err) {
+		t.Errorf("Expected ErrInvalidQuery, got: %v", err)
+	}
+	if len(mockClient.urls) != 0 {
+		t.Errorf("Expected no request for an invalid category, got: %d", len(mockClient.urls))
+	}
+}
+
+func TestCleanText(t *testing.T) {
 	tests := []struct {
 		input    string
 		expected string
 	}{
-		{"http://arxiv.org/abs/2301.12345v1", "2301.12345"},
-		{"http://arxiv.org/abs/2301.12345", "2301.12345"},
-		{"2301.12345", "2301.12345"},
+		{"  hello   world  ", "hello world"},
+		{"line1\nline2\nline3", "line1 line2 line3"},
+		{"  multiple   spaces   ", "multiple spaces"},
 	}
 
 	for _, tt := range tests {
-		result := extractID(tt.input)
+		result := cleanText(tt.input)
 		if result != tt.expected {
-			t.Errorf("extractID(%q) = %q, expected %q", tt.input, result, tt.expected)
+			t.Errorf("cleanText(%q) = %q, expected %q", tt.input, result, tt.expected)
 		}
 	}
 }
@@ -1,6 +1,10 @@
 package arxiv
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	// ErrFetchFailed indicates that fetching papers from arXiv failed.
@@ -14,8 +18,59 @@ var (
 
 	// ErrNotFound indicates that the requested paper was not found.
 	ErrNotFound = errors.New("paper not found")
+
+	// ErrInvalidID indicates that a string is not a valid arXiv identifier.
+	ErrInvalidID = errors.New("invalid arXiv identifier")
+
+	// ErrInvalidQuery indicates that a structured query could not be built.
+	ErrInvalidQuery = errors.New("invalid arXiv query")
+
+	// ErrUnavailable indicates that arXiv cannot currently serve requests.
+	// Errors carrying it are *UnavailableError values.
+	ErrUnavailable = errors.New("arXiv API is unavailable")
+
+	// ErrCircuitOpen indicates that the circuit breaker rejected the request.
+	ErrCircuitOpen = errors.New("arXiv circuit breaker is open")
+
+	// ErrRetriesExhausted indicates that every retry attempt failed.
+	ErrRetriesExhausted = errors.New("arXiv retries exhausted")
 )
 
+// UnavailableError is returned by the Scheduler when arXiv cannot be reached.
+// It matches ErrUnavailable, its Reason and its Cause with errors.Is.
+type UnavailableError struct {
+	Reason     error         // ErrCircuitOpen or ErrRetriesExhausted
+	RetryAfter time.Duration // Suggested wait before trying again (zero if unknown)
+	Cause      error         // Last underlying failure, if any
+}
+
+// Error implements the error interface.
+func (e *UnavailableError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%v: %v: %v", ErrUnavailable, e.Reason, e.Cause)
+	}
+	return fmt.Sprintf("%v: %v", ErrUnavailable, e.Reason)
+}
+
+// Unwrap exposes ErrUnavailable, the reason and the cause to errors.Is and errors.As.
+func (e *UnavailableError) Unwrap() []error {
+	errs := []error{ErrUnavailable, e.Reason}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	return errs
+}
+
+// StatusError records an unexpected HTTP status returned by arXiv.
+type StatusError struct {
+	StatusCode int
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d", e.StatusCode)
+}
+
 // IsFetchFailed checks if the error is ErrFetchFailed.
 func IsFetchFailed(err error) bool { return errors.Is(err, ErrFetchFailed) }
 
@@ -27,3 +82,21 @@ func IsInvalidResponse(err error) bool { return errors.Is(err, ErrInvalidRespons
 
 // IsNotFound checks if the error is ErrNotFound.
 func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsInvalidID checks if the error is ErrInvalidID.
+func IsInvalidID(err error) bool { return errors.Is(err, ErrInvalidID) }
+
+// IsInvalidQuery checks if the error is ErrInvalidQuery.
+func IsInvalidQuery(err error) bool { return errors.Is(err, ErrInvalidQuery) }
+
+// IsUnavailable checks if the error is ErrUnavailable (circuit open or retries exhausted).
+func IsUnavailable(err error) bool { return errors.Is(err, ErrUnavailable) }
+
+// AsUnavailable extracts the *UnavailableError from err, if present.
+func AsUnavailable(err error) (*UnavailableError, bool) {
+	var unavailable *UnavailableError
+	if errors.As(err, &unavailable) {
+		return unavailable, true
+	}
+	return nil, false
+}
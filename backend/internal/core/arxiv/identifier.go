@@ -0,0 +1,131 @@
+package arxiv
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// newStylePattern matches identifiers used since April 2007, e.g. "2301.12345v2".
+	// Numbers have four digits up to 1412 and five digits from 1501 on.
+	newStylePattern = regexp.MustCompile(`^(\d{4})\.(\d{4,5})(?:v(\d+))?$`)
+
+	// oldStylePattern matches pre-2007 identifiers, e.g. "hep-th/9901001v1" or "math.GT/0309136".
+	oldStylePattern = regexp.MustCompile(`^([a-z]+(?:-[a-z]+)*)(?:\.([A-Z]{2}))?/(\d{7})(?:v(\d+))?$`)
+)
+
+// Identifier is a parsed arXiv identifier.
+// Version is zero when the identifier refers to the latest version.
+type Identifier struct {
+	Archive      string // Archive of an old-style identifier (e.g., "hep-th"); empty for new-style
+	SubjectClass string // Optional subject class of an old-style identifier (e.g., "GT" in "math.GT/0309136")
+	Number       string // "2301.12345" for new-style, "9901001" for old-style
+	Version      int    // Explicit version, or 0 for none
+}
+
+// ParseIdentifier parses a new- or old-style arXiv identifier with an optional version.
+// It also accepts the "arXiv:" prefix and abs/pdf URLs.
+// @Returns:
+//   - Identifier: the parsed identifier
+//   - error: ErrInvalidID if s is not a valid identifier
+func ParseIdentifier(s string) (Identifier, error) {
+	raw := strings.TrimSpace(s)
+	id := raw
+	for _, marker := range []string{"/abs/", "/pdf/"} {
+		if idx := strings.Index(id, marker); idx >= 0 {
+			id = id[idx+len(marker):]
+			break
+		}
+	}
+	id = strings.TrimSuffix(id, ".pdf")
+	if len(id) > len("arxiv:") && strings.EqualFold(id[:len("arxiv:")], "arxiv:") {
+		id = id[len("arxiv:"):]
+	}
+
+	if m := newStylePattern.FindStringSubmatch(id); m != nil {
+		month, _ := strconv.Atoi(m[1][2:])
+		if month < 1 || month > 12 {
+			return Identifier{}, fmt.Errorf("%w: %q", ErrInvalidID, raw)
+		}
+		// Five-digit numbers start with 1501; earlier months used four digits.
+		if (len(m[2]) == 5) != (m[1] >= "1501") {
+			return Identifier{}, fmt.Errorf("%w: %q", ErrInvalidID, raw)
+		}
+		version, err := parseVersion(m[3])
+		if err != nil {
+			return Identifier{}, fmt.Errorf("%w: %q", ErrInvalidID, raw)
+		}
+		return Identifier{Number: m[1] + "." + m[2], Version: version}, nil
+	}
+
+	if m := oldStylePattern.FindStringSubmatch(id); m != nil {
+		version, err := parseVersion(m[4])
+		if err != nil {
+			return Identifier{}, fmt.Errorf("%w: %q", ErrInvalidID, raw)
+		}
+		return Identifier{Archive: m[1], SubjectClass: m[2], Number: m[3], Version: version}, nil
+	}
+
+	return Identifier{}, fmt.Errorf("%w: %q", ErrInvalidID, raw)
+}
+
+// IsOldStyle reports whether the identifier uses the pre-2007 archive/number scheme.
+func (id Identifier) IsOldStyle() bool {
+	return id.Archive != ""
+}
+
+// HasVersion reports whether the identifier names an explicit version.
+func (id Identifier) HasVersion() bool {
+	return id.Version > 0
+}
+
+// Base returns the identifier without its version (e.g., "2301.12345").
+func (id Identifier) Base() string {
+	if !id.IsOldStyle() {
+		return id.Number
+	}
+	if id.SubjectClass != "" {
+		return fmt.Sprintf("%s.%s/%s", id.Archive, id.SubjectClass, id.Number)
+	}
+	return fmt.Sprintf("%s/%s", id.Archive, id.Number)
+}
+
+// BaseID parses s and returns its identifier without the version.
+// @Returns:
+//   - string: the base identifier (e.g., "2301.12345")
+//   - error: ErrInvalidID if s is not a valid identifier
+func BaseID(s string) (string, error) {
+	id, err := ParseIdentifier(s)
+	if err != nil {
+		return "", err
+	}
+	return id.Base(), nil
+}
+
+// String returns the canonical form, including the version if one is set.
+func (id Identifier) String() string {
+	if id.HasVersion() {
+		return fmt.Sprintf("%sv%d", id.Base(), id.Version)
+	}
+	return id.Base()
+}
+
+// WithVersion returns a copy of the identifier pointing at the given version.
+func (id Identifier) WithVersion(version int) Identifier {
+	id.Version = version
+	return id
+}
+
+// parseVersion parses the digits after "v"; an empty string means no version.
+func parseVersion(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(s)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid version %q", s)
+	}
+	return version, nil
+}
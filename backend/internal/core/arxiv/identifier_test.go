@@ -0,0 +1,307 @@
+package arxiv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		input    string
+		base     string
+		version  int
+		oldStyle bool
+	}{
+		{"2301.12345", "2301.12345", 0, false},
+		{"2301.12345v2", "2301.12345", 2, false},
+		{"0704.0001", "0704.0001", 0, false},
+		{"arXiv:1412.6980v9", "1412.6980", 9, false},
+		{"http://arxiv.org/abs/2301.12345v1", "2301.12345", 1, false},
+		{"https://arxiv.org/pdf/2301.12345v3.pdf", "2301.12345", 3, false},
+		{"solv-int/9901001v1", "solv-int/9901001", 1, true},
+		{"http://arxiv.org/abs/solv-int/9901001v1", "solv-int/9901001", 1, true},
+		{"hep-th/9901001", "hep-th/9901001", 0, true},
+		{"math.GT/0309136v2", "math.GT/0309136", 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			id, err := ParseIdentifier(tt.input)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if id.Base() != tt.base {
+				t.Errorf("Expected base %q, got: %q", tt.base, id.Base())
+			}
+			if id.Version != tt.version {
+				t.Errorf("Expected version %d, got: %d", tt.version, id.Version)
+			}
+			if id.IsOldStyle() != tt.oldStyle {
+				t.Errorf("Expected old style %v, got: %v", tt.oldStyle, id.IsOldStyle())
+			}
+		})
+	}
+}
+
+func TestParseIdentifier_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"2301.123",
+		"2313.12345",
+		"1412.12345",
+		"1501.1234",
+		"2301.12345v0",
+		"2301.12345 OR x",
+		"HEP-TH/9901001",
+		"hep-th/990100",
+		"http://arxiv.org/api/errors#incorrect_id_format_for_1234",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseIdentifier(input)
+			if !IsInvalidID(err) {
+				t.Errorf("Expected ErrInvalidID, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestIdentifier_String(t *testing.T) {
+	id, err := ParseIdentifier("math.GT/0309136")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if got := id.String(); got != "math.GT/0309136" {
+		t.Errorf("Expected 'math.GT/0309136', got: %s", got)
+	}
+	if got := id.WithVersion(3).String(); got != "math.GT/0309136v3" {
+		t.Errorf("Expected 'math.GT/0309136v3', got: %s", got)
+	}
+}
+
+func TestBaseID(t *testing.T) {
+	if got, err := BaseID("arXiv:2301.12345v2"); err != nil || got != "2301.12345" {
+		t.Errorf("Expected '2301.12345', got: %s (%v)", got, err)
+	}
+	if _, err := BaseID("not-an-id"); !IsInvalidID(err) {
+		t.Errorf("Expected ErrInvalidID, got: %v", err)
+	}
+}
+
+// idListHTTPClient serves one Atom entry per requested id_list identifier.
+// Like arXiv, it rejects a whole request that names a rejected identifier.
+type idListHTTPClient struct {
+	latest   int
+	rejected map[string]bool
+	urls     []string
+}
+
+func (m *idListHTTPClient) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	m.urls = append(m.urls, rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := strings.Split(parsed.Query().Get("id_list"), ",")
+	for _, raw := range ids {
+		if m.rejected[raw] {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+	}
+
+	var body strings.Builder
+	body.WriteString(`<feed xmlns="http://www.w3.org/2005/Atom">`)
+	for _, raw := range ids {
+		id, err := ParseIdentifier(raw)
+		if err != nil {
+			continue
+		}
+		if id.Version == 0 {
+			id.Version = m.latest
+		}
+		updated := time.Date(2023, 1, 10*id.Version, 0, 0, 0, 0, time.UTC)
+		body.WriteString(`<entry>`)
+		body.WriteString(`<id>http://arxiv.org/abs/` + id.String() + `</id>`)
+		body.WriteString(`<published>2023-01-10T00:00:00Z</published>`)
+		body.WriteString(`<updated>` + updated.Format(time.RFC3339) + `</updated>`)
+		body.WriteString(`<title>Version ` + id.String() + `</title>`)
+		body.WriteString(`</entry>`)
+	}
+	body.WriteString(`</feed>`)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body.String())),
+	}, nil
+}
+
+func TestClient_GetByID_Version(t *testing.T) {
+	// Arrange
+	mockClient := &idListHTTPClient{latest: 3}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+	// Act
+	paper, err := client.GetByID(context.Background(), "2301.12345v2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if paper == nil {
+		t.Fatal("Expected paper, got nil")
+	}
+	if paper.ID != "2301.12345" || paper.Version != 2 {
+		t.Errorf("Expected 2301.12345 version 2, got: %s version %d", paper.ID, paper.Version)
+	}
+	if !strings.Contains(mockClient.urls[0], "id_list=2301.12345v2") {
+		t.Errorf("Expected id_list request for the version, got: %s", mockClient.urls[0])
+	}
+}
+
+func TestClient_GetByID_OldStyle(t *testing.T) {
+	// Arrange
+	mockClient := &idListHTTPClient{latest: 1}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+	// Act
+	paper, err := client.GetByID(context.Background(), "solv-int/9901001")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if paper == nil || paper.ID != "solv-int/9901001" {
+		t.Errorf("Expected solv-int/9901001, got: %+v", paper)
+	}
+}
+
+func TestClient_GetByID_InvalidID(t *testing.T) {
+	// Arrange
+	mockClient := &idListHTTPClient{}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+	// Act
+	_, err := client.GetByID(context.Background(), "not-an-id")
+
+	// Assert
+	if !IsInvalidID(err) {
+		t.Errorf("Expected ErrInvalidID, got: %v", err)
+	}
+	if len(mockClient.urls) != 0 {
+		t.Errorf("Expected no request for an invalid ID, got: %d", len(mockClient.urls))
+	}
+}
+
+func TestClient_GetVersions(t *testing.T) {
+	// Arrange
+	mockClient := &idListHTTPClient{latest: 3}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+	// Act
+	versions, err := client.GetVersions(context.Background(), "2301.12345v1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("Expected 3 versions, got: %d", len(versions))
+	}
+	for i, v := range versions {
+		if v.Version != i+1 {
+			t.Errorf("Expected version %d, got: %d", i+1, v.Version)
+		}
+	}
+	if versions[1].ID != "2301.12345v2" {
+		t.Errorf("Expected ID '2301.12345v2', got: %s", versions[1].ID)
+	}
+	if !versions[0].Submitted.Equal(time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected v1 dated by publication, got: %v", versions[0].Submitted)
+	}
+	if !versions[2].Submitted.Equal(time.Date(2023, 1, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected v3 dated by its update, got: %v", versions[2].Submitted)
+	}
+}
+
+func TestClient_GetByIDs_Chunks(t *testing.T) {
+	// Arrange
+	mockClient := &idListHTTPClient{latest: 1}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+	ids := make([]string, maxIDsPerRequest+5)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("2301.%05d", i+1)
+	}
+
+	// Act
+	papers, err := client.GetByIDs(context.Background(), ids)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(papers) != len(ids) {
+		t.Errorf("Expected %d papers, got: %d", len(ids), len(papers))
+	}
+	if len(mockClient.urls) != 2 {
+		t.Errorf("Expected 2 chunked requests, got: %d", len(mockClient.urls))
+	}
+}
+
+func TestClient_GetByIDs_RejectedID(t *testing.T) {
+	// Arrange
+	mockClient := &idListHTTPClient{latest: 1, rejected: map[string]bool{"2301.00005": true}}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+	ids := make([]string, 8)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("2301.%05d", i+1)
+	}
+
+	// Act
+	papers, err := client.GetByIDs(context.Background(), ids)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(papers) != len(ids)-1 {
+		t.Fatalf("Expected every paper but the rejected one, got: %d", len(papers))
+	}
+	for _, p := range papers {
+		if p.ID == "2301.00005" {
+			t.Errorf("Expected the rejected ID to be left out")
+		}
+	}
+	// 8 -> 4+4 -> 2+2 -> 1+1: one request per level for the rejected half.
+	if len(mockClient.urls) != 7 {
+		t.Errorf("Expected 7 requests, got: %d", len(mockClient.urls))
+	}
+}
+
+func TestClient_GetByIDs_InvalidID(t *testing.T) {
+	// Arrange
+	mockClient := &idListHTTPClient{}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+	// Act
+	_, err := client.GetByIDs(context.Background(), []string{"2301.12345", "bogus"})
+
+	// Assert
+	if !IsInvalidID(err) {
+		t.Errorf("Expected ErrInvalidID, got: %v", err)
+	}
+	if len(mockClient.urls) != 0 {
+		t.Errorf("Expected no request, got: %d", len(mockClient.urls))
+	}
+}
@@ -10,9 +10,9 @@ type Service interface {
 	//   - ctx: context for cancellation and tracing
 	//   - req: fetch request parameters
 	// @Returns:
-	//   - []*Paper: list of papers
+	//   - *Result: page of papers with the total number of matches
 	//   - error: ErrFetchFailed if request fails
-	FetchByCategory(ctx context.Context, req *FetchRequest) ([]*Paper, error)
+	FetchByCategory(ctx context.Context, req *FetchRequest) (*Result, error)
 
 	// Search searches papers by keyword.
 	// @Params:
@@ -20,16 +20,44 @@ type Service interface {
 	//   - query: search keyword
 	//   - limit: maximum number of results
 	// @Returns:
-	//   - []*Paper: list of matching papers
+	//   - *Result: matching papers with the total number of matches
 	//   - error: ErrSearchFailed if request fails
-	Search(ctx context.Context, query string, limit int) ([]*Paper, error)
+	Search(ctx context.Context, query string, limit int) (*Result, error)
+
+	// SearchQuery searches papers with a structured, fielded query.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - query: structured query (fields, boolean operators, date range)
+	//   - limit: maximum number of results
+	// @Returns:
+	//   - *Result: matching papers with the total number of matches
+	//   - error: ErrInvalidQuery if the query is invalid, ErrSearchFailed if request fails
+	SearchQuery(ctx context.Context, query *Query, limit int) (*Result, error)
 
 	// GetByID fetches a single paper by its arXiv ID.
 	// @Params:
 	//   - ctx: context for cancellation and tracing
-	//   - id: arXiv paper ID (e.g., "2301.12345")
+	//   - id: arXiv paper ID, optionally versioned (e.g., "2301.12345", "2301.12345v2", "hep-th/9901001")
 	// @Returns:
 	//   - *Paper: the paper if found, nil otherwise
-	//   - error: ErrFetchFailed if request fails
+	//   - error: ErrInvalidID if the ID is malformed, ErrFetchFailed if request fails
 	GetByID(ctx context.Context, id string) (*Paper, error)
+
+	// GetByIDs fetches several papers using id_list, chunking large requests.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - ids: arXiv paper IDs, optionally versioned
+	// @Returns:
+	//   - []*Paper: the papers that exist, in no guaranteed order
+	//   - error: ErrInvalidID if any ID is malformed, ErrFetchFailed if a request fails
+	GetByIDs(ctx context.Context, ids []string) ([]*Paper, error)
+
+	// GetVersions lists every version of a paper, oldest first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - id: arXiv paper ID; any version suffix is ignored
+	// @Returns:
+	//   - []*Version: the versions with their submission dates, nil if the paper does not exist
+	//   - error: ErrInvalidID if the ID is malformed, ErrFetchFailed if request fails
+	GetVersions(ctx context.Context, id string) ([]*Version, error)
 }
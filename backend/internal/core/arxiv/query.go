@@ -0,0 +1,326 @@
+package arxiv
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Field identifies an arXiv metadata field that can be searched.
+type Field string
+
+const (
+	// FieldAll matches against all fields.
+	FieldAll Field = "all"
+	// FieldTitle matches against the paper title.
+	FieldTitle Field = "ti"
+	// FieldAuthor matches against author names.
+	FieldAuthor Field = "au"
+	// FieldAbstract matches against the abstract.
+	FieldAbstract Field = "abs"
+	// FieldCategory matches against subject categories (e.g., "cs.LG" or "cs.*").
+	FieldCategory Field = "cat"
+	// FieldID matches against the arXiv identifier.
+	FieldID Field = "id"
+)
+
+// Operator is a boolean operator joining a clause to the preceding clauses.
+type Operator string
+
+const (
+	// OpAnd requires both sides to match.
+	OpAnd Operator = "AND"
+	// OpOr requires either side to match.
+	OpOr Operator = "OR"
+	// OpAndNot excludes results matching the right-hand side.
+	OpAndNot Operator = "ANDNOT"
+)
+
+// submittedDateLayout is the timestamp format used by submittedDate ranges.
+const submittedDateLayout = "200601021504"
+
+var (
+	// categoryPattern matches category values such as "cs", "cs.LG", "cs.*" or "hep-th".
+	categoryPattern = regexp.MustCompile(`^[A-Za-z-]+(\.([A-Za-z-]+|\*))?$`)
+
+	// idPattern matches new- and old-style arXiv identifiers with optional version.
+	idPattern = regexp.MustCompile(`^[A-Za-z0-9.\-/]+$`)
+
+	// reservedWords are operators that must not appear as bare terms.
+	reservedWords = map[string]bool{"AND": true, "OR": true, "ANDNOT": true, "TO": true}
+)
+
+// Clause is a single term of a Query, or a nested group of terms.
+type Clause struct {
+	Op    Operator // Operator joining this clause to the previous ones; ignored for the first clause
+	Field Field    // Field to match
+	Value string   // Raw, unescaped value
+	Group *Query   // Nested query; when set, Field and Value are ignored
+}
+
+// Query is a structured arXiv search query.
+// It is rendered into arXiv's search_query grammar by Build, which escapes
+// every value so user input cannot inject operators or fields.
+type Query struct {
+	Clauses       []Clause
+	SubmittedFrom time.Time // Inclusive lower bound on submission date (zero for none)
+	SubmittedTo   time.Time // Inclusive upper bound on submission date (zero for none)
+}
+
+// NewQuery creates an empty query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Where adds a clause joined with AND. It is the natural first call on a query.
+func (q *Query) Where(field Field, value string) *Query {
+	return q.add(OpAnd, field, value)
+}
+
+// And adds a clause joined with AND.
+func (q *Query) And(field Field, value string) *Query {
+	return q.add(OpAnd, field, value)
+}
+
+// Or adds a clause joined with OR.
+func (q *Query) Or(field Field, value string) *Query {
+	return q.add(OpOr, field, value)
+}
+
+// AndNot adds a clause joined with ANDNOT.
+func (q *Query) AndNot(field Field, value string) *Query {
+	return q.add(OpAndNot, field, value)
+}
+
+// Group adds a nested query joined with the given operator.
+func (q *Query) Group(op Operator, group *Query) *Query {
+	q.Clauses = append(q.Clauses, Clause{Op: op, Group: group})
+	return q
+}
+
+// SubmittedBetween restricts results to papers submitted within [from, to].
+// Either bound may be zero to leave that side open.
+func (q *Query) SubmittedBetween(from, to time.Time) *Query {
+	q.SubmittedFrom = from
+	q.SubmittedTo = to
+	return q
+}
+
+// IsEmpty reports whether the query has no clauses and no date range.
+func (q *Query) IsEmpty() bool {
+	return q == nil || (len(q.Clauses) == 0 && q.SubmittedFrom.IsZero() && q.SubmittedTo.IsZero())
+}
+
+// Build renders the query into arXiv's search_query syntax.
+// @Returns:
+//   - string: the search_query value (not URL-encoded)
+//   - error: ErrInvalidQuery if the query is empty or contains an invalid value
+func (q *Query) Build() (string, error) {
+	if q.IsEmpty() {
+		return "", fmt.Errorf("%w: query is empty", ErrInvalidQuery)
+	}
+
+	expr, err := q.buildClauses()
+	if err != nil {
+		return "", err
+	}
+
+	if !q.SubmittedFrom.IsZero() || !q.SubmittedTo.IsZero() {
+		dateExpr, err := q.buildDateRange()
+		if err != nil {
+			return "", err
+		}
+		if expr == "" {
+			expr = dateExpr
+		} else {
+			expr = fmt.Sprintf("%s AND %s", wrap(expr), dateExpr)
+		}
+	}
+
+	return expr, nil
+}
+
+// String returns the rendered query, or an empty string if it is invalid.
+func (q *Query) String() string {
+	s, err := q.Build()
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// add appends a field clause.
+func (q *Query) add(op Operator, field Field, value string) *Query {
+	q.Clauses = append(q.Clauses, Clause{Op: op, Field: field, Value: value})
+	return q
+}
+
+// buildClauses joins all clauses left to right, parenthesizing each step
+// so the result does not depend on arXiv's operator precedence.
+func (q *Query) buildClauses() (string, error) {
+	var expr string
+	for _, clause := range q.Clauses {
+		term, err := clause.build()
+		if err != nil {
+			return "", err
+		}
+		if term == "" {
+			continue
+		}
+
+		if expr == "" {
+			if clause.Op == OpAndNot {
+				return "", fmt.Errorf("%w: ANDNOT requires a preceding term", ErrInvalidQuery)
+			}
+			expr = term
+			continue
+		}
+
+		op := clause.Op
+		if op == "" {
+			op = OpAnd
+		}
+		switch op {
+		case OpAnd, OpOr, OpAndNot:
+		default:
+			return "", fmt.Errorf("%w: unknown operator %q", ErrInvalidQuery, op)
+		}
+		expr = fmt.Sprintf("%s %s %s", wrap(expr), op, wrap(term))
+	}
+
+	if expr == "" && len(q.Clauses) > 0 {
+		return "", fmt.Errorf("%w: query has no searchable terms", ErrInvalidQuery)
+	}
+	return expr, nil
+}
+
+// buildDateRange renders the submittedDate range term.
+func (q *Query) buildDateRange() (string, error) {
+	from := q.SubmittedFrom
+	to := q.SubmittedTo
+	if from.IsZero() {
+		from = time.Date(1991, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if to.IsZero() {
+		to = time.Date(9999, time.December, 31, 23, 59, 0, 0, time.UTC)
+	}
+	if to.Before(from) {
+		return "", fmt.Errorf("%w: submitted date range ends before it starts", ErrInvalidQuery)
+	}
+
+	return fmt.Sprintf("submittedDate:[%s TO %s]",
+		from.UTC().Format(submittedDateLayout),
+		to.UTC().Format(submittedDateLayout),
+	), nil
+}
+
+// build renders a single clause.
+func (c Clause) build() (string, error) {
+	if c.Group != nil {
+		if c.Group.IsEmpty() {
+			return "", nil
+		}
+		return c.Group.Build()
+	}
+
+	value := strings.TrimSpace(c.Value)
+	if value == "" {
+		return "", fmt.Errorf("%w: empty value for field %q", ErrInvalidQuery, c.Field)
+	}
+
+	switch c.Field {
+	case FieldCategory:
+		if !categoryPattern.MatchString(value) {
+			return "", fmt.Errorf("%w: invalid category %q", ErrInvalidQuery, value)
+		}
+		return fmt.Sprintf("%s:%s", c.Field, value), nil
+
+	case FieldID:
+		if !idPattern.MatchString(value) {
+			return "", fmt.Errorf("%w: invalid id %q", ErrInvalidQuery, value)
+		}
+		return fmt.Sprintf("%s:%s", c.Field, value), nil
+
+	case FieldAuthor:
+		// Author names are matched as a phrase so "Geoffrey Hinton" stays together.
+		words := sanitizeWords(value)
+		if len(words) == 0 {
+			return "", fmt.Errorf("%w: no searchable terms in %q", ErrInvalidQuery, value)
+		}
+		if len(words) == 1 {
+			return fmt.Sprintf("%s:%s", c.Field, words[0]), nil
+		}
+		return fmt.Sprintf("%s:\"%s\"", c.Field, strings.Join(words, " ")), nil
+
+	case FieldAll, FieldTitle, FieldAbstract:
+		// Free text is split into words that must all match within the field.
+		words := sanitizeWords(value)
+		if len(words) == 0 {
+			return "", fmt.Errorf("%w: no searchable terms in %q", ErrInvalidQuery, value)
+		}
+		terms := make([]string, len(words))
+		for i, w := range words {
+			terms[i] = fmt.Sprintf("%s:%s", c.Field, w)
+		}
+		return strings.Join(terms, " AND "), nil
+
+	default:
+		return "", fmt.Errorf("%w: unknown field %q", ErrInvalidQuery, c.Field)
+	}
+}
+
+// sanitizeWords splits free text into words, dropping characters that carry
+// meaning in the search_query grammar and neutralizing reserved operators.
+func sanitizeWords(value string) []string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case '"', '(', ')', '[', ']', '{', '}', ':', '*', '?', '\\', '^', '~', '+':
+			return ' '
+		}
+		return r
+	}, value)
+
+	fields := strings.Fields(cleaned)
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.Trim(f, "-")
+		if f == "" {
+			continue
+		}
+		if reservedWords[strings.ToUpper(f)] {
+			f = strings.ToLower(f)
+		}
+		words = append(words, f)
+	}
+	return words
+}
+
+// wrap parenthesizes compound expressions.
+func wrap(expr string) string {
+	if !isCompound(expr) {
+		return expr
+	}
+	return "(" + expr + ")"
+}
+
+// isCompound reports whether expr contains an operator at its top level,
+// i.e. a space outside of quotes and parentheses.
+func isCompound(expr string) bool {
+	depth := 0
+	inQuote := false
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth--
+		case r == ' ' && depth == 0:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package arxiv
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingHTTPClient records the requested URL and returns an empty feed.
+type recordingHTTPClient struct {
+	urls []string
+}
+
+func (m *recordingHTTPClient) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	m.urls = append(m.urls, rawURL)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom"></feed>`)),
+	}, nil
+}
+
+func TestQuery_Build(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 31, 23, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    *Query
+		expected string
+	}{
+		{
+			name:     "single author phrase",
+			query:    NewQuery().Where(FieldAuthor, "Geoffrey Hinton"),
+			expected: `au:"Geoffrey Hinton"`,
+		},
+		{
+			name:     "author in category",
+			query:    NewQuery().Where(FieldAuthor, "Hinton").And(FieldCategory, "cs.LG"),
+			expected: `au:Hinton AND cat:cs.LG`,
+		},
+		{
+			name:     "title words are all required",
+			query:    NewQuery().Where(FieldTitle, "diffusion models"),
+			expected: `ti:diffusion AND ti:models`,
+		},
+		{
+			name: "or and andnot are grouped left to right",
+			query: NewQuery().
+				Where(FieldCategory, "cs.CL").
+				Or(FieldCategory, "cs.LG").
+				AndNot(FieldTitle, "survey"),
+			expected: `(cat:cs.CL OR cat:cs.LG) ANDNOT ti:survey`,
+		},
+		{
+			name: "nested group",
+			query: NewQuery().
+				Where(FieldAll, "transformer").
+				Group(OpAnd, NewQuery().Where(FieldCategory, "cs.CL").Or(FieldCategory, "cs.LG")),
+			expected: `all:transformer AND (cat:cs.CL OR cat:cs.LG)`,
+		},
+		{
+			name:     "date range",
+			query:    NewQuery().Where(FieldCategory, "cs.*").SubmittedBetween(from, to),
+			expected: `cat:cs.* AND submittedDate:[202405010000 TO 202405312359]`,
+		},
+		{
+			name:     "date range only",
+			query:    NewQuery().SubmittedBetween(from, time.Time{}),
+			expected: `submittedDate:[202405010000 TO 999912312359]`,
+		},
+		{
+			name:     "injection is neutralized",
+			query:    NewQuery().Where(FieldTitle, `graph") OR au:(evil`),
+			expected: `ti:graph AND ti:or AND ti:au AND ti:evil`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.query.Build()
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Build() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuery_Build_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *Query
+	}{
+		{"empty query", NewQuery()},
+		{"empty value", NewQuery().Where(FieldTitle, "   ")},
+		{"invalid category", NewQuery().Where(FieldCategory, "cs.LG OR au:x")},
+		{"invalid id", NewQuery().Where(FieldID, "2301.12345 OR x")},
+		{"leading andnot", NewQuery().AndNot(FieldTitle, "survey")},
+		{"unknown field", NewQuery().Where(Field("xx"), "value")},
+		{"reversed date range", NewQuery().SubmittedBetween(time.Now(), time.Now().Add(-time.Hour))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.query.Build()
+			if !IsInvalidQuery(err) {
+				t.Errorf("Expected ErrInvalidQuery, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_SearchQuery(t *testing.T) {
+	// Arrange
+	mockClient := &recordingHTTPClient{}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+	query := NewQuery().Where(FieldAuthor, "Ho").And(FieldAll, "diffusion")
+
+	// Act
+	_, err := client.SearchQuery(context.Background(), query, 5)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(mockClient.urls) != 1 {
+		t.Fatalf("Expected 1 request, got: %d", len(mockClient.urls))
+	}
+	parsed, err := url.Parse(mockClient.urls[0])
+	if err != nil {
+		t.Fatalf("Failed to parse request URL: %v", err)
+	}
+	if got := parsed.Query().Get("search_query"); got != "au:Ho AND all:diffusion" {
+		t.Errorf("Expected search_query 'au:Ho AND all:diffusion', got: %s", got)
+	}
+	if got := parsed.Query().Get("max_results"); got != "5" {
+		t.Errorf("Expected max_results '5', got: %s", got)
+	}
+}
+
+func TestClient_SearchQuery_Invalid(t *testing.T) {
+	// Arrange
+	mockClient := &recordingHTTPClient{}
+	client := NewClient(Config{BaseURL: "http://test.com"}, mockClient)
+
+	// Act
+	_, err := client.SearchQuery(context.Background(), NewQuery().Where(FieldCategory, "bad category"), 5)
+
+	// Assert
+	if !IsInvalidQuery(err) {
+		t.Errorf("Expected ErrInvalidQuery, got: %v", err)
+	}
+	if len(mockClient.urls) != 0 {
+		t.Errorf("Expected no request for an invalid query, got: %d", len(mockClient.urls))
+	}
+}
@@ -0,0 +1,310 @@
+package arxiv
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SchedulerConfig holds the configuration for the request scheduler.
+type SchedulerConfig struct {
+	MinInterval      time.Duration // Minimum spacing between two requests (arXiv asks for ~3s)
+	MaxRetries       int           // Retries after the first attempt for 5xx, 429 and transport errors
+	BaseBackoff      time.Duration // Initial backoff before the first retry
+	MaxBackoff       time.Duration // Upper bound for a single backoff
+	FailureThreshold int           // Consecutive failed attempts that open the circuit
+	OpenDuration     time.Duration // How long the circuit stays open before a trial request
+}
+
+// DefaultSchedulerConfig returns a configuration that follows arXiv's usage policy.
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		MinInterval:      3 * time.Second,
+		MaxRetries:       3,
+		BaseBackoff:      time.Second,
+		MaxBackoff:       30 * time.Second,
+		FailureThreshold: 5,
+		OpenDuration:     time.Minute,
+	}
+}
+
+// Scheduler serializes and paces requests to arXiv.
+// A single Scheduler should be shared by every client talking to arXiv so the
+// spacing holds across goroutines. It retries transient failures with jittered
+// exponential backoff (honoring Retry-After) and opens a circuit breaker after
+// repeated failures so callers fail fast while arXiv is down.
+type Scheduler struct {
+	cfg SchedulerConfig
+
+	// slot is a one-element semaphore; holding it grants the right to send a request.
+	slot chan struct{}
+
+	mu           sync.Mutex
+	notBefore    time.Time // Earliest time the next request may start
+	failures     int       // Consecutive failed attempts
+	openUntil    time.Time // Circuit is open until this time
+	halfOpenBusy bool      // A trial request is in flight while half-open
+
+	// Replaceable for tests.
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(d time.Duration) time.Duration
+}
+
+// NewScheduler creates a new request scheduler.
+// Zero values in cfg are replaced by the defaults.
+func NewScheduler(cfg SchedulerConfig) *Scheduler {
+	defaults := DefaultSchedulerConfig()
+	if cfg.MinInterval < 0 {
+		cfg.MinInterval = 0
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = defaults.BaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaults.MaxBackoff
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaults.FailureThreshold
+	}
+	if cfg.OpenDuration <= 0 {
+		cfg.OpenDuration = defaults.OpenDuration
+	}
+
+	s := &Scheduler{
+		cfg:   cfg,
+		slot:  make(chan struct{}, 1),
+		now:   time.Now,
+		sleep: sleepContext,
+		jitter: func(d time.Duration) time.Duration {
+			// Equal jitter: half fixed, half random.
+			half := d / 2
+			return half + time.Duration(rand.Int63n(int64(half)+1))
+		},
+	}
+	return s
+}
+
+// Do sends a request through the scheduler.
+// The send function is called once per attempt; its response is returned to
+// the caller unless it is retryable. Retryable responses have their body closed.
+// @Returns:
+//   - *http.Response: the first non-retryable response
+//   - error: *UnavailableError if the circuit is open or retries are exhausted,
+//     or the context error if ctx is done
+func (s *Scheduler) Do(ctx context.Context, send func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
+	trial, err := s.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= s.cfg.MaxRetries; attempt++ {
+		resp, err := s.attempt(ctx, send)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			s.release(trial)
+			return nil, ctxErr
+		}
+
+		retryAfter, retryable, cause := classify(resp, err, s.now())
+		if !retryable {
+			s.recordSuccess()
+			return resp, nil
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		lastErr = cause
+
+		if open := s.recordFailure(retryAfter); open {
+			return nil, &UnavailableError{Reason: ErrCircuitOpen, RetryAfter: s.retryIn(), Cause: lastErr}
+		}
+		if attempt == s.cfg.MaxRetries {
+			break
+		}
+
+		delay := s.backoff(attempt)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		if err := s.sleep(ctx, delay); err != nil {
+			s.release(trial)
+			return nil, err
+		}
+	}
+
+	s.release(trial)
+	return nil, &UnavailableError{Reason: ErrRetriesExhausted, RetryAfter: s.retryIn(), Cause: lastErr}
+}
+
+// attempt waits for a free, correctly spaced slot and sends one request.
+func (s *Scheduler) attempt(ctx context.Context, send func(ctx context.Context) (*http.Response, error)) (*http.Response, error) {
+	select {
+	case s.slot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-s.slot }()
+
+	s.mu.Lock()
+	wait := s.notBefore.Sub(s.now())
+	s.mu.Unlock()
+	if wait > 0 {
+		if err := s.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := send(ctx)
+
+	s.mu.Lock()
+	next := s.now().Add(s.cfg.MinInterval)
+	if next.After(s.notBefore) {
+		s.notBefore = next
+	}
+	s.mu.Unlock()
+
+	return resp, err
+}
+
+// allow rejects the call while the circuit is open.
+// Once the open period has elapsed a single trial call is let through
+// (half-open); trial reports whether this call is that trial.
+func (s *Scheduler) allow() (trial bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.openUntil.IsZero() {
+		return false, nil
+	}
+	now := s.now()
+	if now.Before(s.openUntil) {
+		return false, &UnavailableError{Reason: ErrCircuitOpen, RetryAfter: s.openUntil.Sub(now)}
+	}
+	if s.halfOpenBusy {
+		return false, &UnavailableError{Reason: ErrCircuitOpen, RetryAfter: s.cfg.MinInterval}
+	}
+	s.halfOpenBusy = true
+	return true, nil
+}
+
+// release ends a half-open trial without changing the circuit state.
+// Calls that were not the trial leave it running.
+func (s *Scheduler) release(trial bool) {
+	if !trial {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.halfOpenBusy = false
+}
+
+// recordSuccess closes the circuit and resets the failure count.
+func (s *Scheduler) recordSuccess() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = 0
+	s.openUntil = time.Time{}
+	s.halfOpenBusy = false
+}
+
+// recordFailure counts a failed attempt and reports whether the circuit is now open.
+// A Retry-After hint also delays every other caller.
+func (s *Scheduler) recordFailure(retryAfter time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if retryAfter > 0 && now.Add(retryAfter).After(s.notBefore) {
+		s.notBefore = now.Add(retryAfter)
+	}
+
+	s.failures++
+	if s.halfOpenBusy || s.failures >= s.cfg.FailureThreshold {
+		s.openUntil = now.Add(s.cfg.OpenDuration)
+		s.halfOpenBusy = false
+		return true
+	}
+	return false
+}
+
+// retryIn returns how long callers should wait before trying again.
+func (s *Scheduler) retryIn() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	until := s.notBefore
+	if s.openUntil.After(until) {
+		until = s.openUntil
+	}
+	if until.After(now) {
+		return until.Sub(now)
+	}
+	return 0
+}
+
+// backoff returns the jittered exponential delay before retry number attempt+1.
+func (s *Scheduler) backoff(attempt int) time.Duration {
+	d := s.cfg.BaseBackoff << attempt
+	if d <= 0 || d > s.cfg.MaxBackoff {
+		d = s.cfg.MaxBackoff
+	}
+	return s.jitter(d)
+}
+
+// classify decides whether an attempt should be retried.
+// It returns the server's Retry-After hint (if any), whether to retry, and the failure cause.
+func classify(resp *http.Response, err error, now time.Time) (time.Duration, bool, error) {
+	if err != nil {
+		return 0, true, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return parseRetryAfter(resp.Header.Get("Retry-After"), now), true, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	return 0, false, nil
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,290 @@
+package arxiv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock drives a Scheduler without real sleeping.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeScheduler(cfg SchedulerConfig) (*Scheduler, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewScheduler(cfg)
+	s.now = func() time.Time {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+		return clock.now
+	}
+	s.sleep = func(ctx context.Context, d time.Duration) error {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+		clock.sleeps = append(clock.sleeps, d)
+		clock.now = clock.now.Add(d)
+		return nil
+	}
+	s.jitter = func(d time.Duration) time.Duration { return d }
+	return s, clock
+}
+
+// statusSequence returns a send function replying with the given statuses in order.
+func statusSequence(calls *int, statuses ...int) func(ctx context.Context) (*http.Response, error) {
+	return func(ctx context.Context) (*http.Response, error) {
+		status := statuses[len(statuses)-1]
+		if *calls < len(statuses) {
+			status = statuses[*calls]
+		}
+		*calls++
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}
+}
+
+func TestScheduler_SpacesRequests(t *testing.T) {
+	// Arrange
+	s, clock := newFakeScheduler(SchedulerConfig{MinInterval: 3 * time.Second})
+	calls := 0
+
+	// Act
+	for i := 0; i < 3; i++ {
+		if _, err := s.Do(context.Background(), statusSequence(&calls, http.StatusOK)); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	// Assert
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got: %d", calls)
+	}
+	if len(clock.sleeps) != 2 || clock.sleeps[0] != 3*time.Second || clock.sleeps[1] != 3*time.Second {
+		t.Errorf("Expected two 3s waits, got: %v", clock.sleeps)
+	}
+}
+
+func TestScheduler_SerializesConcurrentCallers(t *testing.T) {
+	// Arrange
+	interval := 20 * time.Millisecond
+	s := NewScheduler(SchedulerConfig{MinInterval: interval})
+
+	var mu sync.Mutex
+	var starts []time.Time
+	send := func(ctx context.Context) (*http.Response, error) {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Do(context.Background(), send)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if len(starts) != 4 {
+		t.Fatalf("Expected 4 requests, got: %d", len(starts))
+	}
+	for i := 1; i < len(starts); i++ {
+		if gap := starts[i].Sub(starts[i-1]); gap < interval-2*time.Millisecond {
+			t.Errorf("Expected requests spaced by at least %v, got gap %v", interval, gap)
+		}
+	}
+}
+
+func TestScheduler_RetriesServerErrors(t *testing.T) {
+	// Arrange
+	s, clock := newFakeScheduler(SchedulerConfig{MaxRetries: 3, BaseBackoff: time.Second})
+	calls := 0
+
+	// Act
+	resp, err := s.Do(context.Background(), statusSequence(&calls, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got: %d", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got: %d", calls)
+	}
+	if len(clock.sleeps) != 2 || clock.sleeps[0] != time.Second || clock.sleeps[1] != 2*time.Second {
+		t.Errorf("Expected exponential backoff [1s 2s], got: %v", clock.sleeps)
+	}
+}
+
+func TestScheduler_HonorsRetryAfter(t *testing.T) {
+	// Arrange
+	s, clock := newFakeScheduler(SchedulerConfig{MaxRetries: 1, BaseBackoff: time.Second})
+	calls := 0
+	send := func(ctx context.Context) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Retry-After": []string{"7"}},
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+
+	// Act
+	_, err := s.Do(context.Background(), send)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(clock.sleeps) == 0 || clock.sleeps[0] != 7*time.Second {
+		t.Errorf("Expected a 7s wait from Retry-After, got: %v", clock.sleeps)
+	}
+}
+
+func TestScheduler_DoesNotRetryClientErrors(t *testing.T) {
+	// Arrange
+	s, _ := newFakeScheduler(SchedulerConfig{MaxRetries: 3})
+	calls := 0
+
+	// Act
+	resp, err := s.Do(context.Background(), statusSequence(&calls, http.StatusBadRequest))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest || calls != 1 {
+		t.Errorf("Expected a single 400 response, got status %d after %d calls", resp.StatusCode, calls)
+	}
+}
+
+func TestScheduler_RetriesExhausted(t *testing.T) {
+	// Arrange
+	s, _ := newFakeScheduler(SchedulerConfig{MaxRetries: 2, FailureThreshold: 10})
+	calls := 0
+	transportErr := errors.New("connection reset")
+	send := func(ctx context.Context) (*http.Response, error) {
+		calls++
+		return nil, transportErr
+	}
+
+	// Act
+	_, err := s.Do(context.Background(), send)
+
+	// Assert
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got: %d", calls)
+	}
+	if !IsUnavailable(err) || !errors.Is(err, ErrRetriesExhausted) || !errors.Is(err, transportErr) {
+		t.Errorf("Expected unavailable error wrapping the cause, got: %v", err)
+	}
+}
+
+func TestScheduler_CircuitBreaker(t *testing.T) {
+	// Arrange
+	s, clock := newFakeScheduler(SchedulerConfig{MaxRetries: 1, FailureThreshold: 2, OpenDuration: time.Minute})
+	calls := 0
+	failing := statusSequence(&calls, http.StatusInternalServerError)
+
+	// Act: two failed attempts open the circuit
+	_, err := s.Do(context.Background(), failing)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected circuit to open, got: %v", err)
+	}
+
+	// Assert: further calls fail fast
+	callsBefore := calls
+	_, err = s.Do(context.Background(), failing)
+	unavailable, ok := AsUnavailable(err)
+	if !ok || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected circuit open error, got: %v", err)
+	}
+	if unavailable.RetryAfter <= 0 {
+		t.Errorf("Expected a positive RetryAfter, got: %v", unavailable.RetryAfter)
+	}
+	if calls != callsBefore {
+		t.Errorf("Expected no request while open, got %d new calls", calls-callsBefore)
+	}
+
+	// After the open period a trial request is allowed and success closes the circuit
+	clock.mu.Lock()
+	clock.now = clock.now.Add(time.Minute)
+	clock.mu.Unlock()
+	okCalls := 0
+	if _, err := s.Do(context.Background(), statusSequence(&okCalls, http.StatusOK)); err != nil {
+		t.Fatalf("Expected trial request to succeed, got: %v", err)
+	}
+	if _, err := s.Do(context.Background(), statusSequence(&okCalls, http.StatusOK)); err != nil {
+		t.Errorf("Expected circuit to be closed, got: %v", err)
+	}
+}
+
+func TestScheduler_CancelledCallerKeepsHalfOpenTrial(t *testing.T) {
+	// Arrange: a caller admitted while the circuit was closed is in flight
+	s, clock := newFakeScheduler(SchedulerConfig{FailureThreshold: 1, OpenDuration: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Do(ctx, func(ctx context.Context) (*http.Response, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	}()
+	<-started
+
+	// The circuit has since opened and its open period elapsed; another
+	// caller takes the half-open trial
+	s.mu.Lock()
+	s.openUntil = clock.now.Add(-time.Second)
+	s.mu.Unlock()
+	if trial, err := s.allow(); err != nil || !trial {
+		t.Fatalf("Expected the half-open trial to be granted, got: %v, %v", trial, err)
+	}
+
+	// Act: the earlier caller gives up
+	cancel()
+	<-done
+
+	// Assert: no second trial while the first is in flight
+	if _, err := s.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected a second caller to be rejected while the trial runs, got: %v", err)
+	}
+}
+
+func TestClient_FetchByCategory_Unavailable(t *testing.T) {
+	// Arrange
+	s, _ := newFakeScheduler(SchedulerConfig{MaxRetries: 1})
+	mockClient := &mockHTTPClient{err: errors.New("timeout")}
+	client := NewClient(Config{BaseURL: "http://test.com", Scheduler: s}, mockClient)
+
+	// Act
+	_, err := client.FetchByCategory(context.Background(), &FetchRequest{Category: "cs.AI", MaxResults: 10})
+
+	// Assert
+	if !IsFetchFailed(err) || !IsUnavailable(err) {
+		t.Errorf("Expected fetch failure marked unavailable, got: %v", err)
+	}
+}
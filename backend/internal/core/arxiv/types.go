@@ -7,9 +7,11 @@ import (
 
 // Paper represents a paper fetched from arXiv.
 type Paper struct {
-	ID              string
+	ID              string // Identifier without version (e.g., "2301.12345" or "hep-th/9901001")
+	Version         int    // Version of this record; 0 if arXiv did not report one
 	Title           string
 	Authors         []string
+	AuthorDetails   []AuthorDetail
 	Summary         string
 	Published       time.Time
 	Updated         time.Time
@@ -18,37 +20,72 @@ type Paper struct {
 	ArxivURL        string
 	PDFURL          string
 	ImageURL        string
+	DOI             string
+	JournalRef      string
+	Comment         string
+}
+
+// AuthorDetail is an author together with their stated affiliations.
+type AuthorDetail struct {
+	Name         string
+	Affiliations []string
+}
+
+// Version describes one submitted version of a paper.
+type Version struct {
+	ID        string    // Versioned identifier (e.g., "2301.12345v2")
+	Version   int       // Version number, starting at 1
+	Submitted time.Time // When this version was submitted
+	Title     string    // Title as of this version
+	Comment   string    // Author comment as of this version
+}
+
+// Result is a page of papers together with the totals reported by arXiv.
+type Result struct {
+	Papers       []*Paper
+	TotalResults int // Total matches for the query (opensearch:totalResults)
+	StartIndex   int // Offset of the first paper (opensearch:startIndex)
 }
 
 // FetchRequest contains parameters for fetching papers.
 type FetchRequest struct {
-	Category   string // arXiv category (e.g., "cs.AI")
-	MaxResults int    // Maximum number of results
-	SortBy     string // Sort by: "lastUpdatedDate" or "submittedDate"
-	Offset     int    // Pagination offset
+	Category   string   // arXiv category (e.g., "cs.AI")
+	Categories []string // Further categories or archives (e.g., "cs.*"); papers in any of them are returned once
+	Authors    []string // Authors whose papers are merged in as well, each matched as a phrase
+	MaxResults int      // Maximum number of results
+	SortBy     string   // Sort by: "lastUpdatedDate" or "submittedDate"
+	Offset     int      // Pagination offset
 }
 
 // Feed represents the arXiv Atom feed response.
 type Feed struct {
-	XMLName xml.Name `xml:"feed"`
-	Entries []Entry  `xml:"entry"`
+	XMLName      xml.Name `xml:"feed"`
+	TotalResults int      `xml:"http://a9.com/-/spec/opensearch/1.1/ totalResults"`
+	StartIndex   int      `xml:"http://a9.com/-/spec/opensearch/1.1/ startIndex"`
+	ItemsPerPage int      `xml:"http://a9.com/-/spec/opensearch/1.1/ itemsPerPage"`
+	Entries      []Entry  `xml:"entry"`
 }
 
 // Entry represents an arXiv paper entry in the feed.
 type Entry struct {
-	ID         string     `xml:"id"`
-	Published  string     `xml:"published"`
-	Updated    string     `xml:"updated"`
-	Title      string     `xml:"title"`
-	Summary    string     `xml:"summary"`
-	Authors    []Author   `xml:"author"`
-	Links      []Link     `xml:"link"`
-	Categories []Category `xml:"category"`
+	ID              string     `xml:"id"`
+	Published       string     `xml:"published"`
+	Updated         string     `xml:"updated"`
+	Title           string     `xml:"title"`
+	Summary         string     `xml:"summary"`
+	Authors         []Author   `xml:"author"`
+	Links           []Link     `xml:"link"`
+	Categories      []Category `xml:"category"`
+	PrimaryCategory Category   `xml:"http://arxiv.org/schemas/atom primary_category"`
+	DOI             string     `xml:"http://arxiv.org/schemas/atom doi"`
+	JournalRef      string     `xml:"http://arxiv.org/schemas/atom journal_ref"`
+	Comment         string     `xml:"http://arxiv.org/schemas/atom comment"`
 }
 
 // Author represents a paper author.
 type Author struct {
-	Name string `xml:"name"`
+	Name         string   `xml:"name"`
+	Affiliations []string `xml:"http://arxiv.org/schemas/atom affiliation"`
 }
 
 // Link represents a link in the paper entry.
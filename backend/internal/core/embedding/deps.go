@@ -0,0 +1,9 @@
+package embedding
+
+import "net/http"
+
+// httpClient defines the HTTP client capability required by HTTPEmbedder.
+type httpClient interface {
+	// Do performs an HTTP request.
+	Do(req *http.Request) (*http.Response, error)
+}
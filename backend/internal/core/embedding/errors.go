@@ -0,0 +1,24 @@
+package embedding
+
+import "errors"
+
+var (
+	// ErrInvalidConfig indicates that an embedder is missing required settings.
+	ErrInvalidConfig = errors.New("invalid embedder configuration")
+
+	// ErrRequestFailed indicates that the embedding provider could not be reached
+	// or rejected the request.
+	ErrRequestFailed = errors.New("embedding request failed")
+
+	// ErrInvalidResponse indicates that the provider returned malformed embeddings.
+	ErrInvalidResponse = errors.New("invalid embedding response")
+)
+
+// IsInvalidConfig checks if the error is ErrInvalidConfig.
+func IsInvalidConfig(err error) bool { return errors.Is(err, ErrInvalidConfig) }
+
+// IsRequestFailed checks if the error is ErrRequestFailed.
+func IsRequestFailed(err error) bool { return errors.Is(err, ErrRequestFailed) }
+
+// IsInvalidResponse checks if the error is ErrInvalidResponse.
+func IsInvalidResponse(err error) bool { return errors.Is(err, ErrInvalidResponse) }
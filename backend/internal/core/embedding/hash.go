@@ -0,0 +1,121 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// DefaultHashDimension is the vector length of NewHashEmbedder(0).
+const DefaultHashDimension = 256
+
+// Feature weights of the hashed embedding. Character trigrams let word
+// variants ("network", "networks", "networked") overlap; word bigrams keep
+// some phrase information.
+const (
+	wordWeight    = 1.0
+	bigramWeight  = 0.5
+	trigramWeight = 0.3
+)
+
+// HashEmbedder embeds texts with feature hashing over words, word bigrams
+// and character trigrams. It needs no model or network access and always
+// maps the same text to the same vector.
+type HashEmbedder struct {
+	dim int
+}
+
+// Ensure HashEmbedder implements Embedder interface
+var _ Embedder = (*HashEmbedder)(nil)
+
+// NewHashEmbedder creates a hashed n-gram embedder producing vectors of
+// length dim (DefaultHashDimension if zero).
+func NewHashEmbedder(dim int) *HashEmbedder {
+	if dim <= 0 {
+		dim = DefaultHashDimension
+	}
+	return &HashEmbedder{dim: dim}
+}
+
+// Embed returns the hashed embedding of every text.
+func (e *HashEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		vectors[i] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+// Dimension returns the length of the vectors.
+func (e *HashEmbedder) Dimension() int { return e.dim }
+
+// Name identifies the embedder and its dimension.
+func (e *HashEmbedder) Name() string { return fmt.Sprintf("hash-ngram-%d", e.dim) }
+
+// embed hashes the features of one text into a unit vector.
+func (e *HashEmbedder) embed(text string) []float32 {
+	counts := make(map[string]float64)
+	words := tokenize(text)
+	for i, w := range words {
+		counts["w:"+w] += wordWeight
+		if i > 0 {
+			counts["b:"+words[i-1]+" "+w] += bigramWeight
+		}
+		padded := []rune("#" + w + "#")
+		for j := 0; j+3 <= len(padded); j++ {
+			counts["c:"+string(padded[j:j+3])] += trigramWeight
+		}
+	}
+
+	acc := make([]float64, e.dim)
+	for feature, weight := range counts {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		// The top bit picks a sign so colliding features tend to cancel out.
+		sign := 1.0
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		acc[sum%uint64(e.dim)] += sign * math.Log1p(weight)
+	}
+	return normalize(acc)
+}
+
+// tokenize lowercases text and splits it into words of letters and digits,
+// dropping single characters.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words := fields[:0]
+	for _, f := range fields {
+		if len([]rune(f)) > 1 {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// normalize scales a vector to unit length. A zero vector stays zero.
+func normalize(v []float64) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += x * x
+	}
+	out := make([]float32, len(v))
+	if norm == 0 {
+		return out
+	}
+	norm = math.Sqrt(norm)
+	for i, x := range v {
+		out[i] = float32(x / norm)
+	}
+	return out
+}
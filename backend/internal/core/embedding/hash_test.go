@@ -0,0 +1,65 @@
+package embedding
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func TestHashEmbedder_Embed(t *testing.T) {
+	// Arrange
+	e := NewHashEmbedder(0)
+
+	// Act
+	vectors, err := e.Embed(context.Background(), []string{
+		"Graph neural networks for molecules",
+		"Neural network models on molecular graphs",
+		"Surface codes for quantum error correction",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(vectors) != 3 || len(vectors[0]) != DefaultHashDimension {
+		t.Fatalf("Expected 3 vectors of %d, got: %d", DefaultHashDimension, len(vectors))
+	}
+	for i, v := range vectors {
+		if norm := math.Sqrt(dot(v, v)); math.Abs(norm-1) > 1e-5 {
+			t.Errorf("Expected unit vector %d, got norm: %v", i, norm)
+		}
+	}
+	related, unrelated := dot(vectors[0], vectors[1]), dot(vectors[0], vectors[2])
+	if related <= unrelated {
+		t.Errorf("Expected paraphrase (%v) closer than unrelated text (%v)", related, unrelated)
+	}
+}
+
+func TestHashEmbedder_Deterministic(t *testing.T) {
+	a, _ := NewHashEmbedder(64).Embed(context.Background(), []string{"Attention is all you need"})
+	b, _ := NewHashEmbedder(64).Embed(context.Background(), []string{"Attention is all you need"})
+
+	if !reflect.DeepEqual(a, b) {
+		t.Error("Expected identical vectors for identical text")
+	}
+}
+
+func TestHashEmbedder_EmptyText(t *testing.T) {
+	vectors, err := NewHashEmbedder(8).Embed(context.Background(), []string{" - "})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if dot(vectors[0], vectors[0]) != 0 {
+		t.Errorf("Expected zero vector, got: %v", vectors[0])
+	}
+}
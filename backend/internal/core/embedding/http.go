@@ -0,0 +1,132 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultBatchSize is the number of texts sent per provider request.
+const DefaultBatchSize = 64
+
+// HTTPConfig holds the configuration of an embedding provider.
+type HTTPConfig struct {
+	URL       string // Embeddings endpoint, e.g. "https://api.openai.com/v1/embeddings"
+	APIKey    string // Sent as a bearer token (optional)
+	Model     string // Model name sent with each request
+	Dimension int    // Vector length the model returns (required)
+	BatchSize int    // Texts per request (DefaultBatchSize if zero)
+}
+
+// HTTPEmbedder embeds texts through an OpenAI-compatible embeddings API:
+// it POSTs {"model", "input"} and reads {"data": [{"index", "embedding"}]}.
+type HTTPEmbedder struct {
+	cfg        HTTPConfig
+	httpClient httpClient
+}
+
+// Ensure HTTPEmbedder implements Embedder interface
+var _ Embedder = (*HTTPEmbedder)(nil)
+
+// embeddingRequest is the body sent to the provider.
+type embeddingRequest struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+// embeddingResponse is the body returned by the provider.
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// NewHTTPEmbedder creates an embedder backed by an embeddings API.
+// Returns ErrInvalidConfig if the URL or dimension is missing.
+func NewHTTPEmbedder(cfg HTTPConfig, client httpClient) (*HTTPEmbedder, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("%w: URL is required", ErrInvalidConfig)
+	}
+	if cfg.Dimension <= 0 {
+		return nil, fmt.Errorf("%w: dimension is required", ErrInvalidConfig)
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	return &HTTPEmbedder{cfg: cfg, httpClient: client}, nil
+}
+
+// Embed requests embeddings in batches and normalizes them to unit length.
+func (e *HTTPEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += e.cfg.BatchSize {
+		end := start + e.cfg.BatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		batch, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+// Dimension returns the length of the vectors.
+func (e *HTTPEmbedder) Dimension() int { return e.cfg.Dimension }
+
+// Name identifies the provider model and its dimension.
+func (e *HTTPEmbedder) Name() string { return fmt.Sprintf("http-%s-%d", e.cfg.Model, e.cfg.Dimension) }
+
+// embedBatch sends one provider request.
+func (e *HTTPEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(&embeddingRequest{Model: e.cfg.Model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRequestFailed, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRequestFailed, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.cfg.APIKey)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%w: status %d: %s", ErrRequestFailed, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+
+	var parsed embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if len(parsed.Data) != len(texts) {
+		return nil, fmt.Errorf("%w: got %d embeddings for %d texts", ErrInvalidResponse, len(parsed.Data), len(texts))
+	}
+
+	// Providers may return items out of order; place them by index.
+	vectors := make([][]float32, len(texts))
+	for _, item := range parsed.Data {
+		if item.Index < 0 || item.Index >= len(texts) || vectors[item.Index] != nil {
+			return nil, fmt.Errorf("%w: bad index %d", ErrInvalidResponse, item.Index)
+		}
+		if len(item.Embedding) != e.cfg.Dimension {
+			return nil, fmt.Errorf("%w: dimension %d, want %d", ErrInvalidResponse, len(item.Embedding), e.cfg.Dimension)
+		}
+		vectors[item.Index] = normalize(item.Embedding)
+	}
+	return vectors, nil
+}
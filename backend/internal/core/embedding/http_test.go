@@ -0,0 +1,105 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newProvider serves embeddings of dim whose first component is the input's
+// length, returning items in reverse order.
+func newProvider(t *testing.T, dim int, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req embeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Expected JSON request, got: %v", err)
+		}
+		var items []string
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			vec := make([]string, dim)
+			for j := range vec {
+				vec[j] = "0"
+			}
+			vec[0] = fmt.Sprint(len(req.Input[i]))
+			items = append(items, fmt.Sprintf(`{"index":%d,"embedding":[%s]}`, i, strings.Join(vec, ",")))
+		}
+		fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(items, ","))
+	}))
+}
+
+func TestHTTPEmbedder_Embed(t *testing.T) {
+	// Arrange
+	requests := 0
+	server := newProvider(t, 4, &requests)
+	defer server.Close()
+	e, err := NewHTTPEmbedder(HTTPConfig{URL: server.URL, APIKey: "secret", Model: "m", Dimension: 4, BatchSize: 2}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Act
+	vectors, err := e.Embed(context.Background(), []string{"a", "bb", "ccc"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 batched requests, got: %d", requests)
+	}
+	if len(vectors) != 3 {
+		t.Fatalf("Expected 3 vectors, got: %d", len(vectors))
+	}
+	for i, v := range vectors {
+		if len(v) != 4 || v[0] != 1 {
+			t.Errorf("Expected normalized vector %d in request order, got: %v", i, v)
+		}
+	}
+	if e.Name() != "http-m-4" {
+		t.Errorf("Expected name http-m-4, got: %s", e.Name())
+	}
+}
+
+func TestHTTPEmbedder_Errors(t *testing.T) {
+	requests := 0
+	server := newProvider(t, 3, &requests)
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		cfg   HTTPConfig
+		check func(error) bool
+	}{
+		{name: "rejected", cfg: HTTPConfig{URL: server.URL, APIKey: "wrong", Dimension: 3}, check: IsRequestFailed},
+		{name: "dimension mismatch", cfg: HTTPConfig{URL: server.URL, APIKey: "secret", Dimension: 4}, check: IsInvalidResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := NewHTTPEmbedder(tt.cfg, http.DefaultClient)
+
+			_, err := e.Embed(context.Background(), []string{"text"})
+
+			if !tt.check(err) {
+				t.Errorf("Expected matching error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewHTTPEmbedder_InvalidConfig(t *testing.T) {
+	_, err := NewHTTPEmbedder(HTTPConfig{URL: "http://localhost"}, http.DefaultClient)
+
+	if !IsInvalidConfig(err) {
+		t.Errorf("Expected ErrInvalidConfig, got: %v", err)
+	}
+}
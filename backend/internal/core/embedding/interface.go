@@ -0,0 +1,19 @@
+package embedding
+
+import "context"
+
+// Embedder turns texts into dense vectors whose cosine similarity reflects
+// how close the texts are in meaning. Implementations return unit-length
+// vectors, so a dot product is a cosine, and are safe for concurrent use.
+type Embedder interface {
+	// Embed returns one vector of length Dimension per text, in order.
+	// Texts without any content yield a zero vector.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+
+	// Dimension returns the length of the vectors.
+	Dimension() int
+
+	// Name identifies the model and its settings. Vectors from embedders
+	// with different names are not comparable.
+	Name() string
+}
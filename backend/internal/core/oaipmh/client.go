@@ -0,0 +1,327 @@
+package oaipmh
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is arXiv's OAI-PMH endpoint.
+const DefaultBaseURL = "https://oaipmh.arxiv.org/oai"
+
+// datestampLayout is the day granularity supported by arXiv.
+const datestampLayout = "2006-01-02"
+
+// rawVersionLayout is the date format of arXivRaw version entries
+// (e.g., "Mon, 2 Apr 2007 19:18:42 GMT").
+const rawVersionLayout = "Mon, 2 Jan 2006 15:04:05 MST"
+
+// Config holds the configuration for the OAI-PMH client.
+type Config struct {
+	BaseURL string
+
+	// Scheduler paces and retries requests; arXiv answers 503 with Retry-After
+	// for flow control. Share the arxiv.Scheduler here; nil sends requests directly.
+	Scheduler scheduler
+}
+
+// Client implements the oaipmh Service interface.
+type Client struct {
+	baseURL    string
+	httpClient httpClient
+	scheduler  scheduler
+}
+
+// Ensure Client implements Service interface
+var _ Service = (*Client)(nil)
+
+// NewClient creates a new OAI-PMH client.
+func NewClient(cfg Config, client httpClient) *Client {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: client,
+		scheduler:  cfg.Scheduler,
+	}
+}
+
+// ListRecords fetches one page of records.
+func (c *Client) ListRecords(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	params, err := c.listParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
+	resp, err := c.get(ctx, reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", ErrRequestFailed, resp.StatusCode)
+	}
+
+	env, err := c.parseEnvelope(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResponse{}
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(env.ResponseDate)); err == nil {
+		result.ResponseDate = t
+	}
+
+	for _, e := range env.Errors {
+		// An empty selection is an error in OAI-PMH but an empty page to us.
+		if e.Code == "noRecordsMatch" {
+			return result, nil
+		}
+		return nil, &ProtocolError{Code: e.Code, Message: cleanText(e.Message)}
+	}
+
+	if env.ListRecords == nil {
+		return nil, fmt.Errorf("%w: missing ListRecords element", ErrInvalidResponse)
+	}
+
+	result.Records = make([]*Record, 0, len(env.ListRecords.Records))
+	for _, r := range env.ListRecords.Records {
+		result.Records = append(result.Records, convertRecord(r))
+	}
+
+	if token := env.ListRecords.ResumptionToken; token != nil {
+		result.ResumptionToken = strings.TrimSpace(token.Value)
+		result.CompleteListSize, _ = strconv.Atoi(token.CompleteListSize)
+		result.Cursor, _ = strconv.Atoi(token.Cursor)
+	}
+
+	return result, nil
+}
+
+// listParams builds the query parameters for a ListRecords request.
+func (c *Client) listParams(req *ListRequest) (url.Values, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request is required", ErrInvalidRequest)
+	}
+
+	params := url.Values{}
+	params.Add("verb", "ListRecords")
+
+	// A resumption token is an exclusive argument.
+	if req.ResumptionToken != "" {
+		params.Add("resumptionToken", req.ResumptionToken)
+		return params, nil
+	}
+
+	switch req.MetadataPrefix {
+	case FormatArXiv, FormatArXivRaw:
+	default:
+		return nil, fmt.Errorf("%w: unsupported metadata format %q", ErrInvalidRequest, req.MetadataPrefix)
+	}
+	if !req.From.IsZero() && !req.Until.IsZero() && req.Until.Before(req.From) {
+		return nil, fmt.Errorf("%w: until is before from", ErrInvalidRequest)
+	}
+
+	params.Add("metadataPrefix", req.MetadataPrefix)
+	if req.Set != "" {
+		params.Add("set", req.Set)
+	}
+	if !req.From.IsZero() {
+		params.Add("from", req.From.UTC().Format(datestampLayout))
+	}
+	if !req.Until.IsZero() {
+		params.Add("until", req.Until.UTC().Format(datestampLayout))
+	}
+	return params, nil
+}
+
+// get performs a GET request, through the scheduler when one is configured.
+func (c *Client) get(ctx context.Context, reqURL string) (*http.Response, error) {
+	if c.scheduler == nil {
+		return c.httpClient.Get(ctx, reqURL)
+	}
+	return c.scheduler.Do(ctx, func(ctx context.Context) (*http.Response, error) {
+		return c.httpClient.Get(ctx, reqURL)
+	})
+}
+
+// parseEnvelope parses the OAI-PMH response body.
+func (c *Client) parseEnvelope(body io.Reader) (*envelope, error) {
+	var env envelope
+	if err := xml.NewDecoder(body).Decode(&env); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse XML: %v", ErrInvalidResponse, err)
+	}
+	return &env, nil
+}
+
+// convertRecord converts a record element to a Record.
+func convertRecord(r recordXML) *Record {
+	record := &Record{
+		Identifier: strings.TrimSpace(r.Header.Identifier),
+		Sets:       r.Header.SetSpecs,
+		Deleted:    r.Header.Status == "deleted",
+	}
+	if t, err := parseDatestamp(r.Header.Datestamp); err == nil {
+		record.Datestamp = t
+	}
+	if record.Deleted {
+		return record
+	}
+
+	switch {
+	case r.Metadata.ArXiv != nil:
+		record.Metadata = convertArXiv(r.Metadata.ArXiv)
+	case r.Metadata.ArXivRaw != nil:
+		record.Metadata = convertArXivRaw(r.Metadata.ArXivRaw)
+	}
+	return record
+}
+
+// convertArXiv converts the "arXiv" metadata format.
+func convertArXiv(m *arXivXML) *Metadata {
+	meta := &Metadata{
+		ID:         strings.TrimSpace(m.ID),
+		Title:      cleanText(m.Title),
+		Abstract:   cleanText(m.Abstract),
+		Categories: strings.Fields(m.Categories),
+		Comments:   cleanText(m.Comments),
+		JournalRef: cleanText(m.JournalRef),
+		DOI:        strings.TrimSpace(m.DOI),
+		License:    strings.TrimSpace(m.License),
+	}
+	if t, err := parseDatestamp(m.Created); err == nil {
+		meta.Created = t
+	}
+	if t, err := parseDatestamp(m.Updated); err == nil {
+		meta.Updated = t
+	}
+
+	meta.Authors = make([]Author, 0, len(m.Authors))
+	for _, a := range m.Authors {
+		name := cleanText(strings.Join([]string{a.ForeNames, a.KeyName, a.Suffix}, " "))
+		if name == "" {
+			continue
+		}
+		author := Author{Name: name}
+		for _, affiliation := range a.Affiliations {
+			if affiliation = cleanText(affiliation); affiliation != "" {
+				author.Affiliations = append(author.Affiliations, affiliation)
+			}
+		}
+		meta.Authors = append(meta.Authors, author)
+	}
+	return meta
+}
+
+// convertArXivRaw converts the "arXivRaw" metadata format.
+func convertArXivRaw(m *arXivRawXML) *Metadata {
+	meta := &Metadata{
+		ID:         strings.TrimSpace(m.ID),
+		Title:      cleanText(m.Title),
+		Abstract:   cleanText(m.Abstract),
+		Authors:    splitRawAuthors(m.Authors),
+		Categories: strings.Fields(m.Categories),
+		Comments:   cleanText(m.Comments),
+		JournalRef: cleanText(m.JournalRef),
+		DOI:        strings.TrimSpace(m.DOI),
+		License:    strings.TrimSpace(m.License),
+	}
+
+	for _, v := range m.Versions {
+		number, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(v.Version), "v"))
+		if err != nil {
+			continue
+		}
+		version := Version{Version: number}
+		if t, err := time.Parse(rawVersionLayout, cleanText(v.Date)); err == nil {
+			version.Date = t.UTC()
+		}
+		meta.Versions = append(meta.Versions, version)
+	}
+
+	if len(meta.Versions) > 0 {
+		meta.Created = meta.Versions[0].Date
+		if len(meta.Versions) > 1 {
+			meta.Updated = meta.Versions[len(meta.Versions)-1].Date
+		}
+	}
+	return meta
+}
+
+// splitRawAuthors splits an arXivRaw author string such as
+// "A. Author (MIT), B. Author and C. Author" into authors.
+// Parenthesized text is read as the affiliation of the preceding author.
+func splitRawAuthors(raw string) []Author {
+	raw = cleanText(raw)
+	if raw == "" {
+		return nil
+	}
+
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(raw); i++ {
+		switch raw[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, raw[start:i])
+				start = i + 1
+			}
+		case ' ':
+			if depth == 0 && strings.HasPrefix(raw[i:], " and ") {
+				parts = append(parts, raw[start:i])
+				start = i + len(" and ")
+				i = start - 1
+			}
+		}
+	}
+	parts = append(parts, raw[start:])
+
+	authors := make([]Author, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		var affiliations []string
+		if open := strings.Index(part, "("); open >= 0 && strings.HasSuffix(part, ")") {
+			for _, a := range strings.Split(part[open+1:len(part)-1], ";") {
+				if a = strings.TrimSpace(a); a != "" {
+					affiliations = append(affiliations, a)
+				}
+			}
+			part = strings.TrimSpace(part[:open])
+		}
+		if part == "" {
+			continue
+		}
+		authors = append(authors, Author{Name: part, Affiliations: affiliations})
+	}
+	return authors
+}
+
+// parseDatestamp parses a day- or second-granularity datestamp.
+func parseDatestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(datestampLayout, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// cleanText removes extra whitespace from text.
+func cleanText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,303 @@
+package oaipmh
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stdHTTPClient adapts http.Client to the httpClient interface.
+type stdHTTPClient struct{}
+
+func (stdHTTPClient) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return http.DefaultClient.Do(req)
+}
+
+const oaiHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/">
+<responseDate>2024-05-02T10:00:00Z</responseDate>`
+
+const arXivPage1 = oaiHeader + `
+<ListRecords>
+  <record>
+    <header>
+      <identifier>oai:arXiv.org:2301.12345</identifier>
+      <datestamp>2024-05-01</datestamp>
+      <setSpec>cs</setSpec>
+    </header>
+    <metadata>
+      <arXiv xmlns="http://arxiv.org/OAI/arXiv/">
+        <id>2301.12345</id>
+        <created>2023-01-15</created>
+        <updated>2024-04-30</updated>
+        <authors>
+          <author><keyname>Doe</keyname><forenames>Jane</forenames><affiliation>MIT</affiliation></author>
+          <author><keyname>Smith</keyname><forenames>John</forenames><suffix>Jr</suffix></author>
+        </authors>
+        <title>Harvested
+          Paper</title>
+        <categories>cs.LG stat.ML</categories>
+        <comments>10 pages</comments>
+        <doi>10.1000/xyz</doi>
+        <license>http://creativecommons.org/licenses/by/4.0/</license>
+        <abstract>  An abstract.  </abstract>
+      </arXiv>
+    </metadata>
+  </record>
+  <record>
+    <header status="deleted">
+      <identifier>oai:arXiv.org:2301.00001</identifier>
+      <datestamp>2024-05-01</datestamp>
+    </header>
+  </record>
+  <resumptionToken cursor="0" completeListSize="3">token-1</resumptionToken>
+</ListRecords>
+</OAI-PMH>`
+
+const arXivPage2 = oaiHeader + `
+<ListRecords>
+  <record>
+    <header>
+      <identifier>oai:arXiv.org:hep-th/9901001</identifier>
+      <datestamp>2024-05-02</datestamp>
+    </header>
+    <metadata>
+      <arXiv xmlns="http://arxiv.org/OAI/arXiv/">
+        <id>hep-th/9901001</id>
+        <created>1999-01-01</created>
+        <title>Old Paper</title>
+        <categories>hep-th</categories>
+      </arXiv>
+    </metadata>
+  </record>
+  <resumptionToken cursor="2" completeListSize="3"></resumptionToken>
+</ListRecords>
+</OAI-PMH>`
+
+const arXivRawPage = oaiHeader + `
+<ListRecords>
+  <record>
+    <header>
+      <identifier>oai:arXiv.org:0704.0001</identifier>
+      <datestamp>2008-11-13</datestamp>
+    </header>
+    <metadata>
+      <arXivRaw xmlns="http://arxiv.org/OAI/arXivRaw/">
+        <id>0704.0001</id>
+        <version version="v1"><date>Mon, 2 Apr 2007 19:18:42 GMT</date><size>37kb</size></version>
+        <version version="v2"><date>Tue, 24 Jul 2007 20:10:27 GMT</date><size>37kb</size></version>
+        <title>Calculation of prompt diphoton production</title>
+        <authors>C. Bal\'azs, E. L. Berger (ANL), P. M. Nadolsky and
+          C.-P. Yuan</authors>
+        <categories>hep-ph</categories>
+        <journal-ref>Phys.Rev.D76:013009,2007</journal-ref>
+        <abstract>A calculation.</abstract>
+      </arXivRaw>
+    </metadata>
+  </record>
+</ListRecords>
+</OAI-PMH>`
+
+// newOAIServer starts a stand-in OAI-PMH endpoint serving responses keyed by resumption token.
+func newOAIServer(t *testing.T, pages map[string]string, requests *[]url.Values) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*requests = append(*requests, query)
+		body, ok := pages[query.Get("resumptionToken")]
+		if !ok {
+			body = oaiHeader + `<error code="badResumptionToken">The token is expired</error></OAI-PMH>`
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_ListRecords(t *testing.T) {
+	// Arrange
+	var requests []url.Values
+	server := newOAIServer(t, map[string]string{"": arXivPage1, "token-1": arXivPage2}, &requests)
+	client := NewClient(Config{BaseURL: server.URL}, stdHTTPClient{})
+
+	// Act
+	first, err := client.ListRecords(context.Background(), &ListRequest{
+		MetadataPrefix: FormatArXiv,
+		Set:            "cs",
+		From:           time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Until:          time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	second, err := client.ListRecords(context.Background(), &ListRequest{ResumptionToken: first.ResumptionToken})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := requests[0].Encode(); got != "from=2024-05-01&metadataPrefix=arXiv&set=cs&until=2024-05-02&verb=ListRecords" {
+		t.Errorf("Unexpected first request: %s", got)
+	}
+	if got := requests[1].Encode(); got != "resumptionToken=token-1&verb=ListRecords" {
+		t.Errorf("Expected resumption request with exclusive token, got: %s", got)
+	}
+
+	if first.ResumptionToken != "token-1" || first.CompleteListSize != 3 {
+		t.Errorf("Expected token-1 of 3 records, got: %q of %d", first.ResumptionToken, first.CompleteListSize)
+	}
+	if len(first.Records) != 2 {
+		t.Fatalf("Expected 2 records, got: %d", len(first.Records))
+	}
+
+	meta := first.Records[0].Metadata
+	if meta == nil {
+		t.Fatal("Expected metadata, got nil")
+	}
+	if meta.ID != "2301.12345" || meta.Title != "Harvested Paper" || meta.Abstract != "An abstract." {
+		t.Errorf("Unexpected metadata: %+v", meta)
+	}
+	if len(meta.Authors) != 2 || meta.Authors[0].Name != "Jane Doe" || meta.Authors[0].Affiliations[0] != "MIT" || meta.Authors[1].Name != "John Smith Jr" {
+		t.Errorf("Unexpected authors: %+v", meta.Authors)
+	}
+	if len(meta.Categories) != 2 || meta.Categories[0] != "cs.LG" {
+		t.Errorf("Unexpected categories: %v", meta.Categories)
+	}
+	if !meta.Updated.Equal(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected updated date: %v", meta.Updated)
+	}
+	if !first.Records[1].Deleted || first.Records[1].Metadata != nil {
+		t.Errorf("Expected second record to be a deletion, got: %+v", first.Records[1])
+	}
+
+	if second.ResumptionToken != "" {
+		t.Errorf("Expected list to be complete, got token: %q", second.ResumptionToken)
+	}
+	if len(second.Records) != 1 || second.Records[0].Metadata.ID != "hep-th/9901001" {
+		t.Errorf("Unexpected second page: %+v", second.Records)
+	}
+}
+
+func TestClient_ListRecords_ArXivRaw(t *testing.T) {
+	// Arrange
+	var requests []url.Values
+	server := newOAIServer(t, map[string]string{"": arXivRawPage}, &requests)
+	client := NewClient(Config{BaseURL: server.URL}, stdHTTPClient{})
+
+	// Act
+	resp, err := client.ListRecords(context.Background(), &ListRequest{MetadataPrefix: FormatArXivRaw})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	meta := resp.Records[0].Metadata
+	if len(meta.Versions) != 2 || meta.Versions[1].Version != 2 {
+		t.Fatalf("Expected 2 versions, got: %+v", meta.Versions)
+	}
+	if !meta.Created.Equal(time.Date(2007, 4, 2, 19, 18, 42, 0, time.UTC)) {
+		t.Errorf("Expected created from v1 date, got: %v", meta.Created)
+	}
+	if !meta.Updated.Equal(time.Date(2007, 7, 24, 20, 10, 27, 0, time.UTC)) {
+		t.Errorf("Expected updated from latest version date, got: %v", meta.Updated)
+	}
+
+	var names []string
+	for _, a := range meta.Authors {
+		names = append(names, a.Name)
+	}
+	if strings.Join(names, "|") != `C. Bal\'azs|E. L. Berger|P. M. Nadolsky|C.-P. Yuan` {
+		t.Errorf("Unexpected authors: %v", names)
+	}
+	if len(meta.Authors[1].Affiliations) != 1 || meta.Authors[1].Affiliations[0] != "ANL" {
+		t.Errorf("Expected affiliation ANL, got: %+v", meta.Authors[1])
+	}
+}
+
+func TestClient_ListRecords_NoRecordsMatch(t *testing.T) {
+	// Arrange
+	var requests []url.Values
+	page := oaiHeader + `<error code="noRecordsMatch">No records</error></OAI-PMH>`
+	server := newOAIServer(t, map[string]string{"": page}, &requests)
+	client := NewClient(Config{BaseURL: server.URL}, stdHTTPClient{})
+
+	// Act
+	resp, err := client.ListRecords(context.Background(), &ListRequest{MetadataPrefix: FormatArXiv})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(resp.Records) != 0 || resp.ResumptionToken != "" {
+		t.Errorf("Expected empty complete list, got: %+v", resp)
+	}
+	if resp.ResponseDate.IsZero() {
+		t.Error("Expected response date to be parsed")
+	}
+}
+
+func TestClient_ListRecords_BadResumptionToken(t *testing.T) {
+	// Arrange
+	var requests []url.Values
+	server := newOAIServer(t, map[string]string{}, &requests)
+	client := NewClient(Config{BaseURL: server.URL}, stdHTTPClient{})
+
+	// Act
+	_, err := client.ListRecords(context.Background(), &ListRequest{ResumptionToken: "expired"})
+
+	// Assert
+	if !IsBadResumptionToken(err) {
+		t.Errorf("Expected ErrBadResumptionToken, got: %v", err)
+	}
+}
+
+func TestClient_ListRecords_InvalidRequest(t *testing.T) {
+	client := NewClient(Config{BaseURL: "http://test.invalid"}, stdHTTPClient{})
+	tests := []struct {
+		name string
+		req  *ListRequest
+	}{
+		{"nil request", nil},
+		{"unknown format", &ListRequest{MetadataPrefix: "oai_dc"}},
+		{"reversed range", &ListRequest{
+			MetadataPrefix: FormatArXiv,
+			From:           time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+			Until:          time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ListRecords(context.Background(), tt.req)
+			if !IsInvalidRequest(err) {
+				t.Errorf("Expected ErrInvalidRequest, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_ListRecords_HTTPError(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client := NewClient(Config{BaseURL: server.URL}, stdHTTPClient{})
+
+	// Act
+	_, err := client.ListRecords(context.Background(), &ListRequest{MetadataPrefix: FormatArXiv})
+
+	// Assert
+	if !IsRequestFailed(err) {
+		t.Errorf("Expected ErrRequestFailed, got: %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package oaipmh
+
+import (
+	"context"
+	"net/http"
+)
+
+// httpClient defines the HTTP client capability required by this service.
+type httpClient interface {
+	// Get performs an HTTP GET request.
+	Get(ctx context.Context, url string) (*http.Response, error)
+}
+
+// scheduler paces and retries outgoing requests.
+// arxiv.Scheduler satisfies it, so the OAI and search APIs can share one request budget.
+type scheduler interface {
+	// Do sends a request through the scheduler.
+	Do(ctx context.Context, send func(ctx context.Context) (*http.Response, error)) (*http.Response, error)
+}
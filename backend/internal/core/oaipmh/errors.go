@@ -0,0 +1,58 @@
+package oaipmh
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrRequestFailed indicates that the OAI-PMH request could not be completed.
+	ErrRequestFailed = errors.New("OAI-PMH request failed")
+
+	// ErrInvalidResponse indicates that the endpoint returned a malformed response.
+	ErrInvalidResponse = errors.New("invalid OAI-PMH response")
+
+	// ErrBadResumptionToken indicates that the resumption token is invalid or has expired.
+	ErrBadResumptionToken = errors.New("bad OAI-PMH resumption token")
+
+	// ErrInvalidRequest indicates that the request parameters are invalid.
+	ErrInvalidRequest = errors.New("invalid OAI-PMH request")
+)
+
+// ProtocolError is an <error> element returned by the repository.
+type ProtocolError struct {
+	Code    string // OAI-PMH error code (e.g., "badArgument")
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ProtocolError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("OAI-PMH error %s", e.Code)
+	}
+	return fmt.Sprintf("OAI-PMH error %s: %s", e.Code, e.Message)
+}
+
+// Unwrap maps protocol error codes to sentinel errors.
+func (e *ProtocolError) Unwrap() error {
+	switch e.Code {
+	case "badResumptionToken":
+		return ErrBadResumptionToken
+	case "badArgument", "cannotDisseminateFormat", "noSetHierarchy":
+		return ErrInvalidRequest
+	default:
+		return ErrRequestFailed
+	}
+}
+
+// IsRequestFailed checks if the error is ErrRequestFailed.
+func IsRequestFailed(err error) bool { return errors.Is(err, ErrRequestFailed) }
+
+// IsInvalidResponse checks if the error is ErrInvalidResponse.
+func IsInvalidResponse(err error) bool { return errors.Is(err, ErrInvalidResponse) }
+
+// IsBadResumptionToken checks if the error is ErrBadResumptionToken.
+func IsBadResumptionToken(err error) bool { return errors.Is(err, ErrBadResumptionToken) }
+
+// IsInvalidRequest checks if the error is ErrInvalidRequest.
+func IsInvalidRequest(err error) bool { return errors.Is(err, ErrInvalidRequest) }
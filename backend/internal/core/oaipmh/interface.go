@@ -0,0 +1,18 @@
+package oaipmh
+
+import "context"
+
+// Service defines the interface for OAI-PMH harvesting operations.
+// This interface is used by Features that ingest arXiv metadata in bulk.
+type Service interface {
+	// ListRecords fetches one page of records.
+	// Pass the ResumptionToken of the previous page to continue a list;
+	// an empty token in the response means the list is complete.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - req: list parameters (format, set, date range or resumption token)
+	// @Returns:
+	//   - *ListResponse: records of this page and the token for the next one
+	//   - error: ErrInvalidRequest, ErrBadResumptionToken, ErrRequestFailed or ErrInvalidResponse
+	ListRecords(ctx context.Context, req *ListRequest) (*ListResponse, error)
+}
@@ -0,0 +1,152 @@
+package oaipmh
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Metadata formats supported by arXiv's OAI-PMH endpoint.
+const (
+	// FormatArXiv is the "arXiv" format: structured authors, created/updated dates.
+	FormatArXiv = "arXiv"
+	// FormatArXivRaw is the "arXivRaw" format: raw author string and the full version history.
+	FormatArXivRaw = "arXivRaw"
+)
+
+// ListRequest contains parameters for a ListRecords request.
+// When ResumptionToken is set all other fields are ignored, as the protocol requires.
+type ListRequest struct {
+	MetadataPrefix  string    // FormatArXiv or FormatArXivRaw
+	Set             string    // Selective harvesting set (e.g., "cs", "physics:hep-th"); empty for all
+	From            time.Time // Inclusive lower bound on record datestamps (zero for none)
+	Until           time.Time // Inclusive upper bound on record datestamps (zero for none)
+	ResumptionToken string    // Token from the previous page
+}
+
+// ListResponse is one page of a ListRecords result.
+type ListResponse struct {
+	ResponseDate     time.Time // Server time when the response was generated
+	Records          []*Record
+	ResumptionToken  string // Empty when the list is complete
+	CompleteListSize int    // Total records in the list, if reported
+	Cursor           int    // Position of this page in the list, if reported
+}
+
+// Record is a single harvested record.
+type Record struct {
+	Identifier string    // OAI identifier (e.g., "oai:arXiv.org:2301.12345")
+	Datestamp  time.Time // Last change of the record
+	Sets       []string  // Sets the record belongs to
+	Deleted    bool      // The record was withdrawn; Metadata is nil
+	Metadata   *Metadata
+}
+
+// Metadata is the paper metadata of a record, normalized across formats.
+type Metadata struct {
+	ID         string // arXiv identifier without version
+	Title      string
+	Abstract   string
+	Authors    []Author
+	Categories []string // First entry is the primary category
+	Comments   string
+	JournalRef string
+	DOI        string
+	License    string
+	Created    time.Time // Submission date of the first version
+	Updated    time.Time // Submission date of the latest version (zero if never revised)
+	Versions   []Version // Only populated by FormatArXivRaw
+}
+
+// Author is a paper author.
+type Author struct {
+	Name         string
+	Affiliations []string
+}
+
+// Version is one submitted version of a paper.
+type Version struct {
+	Version int
+	Date    time.Time
+}
+
+// envelope is the root element of every OAI-PMH response.
+type envelope struct {
+	XMLName      xml.Name     `xml:"OAI-PMH"`
+	ResponseDate string       `xml:"responseDate"`
+	Errors       []errorXML   `xml:"error"`
+	ListRecords  *listRecords `xml:"ListRecords"`
+}
+
+type errorXML struct {
+	Code    string `xml:"code,attr"`
+	Message string `xml:",chardata"`
+}
+
+type listRecords struct {
+	Records         []recordXML         `xml:"record"`
+	ResumptionToken *resumptionTokenXML `xml:"resumptionToken"`
+}
+
+type resumptionTokenXML struct {
+	Value            string `xml:",chardata"`
+	CompleteListSize string `xml:"completeListSize,attr"`
+	Cursor           string `xml:"cursor,attr"`
+}
+
+type recordXML struct {
+	Header   headerXML   `xml:"header"`
+	Metadata metadataXML `xml:"metadata"`
+}
+
+type headerXML struct {
+	Status     string   `xml:"status,attr"`
+	Identifier string   `xml:"identifier"`
+	Datestamp  string   `xml:"datestamp"`
+	SetSpecs   []string `xml:"setSpec"`
+}
+
+type metadataXML struct {
+	ArXiv    *arXivXML    `xml:"http://arxiv.org/OAI/arXiv/ arXiv"`
+	ArXivRaw *arXivRawXML `xml:"http://arxiv.org/OAI/arXivRaw/ arXivRaw"`
+}
+
+// arXivXML is the "arXiv" metadata format.
+type arXivXML struct {
+	ID         string           `xml:"id"`
+	Created    string           `xml:"created"`
+	Updated    string           `xml:"updated"`
+	Authors    []arXivAuthorXML `xml:"authors>author"`
+	Title      string           `xml:"title"`
+	Categories string           `xml:"categories"`
+	Comments   string           `xml:"comments"`
+	JournalRef string           `xml:"journal-ref"`
+	DOI        string           `xml:"doi"`
+	License    string           `xml:"license"`
+	Abstract   string           `xml:"abstract"`
+}
+
+type arXivAuthorXML struct {
+	KeyName      string   `xml:"keyname"`
+	ForeNames    string   `xml:"forenames"`
+	Suffix       string   `xml:"suffix"`
+	Affiliations []string `xml:"affiliation"`
+}
+
+// arXivRawXML is the "arXivRaw" metadata format.
+type arXivRawXML struct {
+	ID         string          `xml:"id"`
+	Versions   []rawVersionXML `xml:"version"`
+	Title      string          `xml:"title"`
+	Authors    string          `xml:"authors"`
+	Categories string          `xml:"categories"`
+	Comments   string          `xml:"comments"`
+	JournalRef string          `xml:"journal-ref"`
+	DOI        string          `xml:"doi"`
+	License    string          `xml:"license"`
+	Abstract   string          `xml:"abstract"`
+}
+
+type rawVersionXML struct {
+	Version string `xml:"version,attr"`
+	Date    string `xml:"date"`
+}
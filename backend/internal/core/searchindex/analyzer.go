@@ -0,0 +1,42 @@
+package searchindex
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are common English words that carry no meaning for search.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "from": true, "has": true,
+	"have": true, "if": true, "in": true, "into": true, "is": true, "it": true,
+	"its": true, "no": true, "not": true, "of": true, "on": true, "or": true,
+	"our": true, "such": true, "that": true, "the": true, "their": true,
+	"then": true, "there": true, "these": true, "they": true, "this": true,
+	"to": true, "was": true, "we": true, "were": true, "which": true,
+	"will": true, "with": true,
+}
+
+// Analyze splits text into search terms: it lowercases, splits on anything
+// that is not a letter or digit, drops stop words and single letters, and
+// reduces English words to their Porter stem. Duplicates are kept so term
+// frequencies can be counted.
+func Analyze(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := words[:0]
+	for _, w := range words {
+		if stopWords[w] || (len(w) == 1 && !unicode.IsDigit(rune(w[0]))) {
+			continue
+		}
+		terms = append(terms, stem(w))
+	}
+	return terms
+}
+
+// normalizeKeyword prepares a keyword value for exact matching.
+func normalizeKeyword(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
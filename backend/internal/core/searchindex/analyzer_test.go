@@ -0,0 +1,94 @@
+package searchindex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	// Expected stems from Porter's reference vocabulary.
+	tests := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"ties":           "ti",
+		"cats":           "cat",
+		"feed":           "feed",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"sing":           "sing",
+		"conflated":      "conflat",
+		"sized":          "size",
+		"hopping":        "hop",
+		"falling":        "fall",
+		"hissing":        "hiss",
+		"filing":         "file",
+		"happy":          "happi",
+		"sky":            "sky",
+		"relational":     "relat",
+		"conditional":    "condit",
+		"rational":       "ration",
+		"generalization": "gener",
+		"electrical":     "electr",
+		"hopeful":        "hope",
+		"goodness":       "good",
+		"adjustment":     "adjust",
+		"adoption":       "adopt",
+		"controll":       "control",
+		"networks":       "network",
+		"learning":       "learn",
+		"learned":        "learn",
+		"bert":           "bert",
+		"gpt4":           "gpt4",
+	}
+
+	for word, want := range tests {
+		if got := stem(word); got != want {
+			t.Errorf("stem(%q): expected %q, got: %q", word, want, got)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "stems and lowercases",
+			text: "Learning Graph Networks",
+			want: []string{"learn", "graph", "network"},
+		},
+		{
+			name: "drops stop words and single letters",
+			text: "A study of the k-means algorithm",
+			want: []string{"studi", "mean", "algorithm"},
+		},
+		{
+			name: "keeps numbers and repeated terms",
+			text: "GPT-4 beats GPT-3, 2 times",
+			want: []string{"gpt", "4", "beat", "gpt", "3", "2", "time"},
+		},
+		{
+			name: "only stop words",
+			text: "of the and",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := Analyze(tt.text)
+
+			// Assert
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
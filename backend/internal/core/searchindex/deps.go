@@ -0,0 +1,26 @@
+package searchindex
+
+// Config holds the ranking parameters of the index.
+type Config struct {
+	// K1 controls term frequency saturation. Typical values are 1.2-2.0.
+	K1 float64
+
+	// B controls document length normalization, from 0 (none) to 1 (full).
+	B float64
+
+	// FieldBoosts weighs matches per text field, e.g. {"title": 3, "abstract": 1}.
+	// Fields not listed have a boost of 1.
+	FieldBoosts map[string]float64
+
+	// KeywordWeights weighs keyword fields in Similar, e.g. {"category": 1}.
+	// Keyword fields not listed are ignored by Similar.
+	KeywordWeights map[string]float64
+}
+
+// DefaultConfig returns the standard BM25 parameters with no field boosts.
+func DefaultConfig() Config {
+	return Config{
+		K1: 1.2,
+		B:  0.75,
+	}
+}
@@ -0,0 +1,24 @@
+package searchindex
+
+import "errors"
+
+var (
+	// ErrEmptyQuery indicates that a query has nothing to match on.
+	ErrEmptyQuery = errors.New("empty search query")
+
+	// ErrBadSnapshot indicates that an index snapshot is corrupt or was
+	// written by an incompatible version.
+	ErrBadSnapshot = errors.New("invalid index snapshot")
+
+	// ErrNotIndexed indicates that a document is not in the index.
+	ErrNotIndexed = errors.New("document is not indexed")
+)
+
+// IsEmptyQuery checks if the error is ErrEmptyQuery.
+func IsEmptyQuery(err error) bool { return errors.Is(err, ErrEmptyQuery) }
+
+// IsBadSnapshot checks if the error is ErrBadSnapshot.
+func IsBadSnapshot(err error) bool { return errors.Is(err, ErrBadSnapshot) }
+
+// IsNotIndexed checks if the error is ErrNotIndexed.
+func IsNotIndexed(err error) bool { return errors.Is(err, ErrNotIndexed) }
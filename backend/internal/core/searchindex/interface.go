@@ -0,0 +1,37 @@
+package searchindex
+
+import "io"
+
+// Service defines the interface for an embedded full-text index.
+// Documents are analyzed into stemmed terms per field and ranked with
+// BM25, with a configurable boost per field. All methods are safe for
+// concurrent use.
+type Service interface {
+	// Add indexes documents, replacing any already indexed under the same ID.
+	Add(docs ...*Document)
+
+	// Remove drops documents from the index. Unknown IDs are ignored.
+	Remove(ids ...string)
+
+	// Search returns the documents matching the query, best match first.
+	// Returns ErrEmptyQuery if the query has no terms, clauses, IDs or date bound.
+	Search(q *Query) (*Result, error)
+
+	// Similar returns the documents most similar to an indexed document,
+	// most similar first, with cosine similarity scores in (0, 1].
+	// Returns ErrNotIndexed if the document is not in the index.
+	Similar(id string, limit int) (*Result, error)
+
+	// Len returns the number of indexed documents.
+	Len() int
+
+	// Reset removes every document.
+	Reset()
+
+	// Save writes a snapshot of the index to w.
+	Save(w io.Writer) error
+
+	// Load replaces the index contents with a snapshot written by Save.
+	// Returns ErrBadSnapshot if the snapshot cannot be read.
+	Load(r io.Reader) error
+}
@@ -0,0 +1,520 @@
+package searchindex
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// snapshotFormat is bumped whenever the snapshot layout or the analyzer
+// changes, so stale snapshots are rejected instead of silently misranking.
+const snapshotFormat = 1
+
+// Impl implements the Service interface with an in-memory inverted index.
+type Impl struct {
+	cfg Config
+
+	mu       sync.RWMutex
+	docs     map[string]*document
+	postings map[string]map[string]map[string]int  // field -> term -> doc ID -> term frequency
+	keywords map[string]map[string]map[string]bool // field -> value -> doc IDs
+	totalLen map[string]int                        // field -> total terms across documents
+}
+
+// document is the indexed form of a Document. Fields are exported for gob.
+type document struct {
+	Terms    map[string]map[string]int // field -> term -> frequency
+	Lengths  map[string]int            // field -> number of terms
+	Keywords map[string][]string       // field -> normalized values
+	Date     int64                     // Unix nanoseconds, 0 if unknown
+}
+
+// snapshot is the on-disk form of the index.
+type snapshot struct {
+	Format int
+	Docs   map[string]*document
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates an empty index.
+func New(cfg Config) *Impl {
+	if cfg.K1 <= 0 {
+		cfg.K1 = DefaultConfig().K1
+	}
+	if cfg.B < 0 || cfg.B > 1 {
+		cfg.B = DefaultConfig().B
+	}
+
+	idx := &Impl{cfg: cfg}
+	idx.reset()
+	return idx
+}
+
+// Add indexes documents, replacing any already indexed under the same ID.
+func (idx *Impl) Add(docs ...*Document) {
+	analyzed := make([]*document, len(docs))
+	for i, d := range docs {
+		analyzed[i] = analyzeDocument(d)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for i, d := range docs {
+		if d == nil || d.ID == "" {
+			continue
+		}
+		idx.remove(d.ID)
+		idx.insert(d.ID, analyzed[i])
+	}
+}
+
+// Remove drops documents from the index.
+func (idx *Impl) Remove(ids ...string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, id := range ids {
+		idx.remove(id)
+	}
+}
+
+// Len returns the number of indexed documents.
+func (idx *Impl) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// Reset removes every document.
+func (idx *Impl) Reset() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.reset()
+}
+
+// Search returns the documents matching the query, best match first.
+// Ties, including every hit of a query without text, are ordered by date,
+// newest first, then by ID.
+func (idx *Impl) Search(q *Query) (*Result, error) {
+	if q == nil {
+		return nil, ErrEmptyQuery
+	}
+
+	textTerms := Analyze(q.Text)
+	type clauseTerms struct {
+		field string
+		terms []string
+	}
+	var scored []clauseTerms
+	if len(textTerms) > 0 {
+		scored = append(scored, clauseTerms{terms: textTerms})
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// Collect the candidate set from free text and clauses.
+	var candidates map[string]bool
+	restricted := false
+	narrow := func(set map[string]bool) {
+		if !restricted {
+			candidates, restricted = set, true
+			return
+		}
+		candidates = intersect(candidates, set)
+	}
+
+	if len(textTerms) > 0 {
+		narrow(idx.matchTerms("", textTerms))
+	}
+
+	var must map[string]bool
+	mustUsed := false
+	for _, c := range q.Must {
+		set, terms, ok := idx.matchClause(c)
+		if !ok {
+			continue
+		}
+		if len(terms) > 0 {
+			scored = append(scored, clauseTerms{field: c.Field, terms: terms})
+		}
+		switch {
+		case !mustUsed:
+			must, mustUsed = set, true
+		case q.MatchAny:
+			must = union(must, set)
+		default:
+			must = intersect(must, set)
+		}
+	}
+	if mustUsed {
+		narrow(must)
+	}
+
+	if q.IDs != nil {
+		set := make(map[string]bool, len(q.IDs))
+		for _, id := range q.IDs {
+			if _, ok := idx.docs[id]; ok {
+				set[id] = true
+			}
+		}
+		narrow(set)
+	}
+
+	from, until := q.From.UnixNano(), q.Until.UnixNano()
+	hasRange := !q.From.IsZero() || !q.Until.IsZero()
+	if !restricted {
+		if !hasRange {
+			return nil, ErrEmptyQuery
+		}
+		candidates = make(map[string]bool, len(idx.docs))
+		for id := range idx.docs {
+			candidates[id] = true
+		}
+	}
+
+	for _, c := range q.MustNot {
+		if set, _, ok := idx.matchClause(c); ok {
+			for id := range set {
+				delete(candidates, id)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(candidates))
+	for id := range candidates {
+		doc := idx.docs[id]
+		if hasRange {
+			if doc.Date == 0 || (!q.From.IsZero() && doc.Date < from) || (!q.Until.IsZero() && doc.Date > until) {
+				continue
+			}
+		}
+		hits = append(hits, Hit{ID: id})
+	}
+
+	// Score every hit against the free text and the text clauses.
+	for _, s := range scored {
+		for _, term := range s.terms {
+			idf := idx.idf(term)
+			for i := range hits {
+				hits[i].Score += idf * idx.termScore(hits[i].ID, s.field, term)
+			}
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		di, dj := idx.docs[hits[i].ID].Date, idx.docs[hits[j].ID].Date
+		if di != dj {
+			return di > dj
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	result := &Result{Hits: []Hit{}, Total: len(hits)}
+	if q.Offset < len(hits) {
+		end := q.Offset + limit
+		if end > len(hits) {
+			end = len(hits)
+		}
+		result.Hits = hits[q.Offset:end]
+	}
+	return result, nil
+}
+
+// Save writes a snapshot of the index to w.
+func (idx *Impl) Save(w io.Writer) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	if err := gob.NewEncoder(w).Encode(&snapshot{Format: snapshotFormat, Docs: idx.docs}); err != nil {
+		return fmt.Errorf("failed to write index snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load replaces the index contents with a snapshot written by Save.
+// The index is left unchanged if the snapshot cannot be read.
+func (idx *Impl) Load(r io.Reader) error {
+	var snap snapshot
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("%w: %v", ErrBadSnapshot, err)
+	}
+	if snap.Format != snapshotFormat {
+		return fmt.Errorf("%w: format %d, want %d", ErrBadSnapshot, snap.Format, snapshotFormat)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.reset()
+	for id, doc := range snap.Docs {
+		if doc != nil {
+			idx.insert(id, doc)
+		}
+	}
+	return nil
+}
+
+// reset empties the index. The caller must hold the write lock.
+func (idx *Impl) reset() {
+	idx.docs = make(map[string]*document)
+	idx.postings = make(map[string]map[string]map[string]int)
+	idx.keywords = make(map[string]map[string]map[string]bool)
+	idx.totalLen = make(map[string]int)
+}
+
+// analyzeDocument turns a Document into its indexed form.
+func analyzeDocument(d *Document) *document {
+	if d == nil {
+		return nil
+	}
+
+	doc := &document{
+		Terms:    make(map[string]map[string]int, len(d.Fields)),
+		Lengths:  make(map[string]int, len(d.Fields)),
+		Keywords: make(map[string][]string, len(d.Keywords)),
+	}
+	if !d.Date.IsZero() {
+		doc.Date = d.Date.UnixNano()
+	}
+	for field, text := range d.Fields {
+		terms := Analyze(text)
+		if len(terms) == 0 {
+			continue
+		}
+		freqs := make(map[string]int, len(terms))
+		for _, t := range terms {
+			freqs[t]++
+		}
+		doc.Terms[field] = freqs
+		doc.Lengths[field] = len(terms)
+	}
+	for field, values := range d.Keywords {
+		for _, v := range values {
+			if v = normalizeKeyword(v); v != "" {
+				doc.Keywords[field] = append(doc.Keywords[field], v)
+			}
+		}
+	}
+	return doc
+}
+
+// insert adds an analyzed document. The caller must hold the write lock.
+func (idx *Impl) insert(id string, doc *document) {
+	idx.docs[id] = doc
+	for field, freqs := range doc.Terms {
+		terms := idx.postings[field]
+		if terms == nil {
+			terms = make(map[string]map[string]int)
+			idx.postings[field] = terms
+		}
+		for term, tf := range freqs {
+			if terms[term] == nil {
+				terms[term] = make(map[string]int)
+			}
+			terms[term][id] = tf
+		}
+		idx.totalLen[field] += doc.Lengths[field]
+	}
+	for field, values := range doc.Keywords {
+		byValue := idx.keywords[field]
+		if byValue == nil {
+			byValue = make(map[string]map[string]bool)
+			idx.keywords[field] = byValue
+		}
+		for _, v := range values {
+			if byValue[v] == nil {
+				byValue[v] = make(map[string]bool)
+			}
+			byValue[v][id] = true
+		}
+	}
+}
+
+// remove drops a document. The caller must hold the write lock.
+func (idx *Impl) remove(id string) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	delete(idx.docs, id)
+
+	for field, freqs := range doc.Terms {
+		for term := range freqs {
+			delete(idx.postings[field][term], id)
+			if len(idx.postings[field][term]) == 0 {
+				delete(idx.postings[field], term)
+			}
+		}
+		idx.totalLen[field] -= doc.Lengths[field]
+	}
+	for field, values := range doc.Keywords {
+		for _, v := range values {
+			delete(idx.keywords[field][v], id)
+			if len(idx.keywords[field][v]) == 0 {
+				delete(idx.keywords[field], v)
+			}
+		}
+	}
+}
+
+// isKeywordField reports whether clauses on field use exact matching.
+func (idx *Impl) isKeywordField(field string) bool {
+	if field == "" {
+		return false
+	}
+	if _, boosted := idx.cfg.FieldBoosts[field]; boosted {
+		return false
+	}
+	_, ok := idx.keywords[field]
+	return ok
+}
+
+// matchClause returns the documents matching a clause and, for text
+// clauses, the terms to score. ok is false if the clause has no terms.
+func (idx *Impl) matchClause(c Clause) (set map[string]bool, terms []string, ok bool) {
+	if idx.isKeywordField(c.Field) {
+		value := normalizeKeyword(c.Text)
+		if value == "" {
+			return nil, nil, false
+		}
+		return idx.matchKeyword(c.Field, value), nil, true
+	}
+
+	terms = Analyze(c.Text)
+	if len(terms) == 0 {
+		return nil, nil, false
+	}
+	return idx.matchTerms(c.Field, terms), terms, true
+}
+
+// matchKeyword returns the documents with a keyword value, or with a value
+// starting with the given prefix if it ends with "*".
+func (idx *Impl) matchKeyword(field, value string) map[string]bool {
+	byValue := idx.keywords[field]
+	prefix, isPrefix := strings.CutSuffix(value, "*")
+	if !isPrefix {
+		return copySet(byValue[value])
+	}
+
+	set := make(map[string]bool)
+	for v, ids := range byValue {
+		if strings.HasPrefix(v, prefix) {
+			for id := range ids {
+				set[id] = true
+			}
+		}
+	}
+	return set
+}
+
+// matchTerms returns the documents containing every term in field, or in
+// any text field if field is empty.
+func (idx *Impl) matchTerms(field string, terms []string) map[string]bool {
+	var set map[string]bool
+	for i, term := range terms {
+		docs := idx.docsWithTerm(field, term)
+		if i == 0 {
+			set = docs
+		} else {
+			set = intersect(set, docs)
+		}
+		if len(set) == 0 {
+			break
+		}
+	}
+	return set
+}
+
+// docsWithTerm returns the documents containing term in field, or in any
+// text field if field is empty.
+func (idx *Impl) docsWithTerm(field, term string) map[string]bool {
+	set := make(map[string]bool)
+	for f, terms := range idx.postings {
+		if field != "" && f != field {
+			continue
+		}
+		for id := range terms[term] {
+			set[id] = true
+		}
+	}
+	return set
+}
+
+// idf is the BM25 inverse document frequency of term across all text fields.
+func (idx *Impl) idf(term string) float64 {
+	n := float64(len(idx.docs))
+	df := float64(len(idx.docsWithTerm("", term)))
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// termScore sums the boosted BM25 term weight of term in a document over
+// field, or over every text field if field is empty.
+func (idx *Impl) termScore(id, field, term string) float64 {
+	doc := idx.docs[id]
+	score := 0.0
+	for f, freqs := range doc.Terms {
+		if field != "" && f != field {
+			continue
+		}
+		tf := float64(freqs[term])
+		if tf == 0 {
+			continue
+		}
+		avgLen := float64(idx.totalLen[f]) / float64(len(idx.docs))
+		norm := 1 - idx.cfg.B + idx.cfg.B*float64(doc.Lengths[f])/avgLen
+		score += idx.boost(f) * tf * (idx.cfg.K1 + 1) / (tf + idx.cfg.K1*norm)
+	}
+	return score
+}
+
+// boost returns the weight of a text field.
+func (idx *Impl) boost(field string) float64 {
+	if b, ok := idx.cfg.FieldBoosts[field]; ok {
+		return b
+	}
+	return 1
+}
+
+// intersect returns the elements present in both sets.
+func intersect(a, b map[string]bool) map[string]bool {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	set := make(map[string]bool, len(a))
+	for id := range a {
+		if b[id] {
+			set[id] = true
+		}
+	}
+	return set
+}
+
+// union returns the elements present in either set.
+func union(a, b map[string]bool) map[string]bool {
+	set := copySet(a)
+	for id := range b {
+		set[id] = true
+	}
+	return set
+}
+
+// copySet returns a copy of set, so callers may modify it.
+func copySet(set map[string]bool) map[string]bool {
+	c := make(map[string]bool, len(set))
+	for id := range set {
+		c[id] = true
+	}
+	return c
+}
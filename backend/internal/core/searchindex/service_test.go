@@ -0,0 +1,234 @@
+package searchindex
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestIndex() *Impl {
+	idx := New(Config{K1: 1.2, B: 0.75, FieldBoosts: map[string]float64{"title": 3, "abstract": 1}})
+	idx.Add(
+		&Document{
+			ID:       "1",
+			Fields:   map[string]string{"title": "Graph neural networks", "abstract": "We study message passing on graphs."},
+			Keywords: map[string][]string{"category": {"cs.LG"}},
+			Date:     time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		&Document{
+			ID:       "2",
+			Fields:   map[string]string{"title": "Message passing for language models", "abstract": "A survey of graph methods and neural networks."},
+			Keywords: map[string][]string{"category": {"cs.CL"}},
+			Date:     time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+		},
+		&Document{
+			ID:       "3",
+			Fields:   map[string]string{"title": "Quantum error correction", "abstract": "Surface codes for qubits."},
+			Keywords: map[string][]string{"category": {"quant-ph"}},
+			Date:     time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+	)
+	return idx
+}
+
+func hitIDs(result *Result) []string {
+	ids := make([]string, len(result.Hits))
+	for i, h := range result.Hits {
+		ids[i] = h.ID
+	}
+	return ids
+}
+
+func TestImpl_Search_TitleOutranksAbstract(t *testing.T) {
+	// Arrange
+	idx := newTestIndex()
+
+	// Act
+	result, err := idx.Search(&Query{Text: "neural network"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Total != 2 {
+		t.Fatalf("Expected 2 matches, got: %d", result.Total)
+	}
+	if result.Hits[0].ID != "1" {
+		t.Errorf("Expected title match first, got: %v", hitIDs(result))
+	}
+	if result.Hits[0].Score <= result.Hits[1].Score {
+		t.Errorf("Expected descending scores, got: %v", result.Hits)
+	}
+}
+
+func TestImpl_Search_AllTermsRequired(t *testing.T) {
+	idx := newTestIndex()
+
+	result, err := idx.Search(&Query{Text: "graph qubits"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Total != 0 {
+		t.Errorf("Expected no matches, got: %v", hitIDs(result))
+	}
+}
+
+func TestImpl_Search_Clauses(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *Query
+		want  []string
+	}{
+		{
+			name:  "fielded text",
+			query: &Query{Must: []Clause{{Field: "title", Text: "message passing"}}},
+			want:  []string{"2"},
+		},
+		{
+			name:  "keyword exact",
+			query: &Query{Must: []Clause{{Field: "category", Text: "CS.lg"}}},
+			want:  []string{"1"},
+		},
+		{
+			name:  "keyword prefix, newest first",
+			query: &Query{Must: []Clause{{Field: "category", Text: "cs.*"}}},
+			want:  []string{"2", "1"},
+		},
+		{
+			name: "match any",
+			query: &Query{
+				Must:     []Clause{{Field: "category", Text: "quant-ph"}, {Field: "title", Text: "graph"}},
+				MatchAny: true,
+			},
+			want: []string{"1", "3"},
+		},
+		{
+			name: "must not",
+			query: &Query{
+				Text:    "message passing",
+				MustNot: []Clause{{Field: "category", Text: "cs.CL"}},
+			},
+			want: []string{"1"},
+		},
+		{
+			name:  "restricted to IDs",
+			query: &Query{Text: "graph", IDs: []string{"2", "3", "unknown"}},
+			want:  []string{"2"},
+		},
+		{
+			name: "date range only",
+			query: &Query{
+				From:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: []string{"2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := newTestIndex()
+
+			result, err := idx.Search(tt.query)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got := hitIDs(result); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestImpl_Search_Paging(t *testing.T) {
+	idx := newTestIndex()
+
+	result, err := idx.Search(&Query{Must: []Clause{{Field: "category", Text: "*"}}, Limit: 2, Offset: 1})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Total != 3 {
+		t.Errorf("Expected total 3, got: %d", result.Total)
+	}
+	if got := hitIDs(result); len(got) != 2 || got[0] != "2" || got[1] != "1" {
+		t.Errorf("Expected [2 1], got: %v", got)
+	}
+}
+
+func TestImpl_Search_EmptyQuery(t *testing.T) {
+	idx := newTestIndex()
+
+	_, err := idx.Search(&Query{Text: "the of"})
+
+	if !IsEmptyQuery(err) {
+		t.Errorf("Expected ErrEmptyQuery, got: %v", err)
+	}
+}
+
+func TestImpl_AddReplacesAndRemove(t *testing.T) {
+	// Arrange
+	idx := newTestIndex()
+
+	// Act
+	idx.Add(&Document{ID: "3", Fields: map[string]string{"title": "Graph rewriting"}})
+	replaced, _ := idx.Search(&Query{Text: "quantum"})
+	graph, _ := idx.Search(&Query{Text: "graph"})
+	idx.Remove("1", "unknown")
+	afterRemove, _ := idx.Search(&Query{Text: "graph"})
+
+	// Assert
+	if idx.Len() != 2 {
+		t.Errorf("Expected 2 documents, got: %d", idx.Len())
+	}
+	if replaced.Total != 0 {
+		t.Errorf("Expected replaced text to be gone, got: %v", hitIDs(replaced))
+	}
+	if graph.Total != 3 {
+		t.Errorf("Expected 3 graph matches, got: %v", hitIDs(graph))
+	}
+	if afterRemove.Total != 2 {
+		t.Errorf("Expected 2 graph matches after remove, got: %v", hitIDs(afterRemove))
+	}
+}
+
+func TestImpl_SaveLoad(t *testing.T) {
+	// Arrange
+	idx := newTestIndex()
+	want, _ := idx.Search(&Query{Text: "graph"})
+
+	// Act
+	var buf bytes.Buffer
+	if err := idx.Save(&buf); err != nil {
+		t.Fatalf("Expected no error saving, got: %v", err)
+	}
+	loaded := New(Config{K1: 1.2, B: 0.75, FieldBoosts: map[string]float64{"title": 3, "abstract": 1}})
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("Expected no error loading, got: %v", err)
+	}
+	got, _ := loaded.Search(&Query{Text: "graph"})
+
+	// Assert
+	if loaded.Len() != 3 {
+		t.Errorf("Expected 3 documents, got: %d", loaded.Len())
+	}
+	if !reflect.DeepEqual(hitIDs(got), hitIDs(want)) {
+		t.Errorf("Expected %v, got: %v", hitIDs(want), hitIDs(got))
+	}
+}
+
+func TestImpl_Load_BadSnapshot(t *testing.T) {
+	idx := newTestIndex()
+
+	err := idx.Load(bytes.NewBufferString("not a snapshot"))
+
+	if !IsBadSnapshot(err) {
+		t.Errorf("Expected ErrBadSnapshot, got: %v", err)
+	}
+	if idx.Len() != 3 {
+		t.Errorf("Expected index to be unchanged, got %d documents", idx.Len())
+	}
+}
@@ -0,0 +1,153 @@
+package searchindex
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// feature is one dimension of a document vector: a term of the text fields
+// or a value of a keyword field.
+type feature struct {
+	field string // Keyword field, or empty for text terms
+	value string
+}
+
+// Similar returns the documents most similar to an indexed document.
+// Documents are compared by the cosine of their TF-IDF vectors over the
+// terms of all text fields, each occurrence weighted by its field boost, and
+// the values of the keyword fields listed in KeywordWeights.
+// Only documents sharing at least one feature are returned.
+func (idx *Impl) Similar(id string, limit int) (*Result, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	source, ok := idx.docs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotIndexed, id)
+	}
+	idfs := make(map[feature]float64) // Memoized across candidate vectors
+	sourceVec := idx.vector(source, idfs)
+	sourceNorm := vectorNorm(sourceVec)
+	if sourceNorm == 0 {
+		return &Result{Hits: []Hit{}}, nil
+	}
+
+	// Accumulate dot products through the postings of the source's features.
+	dots := make(map[string]float64)
+	for f, weight := range sourceVec {
+		idf := idx.featureIDF(f, idfs)
+		for other, tf := range idx.featureDocs(f) {
+			if other != id {
+				dots[other] += weight * idx.featureWeight(f, tf, idf)
+			}
+		}
+	}
+
+	hits := make([]Hit, 0, len(dots))
+	for other, dot := range dots {
+		if n := vectorNorm(idx.vector(idx.docs[other], idfs)); n > 0 {
+			hits = append(hits, Hit{ID: other, Score: dot / (sourceNorm * n)})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		di, dj := idx.docs[hits[i].ID].Date, idx.docs[hits[j].ID].Date
+		if di != dj {
+			return di > dj
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	result := &Result{Hits: hits, Total: len(hits)}
+	if len(hits) > limit {
+		result.Hits = hits[:limit]
+	}
+	return result, nil
+}
+
+// vector returns the TF-IDF vector of a document.
+// The caller must hold the read lock.
+func (idx *Impl) vector(doc *document, idfs map[feature]float64) map[feature]float64 {
+	tfs := make(map[feature]float64)
+	for field, freqs := range doc.Terms {
+		for term, tf := range freqs {
+			tfs[feature{value: term}] += idx.boost(field) * float64(tf)
+		}
+	}
+	for field, values := range doc.Keywords {
+		if idx.cfg.KeywordWeights[field] <= 0 {
+			continue
+		}
+		for _, v := range values {
+			tfs[feature{field: field, value: v}] = 1
+		}
+	}
+
+	vec := make(map[feature]float64, len(tfs))
+	for f, tf := range tfs {
+		vec[f] = idx.featureWeight(f, tf, idx.featureIDF(f, idfs))
+	}
+	return vec
+}
+
+// featureWeight is the TF-IDF weight of a feature with boosted frequency tf.
+func (idx *Impl) featureWeight(f feature, tf, idf float64) float64 {
+	if f.field != "" {
+		return idx.cfg.KeywordWeights[f.field] * idf
+	}
+	return math.Log(1+tf) * idf
+}
+
+// featureIDF is the inverse document frequency of a feature, memoized in idfs.
+// The caller must hold the read lock.
+func (idx *Impl) featureIDF(f feature, idfs map[feature]float64) float64 {
+	if idf, ok := idfs[f]; ok {
+		return idf
+	}
+	var df int
+	if f.field != "" {
+		df = len(idx.keywords[f.field][f.value])
+	} else {
+		df = len(idx.docsWithTerm("", f.value))
+	}
+	var idf float64
+	if df > 0 {
+		idf = math.Log(1 + float64(len(idx.docs))/float64(df))
+	}
+	idfs[f] = idf
+	return idf
+}
+
+// featureDocs returns the documents containing a feature with their boosted
+// frequency of it. The caller must hold the read lock.
+func (idx *Impl) featureDocs(f feature) map[string]float64 {
+	docs := make(map[string]float64)
+	if f.field != "" {
+		for id := range idx.keywords[f.field][f.value] {
+			docs[id] = 1
+		}
+		return docs
+	}
+	for field, terms := range idx.postings {
+		for id, tf := range terms[f.value] {
+			docs[id] += idx.boost(field) * float64(tf)
+		}
+	}
+	return docs
+}
+
+// vectorNorm returns the Euclidean length of a vector.
+func vectorNorm(vec map[feature]float64) float64 {
+	var sum float64
+	for _, w := range vec {
+		sum += w * w
+	}
+	return math.Sqrt(sum)
+}
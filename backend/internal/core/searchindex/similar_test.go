@@ -0,0 +1,76 @@
+package searchindex
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestImpl_Similar(t *testing.T) {
+	// Arrange
+	idx := newTestIndex()
+	idx.cfg.KeywordWeights = map[string]float64{"category": 1}
+	idx.Add(&Document{
+		ID:       "4",
+		Fields:   map[string]string{"title": "Convex optimization", "abstract": "Stochastic gradient descent for convex losses."},
+		Keywords: map[string][]string{"category": {"cs.LG"}},
+		Date:     time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+	})
+
+	// Act
+	result, err := idx.Similar("1", 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := hitIDs(result); !reflect.DeepEqual(got, []string{"2", "4"}) {
+		t.Fatalf("Expected [2 4], got: %v", got)
+	}
+	for _, h := range result.Hits {
+		if h.Score <= 0 || h.Score > 1 {
+			t.Errorf("Expected score in (0, 1], got: %v", h)
+		}
+	}
+	if result.Hits[0].Score <= result.Hits[1].Score {
+		t.Errorf("Expected descending scores, got: %v", result.Hits)
+	}
+}
+
+func TestImpl_Similar_IgnoresUnweightedKeywords(t *testing.T) {
+	idx := newTestIndex()
+	idx.Add(&Document{ID: "4", Fields: map[string]string{"title": "Convex optimization"}, Keywords: map[string][]string{"category": {"cs.LG"}}})
+
+	result, err := idx.Similar("1", 10)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := hitIDs(result); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Errorf("Expected [2], got: %v", got)
+	}
+}
+
+func TestImpl_Similar_Limit(t *testing.T) {
+	idx := newTestIndex()
+	idx.Add(&Document{ID: "4", Fields: map[string]string{"title": "Graph neural networks again"}})
+
+	result, err := idx.Similar("1", 1)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Total != 2 || len(result.Hits) != 1 || result.Hits[0].ID != "4" {
+		t.Errorf("Expected the near-duplicate of 2 candidates, got: %v (total %d)", result.Hits, result.Total)
+	}
+}
+
+func TestImpl_Similar_NotIndexed(t *testing.T) {
+	idx := newTestIndex()
+
+	_, err := idx.Similar("unknown", 10)
+
+	if !IsNotIndexed(err) {
+		t.Errorf("Expected ErrNotIndexed, got: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package searchindex
+
+import "time"
+
+// DefaultLimit is the number of hits returned when a Query has no limit.
+const DefaultLimit = 20
+
+// Document is a unit of indexing.
+type Document struct {
+	ID string
+
+	// Fields holds full-text fields, analyzed into stemmed terms.
+	Fields map[string]string
+
+	// Keywords holds exact-match fields such as categories. Values are
+	// compared case-insensitively and are not scored.
+	Keywords map[string][]string
+
+	// Date is used for range filtering and to order unscored results.
+	Date time.Time
+}
+
+// Clause restricts a search to documents matching Text in Field.
+//
+// For a text field every term of Text must occur in that field; an empty
+// Field searches all text fields. For a keyword field the whole Text must
+// equal one of the values, or prefix one if it ends with "*".
+type Clause struct {
+	Field string
+	Text  string
+}
+
+// Query describes a search. Free text, clauses, IDs and the date range are
+// combined with AND; at least one of them is required.
+type Query struct {
+	Text     string   // Free text; every term must occur in some text field
+	Must     []Clause // Clauses results must match
+	MatchAny bool     // Match any Must clause instead of all of them
+	MustNot  []Clause // Clauses results must not match
+	IDs      []string // Restrict results to these documents (nil for no restriction)
+
+	From  time.Time // Inclusive lower bound on Document.Date (zero for none)
+	Until time.Time // Inclusive upper bound on Document.Date (zero for none)
+
+	Limit  int // Maximum hits to return (DefaultLimit if zero)
+	Offset int
+}
+
+// Hit is a matching document and its relevance score.
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+// Result is a page of hits together with the total number of matches.
+type Result struct {
+	Hits  []Hit
+	Total int
+}
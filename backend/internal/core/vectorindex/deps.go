@@ -0,0 +1,36 @@
+package vectorindex
+
+// Config holds the shape of the index and its hashing parameters.
+type Config struct {
+	// Dimension is the length of every vector (required).
+	Dimension int
+
+	// Model names the embedder the vectors come from. Snapshots of another
+	// model are rejected, since their vectors are not comparable.
+	Model string
+
+	// Tables is the number of hash tables. More tables find more true
+	// neighbors at the cost of memory.
+	Tables int
+
+	// Bits is the number of random hyperplanes per table (at most 64).
+	// More bits make buckets smaller and searches faster but less complete.
+	Bits int
+
+	// Seed makes the hyperplanes, and so the buckets, reproducible.
+	Seed int64
+
+	// ExactThreshold is the size up to which searches scan every vector.
+	ExactThreshold int
+}
+
+// DefaultConfig returns the standard hashing parameters for a dimension.
+func DefaultConfig(dimension int) Config {
+	return Config{
+		Dimension:      dimension,
+		Tables:         8,
+		Bits:           10,
+		Seed:           1,
+		ExactThreshold: 2000,
+	}
+}
@@ -0,0 +1,17 @@
+package vectorindex
+
+import "errors"
+
+var (
+	// ErrDimensionMismatch indicates that a vector does not have the index dimension.
+	ErrDimensionMismatch = errors.New("vector dimension mismatch")
+
+	// ErrBadSnapshot indicates that an index snapshot is corrupt or incompatible.
+	ErrBadSnapshot = errors.New("invalid vector index snapshot")
+)
+
+// IsDimensionMismatch checks if the error is ErrDimensionMismatch.
+func IsDimensionMismatch(err error) bool { return errors.Is(err, ErrDimensionMismatch) }
+
+// IsBadSnapshot checks if the error is ErrBadSnapshot.
+func IsBadSnapshot(err error) bool { return errors.Is(err, ErrBadSnapshot) }
@@ -0,0 +1,38 @@
+package vectorindex
+
+import "io"
+
+// Service defines the interface for an in-process approximate nearest
+// neighbor index over unit vectors, ranked by cosine similarity.
+// All methods are safe for concurrent use.
+type Service interface {
+	// Add indexes vectors, replacing any already indexed under the same ID.
+	// Zero vectors are not indexed. Returns ErrDimensionMismatch if a vector
+	// has the wrong length; no item is added in that case.
+	Add(items ...Item) error
+
+	// Remove drops vectors from the index. Unknown IDs are ignored.
+	Remove(ids ...string)
+
+	// Search returns up to limit vectors closest to a query vector, closest
+	// first. Only vectors with a positive similarity are returned.
+	// Returns ErrDimensionMismatch if the query has the wrong length.
+	Search(vector []float32, limit int) ([]Hit, error)
+
+	// Has reports whether a vector is indexed under an ID.
+	Has(id string) bool
+
+	// Len returns the number of indexed vectors.
+	Len() int
+
+	// Reset removes every vector.
+	Reset()
+
+	// Save writes a snapshot of the index to w.
+	Save(w io.Writer) error
+
+	// Load replaces the index contents with a snapshot written by Save.
+	// Returns ErrBadSnapshot if the snapshot cannot be read or was written
+	// for another dimension or model.
+	Load(r io.Reader) error
+}
@@ -0,0 +1,310 @@
+package vectorindex
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// snapshotFormat is bumped whenever the snapshot layout changes.
+const snapshotFormat = 1
+
+// Impl implements the Service interface with random-hyperplane locality
+// sensitive hashing. Each table hashes a vector to the side of Bits random
+// hyperplanes it falls on; close vectors tend to share buckets. A search
+// probes the query's bucket and every bucket one bit away in each table,
+// then ranks the candidates by exact cosine similarity.
+type Impl struct {
+	cfg    Config
+	planes [][][]float32 // table -> bit -> hyperplane normal
+
+	mu      sync.RWMutex
+	vectors map[string][]float32         // ID -> unit vector
+	buckets []map[uint64]map[string]bool // table -> signature -> IDs
+}
+
+// snapshot is the on-disk form of the index. Buckets are rebuilt on load.
+type snapshot struct {
+	Format    int
+	Dimension int
+	Model     string
+	Vectors   map[string][]float32
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates an empty index. Unset hashing parameters take their defaults.
+func New(cfg Config) *Impl {
+	defaults := DefaultConfig(cfg.Dimension)
+	if cfg.Tables <= 0 {
+		cfg.Tables = defaults.Tables
+	}
+	if cfg.Bits <= 0 || cfg.Bits > 64 {
+		cfg.Bits = defaults.Bits
+	}
+	if cfg.ExactThreshold <= 0 {
+		cfg.ExactThreshold = defaults.ExactThreshold
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	planes := make([][][]float32, cfg.Tables)
+	for t := range planes {
+		planes[t] = make([][]float32, cfg.Bits)
+		for b := range planes[t] {
+			plane := make([]float32, cfg.Dimension)
+			for i := range plane {
+				plane[i] = float32(rng.NormFloat64())
+			}
+			planes[t][b] = plane
+		}
+	}
+
+	idx := &Impl{cfg: cfg, planes: planes}
+	idx.reset()
+	return idx
+}
+
+// Add indexes vectors, replacing any already indexed under the same ID.
+func (idx *Impl) Add(items ...Item) error {
+	for _, item := range items {
+		if len(item.Vector) != idx.cfg.Dimension {
+			return fmt.Errorf("%w: %s has %d, want %d", ErrDimensionMismatch, item.ID, len(item.Vector), idx.cfg.Dimension)
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, item := range items {
+		idx.remove(item.ID)
+		if v := unit(item.Vector); v != nil {
+			idx.insert(item.ID, v)
+		}
+	}
+	return nil
+}
+
+// Remove drops vectors from the index.
+func (idx *Impl) Remove(ids ...string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, id := range ids {
+		idx.remove(id)
+	}
+}
+
+// Has reports whether a vector is indexed under an ID.
+func (idx *Impl) Has(id string) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	_, ok := idx.vectors[id]
+	return ok
+}
+
+// Len returns the number of indexed vectors.
+func (idx *Impl) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.vectors)
+}
+
+// Reset removes every vector.
+func (idx *Impl) Reset() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.reset()
+}
+
+// Search returns the vectors closest to a query vector.
+// Small indexes, and searches whose probed buckets hold fewer than limit
+// vectors, scan every vector instead.
+func (idx *Impl) Search(vector []float32, limit int) ([]Hit, error) {
+	if len(vector) != idx.cfg.Dimension {
+		return nil, fmt.Errorf("%w: query has %d, want %d", ErrDimensionMismatch, len(vector), idx.cfg.Dimension)
+	}
+	query := unit(vector)
+	if query == nil || limit <= 0 {
+		return []Hit{}, nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var candidates map[string]bool
+	if len(idx.vectors) > idx.cfg.ExactThreshold {
+		candidates = idx.probe(query)
+		if len(candidates) < limit {
+			candidates = nil
+		}
+	}
+
+	hits := make([]Hit, 0, limit)
+	score := func(id string, v []float32) {
+		if s := dot(query, v); s > 0 {
+			hits = append(hits, Hit{ID: id, Score: s})
+		}
+	}
+	if candidates == nil {
+		for id, v := range idx.vectors {
+			score(id, v)
+		}
+	} else {
+		for id := range candidates {
+			score(id, idx.vectors[id])
+		}
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// Save writes a snapshot of the index to w.
+func (idx *Impl) Save(w io.Writer) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	snap := &snapshot{
+		Format:    snapshotFormat,
+		Dimension: idx.cfg.Dimension,
+		Model:     idx.cfg.Model,
+		Vectors:   idx.vectors,
+	}
+	if err := gob.NewEncoder(w).Encode(snap); err != nil {
+		return fmt.Errorf("failed to write vector index snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load replaces the index contents with a snapshot written by Save.
+// The index is left unchanged if the snapshot cannot be used.
+func (idx *Impl) Load(r io.Reader) error {
+	var snap snapshot
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("%w: %v", ErrBadSnapshot, err)
+	}
+	if snap.Format != snapshotFormat {
+		return fmt.Errorf("%w: format %d, want %d", ErrBadSnapshot, snap.Format, snapshotFormat)
+	}
+	if snap.Dimension != idx.cfg.Dimension || snap.Model != idx.cfg.Model {
+		return fmt.Errorf("%w: written for %s/%d, want %s/%d",
+			ErrBadSnapshot, snap.Model, snap.Dimension, idx.cfg.Model, idx.cfg.Dimension)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.reset()
+	for id, v := range snap.Vectors {
+		if len(v) == idx.cfg.Dimension {
+			idx.insert(id, v)
+		}
+	}
+	return nil
+}
+
+// reset empties the index. The caller must hold the write lock.
+func (idx *Impl) reset() {
+	idx.vectors = make(map[string][]float32)
+	idx.buckets = make([]map[uint64]map[string]bool, idx.cfg.Tables)
+	for t := range idx.buckets {
+		idx.buckets[t] = make(map[uint64]map[string]bool)
+	}
+}
+
+// insert adds a unit vector. The caller must hold the write lock.
+func (idx *Impl) insert(id string, v []float32) {
+	idx.vectors[id] = v
+	for t := range idx.buckets {
+		sig := idx.signature(t, v)
+		bucket := idx.buckets[t][sig]
+		if bucket == nil {
+			bucket = make(map[string]bool)
+			idx.buckets[t][sig] = bucket
+		}
+		bucket[id] = true
+	}
+}
+
+// remove drops a vector. The caller must hold the write lock.
+func (idx *Impl) remove(id string) {
+	v, ok := idx.vectors[id]
+	if !ok {
+		return
+	}
+	delete(idx.vectors, id)
+	for t := range idx.buckets {
+		sig := idx.signature(t, v)
+		delete(idx.buckets[t][sig], id)
+		if len(idx.buckets[t][sig]) == 0 {
+			delete(idx.buckets[t], sig)
+		}
+	}
+}
+
+// probe collects the IDs in the query's bucket and in every bucket one bit
+// away from it, across all tables. The caller must hold the read lock.
+func (idx *Impl) probe(query []float32) map[string]bool {
+	candidates := make(map[string]bool)
+	for t := range idx.buckets {
+		sig := idx.signature(t, query)
+		for b := -1; b < idx.cfg.Bits; b++ {
+			probe := sig
+			if b >= 0 {
+				probe ^= 1 << uint(b)
+			}
+			for id := range idx.buckets[t][probe] {
+				candidates[id] = true
+			}
+		}
+	}
+	return candidates
+}
+
+// signature returns the bucket of a vector in a table: bit b is set when the
+// vector lies on the positive side of hyperplane b.
+func (idx *Impl) signature(table int, v []float32) uint64 {
+	var sig uint64
+	for b, plane := range idx.planes[table] {
+		if dot(plane, v) >= 0 {
+			sig |= 1 << uint(b)
+		}
+	}
+	return sig
+}
+
+// unit returns a copy of v scaled to unit length, or nil for a zero vector.
+func unit(v []float32) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(float64(x) / norm)
+	}
+	return out
+}
+
+// dot returns the dot product of two vectors of equal length.
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
@@ -0,0 +1,168 @@
+package vectorindex
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func newTestIndex() *Impl {
+	idx := New(Config{Dimension: 3, Model: "test"})
+	idx.Add(
+		Item{ID: "x", Vector: []float32{1, 0, 0}},
+		Item{ID: "xy", Vector: []float32{1, 1, 0}},
+		Item{ID: "y", Vector: []float32{0, 2, 0}},
+		Item{ID: "-x", Vector: []float32{-1, 0, 0}},
+	)
+	return idx
+}
+
+func hitIDs(hits []Hit) []string {
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.ID
+	}
+	return ids
+}
+
+func TestImpl_Search(t *testing.T) {
+	// Arrange
+	idx := newTestIndex()
+
+	// Act
+	hits, err := idx.Search([]float32{2, 0.1, 0}, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := hitIDs(hits); !reflect.DeepEqual(got, []string{"x", "xy", "y"}) {
+		t.Errorf("Expected [x xy y] without opposite vector, got: %v", got)
+	}
+	if hits[0].Score > 1 || hits[0].Score <= hits[1].Score {
+		t.Errorf("Expected descending cosine scores, got: %v", hits)
+	}
+}
+
+func TestImpl_AddReplacesAndRemove(t *testing.T) {
+	idx := newTestIndex()
+
+	idx.Add(Item{ID: "x", Vector: []float32{0, 0, 1}}, Item{ID: "zero", Vector: []float32{0, 0, 0}})
+	idx.Remove("y", "unknown")
+	hits, _ := idx.Search([]float32{0, 0, 1}, 10)
+
+	if idx.Len() != 3 {
+		t.Errorf("Expected 3 vectors, got: %d", idx.Len())
+	}
+	if idx.Has("y") || idx.Has("zero") || !idx.Has("x") {
+		t.Errorf("Expected removed and zero vectors to be absent")
+	}
+	if got := hitIDs(hits); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("Expected replaced vector to match, got: %v", got)
+	}
+}
+
+func TestImpl_DimensionMismatch(t *testing.T) {
+	idx := newTestIndex()
+
+	addErr := idx.Add(Item{ID: "ok", Vector: []float32{1, 0, 0}}, Item{ID: "bad", Vector: []float32{1}})
+	_, searchErr := idx.Search([]float32{1, 0}, 10)
+
+	if !IsDimensionMismatch(addErr) || !IsDimensionMismatch(searchErr) {
+		t.Errorf("Expected ErrDimensionMismatch, got: %v, %v", addErr, searchErr)
+	}
+	if idx.Len() != 4 {
+		t.Errorf("Expected no vector added, got: %d", idx.Len())
+	}
+}
+
+func TestImpl_Search_Approximate(t *testing.T) {
+	// Arrange: force bucket probing with a tiny exact threshold.
+	const dim = 32
+	idx := New(Config{Dimension: dim, Tables: 8, Bits: 8, Seed: 7, ExactThreshold: 1})
+	rng := rand.New(rand.NewSource(1))
+	vectors := make(map[string][]float32)
+	for i := 0; i < 2000; i++ {
+		v := make([]float32, dim)
+		for j := range v {
+			v[j] = float32(rng.NormFloat64())
+		}
+		id := fmt.Sprintf("v%d", i)
+		vectors[id] = v
+		idx.Add(Item{ID: id, Vector: v})
+	}
+
+	// Act: query slightly perturbed copies of indexed vectors.
+	found := 0
+	for id, v := range vectors {
+		query := make([]float32, dim)
+		for j := range query {
+			query[j] = v[j] + float32(rng.NormFloat64()*0.1)
+		}
+		hits, err := idx.Search(query, 5)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(hits) > 0 && hits[0].ID == id {
+			found++
+		}
+	}
+
+	// Assert
+	if recall := float64(found) / float64(len(vectors)); recall < 0.95 {
+		t.Errorf("Expected recall@1 of at least 0.95, got: %.3f", recall)
+	}
+}
+
+func TestImpl_SaveLoad(t *testing.T) {
+	// Arrange
+	idx := newTestIndex()
+	want, _ := idx.Search([]float32{1, 1, 0}, 10)
+
+	// Act
+	var buf bytes.Buffer
+	if err := idx.Save(&buf); err != nil {
+		t.Fatalf("Expected no error saving, got: %v", err)
+	}
+	loaded := New(Config{Dimension: 3, Model: "test"})
+	err := loaded.Load(bytes.NewReader(buf.Bytes()))
+	got, _ := loaded.Search([]float32{1, 1, 0}, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error loading, got: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v after reload, got: %v", want, got)
+	}
+}
+
+func TestImpl_Load_BadSnapshot(t *testing.T) {
+	idx := newTestIndex()
+	var buf bytes.Buffer
+	idx.Save(&buf)
+
+	tests := []struct {
+		name string
+		data []byte
+		cfg  Config
+	}{
+		{name: "garbage", data: []byte("not gob"), cfg: Config{Dimension: 3, Model: "test"}},
+		{name: "other model", data: buf.Bytes(), cfg: Config{Dimension: 3, Model: "other"}},
+		{name: "other dimension", data: buf.Bytes(), cfg: Config{Dimension: 4, Model: "test"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := New(tt.cfg)
+
+			err := target.Load(bytes.NewReader(tt.data))
+
+			if !IsBadSnapshot(err) {
+				t.Errorf("Expected ErrBadSnapshot, got: %v", err)
+			}
+		})
+	}
+}
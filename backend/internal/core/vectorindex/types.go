@@ -0,0 +1,13 @@
+package vectorindex
+
+// Item is a vector and the ID it is indexed under.
+type Item struct {
+	ID     string
+	Vector []float32
+}
+
+// Hit is a matching vector and its cosine similarity to the query.
+type Hit struct {
+	ID    string
+	Score float64
+}
@@ -0,0 +1,66 @@
+package facade
+
+import (
+	"context"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/features/alerts"
+	"github.com/rrlian/papertok/backend/internal/features/papersearch"
+	"github.com/rrlian/papertok/backend/internal/features/searchhistory"
+)
+
+// savedSearchRunner lets the alerts matcher run saved searches through
+// papersearch. Runs are not added to the users' search history.
+type savedSearchRunner struct {
+	saved  searchhistory.Service
+	search papersearch.Service
+}
+
+// SavedSearches returns every user's saved searches.
+func (r *savedSearchRunner) SavedSearches(ctx context.Context) ([]*alerts.Search, error) {
+	saved, err := r.saved.ListAllSaved(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*alerts.Search, len(saved))
+	for i, s := range saved {
+		result[i] = &alerts.Search{ID: s.ID, UserID: s.OwnerID}
+	}
+	return result, nil
+}
+
+// Run runs a saved search. Papers submitted outside since and until are
+// filtered out by the query itself, so new papers are not crowded off the
+// page by older, more relevant ones.
+func (r *savedSearchRunner) Run(ctx context.Context, search *alerts.Search, since, until time.Time, limit int) (*alerts.RunResult, error) {
+	saved, err := r.saved.GetSaved(ctx, search.UserID, search.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := searchRequest(saved.Query)
+	req.Limit = limit
+	if since.After(req.SubmittedFrom) {
+		req.SubmittedFrom = since
+	}
+	if !until.IsZero() && (req.SubmittedTo.IsZero() || until.Before(req.SubmittedTo)) {
+		req.SubmittedTo = until
+	}
+	if !req.SubmittedTo.IsZero() && req.SubmittedTo.Before(req.SubmittedFrom) {
+		// The search only covers papers older than the mark
+		return &alerts.RunResult{Newest: true}, nil
+	}
+
+	result, err := r.search.Search(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	run := &alerts.RunResult{
+		Matches: make([]*alerts.Match, len(result.Papers)),
+		Newest:  result.Backend == papersearch.BackendArxiv,
+	}
+	for i, p := range result.Papers {
+		run.Matches[i] = &alerts.Match{PaperID: p.ID, Published: p.Published}
+	}
+	return run, nil
+}
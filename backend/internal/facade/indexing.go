@@ -0,0 +1,135 @@
+package facade
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/embedding"
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+	"github.com/rrlian/papertok/backend/internal/core/vectorindex"
+	"github.com/rrlian/papertok/backend/internal/features/papersearch"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// embedBatchSize is the most queued papers embedded in one pass.
+const embedBatchSize = 500
+
+// indexingRepository feeds every paper written to the repository into the
+// local search index, and into the semantic index when one is configured,
+// whichever feature writes it. Embedding can be slow or billed by the
+// provider, so papers not embedded yet are queued and embedded in batches in
+// the background rather than on the request path.
+type indexingRepository struct {
+	paperRepo.Repository
+	index    searchindex.Service
+	embedder embedding.Embedder  // nil if semantic search is disabled
+	vectors  vectorindex.Service // nil if semantic search is disabled
+
+	mu       sync.Mutex
+	pending  map[string]*paperRepo.Paper // Papers waiting to be embedded, keyed by ID
+	flushing bool                        // Whether a flush goroutine is running
+}
+
+// Save stores a single paper and indexes it.
+func (r *indexingRepository) Save(ctx context.Context, paper *paperRepo.Paper, ttl time.Duration) {
+	r.Repository.Save(ctx, paper, ttl)
+	r.addToIndex([]*paperRepo.Paper{paper})
+}
+
+// SaveByCategory stores a feed page and its papers, and indexes the papers.
+func (r *indexingRepository) SaveByCategory(ctx context.Context, category string, list *paperRepo.PaperList, ttl time.Duration) {
+	r.Repository.SaveByCategory(ctx, category, list, ttl)
+	r.addToIndex(list.Papers)
+}
+
+// Upsert stores papers permanently and indexes them once stored.
+func (r *indexingRepository) Upsert(ctx context.Context, papers []*paperRepo.Paper) error {
+	if err := r.Repository.Upsert(ctx, papers); err != nil {
+		return err
+	}
+
+	r.addToIndex(papers)
+	return nil
+}
+
+// addToIndex indexes stored papers and queues them for embedding.
+func (r *indexingRepository) addToIndex(papers []*paperRepo.Paper) {
+	docs := make([]*searchindex.Document, len(papers))
+	for i, p := range papers {
+		docs[i] = papersearch.IndexDocument(p)
+	}
+	r.index.Add(docs...)
+	r.queueEmbedding(papers)
+}
+
+// queueEmbedding queues the stored papers that are not in the semantic index
+// yet and starts a flush if none is running.
+func (r *indexingRepository) queueEmbedding(papers []*paperRepo.Paper) {
+	if r.vectors == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range papers {
+		if r.vectors.Has(p.ID) {
+			continue
+		}
+		if r.pending == nil {
+			r.pending = make(map[string]*paperRepo.Paper)
+		}
+		r.pending[p.ID] = p
+	}
+	if len(r.pending) > 0 && !r.flushing {
+		r.flushing = true
+		go r.flush()
+	}
+}
+
+// flush embeds queued papers until the queue is empty. The papers are
+// already stored, so a failure is only logged; the next sync of the semantic
+// index picks them up.
+func (r *indexingRepository) flush() {
+	for {
+		r.mu.Lock()
+		if len(r.pending) == 0 {
+			r.flushing = false
+			r.mu.Unlock()
+			return
+		}
+		papers := make([]*paperRepo.Paper, 0, min(len(r.pending), embedBatchSize))
+		for id, p := range r.pending {
+			if len(papers) == embedBatchSize {
+				break
+			}
+			papers = append(papers, p)
+			delete(r.pending, id)
+		}
+		r.mu.Unlock()
+
+		r.embed(context.Background(), papers)
+	}
+}
+
+// embed adds the embeddings of stored papers to the semantic index.
+func (r *indexingRepository) embed(ctx context.Context, papers []*paperRepo.Paper) {
+	texts := make([]string, len(papers))
+	for i, p := range papers {
+		texts[i] = papersearch.EmbeddingText(p)
+	}
+	vectors, err := r.embedder.Embed(ctx, texts)
+	if err != nil {
+		log.Printf("Failed to embed %d papers for semantic search: %v", len(papers), err)
+		return
+	}
+
+	items := make([]vectorindex.Item, len(papers))
+	for i, p := range papers {
+		items[i] = vectorindex.Item{ID: p.ID, Vector: vectors[i]}
+	}
+	if err := r.vectors.Add(items...); err != nil {
+		log.Printf("Failed to index %d embeddings: %v", len(papers), err)
+	}
+}
@@ -0,0 +1,25 @@
+package facade
+
+import (
+	"context"
+
+	"github.com/rrlian/papertok/backend/internal/features/paperfeed"
+)
+
+// feedRefresher lets the prewarm scheduler refresh feed pages through paperfeed.
+type feedRefresher struct {
+	feed paperfeed.Service
+}
+
+// Refresh fetches the first page of a feed and caches it.
+func (r *feedRefresher) Refresh(ctx context.Context, category, sortBy string, limit int) (int, error) {
+	result, err := r.feed.Refresh(ctx, &paperfeed.FetchRequest{
+		Categories: []string{category},
+		Limit:      limit,
+		SortBy:     sortBy,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(result.Papers), nil
+}
@@ -0,0 +1,101 @@
+package facade
+
+import (
+	"context"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/features/bookmarks"
+	"github.com/rrlian/papertok/backend/internal/features/follows"
+	"github.com/rrlian/papertok/backend/internal/features/history"
+	"github.com/rrlian/papertok/backend/internal/features/likes"
+	"github.com/rrlian/papertok/backend/internal/features/paperfeed"
+)
+
+// Number of most recent bookmarks, likes and viewed papers a profile is built from.
+const (
+	profileBookmarks = 100
+	profileLikes     = 100
+	profileViews     = history.MaxLimit
+)
+
+// interestProfiles builds personal ranking profiles from the user's likes,
+// bookmarks, reading history and follows. It also supplies the follows the
+// following feed is built from.
+type interestProfiles struct {
+	bookmarks bookmarks.Service
+	likes     likes.Service
+	history   history.Service
+	follows   follows.Service
+	now       func() time.Time
+}
+
+// Profile gathers the user's interactions and follows.
+func (p *interestProfiles) Profile(ctx context.Context, userID int64) (*paperfeed.Profile, error) {
+	profile := &paperfeed.Profile{UserID: userID, Now: p.now()}
+
+	saved, err := p.bookmarks.List(ctx, &bookmarks.ListRequest{UserID: userID, Limit: profileBookmarks})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range saved.Bookmarks {
+		in := paperfeed.Interaction{Kind: paperfeed.InteractionBookmark, PaperID: b.PaperID, At: b.CreatedAt}
+		if b.Paper != nil {
+			in.Categories = b.Paper.Categories
+			in.Authors = b.Paper.Authors
+		}
+		profile.Interactions = append(profile.Interactions, in)
+	}
+
+	liked, err := p.likes.Recent(ctx, userID, profileLikes)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range liked {
+		in := paperfeed.Interaction{Kind: paperfeed.InteractionLike, PaperID: l.PaperID, At: l.CreatedAt}
+		if l.Paper != nil {
+			in.Categories = l.Paper.Categories
+			in.Authors = l.Paper.Authors
+		}
+		profile.Interactions = append(profile.Interactions, in)
+	}
+
+	viewed, err := p.history.List(ctx, &history.ListRequest{UserID: userID, Limit: profileViews})
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range viewed.Groups {
+		for _, e := range g.Entries {
+			in := paperfeed.Interaction{
+				Kind:    paperfeed.InteractionView,
+				PaperID: e.PaperID,
+				Dwell:   time.Duration(e.DwellSeconds) * time.Second,
+				At:      e.LastViewedAt,
+			}
+			if e.Paper != nil {
+				in.Categories = e.Paper.Categories
+				in.Authors = e.Paper.Authors
+			}
+			profile.Interactions = append(profile.Interactions, in)
+		}
+	}
+
+	if profile.FollowedCategories, profile.FollowedAuthors, err = p.Follows(ctx, userID); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Follows returns the categories and authors the user follows.
+func (p *interestProfiles) Follows(ctx context.Context, userID int64) (categories, authors []string, err error) {
+	following, err := p.follows.List(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, c := range following.Categories {
+		categories = append(categories, c.Name)
+	}
+	for _, a := range following.Authors {
+		authors = append(authors, a.Name)
+	}
+	return categories, authors, nil
+}
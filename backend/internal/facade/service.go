@@ -2,31 +2,90 @@ package facade
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/rrlian/papertok/backend/internal/core/arxiv"
 	"github.com/rrlian/papertok/backend/internal/core/auth"
+	"github.com/rrlian/papertok/backend/internal/core/embedding"
+	"github.com/rrlian/papertok/backend/internal/core/oaipmh"
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+	"github.com/rrlian/papertok/backend/internal/core/vectorindex"
+	"github.com/rrlian/papertok/backend/internal/features/alerts"
+	"github.com/rrlian/papertok/backend/internal/features/bookmarks"
+	"github.com/rrlian/papertok/backend/internal/features/collections"
+	"github.com/rrlian/papertok/backend/internal/features/follows"
+	"github.com/rrlian/papertok/backend/internal/features/harvest"
+	"github.com/rrlian/papertok/backend/internal/features/history"
+	"github.com/rrlian/papertok/backend/internal/features/likes"
+	"github.com/rrlian/papertok/backend/internal/features/notes"
 	"github.com/rrlian/papertok/backend/internal/features/paperfeed"
+	"github.com/rrlian/papertok/backend/internal/features/paperimport"
 	"github.com/rrlian/papertok/backend/internal/features/papersearch"
+	"github.com/rrlian/papertok/backend/internal/features/prewarm"
+	"github.com/rrlian/papertok/backend/internal/features/relatedpapers"
+	"github.com/rrlian/papertok/backend/internal/features/searchhistory"
+	"github.com/rrlian/papertok/backend/internal/features/trending"
 	"github.com/rrlian/papertok/backend/internal/features/userauth"
 	"github.com/rrlian/papertok/backend/internal/infra/cache"
 	"github.com/rrlian/papertok/backend/internal/infra/database"
 	"github.com/rrlian/papertok/backend/internal/infra/httpclient"
+	alertRepo "github.com/rrlian/papertok/backend/internal/repository/alert"
+	bookmarkRepo "github.com/rrlian/papertok/backend/internal/repository/bookmark"
+	collectionRepo "github.com/rrlian/papertok/backend/internal/repository/collection"
+	followRepo "github.com/rrlian/papertok/backend/internal/repository/follow"
+	harvestRepo "github.com/rrlian/papertok/backend/internal/repository/harvest"
+	historyRepo "github.com/rrlian/papertok/backend/internal/repository/history"
+	likeRepo "github.com/rrlian/papertok/backend/internal/repository/like"
+	noteRepo "github.com/rrlian/papertok/backend/internal/repository/note"
 	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+	searchHistoryRepo "github.com/rrlian/papertok/backend/internal/repository/searchhistory"
 	userRepo "github.com/rrlian/papertok/backend/internal/repository/user"
 )
 
 // Config holds the configuration for the Facade.
 type Config struct {
 	// ArXiv configuration
-	ArxivBaseURL string
-	HTTPTimeout  time.Duration
-	CacheTTL     time.Duration
-	CacheEnabled bool
+	ArxivBaseURL          string
+	HTTPTimeout           time.Duration
+	ArxivMaxRetries       int
+	ArxivMinInterval      time.Duration
+	ArxivFailureThreshold int
+	ArxivOpenDuration     time.Duration
+	CacheTTL              time.Duration
+	CacheEnabled          bool
+	OAIBaseURL            string // OAI-PMH endpoint for harvesting (empty for arXiv's)
+
+	// Search configuration
+	SearchBackend string // Default search backend: "arxiv" (default) or "local"
+
+	// Semantic search configuration
+	SemanticEnabled   bool   // Embed stored papers for semantic and hybrid search
+	EmbedderProvider  string // "hash" (default, CPU-only) or "http" (OpenAI-compatible API)
+	EmbedderDimension int    // Vector length (hash default 256; required for http)
+	EmbedderURL       string // http: embeddings endpoint
+	EmbedderAPIKey    string // http: bearer token
+	EmbedderModel     string // http: model name
+
+	// Feed pre-warming configuration
+	PrewarmCategories []string      // Categories whose first feed page is kept warm
+	PrewarmSortOrders []string      // Sort orders warmed for each category (default lastUpdatedDate)
+	PrewarmInterval   time.Duration // Time between refreshes; keep it below CacheTTL
+	PrewarmJitter     time.Duration // Random spread applied to each refresh
+	PrewarmLimit      int           // Papers per warmed page
+
+	// Saved search alerts configuration
+	AlertsInterval time.Duration // Time between checks
+	AlertsLimit    int           // Matches fetched per saved search and check
+	AlertsSearches int           // Saved searches re-run per check, in turn
 
 	// Auth configuration
-	JWTSecret      string
-	JWTExpiresIn   time.Duration
+	JWTSecret       string
+	JWTExpiresIn    time.Duration
 	UseInMemoryAuth bool // If true, use in-memory repositories for testing
 
 	// Database configuration
@@ -36,17 +95,169 @@ type Config struct {
 // Paper represents a paper in the API response.
 // This is a unified type exposed by the Facade.
 type Paper struct {
-	ID              string    `json:"id"`
-	Title           string    `json:"title"`
-	Authors         []string  `json:"authors"`
-	Summary         string    `json:"summary"`
-	Published       time.Time `json:"published"`
-	Updated         time.Time `json:"updated"`
-	Categories      []string  `json:"categories"`
-	PrimaryCategory string    `json:"primaryCategory"`
-	ArxivURL        string    `json:"arxivUrl"`
-	PDFURL          string    `json:"pdfUrl"`
-	ImageURL        string    `json:"imageUrl"`
+	ID              string         `json:"id"`
+	Version         int            `json:"version,omitempty"`
+	Title           string         `json:"title"`
+	Authors         []string       `json:"authors"`
+	AuthorDetails   []AuthorDetail `json:"authorDetails,omitempty"`
+	Summary         string         `json:"summary"`
+	Published       time.Time      `json:"published"`
+	Updated         time.Time      `json:"updated"`
+	Categories      []string       `json:"categories"`
+	PrimaryCategory string         `json:"primaryCategory"`
+	ArxivURL        string         `json:"arxivUrl"`
+	PDFURL          string         `json:"pdfUrl"`
+	ImageURL        string         `json:"imageUrl"`
+	DOI             string         `json:"doi,omitempty"`
+	JournalRef      string         `json:"journalRef,omitempty"`
+	Comment         string         `json:"comment,omitempty"`
+	LikeCount       int            `json:"likeCount"`
+}
+
+// AuthorDetail is an author together with their stated affiliations.
+type AuthorDetail struct {
+	Name         string   `json:"name"`
+	Affiliations []string `json:"affiliations,omitempty"`
+}
+
+// PaperBatch holds the result of a batch lookup.
+type PaperBatch struct {
+	Papers  []*Paper `json:"papers"`
+	Missing []string `json:"missing"`
+}
+
+// PaperVersion describes one submitted version of a paper.
+type PaperVersion struct {
+	ID        string    `json:"id"`
+	Version   int       `json:"version"`
+	Submitted time.Time `json:"submitted"`
+	Title     string    `json:"title"`
+	Comment   string    `json:"comment,omitempty"`
+}
+
+// RelatedPaper is a paper similar to another, with its similarity score.
+type RelatedPaper struct {
+	Paper
+	Score float64 `json:"score"` // Cosine similarity, in (0, 1]
+}
+
+// TrendingPaper is a paper with recent engagement, with its trending score.
+type TrendingPaper struct {
+	Paper
+	Score   float64                     `json:"score"`   // Decayed, weighted engagement in the window
+	Signals map[trending.SignalKind]int `json:"signals"` // Engagement counts in the window by kind
+}
+
+// Bookmark is a paper saved by a user.
+type Bookmark struct {
+	PaperID   string    `json:"paperId"`
+	CreatedAt time.Time `json:"createdAt"`
+	Paper     *Paper    `json:"paper,omitempty"` // Nil if the paper can no longer be found
+}
+
+// HistoryEntry is a user's reading history for one paper.
+type HistoryEntry struct {
+	PaperID       string    `json:"paperId"`
+	FirstViewedAt time.Time `json:"firstViewedAt"`
+	LastViewedAt  time.Time `json:"lastViewedAt"`
+	Views         int       `json:"views"`           // Reading sessions
+	DwellSeconds  int       `json:"dwellSeconds"`    // Total time spent reading
+	ScrollDepth   float64   `json:"scrollDepth"`     // Furthest scroll position reached, 0 to 1
+	PDFPage       int       `json:"pdfPage"`         // Last PDF page reached, 0 if never opened
+	Paper         *Paper    `json:"paper,omitempty"` // Nil if the paper can no longer be found
+}
+
+// HistoryGroup is the history entries last viewed on one day.
+type HistoryGroup struct {
+	Date    string          `json:"date"` // YYYY-MM-DD
+	Entries []*HistoryEntry `json:"entries"`
+}
+
+// HistoryList is a page of a user's history grouped by day, together with
+// the user's total number of entries.
+type HistoryList struct {
+	Groups []*HistoryGroup
+	Total  int
+}
+
+// CollectionItem is a paper in a collection with the owner's note.
+type CollectionItem struct {
+	PaperID  string    `json:"paperId"`
+	Position int       `json:"position"` // 1-based
+	Note     string    `json:"note"`
+	AddedAt  time.Time `json:"addedAt"`
+	Paper    *Paper    `json:"paper,omitempty"` // Nil if the paper can no longer be found
+}
+
+// Collection is a named, ordered list of papers owned by a user.
+type Collection struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Visibility  string            `json:"visibility"`
+	Slug        string            `json:"slug"`
+	Owner       string            `json:"owner,omitempty"` // Owner's username, on shared and public collections
+	ItemCount   int               `json:"itemCount"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	Items       []*CollectionItem `json:"items,omitempty"` // Only filled in when a single collection is read
+}
+
+// CollectionList is a page of collections together with their total number.
+type CollectionList struct {
+	Collections []*Collection
+	Total       int
+}
+
+// Note is a user's markdown note or highlight on a paper.
+type Note struct {
+	ID        int64         `json:"id"`
+	PaperID   string        `json:"paperId"`
+	Body      string        `json:"body"`             // Markdown
+	Anchor    *notes.Anchor `json:"anchor,omitempty"` // Nil for a note on the whole paper
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	Paper     *Paper        `json:"paper,omitempty"` // Only filled in by searches; nil if the paper can no longer be found
+}
+
+// NoteList is a page of notes together with their total number.
+type NoteList struct {
+	Notes []*Note
+	Total int
+}
+
+// Alert is a paper that newly matched one of a user's saved searches.
+type Alert struct {
+	ID              int64      `json:"id"`
+	SavedSearchID   int64      `json:"savedSearchId"`
+	SavedSearchName string     `json:"savedSearchName"`
+	PaperID         string     `json:"paperId"`
+	Published       time.Time  `json:"published"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	Read            bool       `json:"read"`
+	ReadAt          *time.Time `json:"readAt,omitempty"`
+	Paper           *Paper     `json:"paper,omitempty"` // Nil if the paper can no longer be found
+}
+
+// AlertList is a page of alerts together with their total and unread numbers.
+type AlertList struct {
+	Alerts []*Alert
+	Total  int
+	Unread int
+}
+
+// BookmarkList is a page of a user's bookmarks together with their total number.
+type BookmarkList struct {
+	Bookmarks []*Bookmark
+	Total     int
+}
+
+// PaperList is a page of papers together with the total number available.
+type PaperList struct {
+	Papers       []*Paper
+	Total        int
+	NextCursor   string // Cursor for the next page of a feed, empty otherwise
+	RankingScope string // How far a feed was reordered (paperfeed.RankingScopePage), empty in date order
 }
 
 // Facade is the unified entry point for all business operations.
@@ -56,6 +267,21 @@ type Facade struct {
 	paperSearchSvc papersearch.Service
 	userAuthSvc    *userauth.Impl
 	authCoreSvc    auth.Service
+	harvestSvc     harvest.Service
+	importSvc      paperimport.Service
+	prewarmSvc     prewarm.Service
+	relatedSvc     relatedpapers.Service
+	trendingSvc    trending.Service
+	bookmarkSvc    bookmarks.Service
+	likeSvc        likes.Service
+	historySvc     history.Service
+	collectionSvc  collections.Service
+	noteSvc        notes.Service
+	searchHistSvc  searchhistory.Service
+	alertSvc       alerts.Service
+	followSvc      follows.Service
+	searchIndex    searchindex.Service
+	vectorIndex    vectorindex.Service // nil if semantic search is disabled
 }
 
 // New creates a new Facade instance with all dependencies initialized.
@@ -73,7 +299,35 @@ func New(cfg Config) *Facade {
 	}
 
 	// Initialize repositories
-	paperRepository := paperRepo.NewMemoryRepository(memCache)
+	// Harvest progress must live alongside the papers it describes,
+	// so both are persisted only when a database is provided.
+	var paperRepository paperRepo.Repository
+	var harvestStateRepository harvestRepo.Repository
+	if cfg.DB != nil {
+		paperRepository = paperRepo.NewSQLRepository(cfg.DB, memCache)
+		harvestStateRepository = harvestRepo.NewSQLRepository(cfg.DB)
+	} else {
+		paperRepository = paperRepo.NewMemoryRepository(memCache)
+		harvestStateRepository = harvestRepo.NewMemoryRepository()
+	}
+
+	// Every paper that lands in the repository is also indexed for local
+	// search and, if enabled, embedded for semantic search.
+	searchIndex := searchindex.New(papersearch.IndexConfig())
+	var embedder embedding.Embedder
+	var vectorIndex vectorindex.Service
+	if cfg.SemanticEnabled {
+		embedder = newEmbedder(cfg, httpClient)
+		vectorCfg := vectorindex.DefaultConfig(embedder.Dimension())
+		vectorCfg.Model = embedder.Name()
+		vectorIndex = vectorindex.New(vectorCfg)
+	}
+	paperRepository = &indexingRepository{
+		Repository: paperRepository,
+		index:      searchIndex,
+		embedder:   embedder,
+		vectors:    vectorIndex,
+	}
 
 	// Initialize user repository
 	var userRepository userRepo.Repository
@@ -86,10 +340,52 @@ func New(cfg Config) *Facade {
 		userRepository = userRepo.NewMemoryRepository()
 	}
 
+	// Bookmarks, likes, reading history, collections, notes, search history,
+	// alerts and follows reference users, so they live in the same store.
+	var bookmarkRepository bookmarkRepo.Repository
+	var likeRepository likeRepo.Repository
+	var historyRepository historyRepo.Repository
+	var collectionRepository collectionRepo.Repository
+	var noteRepository noteRepo.Repository
+	var searchHistoryRepository searchHistoryRepo.Repository
+	var alertRepository alertRepo.Repository
+	var followRepository followRepo.Repository
+	if cfg.DB != nil && !cfg.UseInMemoryAuth {
+		bookmarkRepository = bookmarkRepo.NewSQLRepository(cfg.DB)
+		likeRepository = likeRepo.NewSQLRepository(cfg.DB)
+		historyRepository = historyRepo.NewSQLRepository(cfg.DB)
+		collectionRepository = collectionRepo.NewSQLRepository(cfg.DB)
+		noteRepository = noteRepo.NewSQLRepository(cfg.DB)
+		searchHistoryRepository = searchHistoryRepo.NewSQLRepository(cfg.DB)
+		alertRepository = alertRepo.NewSQLRepository(cfg.DB)
+		followRepository = followRepo.NewSQLRepository(cfg.DB)
+	} else {
+		bookmarkRepository = bookmarkRepo.NewMemoryRepository()
+		likeRepository = likeRepo.NewMemoryRepository()
+		historyRepository = historyRepo.NewMemoryRepository()
+		collectionRepository = collectionRepo.NewMemoryRepository()
+		noteRepository = noteRepo.NewMemoryRepository()
+		searchHistoryRepository = searchHistoryRepo.NewMemoryRepository()
+		alertRepository = alertRepo.NewMemoryRepository()
+		followRepository = followRepo.NewMemoryRepository()
+	}
+
 	// Initialize core services
+	// A single scheduler paces every request to arXiv.
+	arxivScheduler := arxiv.NewScheduler(arxiv.SchedulerConfig{
+		MinInterval:      cfg.ArxivMinInterval,
+		MaxRetries:       cfg.ArxivMaxRetries,
+		FailureThreshold: cfg.ArxivFailureThreshold,
+		OpenDuration:     cfg.ArxivOpenDuration,
+	})
 	arxivSvc := arxiv.NewClient(arxiv.Config{
-		BaseURL: cfg.ArxivBaseURL,
-		Timeout: cfg.HTTPTimeout,
+		BaseURL:   cfg.ArxivBaseURL,
+		Timeout:   cfg.HTTPTimeout,
+		Scheduler: arxivScheduler,
+	}, httpClient)
+	oaiSvc := oaipmh.NewClient(oaipmh.Config{
+		BaseURL:   cfg.OAIBaseURL,
+		Scheduler: arxivScheduler,
 	}, httpClient)
 
 	authCoreSvc, err := auth.New(auth.Config{
@@ -104,41 +400,118 @@ func New(cfg Config) *Facade {
 	}
 
 	// Initialize features
-	paperFeedSvc := paperfeed.New(arxivSvc, paperRepository, cfg.CacheTTL)
-	paperSearchSvc := papersearch.New(arxivSvc)
+	// Feed cursors are signed with a key derived from the JWT secret.
+	bookmarkSvc := bookmarks.New(bookmarkRepository, paperRepository)
+	likeSvc := likes.New(likeRepository, paperRepository)
+	historySvc := history.New(historyRepository, paperRepository, history.Config{})
+	collectionSvc := collections.New(collectionRepository, paperRepository)
+	noteSvc := notes.New(noteRepository, paperRepository)
+	searchHistSvc := searchhistory.New(searchHistoryRepository, searchhistory.Config{})
+	followSvc := follows.New(followRepository)
+	profiles := &interestProfiles{bookmarks: bookmarkSvc, likes: likeSvc, history: historySvc, follows: followSvc, now: time.Now}
+	paperFeedSvc := paperfeed.New(arxivSvc, paperRepository, cfg.CacheTTL, cfg.JWTSecret, nil, profiles, historySvc, profiles)
+	paperSearchSvc := papersearch.New(arxivSvc, paperRepository, searchIndex, embedder, vectorIndex, cfg.SearchBackend, cfg.CacheTTL)
 	userAuthSvc := userauth.New(authCoreSvc, userRepository)
+	harvestSvc := harvest.New(oaiSvc, paperRepository, harvestStateRepository)
+	importSvc := paperimport.New(paperRepository)
+	relatedSvc := relatedpapers.New(searchIndex, paperRepository, memCache, cfg.CacheTTL)
+	trendingSvc := trending.New(paperRepository, likeRepository, bookmarkRepository, trending.Config{})
+	alertSvc := alerts.New(&savedSearchRunner{saved: searchHistSvc, search: paperSearchSvc}, alertRepository, paperRepository, alerts.Config{
+		Interval:         cfg.AlertsInterval,
+		Limit:            cfg.AlertsLimit,
+		SearchesPerCheck: cfg.AlertsSearches,
+	})
+
+	sortOrders := cfg.PrewarmSortOrders
+	if len(sortOrders) == 0 {
+		sortOrders = []string{prewarm.DefaultSortBy}
+	}
+	var prewarmJobs []prewarm.Job
+	for _, category := range cfg.PrewarmCategories {
+		for _, sortBy := range sortOrders {
+			prewarmJobs = append(prewarmJobs, prewarm.Job{Category: category, SortBy: sortBy})
+		}
+	}
+	prewarmSvc := prewarm.New(&feedRefresher{feed: paperFeedSvc}, prewarm.Config{
+		Jobs:     prewarmJobs,
+		Interval: cfg.PrewarmInterval,
+		Jitter:   cfg.PrewarmJitter,
+		Limit:    cfg.PrewarmLimit,
+	})
 
 	return &Facade{
 		paperFeedSvc:   paperFeedSvc,
 		paperSearchSvc: paperSearchSvc,
 		userAuthSvc:    userAuthSvc,
 		authCoreSvc:    authCoreSvc,
+		harvestSvc:     harvestSvc,
+		importSvc:      importSvc,
+		prewarmSvc:     prewarmSvc,
+		relatedSvc:     relatedSvc,
+		trendingSvc:    trendingSvc,
+		bookmarkSvc:    bookmarkSvc,
+		likeSvc:        likeSvc,
+		historySvc:     historySvc,
+		collectionSvc:  collectionSvc,
+		noteSvc:        noteSvc,
+		searchHistSvc:  searchHistSvc,
+		alertSvc:       alertSvc,
+		followSvc:      followSvc,
+		searchIndex:    searchIndex,
+		vectorIndex:    vectorIndex,
+	}
+}
+
+// newEmbedder creates the text embedder selected by the configuration.
+func newEmbedder(cfg Config, httpClient httpclient.HTTPClient) embedding.Embedder {
+	switch cfg.EmbedderProvider {
+	case "", "hash":
+		return embedding.NewHashEmbedder(cfg.EmbedderDimension)
+	case "http":
+		embedder, err := embedding.NewHTTPEmbedder(embedding.HTTPConfig{
+			URL:       cfg.EmbedderURL,
+			APIKey:    cfg.EmbedderAPIKey,
+			Model:     cfg.EmbedderModel,
+			Dimension: cfg.EmbedderDimension,
+		}, httpClient)
+		if err != nil {
+			panic(err) // In production, handle this gracefully
+		}
+		return embedder
+	default:
+		panic(fmt.Sprintf("unknown embedder provider %q", cfg.EmbedderProvider))
 	}
 }
 
 // GetPaperFeed fetches papers for the feed.
-func (f *Facade) GetPaperFeed(ctx context.Context, category string, limit, offset int, sortBy string) ([]*Paper, error) {
-	papers, err := f.paperFeedSvc.GetFeed(ctx, &paperfeed.FetchRequest{
-		Category: category,
-		Limit:    limit,
-		Offset:   offset,
-		SortBy:   sortBy,
-	})
+func (f *Facade) GetPaperFeed(ctx context.Context, req *paperfeed.FetchRequest) (*PaperList, error) {
+	result, err := f.paperFeedSvc.GetFeed(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return f.convertFeedPapers(papers), nil
+	papers := f.convertFeedPapers(result.Papers)
+	f.attachLikeCounts(ctx, papers)
+	return &PaperList{Papers: papers, Total: result.Total, NextCursor: result.NextCursor, RankingScope: result.RankingScope}, nil
 }
 
-// SearchPapers searches papers by keyword.
-func (f *Facade) SearchPapers(ctx context.Context, query string, limit int) ([]*Paper, error) {
-	papers, err := f.paperSearchSvc.Search(ctx, query, limit)
+// SearchPapers searches papers by keyword and fielded terms. Successful
+// searches by signed-in users (userID != 0) are added to their search history.
+func (f *Facade) SearchPapers(ctx context.Context, userID int64, req *papersearch.SearchRequest) (*PaperList, error) {
+	result, err := f.paperSearchSvc.Search(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return f.convertSearchPapers(papers), nil
+	if userID != 0 {
+		if _, err := f.searchHistSvc.Record(ctx, userID, searchQuery(req), result.Total); err != nil {
+			log.Printf("Failed to record search history: %v", err)
+		}
+	}
+
+	papers := f.convertSearchPapers(result.Papers)
+	f.attachLikeCounts(ctx, papers)
+	return &PaperList{Papers: papers, Total: result.Total}, nil
 }
 
 // GetPaperByID retrieves a single paper by ID.
@@ -152,7 +525,661 @@ func (f *Facade) GetPaperByID(ctx context.Context, id string) (*Paper, error) {
 		return nil, nil
 	}
 
-	return f.convertSearchPaper(paper), nil
+	result := f.convertSearchPaper(paper)
+	f.attachLikeCounts(ctx, []*Paper{result})
+	return result, nil
+}
+
+// GetPapersByIDs retrieves several papers in request order, reporting IDs that were not found.
+func (f *Facade) GetPapersByIDs(ctx context.Context, ids []string) (*PaperBatch, error) {
+	result, err := f.paperSearchSvc.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	papers := f.convertSearchPapers(result.Papers)
+	f.attachLikeCounts(ctx, papers)
+	return &PaperBatch{Papers: papers, Missing: result.Missing}, nil
+}
+
+// GetPaperVersions lists every version of a paper, oldest first.
+func (f *Facade) GetPaperVersions(ctx context.Context, id string) ([]*PaperVersion, error) {
+	versions, err := f.paperSearchSvc.GetVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	result := make([]*PaperVersion, len(versions))
+	for i, v := range versions {
+		result[i] = &PaperVersion{
+			ID:        v.ID,
+			Version:   v.Version,
+			Submitted: v.Submitted,
+			Title:     v.Title,
+			Comment:   v.Comment,
+		}
+	}
+	return result, nil
+}
+
+// GetRelatedPapers returns the stored papers most similar to a paper.
+// A paper that is not stored yet is fetched first, which also indexes it.
+// Returns nil if the paper does not exist.
+func (f *Facade) GetRelatedPapers(ctx context.Context, id string, limit int) ([]*RelatedPaper, error) {
+	papers, err := f.relatedSvc.Find(ctx, id, limit)
+	if relatedpapers.IsNotIndexed(err) {
+		if err = f.ensurePaperStored(ctx, id, err); err == nil {
+			papers, err = f.relatedSvc.Find(ctx, id, limit)
+		}
+		if relatedpapers.IsNotIndexed(err) {
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*RelatedPaper, len(papers))
+	for i, p := range papers {
+		result[i] = &RelatedPaper{
+			Paper: *convertStoredPaper(p.Paper),
+			Score: p.Score,
+		}
+	}
+
+	shown := make([]*Paper, len(result))
+	for i, p := range result {
+		shown[i] = &p.Paper
+	}
+	f.attachLikeCounts(ctx, shown)
+	return result, nil
+}
+
+// GetTrendingPapers returns the stored papers with the most recent engagement.
+func (f *Facade) GetTrendingPapers(ctx context.Context, req *trending.Request) ([]*TrendingPaper, error) {
+	papers, err := f.trendingSvc.Trending(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*TrendingPaper, len(papers))
+	for i, p := range papers {
+		result[i] = &TrendingPaper{
+			Paper:   *convertStoredPaper(p.Paper),
+			Score:   p.Score,
+			Signals: p.Signals,
+		}
+	}
+
+	shown := make([]*Paper, len(result))
+	for i, p := range result {
+		shown[i] = &p.Paper
+	}
+	f.attachLikeCounts(ctx, shown)
+	return result, nil
+}
+
+// RecordEngagement records a view, like, bookmark or share of a paper for
+// trending. Papers the repository no longer holds, or never held because only
+// a specific version was requested, are fetched and stored first.
+func (f *Facade) RecordEngagement(ctx context.Context, signal trending.Signal) error {
+	err := f.trendingSvc.Record(ctx, signal)
+	if trending.IsPaperNotFound(err) {
+		if err = f.ensurePaperStored(ctx, signal.PaperID, err); err == nil {
+			err = f.trendingSvc.Record(ctx, signal)
+		}
+	}
+	return err
+}
+
+// recordEngagement records a signal for trending, logging any failure since
+// trending is secondary to the change that caused it.
+func (f *Facade) recordEngagement(ctx context.Context, signal trending.Signal) {
+	if err := f.RecordEngagement(ctx, signal); err != nil {
+		log.Printf("Failed to record %s of %s for trending: %v", signal.Kind, signal.PaperID, err)
+	}
+}
+
+// AddBookmark bookmarks a paper for a user, fetching and storing the paper
+// first if necessary. It reports whether the bookmark was newly created.
+func (f *Facade) AddBookmark(ctx context.Context, userID int64, paperID string) (*Bookmark, bool, error) {
+	b, created, err := f.bookmarkSvc.Add(ctx, userID, paperID)
+	if bookmarks.IsPaperNotFound(err) {
+		if err = f.ensurePaperStored(ctx, paperID, err); err == nil {
+			b, created, err = f.bookmarkSvc.Add(ctx, userID, paperID)
+		}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if created {
+		f.recordEngagement(ctx, trending.Signal{PaperID: b.PaperID, Kind: trending.SignalBookmark})
+	}
+	return f.convertBookmark(b), created, nil
+}
+
+// RemoveBookmark deletes a user's bookmark.
+func (f *Facade) RemoveBookmark(ctx context.Context, userID int64, paperID string) error {
+	if err := f.bookmarkSvc.Remove(ctx, userID, paperID); err != nil {
+		return err
+	}
+
+	f.recordEngagement(ctx, trending.Signal{PaperID: paperID, Kind: trending.SignalBookmark, Count: -1})
+	return nil
+}
+
+// ListBookmarks returns a page of a user's bookmarks. Papers no longer in the
+// paper repository are fetched again; any that cannot be found are left empty.
+func (f *Facade) ListBookmarks(ctx context.Context, req *bookmarks.ListRequest) (*BookmarkList, error) {
+	result, err := f.bookmarkSvc.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &BookmarkList{Bookmarks: make([]*Bookmark, len(result.Bookmarks)), Total: result.Total}
+	var missing []string
+	for i, b := range result.Bookmarks {
+		list.Bookmarks[i] = f.convertBookmark(b)
+		if b.Paper == nil {
+			missing = append(missing, b.PaperID)
+		}
+	}
+	if len(missing) > 0 {
+		found := f.refetchPapers(ctx, missing)
+		for _, b := range list.Bookmarks {
+			if b.Paper == nil {
+				b.Paper = found[b.PaperID]
+			}
+		}
+	}
+
+	var shown []*Paper
+	for _, b := range list.Bookmarks {
+		if b.Paper != nil {
+			shown = append(shown, b.Paper)
+		}
+	}
+	f.attachLikeCounts(ctx, shown)
+	return list, nil
+}
+
+// BookmarkStatus reports which of the given papers a user has bookmarked.
+func (f *Facade) BookmarkStatus(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error) {
+	return f.bookmarkSvc.Status(ctx, userID, paperIDs)
+}
+
+// LikePaper records that a user likes a paper, fetching and storing the paper
+// first if necessary.
+func (f *Facade) LikePaper(ctx context.Context, userID int64, paperID string) (*likes.Status, error) {
+	status, added, err := f.likeSvc.Like(ctx, userID, paperID)
+	if likes.IsPaperNotFound(err) {
+		if err = f.ensurePaperStored(ctx, paperID, err); err == nil {
+			status, added, err = f.likeSvc.Like(ctx, userID, paperID)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if added {
+		f.recordEngagement(ctx, trending.Signal{PaperID: status.PaperID, Kind: trending.SignalLike})
+	}
+	return status, nil
+}
+
+// UnlikePaper removes a user's like of a paper.
+func (f *Facade) UnlikePaper(ctx context.Context, userID int64, paperID string) (*likes.Status, error) {
+	status, removed, err := f.likeSvc.Unlike(ctx, userID, paperID)
+	if err != nil {
+		return nil, err
+	}
+
+	if removed {
+		f.recordEngagement(ctx, trending.Signal{PaperID: status.PaperID, Kind: trending.SignalLike, Count: -1})
+	}
+	return status, nil
+}
+
+// GetLikeStatus returns whether a user likes a paper and its like count.
+func (f *Facade) GetLikeStatus(ctx context.Context, userID int64, paperID string) (*likes.Status, error) {
+	return f.likeSvc.Status(ctx, userID, paperID)
+}
+
+// RecordView adds a view report to a user's reading history, fetching and
+// storing the paper first if necessary.
+func (f *Facade) RecordView(ctx context.Context, view *history.View) (*HistoryEntry, error) {
+	entry, err := f.historySvc.Record(ctx, view)
+	if history.IsPaperNotFound(err) {
+		if err = f.ensurePaperStored(ctx, view.PaperID, err); err == nil {
+			entry, err = f.historySvc.Record(ctx, view)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := f.convertHistoryEntry(entry)
+	if result.Paper != nil {
+		f.attachLikeCounts(ctx, []*Paper{result.Paper})
+	}
+	return result, nil
+}
+
+// ListHistory returns a page of a user's reading history grouped by day.
+// Papers no longer in the paper repository are fetched again; any that
+// cannot be found are left empty.
+func (f *Facade) ListHistory(ctx context.Context, req *history.ListRequest) (*HistoryList, error) {
+	result, err := f.historySvc.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &HistoryList{Groups: make([]*HistoryGroup, len(result.Groups)), Total: result.Total}
+	var entries []*HistoryEntry
+	var missing []string
+	for i, g := range result.Groups {
+		group := &HistoryGroup{Date: g.Date, Entries: make([]*HistoryEntry, len(g.Entries))}
+		for j, e := range g.Entries {
+			group.Entries[j] = f.convertHistoryEntry(e)
+			if e.Paper == nil {
+				missing = append(missing, e.PaperID)
+			}
+		}
+		list.Groups[i] = group
+		entries = append(entries, group.Entries...)
+	}
+	if len(missing) > 0 {
+		found := f.refetchPapers(ctx, missing)
+		for _, e := range entries {
+			if e.Paper == nil {
+				e.Paper = found[e.PaperID]
+			}
+		}
+	}
+
+	var shown []*Paper
+	for _, e := range entries {
+		if e.Paper != nil {
+			shown = append(shown, e.Paper)
+		}
+	}
+	f.attachLikeCounts(ctx, shown)
+	return list, nil
+}
+
+// DeleteHistoryEntry removes a paper from a user's reading history.
+func (f *Facade) DeleteHistoryEntry(ctx context.Context, userID int64, paperID string) error {
+	return f.historySvc.Delete(ctx, userID, paperID)
+}
+
+// ClearHistory removes a user's whole reading history and returns the number
+// of entries removed.
+func (f *Facade) ClearHistory(ctx context.Context, userID int64) (int, error) {
+	return f.historySvc.Clear(ctx, userID)
+}
+
+// CreateCollection creates a collection for a user.
+func (f *Facade) CreateCollection(ctx context.Context, userID int64, req *collections.CreateRequest) (*Collection, error) {
+	c, err := f.collectionSvc.Create(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	return f.convertCollection(c), nil
+}
+
+// UpdateCollection renames a collection or changes its description or visibility.
+func (f *Facade) UpdateCollection(ctx context.Context, userID, id int64, req *collections.UpdateRequest) (*Collection, error) {
+	c, err := f.collectionSvc.Update(ctx, userID, id, req)
+	if err != nil {
+		return nil, err
+	}
+	return f.convertCollection(c), nil
+}
+
+// DeleteCollection removes a user's collection.
+func (f *Facade) DeleteCollection(ctx context.Context, userID, id int64) error {
+	return f.collectionSvc.Delete(ctx, userID, id)
+}
+
+// ListCollections returns a user's collections without their items.
+func (f *Facade) ListCollections(ctx context.Context, userID int64) ([]*Collection, error) {
+	stored, err := f.collectionSvc.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Collection, len(stored))
+	for i, c := range stored {
+		result[i] = f.convertCollection(c)
+	}
+	return result, nil
+}
+
+// GetCollection returns one of a user's collections with its items.
+func (f *Facade) GetCollection(ctx context.Context, userID, id int64) (*Collection, error) {
+	c, err := f.collectionSvc.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	result := f.convertCollection(c)
+	f.completeCollectionPapers(ctx, result)
+	return result, nil
+}
+
+// GetSharedCollection returns an unlisted or public collection by its share
+// slug, with its items and owner.
+func (f *Facade) GetSharedCollection(ctx context.Context, slug string) (*Collection, error) {
+	c, err := f.collectionSvc.GetShared(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	result := f.convertCollection(c)
+	result.Owner = f.username(ctx, c.OwnerID)
+	f.completeCollectionPapers(ctx, result)
+	return result, nil
+}
+
+// ListPublicCollections returns a page of public collections with their owners.
+func (f *Facade) ListPublicCollections(ctx context.Context, offset, limit int) (*CollectionList, error) {
+	page, err := f.collectionSvc.ListPublic(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &CollectionList{Collections: make([]*Collection, len(page.Collections)), Total: page.Total}
+	owners := make(map[int64]string)
+	for i, c := range page.Collections {
+		list.Collections[i] = f.convertCollection(c)
+		owner, ok := owners[c.OwnerID]
+		if !ok {
+			owner = f.username(ctx, c.OwnerID)
+			owners[c.OwnerID] = owner
+		}
+		list.Collections[i].Owner = owner
+	}
+	return list, nil
+}
+
+// AddCollectionItem appends a paper to a user's collection, fetching and
+// storing the paper first if necessary. It reports whether the paper was added.
+func (f *Facade) AddCollectionItem(ctx context.Context, userID, id int64, paperID, note string) (*CollectionItem, bool, error) {
+	item, added, err := f.collectionSvc.AddItem(ctx, userID, id, paperID, note)
+	if collections.IsPaperNotFound(err) {
+		if err = f.ensurePaperStored(ctx, paperID, err); err == nil {
+			item, added, err = f.collectionSvc.AddItem(ctx, userID, id, paperID, note)
+		}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	result := f.convertCollectionItem(item)
+	if result.Paper != nil {
+		f.attachLikeCounts(ctx, []*Paper{result.Paper})
+	}
+	return result, added, nil
+}
+
+// UpdateCollectionItem replaces the note on a paper in a user's collection.
+func (f *Facade) UpdateCollectionItem(ctx context.Context, userID, id int64, paperID, note string) (*CollectionItem, error) {
+	item, err := f.collectionSvc.UpdateItem(ctx, userID, id, paperID, note)
+	if err != nil {
+		return nil, err
+	}
+	result := f.convertCollectionItem(item)
+	if result.Paper != nil {
+		f.attachLikeCounts(ctx, []*Paper{result.Paper})
+	}
+	return result, nil
+}
+
+// RemoveCollectionItem removes a paper from a user's collection.
+func (f *Facade) RemoveCollectionItem(ctx context.Context, userID, id int64, paperID string) error {
+	return f.collectionSvc.RemoveItem(ctx, userID, id, paperID)
+}
+
+// ReorderCollection puts the papers in a user's collection in the given order.
+func (f *Facade) ReorderCollection(ctx context.Context, userID, id int64, paperIDs []string) (*Collection, error) {
+	c, err := f.collectionSvc.Reorder(ctx, userID, id, paperIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := f.convertCollection(c)
+	f.completeCollectionPapers(ctx, result)
+	return result, nil
+}
+
+// CreateNote adds a note to a paper, fetching and storing the paper first if necessary.
+func (f *Facade) CreateNote(ctx context.Context, userID int64, paperID string, req *notes.NoteRequest) (*Note, error) {
+	n, err := f.noteSvc.Create(ctx, userID, paperID, req)
+	if notes.IsPaperNotFound(err) {
+		if err = f.ensurePaperStored(ctx, paperID, err); err == nil {
+			n, err = f.noteSvc.Create(ctx, userID, paperID, req)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f.convertNote(n), nil
+}
+
+// ListNotes returns a user's notes on a paper, oldest first.
+func (f *Facade) ListNotes(ctx context.Context, userID int64, paperID string) ([]*Note, error) {
+	stored, err := f.noteSvc.List(ctx, userID, paperID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Note, len(stored))
+	for i, n := range stored {
+		result[i] = f.convertNote(n)
+	}
+	return result, nil
+}
+
+// GetNote returns one of a user's notes on a paper.
+func (f *Facade) GetNote(ctx context.Context, userID int64, paperID string, id int64) (*Note, error) {
+	n, err := f.noteSvc.Get(ctx, userID, paperID, id)
+	if err != nil {
+		return nil, err
+	}
+	return f.convertNote(n), nil
+}
+
+// UpdateNote changes a note's body or anchor. A new abstract anchor is
+// checked against the paper, which is fetched again if it has expired.
+func (f *Facade) UpdateNote(ctx context.Context, userID int64, paperID string, id int64, req *notes.UpdateRequest) (*Note, error) {
+	n, err := f.noteSvc.Update(ctx, userID, paperID, id, req)
+	if notes.IsPaperNotFound(err) {
+		if err = f.ensurePaperStored(ctx, paperID, err); err == nil {
+			n, err = f.noteSvc.Update(ctx, userID, paperID, id, req)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f.convertNote(n), nil
+}
+
+// DeleteNote removes a user's note.
+func (f *Facade) DeleteNote(ctx context.Context, userID int64, paperID string, id int64) error {
+	return f.noteSvc.Delete(ctx, userID, paperID, id)
+}
+
+// SearchNotes searches a user's own notes. Papers no longer in the paper
+// repository are fetched again; any that cannot be found are left empty.
+func (f *Facade) SearchNotes(ctx context.Context, req *notes.SearchRequest) (*NoteList, error) {
+	result, err := f.noteSvc.Search(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &NoteList{Notes: make([]*Note, len(result.Notes)), Total: result.Total}
+	var missing []string
+	for i, n := range result.Notes {
+		list.Notes[i] = f.convertNote(n)
+		if n.Paper == nil {
+			missing = append(missing, n.PaperID)
+		}
+	}
+	if len(missing) > 0 {
+		found := f.refetchPapers(ctx, missing)
+		for _, n := range list.Notes {
+			if n.Paper == nil {
+				n.Paper = found[n.PaperID]
+			}
+		}
+	}
+
+	var shown []*Paper
+	for _, n := range list.Notes {
+		if n.Paper != nil {
+			shown = append(shown, n.Paper)
+		}
+	}
+	f.attachLikeCounts(ctx, shown)
+	return list, nil
+}
+
+// ensurePaperStored fetches and stores a paper a feature service could not
+// find, so the caller can retry. It returns notFound if the paper does not
+// exist. paperID must already have been validated by that service.
+func (f *Facade) ensurePaperStored(ctx context.Context, paperID string, notFound error) error {
+	id, _ := arxiv.BaseID(paperID)
+	paper, err := f.paperSearchSvc.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if paper == nil {
+		return notFound
+	}
+	return nil
+}
+
+// ListSearchHistory returns a page of a user's search history, most recent first.
+func (f *Facade) ListSearchHistory(ctx context.Context, userID int64, offset, limit int) (*searchhistory.EntryList, error) {
+	return f.searchHistSvc.List(ctx, userID, offset, limit)
+}
+
+// DeleteSearchHistoryEntry removes an entry from a user's search history.
+func (f *Facade) DeleteSearchHistoryEntry(ctx context.Context, userID, id int64) error {
+	return f.searchHistSvc.Delete(ctx, userID, id)
+}
+
+// ClearSearchHistory removes a user's whole search history.
+func (f *Facade) ClearSearchHistory(ctx context.Context, userID int64) (int, error) {
+	return f.searchHistSvc.Clear(ctx, userID)
+}
+
+// SaveSearch promotes an entry in a user's search history to a named saved search.
+func (f *Facade) SaveSearch(ctx context.Context, userID, entryID int64, name string) (*searchhistory.SavedSearch, error) {
+	return f.searchHistSvc.Save(ctx, userID, entryID, name)
+}
+
+// ListSavedSearches returns a user's saved searches, most recently created first.
+func (f *Facade) ListSavedSearches(ctx context.Context, userID int64) ([]*searchhistory.SavedSearch, error) {
+	return f.searchHistSvc.ListSaved(ctx, userID)
+}
+
+// RenameSavedSearch renames one of a user's saved searches.
+func (f *Facade) RenameSavedSearch(ctx context.Context, userID, id int64, name string) (*searchhistory.SavedSearch, error) {
+	return f.searchHistSvc.RenameSaved(ctx, userID, id, name)
+}
+
+// DeleteSavedSearch removes one of a user's saved searches.
+func (f *Facade) DeleteSavedSearch(ctx context.Context, userID, id int64) error {
+	return f.searchHistSvc.DeleteSaved(ctx, userID, id)
+}
+
+// RunSavedSearch runs one of a user's saved searches again. Like any other
+// search, the run is added to the user's search history.
+func (f *Facade) RunSavedSearch(ctx context.Context, userID, id int64, limit int) (*searchhistory.SavedSearch, *PaperList, error) {
+	saved, err := f.searchHistSvc.GetSaved(ctx, userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	req := searchRequest(saved.Query)
+	req.Limit = limit
+	list, err := f.SearchPapers(ctx, userID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return saved, list, nil
+}
+
+// ListAlerts returns a page of a user's saved search alerts, newest first.
+// Papers no longer in the paper repository are fetched again; any that
+// cannot be found are left empty.
+func (f *Facade) ListAlerts(ctx context.Context, req *alerts.ListRequest) (*AlertList, error) {
+	result, err := f.alertSvc.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string)
+	if saved, err := f.searchHistSvc.ListSaved(ctx, req.UserID); err != nil {
+		log.Printf("Failed to get saved search names: %v", err)
+	} else {
+		for _, s := range saved {
+			names[s.ID] = s.Name
+		}
+	}
+
+	list := &AlertList{Alerts: make([]*Alert, len(result.Alerts)), Total: result.Total, Unread: result.Unread}
+	var missing []string
+	for i, a := range result.Alerts {
+		list.Alerts[i] = f.convertAlert(a)
+		list.Alerts[i].SavedSearchName = names[a.SavedSearchID]
+		if a.Paper == nil {
+			missing = append(missing, a.PaperID)
+		}
+	}
+	if len(missing) > 0 {
+		found := f.refetchPapers(ctx, missing)
+		for _, a := range list.Alerts {
+			if a.Paper == nil {
+				a.Paper = found[a.PaperID]
+			}
+		}
+	}
+
+	var shown []*Paper
+	for _, a := range list.Alerts {
+		if a.Paper != nil {
+			shown = append(shown, a.Paper)
+		}
+	}
+	f.attachLikeCounts(ctx, shown)
+	return list, nil
+}
+
+// MarkAlertsRead marks some of a user's alerts as read.
+func (f *Facade) MarkAlertsRead(ctx context.Context, userID int64, ids []int64) (int, error) {
+	return f.alertSvc.MarkRead(ctx, userID, ids)
+}
+
+// MarkAllAlertsRead marks all of a user's alerts as read.
+func (f *Facade) MarkAllAlertsRead(ctx context.Context, userID int64) (int, error) {
+	return f.alertSvc.MarkAllRead(ctx, userID)
+}
+
+// Follow follows an author or arXiv category for a user.
+// The bool reports whether the follow was created.
+func (f *Facade) Follow(ctx context.Context, userID int64, kind, name string) (*follows.Follow, bool, error) {
+	return f.followSvc.Follow(ctx, userID, kind, name)
+}
+
+// Unfollow stops following an author or arXiv category.
+func (f *Facade) Unfollow(ctx context.Context, userID int64, kind, name string) error {
+	return f.followSvc.Unfollow(ctx, userID, kind, name)
+}
+
+// ListFollowing returns the authors and categories a user follows.
+func (f *Facade) ListFollowing(ctx context.Context, userID int64) (*follows.Following, error) {
+	return f.followSvc.List(ctx, userID)
 }
 
 // UserAuth returns the user authentication service.
@@ -165,14 +1192,157 @@ func (f *Facade) AuthCore() auth.Service {
 	return f.authCoreSvc
 }
 
+// Harvester returns the OAI-PMH harvest service.
+func (f *Facade) Harvester() harvest.Service {
+	return f.harvestSvc
+}
+
+// Importer returns the metadata snapshot import service.
+func (f *Facade) Importer() paperimport.Service {
+	return f.importSvc
+}
+
+// Prewarmer returns the feed pre-warming scheduler.
+func (f *Facade) Prewarmer() prewarm.Service {
+	return f.prewarmSvc
+}
+
+// Alerter returns the saved search alert matcher.
+func (f *Facade) Alerter() alerts.Service {
+	return f.alertSvc
+}
+
+// Trending returns the trending papers service.
+func (f *Facade) Trending() trending.Service {
+	return f.trendingSvc
+}
+
+// RebuildSearchIndex refills the local search index from the paper repository.
+func (f *Facade) RebuildSearchIndex(ctx context.Context) (int, error) {
+	return f.paperSearchSvc.RebuildIndex(ctx)
+}
+
+// SyncSearchIndex adds the papers stored or changed since the given time to
+// the local search index, including those stored by other processes.
+func (f *Facade) SyncSearchIndex(ctx context.Context, since time.Time) (int, error) {
+	return f.paperSearchSvc.SyncIndex(ctx, since)
+}
+
+// LoadSearchIndex replaces the local search index with the snapshot at path
+// and returns the time the snapshot was written.
+func (f *Facade) LoadSearchIndex(path string) (time.Time, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), f.searchIndex.Load(file)
+}
+
+// SaveSearchIndex writes a snapshot of the local search index to path.
+func (f *Facade) SaveSearchIndex(path string) error {
+	return writeSnapshot(path, f.searchIndex.Save)
+}
+
+// SearchIndexSize returns the number of papers in the local search index.
+func (f *Facade) SearchIndexSize() int {
+	return f.searchIndex.Len()
+}
+
+// RebuildVectorIndex re-embeds every paper in the paper repository for semantic search.
+func (f *Facade) RebuildVectorIndex(ctx context.Context) (int, error) {
+	return f.paperSearchSvc.RebuildVectorIndex(ctx)
+}
+
+// SyncVectorIndex embeds the papers stored or changed since the given time
+// that are not in the semantic index yet, including those stored by other
+// processes.
+func (f *Facade) SyncVectorIndex(ctx context.Context, since time.Time) (int, error) {
+	return f.paperSearchSvc.SyncVectorIndex(ctx, since)
+}
+
+// LoadVectorIndex replaces the semantic index with the snapshot at path and
+// returns the time the snapshot was written. Snapshots written for another
+// embedder are rejected.
+func (f *Facade) LoadVectorIndex(path string) (time.Time, error) {
+	if f.vectorIndex == nil {
+		return time.Time{}, papersearch.ErrSemanticDisabled
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), f.vectorIndex.Load(file)
+}
+
+// SaveVectorIndex writes a snapshot of the semantic index to path.
+func (f *Facade) SaveVectorIndex(path string) error {
+	if f.vectorIndex == nil {
+		return papersearch.ErrSemanticDisabled
+	}
+	return writeSnapshot(path, f.vectorIndex.Save)
+}
+
+// VectorIndexSize returns the number of papers in the semantic index.
+func (f *Facade) VectorIndexSize() int {
+	if f.vectorIndex == nil {
+		return 0
+	}
+	return f.vectorIndex.Len()
+}
+
+// writeSnapshot writes an index snapshot to path. The snapshot is written
+// to a temporary file first, so readers never see a partial one.
+func writeSnapshot(path string, save func(w io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create index snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := save(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write index snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace index snapshot: %w", err)
+	}
+	return nil
+}
+
 // convertFeedPapers converts paperfeed.Paper to facade.Paper.
 func (f *Facade) convertFeedPapers(papers []*paperfeed.Paper) []*Paper {
 	result := make([]*Paper, len(papers))
 	for i, p := range papers {
+		var authors []AuthorDetail
+		for _, a := range p.AuthorDetails {
+			authors = append(authors, AuthorDetail{Name: a.Name, Affiliations: a.Affiliations})
+		}
 		result[i] = &Paper{
 			ID:              p.ID,
+			Version:         p.Version,
 			Title:           p.Title,
 			Authors:         p.Authors,
+			AuthorDetails:   authors,
 			Summary:         p.Summary,
 			Published:       p.Published,
 			Updated:         p.Updated,
@@ -181,6 +1351,9 @@ func (f *Facade) convertFeedPapers(papers []*paperfeed.Paper) []*Paper {
 			ArxivURL:        p.ArxivURL,
 			PDFURL:          p.PDFURL,
 			ImageURL:        p.ImageURL,
+			DOI:             p.DOI,
+			JournalRef:      p.JournalRef,
+			Comment:         p.Comment,
 		}
 	}
 	return result
@@ -197,10 +1370,44 @@ func (f *Facade) convertSearchPapers(papers []*papersearch.Paper) []*Paper {
 
 // convertSearchPaper converts a single papersearch.Paper to facade.Paper.
 func (f *Facade) convertSearchPaper(p *papersearch.Paper) *Paper {
+	var authors []AuthorDetail
+	for _, a := range p.AuthorDetails {
+		authors = append(authors, AuthorDetail{Name: a.Name, Affiliations: a.Affiliations})
+	}
+	return &Paper{
+		ID:              p.ID,
+		Version:         p.Version,
+		Title:           p.Title,
+		Authors:         p.Authors,
+		AuthorDetails:   authors,
+		Summary:         p.Summary,
+		Published:       p.Published,
+		Updated:         p.Updated,
+		Categories:      p.Categories,
+		PrimaryCategory: p.PrimaryCategory,
+		ArxivURL:        p.ArxivURL,
+		PDFURL:          p.PDFURL,
+		ImageURL:        p.ImageURL,
+		DOI:             p.DOI,
+		JournalRef:      p.JournalRef,
+		Comment:         p.Comment,
+	}
+}
+
+// convertStoredPaper converts a paper from the paper repository, as the
+// bookmark, history, collection, note, alert, related and trending features
+// return it.
+func convertStoredPaper(p *paperRepo.Paper) *Paper {
+	var authors []AuthorDetail
+	for _, a := range p.AuthorDetails {
+		authors = append(authors, AuthorDetail{Name: a.Name, Affiliations: a.Affiliations})
+	}
 	return &Paper{
 		ID:              p.ID,
+		Version:         p.Version,
 		Title:           p.Title,
 		Authors:         p.Authors,
+		AuthorDetails:   authors,
 		Summary:         p.Summary,
 		Published:       p.Published,
 		Updated:         p.Updated,
@@ -209,13 +1416,225 @@ func (f *Facade) convertSearchPaper(p *papersearch.Paper) *Paper {
 		ArxivURL:        p.ArxivURL,
 		PDFURL:          p.PDFURL,
 		ImageURL:        p.ImageURL,
+		DOI:             p.DOI,
+		JournalRef:      p.JournalRef,
+		Comment:         p.Comment,
 	}
 }
 
 // noopCache is a no-op cache implementation for when caching is disabled.
 type noopCache struct{}
 
-func (n *noopCache) Get(key string) (interface{}, bool) { return nil, false }
+func (n *noopCache) Get(key string) (interface{}, bool)                   { return nil, false }
 func (n *noopCache) Set(key string, value interface{}, ttl time.Duration) {}
-func (n *noopCache) Delete(key string) {}
-func (n *noopCache) Clear() {}
+func (n *noopCache) Delete(key string)                                    {}
+func (n *noopCache) Clear()                                               {}
+
+// attachLikeCounts fills in the like counts of papers with one lookup.
+// Counts are decoration, so a failing lookup leaves them at zero.
+func (f *Facade) attachLikeCounts(ctx context.Context, papers []*Paper) {
+	if len(papers) == 0 {
+		return
+	}
+	ids := make([]string, len(papers))
+	for i, p := range papers {
+		ids[i] = p.ID
+	}
+	counts, err := f.likeSvc.Counts(ctx, ids)
+	if err != nil {
+		log.Printf("Failed to load like counts: %v", err)
+		return
+	}
+	for _, p := range papers {
+		p.LikeCount = counts[p.ID]
+	}
+}
+
+// refetchPapers fetches papers that are no longer in the paper repository.
+// Papers that cannot be fetched are left out.
+func (f *Facade) refetchPapers(ctx context.Context, ids []string) map[string]*Paper {
+	found := make(map[string]*Paper, len(ids))
+	batch, err := f.paperSearchSvc.GetByIDs(ctx, ids)
+	if err != nil {
+		return found
+	}
+	for _, p := range f.convertSearchPapers(batch.Papers) {
+		found[p.ID] = p
+	}
+	return found
+}
+
+// completeCollectionPapers fetches the papers of a collection's items that
+// are no longer in the paper repository and fills in like counts.
+func (f *Facade) completeCollectionPapers(ctx context.Context, c *Collection) {
+	var missing []string
+	for _, item := range c.Items {
+		if item.Paper == nil {
+			missing = append(missing, item.PaperID)
+		}
+	}
+	if len(missing) > 0 {
+		found := f.refetchPapers(ctx, missing)
+		for _, item := range c.Items {
+			if item.Paper == nil {
+				item.Paper = found[item.PaperID]
+			}
+		}
+	}
+
+	var shown []*Paper
+	for _, item := range c.Items {
+		if item.Paper != nil {
+			shown = append(shown, item.Paper)
+		}
+	}
+	f.attachLikeCounts(ctx, shown)
+}
+
+// username returns a user's name, or an empty string if it cannot be found.
+func (f *Facade) username(ctx context.Context, userID int64) string {
+	profile, err := f.userAuthSvc.GetProfile(ctx, userID)
+	if err != nil {
+		return ""
+	}
+	return profile.Username
+}
+
+// convertBookmark converts a bookmark to the facade type.
+func (f *Facade) convertBookmark(b *bookmarks.Bookmark) *Bookmark {
+	result := &Bookmark{PaperID: b.PaperID, CreatedAt: b.CreatedAt}
+	if p := b.Paper; p != nil {
+		result.Paper = convertStoredPaper(p)
+	}
+	return result
+}
+
+// convertHistoryEntry converts a history entry to the facade type.
+func (f *Facade) convertHistoryEntry(e *history.Entry) *HistoryEntry {
+	result := &HistoryEntry{
+		PaperID:       e.PaperID,
+		FirstViewedAt: e.FirstViewedAt,
+		LastViewedAt:  e.LastViewedAt,
+		Views:         e.Views,
+		DwellSeconds:  e.DwellSeconds,
+		ScrollDepth:   e.ScrollDepth,
+		PDFPage:       e.PDFPage,
+	}
+	if p := e.Paper; p != nil {
+		result.Paper = convertStoredPaper(p)
+	}
+	return result
+}
+
+// convertCollection converts a collection and its items, if any, to the facade type.
+func (f *Facade) convertCollection(c *collections.Collection) *Collection {
+	result := &Collection{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		Visibility:  c.Visibility,
+		Slug:        c.Slug,
+		ItemCount:   c.ItemCount,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+	for _, item := range c.Items {
+		result.Items = append(result.Items, f.convertCollectionItem(item))
+	}
+	return result
+}
+
+// convertCollectionItem converts a collection item to the facade type.
+func (f *Facade) convertCollectionItem(item *collections.Item) *CollectionItem {
+	result := &CollectionItem{
+		PaperID:  item.PaperID,
+		Position: item.Position,
+		Note:     item.Note,
+		AddedAt:  item.AddedAt,
+	}
+	if p := item.Paper; p != nil {
+		result.Paper = convertStoredPaper(p)
+	}
+	return result
+}
+
+// convertNote converts a note, with its paper if the feature filled it in.
+func (f *Facade) convertNote(n *notes.Note) *Note {
+	result := &Note{
+		ID:        n.ID,
+		PaperID:   n.PaperID,
+		Body:      n.Body,
+		Anchor:    n.Anchor,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+	if p := n.Paper; p != nil {
+		result.Paper = convertStoredPaper(p)
+	}
+	return result
+}
+
+// searchQuery converts a paper search request to a search history query.
+func searchQuery(req *papersearch.SearchRequest) *searchhistory.Query {
+	q := &searchhistory.Query{
+		Text:     req.Query,
+		MatchAny: req.MatchAny,
+		Backend:  req.Backend,
+		Mode:     req.Mode,
+	}
+	for _, t := range req.Include {
+		q.Include = append(q.Include, searchhistory.Term{Field: t.Field, Value: t.Value})
+	}
+	for _, t := range req.Exclude {
+		q.Exclude = append(q.Exclude, searchhistory.Term{Field: t.Field, Value: t.Value})
+	}
+	if !req.SubmittedFrom.IsZero() {
+		from := req.SubmittedFrom
+		q.SubmittedFrom = &from
+	}
+	if !req.SubmittedTo.IsZero() {
+		to := req.SubmittedTo
+		q.SubmittedTo = &to
+	}
+	return q
+}
+
+// searchRequest converts a search history query back to a paper search request.
+func searchRequest(q *searchhistory.Query) *papersearch.SearchRequest {
+	req := &papersearch.SearchRequest{
+		Query:    q.Text,
+		MatchAny: q.MatchAny,
+		Backend:  q.Backend,
+		Mode:     q.Mode,
+	}
+	for _, t := range q.Include {
+		req.Include = append(req.Include, papersearch.Term{Field: t.Field, Value: t.Value})
+	}
+	for _, t := range q.Exclude {
+		req.Exclude = append(req.Exclude, papersearch.Term{Field: t.Field, Value: t.Value})
+	}
+	if q.SubmittedFrom != nil {
+		req.SubmittedFrom = *q.SubmittedFrom
+	}
+	if q.SubmittedTo != nil {
+		req.SubmittedTo = *q.SubmittedTo
+	}
+	return req
+}
+
+// convertAlert converts an alert, with its paper if the feature filled it in.
+func (f *Facade) convertAlert(a *alerts.Alert) *Alert {
+	result := &Alert{
+		ID:            a.ID,
+		SavedSearchID: a.SavedSearchID,
+		PaperID:       a.PaperID,
+		Published:     a.Published,
+		CreatedAt:     a.CreatedAt,
+		Read:          a.ReadAt != nil,
+		ReadAt:        a.ReadAt,
+	}
+	if p := a.Paper; p != nil {
+		result.Paper = convertStoredPaper(p)
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	alertRepo "github.com/rrlian/papertok/backend/internal/repository/alert"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// searchSource defines the saved search capabilities required by this feature.
+type searchSource interface {
+	// SavedSearches returns every user's saved searches.
+	SavedSearches(ctx context.Context) ([]*Search, error)
+
+	// Run runs a saved search and returns up to limit matches. When since
+	// is not zero, only papers submitted after it need to be returned; when
+	// until is not zero, only papers submitted up to it (inclusive).
+	Run(ctx context.Context, search *Search, since, until time.Time, limit int) (*RunResult, error)
+}
+
+// alertStore defines the repository capabilities required for alerts.
+type alertStore interface {
+	// ListMarks returns the marks of every saved search checked so far.
+	ListMarks(ctx context.Context) ([]*alertRepo.Mark, error)
+
+	// Record adds alerts and saves the mark of the search that produced them.
+	Record(ctx context.Context, mark *alertRepo.Mark, alerts []*alertRepo.Alert) (int, error)
+
+	// List returns a page of a user's alerts and the total matching the filter.
+	List(ctx context.Context, filter alertRepo.Filter, offset, limit int) ([]*alertRepo.Alert, int, error)
+
+	// CountUnread returns the number of a user's unread alerts.
+	CountUnread(ctx context.Context, userID int64) (int, error)
+
+	// MarkRead marks a user's unread alerts as read (all of them if ids is nil).
+	MarkRead(ctx context.Context, userID int64, ids []int64, at time.Time) (int, error)
+
+	// DeleteSearch removes the mark and alerts of a saved search.
+	DeleteSearch(ctx context.Context, searchID int64) error
+}
+
+// paperStore defines the repository capability used to hydrate alerted papers.
+type paperStore interface {
+	// GetByID retrieves a single paper by ID.
+	GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool)
+}
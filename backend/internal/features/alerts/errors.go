@@ -0,0 +1,30 @@
+package alerts
+
+import "errors"
+
+var (
+	// ErrAlreadyRunning indicates that Start was called on a running matcher.
+	ErrAlreadyRunning = errors.New("alert matcher is already running")
+
+	// ErrNotRunning indicates that the matcher has not been started.
+	ErrNotRunning = errors.New("alert matcher is not running")
+
+	// ErrTooManyMatches indicates that MaxRuns runs of a saved search did not
+	// reach back to its mark, so the mark was kept.
+	ErrTooManyMatches = errors.New("too many new matches for one check")
+
+	// ErrInvalidIDs indicates that no alert IDs or too many were given.
+	ErrInvalidIDs = errors.New("invalid alert IDs")
+)
+
+// IsAlreadyRunning checks if the error is ErrAlreadyRunning.
+func IsAlreadyRunning(err error) bool { return errors.Is(err, ErrAlreadyRunning) }
+
+// IsNotRunning checks if the error is ErrNotRunning.
+func IsNotRunning(err error) bool { return errors.Is(err, ErrNotRunning) }
+
+// IsTooManyMatches checks if the error is ErrTooManyMatches.
+func IsTooManyMatches(err error) bool { return errors.Is(err, ErrTooManyMatches) }
+
+// IsInvalidIDs checks if the error is ErrInvalidIDs.
+func IsInvalidIDs(err error) bool { return errors.Is(err, ErrInvalidIDs) }
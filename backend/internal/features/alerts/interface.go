@@ -0,0 +1,165 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// Defaults applied by New, and limits on list pages.
+const (
+	DefaultInterval         = time.Hour
+	DefaultMatchLimit       = 50 // Matches fetched per run of a saved search
+	DefaultSearchesPerCheck = 20 // Saved searches run per check, so a check does not hold the arXiv scheduler for long
+	MaxRuns                 = 10 // Runs of one saved search per check, each reaching further back
+	DefaultLimit            = 20
+	MaxLimit                = 100
+)
+
+// Paper is the paper an alert is about, as stored by the paper repository.
+type Paper = paperRepo.Paper
+
+// Search is a user's saved search, checked for new papers.
+type Search struct {
+	ID     int64
+	UserID int64
+}
+
+// Match is a paper found by running a saved search.
+type Match struct {
+	PaperID   string    // Paper ID; any version suffix is ignored
+	Published time.Time // First submission of the paper
+}
+
+// RunResult is the result of one run of a saved search.
+type RunResult struct {
+	Matches []*Match
+
+	// Newest reports that Matches are the newest papers in the requested
+	// window, newest first, so older ones are reached by narrowing the
+	// window. Relevance-ranked results (the local index, also used while
+	// arXiv is unavailable) are not.
+	Newest bool
+}
+
+// Alert is a paper that newly matched one of a user's saved searches.
+type Alert struct {
+	ID            int64      `json:"id"`
+	SavedSearchID int64      `json:"savedSearchId"`
+	PaperID       string     `json:"paperId"`
+	Published     time.Time  `json:"published"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	ReadAt        *time.Time `json:"readAt,omitempty"` // Nil while unread
+	Paper         *Paper     `json:"paper,omitempty"`  // Nil if the paper is not stored
+}
+
+// ListRequest selects a page of a user's alerts.
+type ListRequest struct {
+	UserID        int64
+	SavedSearchID int64 // Only alerts of this saved search (0 for all)
+	UnreadOnly    bool
+	Offset        int
+	Limit         int // DefaultLimit if zero, at most MaxLimit
+}
+
+// AlertList is a page of a user's alerts, newest first.
+type AlertList struct {
+	Alerts []*Alert
+	Total  int // Alerts matching the request
+	Unread int // The user's unread alerts, whatever the request
+}
+
+// CheckResult summarizes one check of every saved search.
+type CheckResult struct {
+	Searches int `json:"searches"` // Saved searches run
+	Failed   int `json:"failed"`   // Saved searches that could not be run
+	Alerts   int `json:"alerts"`   // New alerts recorded
+}
+
+// Status reports the state of the matcher.
+type Status struct {
+	Running    bool        `json:"running"`
+	Checks     int         `json:"checks"`
+	LastCheck  time.Time   `json:"lastCheck"`
+	LastResult CheckResult `json:"lastResult"`
+	LastError  string      `json:"lastError,omitempty"` // Errors of the last check, empty if there were none
+	NextCheck  time.Time   `json:"nextCheck"`           // Zero while the matcher is stopped
+}
+
+// Config contains the matcher settings.
+type Config struct {
+	Interval         time.Duration    // Time between checks (DefaultInterval if zero)
+	Limit            int              // Matches fetched per run of a saved search (DefaultMatchLimit if zero, at least 2)
+	SearchesPerCheck int              // Saved searches run per check (DefaultSearchesPerCheck if zero)
+	Now              func() time.Time // Clock (time.Now if nil)
+}
+
+// Service defines the interface for saved search alerts.
+//
+// The matcher re-runs saved searches and keeps a high-water mark per
+// search: the latest submission date it has seen. Each check runs at most
+// SearchesPerCheck searches, continuing where the previous check stopped,
+// so every search is re-run within a few checks. Papers submitted after
+// the mark become unread alerts for the search's owner. The first check of
+// a search only sets its mark, so saving a search does not flood its owner
+// with old papers.
+type Service interface {
+	// Start begins checking in the background: once right away, then
+	// every interval.
+	// @Returns:
+	//   - error: ErrAlreadyRunning if the matcher is running
+	Start() error
+
+	// Stop cancels pending checks and waits for a running check to return.
+	// @Params:
+	//   - ctx: bounds how long to wait for a running check
+	// @Returns:
+	//   - error: ErrNotRunning if the matcher is stopped, or ctx.Err() if waiting timed out
+	Stop(ctx context.Context) error
+
+	// Check runs the next SearchesPerCheck saved searches, in ID order and
+	// wrapping around, and records new alerts. Marks and alerts of saved
+	// searches that no longer exist are removed.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	// @Returns:
+	//   - *CheckResult: what the check did (also when some searches failed)
+	//   - error: if the saved searches cannot be listed, or the errors of the
+	//     searches that failed
+	Check(ctx context.Context) (*CheckResult, error)
+
+	// Status reports the state of the matcher.
+	// @Returns:
+	//   - Status: the current state
+	Status() Status
+
+	// List returns a page of a user's alerts, newest first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - req: the user, filters and page
+	// @Returns:
+	//   - *AlertList: the alerts with their stored papers, and the counts
+	//   - error: if the alerts cannot be read
+	List(ctx context.Context, req *ListRequest) (*AlertList, error)
+
+	// MarkRead marks some of a user's alerts as read. IDs of other users'
+	// or already read alerts are ignored.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	//   - ids: alert IDs, 1 to MaxLimit of them
+	// @Returns:
+	//   - int: the number of alerts marked
+	//   - error: ErrInvalidIDs if there are no IDs or too many
+	MarkRead(ctx context.Context, userID int64, ids []int64) (int, error)
+
+	// MarkAllRead marks all of a user's alerts as read.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	// @Returns:
+	//   - int: the number of alerts marked
+	//   - error: if the alerts cannot be updated
+	MarkAllRead(ctx context.Context, userID int64) (int, error)
+}
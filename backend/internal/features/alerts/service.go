@@ -0,0 +1,402 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	alertRepo "github.com/rrlian/papertok/backend/internal/repository/alert"
+)
+
+// Impl implements the alerts Service interface.
+type Impl struct {
+	searches searchSource
+	store    alertStore
+	papers   paperStore
+	cfg      Config
+	now      func() time.Time
+
+	checkMu sync.Mutex // Serializes checks
+	lastID  int64      // Last saved search run; the next check continues after it
+
+	mu     sync.Mutex
+	status Status
+	cancel context.CancelFunc // nil while stopped
+	wg     sync.WaitGroup
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new alerts service instance.
+func New(searches searchSource, store alertStore, papers paperStore, cfg Config) *Impl {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Limit <= 0 {
+		cfg.Limit = DefaultMatchLimit
+	}
+	if cfg.Limit < 2 {
+		cfg.Limit = 2 // Consecutive runs share their boundary paper
+	}
+	if cfg.SearchesPerCheck <= 0 {
+		cfg.SearchesPerCheck = DefaultSearchesPerCheck
+	}
+	now := cfg.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Impl{
+		searches: searches,
+		store:    store,
+		papers:   papers,
+		cfg:      cfg,
+		now:      now,
+	}
+}
+
+// Start begins checking in the background.
+func (s *Impl) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return ErrAlreadyRunning
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.status.Running = true
+	s.wg.Add(1)
+	go s.run(ctx)
+	return nil
+}
+
+// Stop cancels pending checks and waits for a running check to return.
+func (s *Impl) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.status.Running = false
+	s.mu.Unlock()
+	if cancel == nil {
+		return ErrNotRunning
+	}
+
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Check runs the next batch of saved searches and records new alerts.
+func (s *Impl) Check(ctx context.Context) (*CheckResult, error) {
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
+
+	result, err := s.check(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil && ctx.Err() != nil {
+		// Interrupted by Stop; not a failed check.
+		return result, err
+	}
+	s.status.Checks++
+	s.status.LastCheck = s.now()
+	s.status.LastResult = CheckResult{}
+	if result != nil {
+		s.status.LastResult = *result
+	}
+	s.status.LastError = ""
+	if err != nil {
+		s.status.LastError = err.Error()
+	}
+	return result, err
+}
+
+// Status reports the state of the matcher.
+func (s *Impl) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// List returns a page of a user's alerts, newest first.
+func (s *Impl) List(ctx context.Context, req *ListRequest) (*AlertList, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	stored, total, err := s.store.List(ctx, alertRepo.Filter{
+		UserID:     req.UserID,
+		SearchID:   req.SavedSearchID,
+		UnreadOnly: req.UnreadOnly,
+	}, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.store.CountUnread(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &AlertList{Alerts: make([]*Alert, len(stored)), Total: total, Unread: unread}
+	for i, a := range stored {
+		list.Alerts[i] = &Alert{
+			ID:            a.ID,
+			SavedSearchID: a.SearchID,
+			PaperID:       a.PaperID,
+			Published:     a.Published,
+			CreatedAt:     a.CreatedAt,
+			ReadAt:        a.ReadAt,
+		}
+		if p, found := s.papers.GetByID(ctx, a.PaperID); found {
+			list.Alerts[i].Paper = p
+		}
+	}
+	return list, nil
+}
+
+// MarkRead marks some of a user's alerts as read.
+func (s *Impl) MarkRead(ctx context.Context, userID int64, ids []int64) (int, error) {
+	if len(ids) == 0 || len(ids) > MaxLimit {
+		return 0, fmt.Errorf("%w: 1 to %d IDs are required", ErrInvalidIDs, MaxLimit)
+	}
+	return s.store.MarkRead(ctx, userID, ids, s.now())
+}
+
+// MarkAllRead marks all of a user's alerts as read.
+func (s *Impl) MarkAllRead(ctx context.Context, userID int64) (int, error) {
+	return s.store.MarkRead(ctx, userID, nil, s.now())
+}
+
+// run checks until ctx is cancelled: once right away, then every interval.
+func (s *Impl) run(ctx context.Context) {
+	defer s.wg.Done()
+	defer s.setNextCheck(time.Time{})
+
+	var delay time.Duration
+	for {
+		s.setNextCheck(s.now().Add(delay))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.Check(ctx)
+		delay = s.cfg.Interval
+	}
+}
+
+// setNextCheck records when the next check will run.
+func (s *Impl) setNextCheck(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.NextCheck = at
+}
+
+// check runs every saved search once. A failing search does not stop the
+// others; their errors are joined.
+func (s *Impl) check(ctx context.Context) (*CheckResult, error) {
+	searches, err := s.searches.SavedSearches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	marks, err := s.store.ListMarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make(map[int64]bool, len(searches))
+	for _, search := range searches {
+		exists[search.ID] = true
+	}
+	byID := make(map[int64]*alertRepo.Mark, len(marks))
+	for _, m := range marks {
+		if exists[m.SearchID] {
+			byID[m.SearchID] = m
+			continue
+		}
+		// The saved search was deleted: forget its mark and alerts.
+		if err := s.store.DeleteSearch(ctx, m.SearchID); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CheckResult{}
+	var errs []error
+	for _, search := range s.nextBatch(searches) {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		s.lastID = search.ID
+		result.Searches++
+		added, err := s.checkSearch(ctx, search, byID[search.ID])
+		result.Alerts += added
+		if err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("saved search %d: %w", search.ID, err))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// nextBatch picks the searches for this check: up to SearchesPerCheck of
+// them in ID order, starting after the last search run and wrapping around.
+func (s *Impl) nextBatch(searches []*Search) []*Search {
+	if len(searches) <= s.cfg.SearchesPerCheck {
+		return searches
+	}
+
+	sorted := make([]*Search, len(searches))
+	copy(sorted, searches)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	start := sort.Search(len(sorted), func(i int) bool { return sorted[i].ID > s.lastID })
+
+	batch := make([]*Search, s.cfg.SearchesPerCheck)
+	for i := range batch {
+		batch[i] = sorted[(start+i)%len(sorted)]
+	}
+	return batch
+}
+
+// checkSearch runs one saved search, records alerts for papers submitted
+// after its mark and raises the mark. Without a mark, only the mark is set.
+//
+// The mark never passes a paper that was not seen: it is only raised once
+// every match after it has been fetched, newest first. Relevance-ranked
+// results are recorded but leave the mark where it is.
+func (s *Impl) checkSearch(ctx context.Context, search *Search, mark *alertRepo.Mark) (int, error) {
+	now := s.now()
+	if mark == nil {
+		return s.setBaseline(ctx, search, now)
+	}
+
+	since := mark.HighWater
+	matches, complete, err := s.fetchMatches(ctx, search, since)
+	if err != nil && !IsTooManyMatches(err) {
+		return 0, err
+	}
+	// Papers beyond the last run are unseen: record what was fetched but
+	// keep the mark and report the shortfall.
+	fetchErr := err
+
+	highWater := since
+	var alerts []*alertRepo.Alert
+	for _, m := range matches {
+		if !m.Published.After(since) {
+			continue
+		}
+		if complete && m.Published.After(highWater) {
+			highWater = m.Published
+		}
+		ident, err := arxiv.ParseIdentifier(m.PaperID)
+		if err != nil {
+			continue
+		}
+		alerts = append(alerts, &alertRepo.Alert{
+			UserID:    search.UserID,
+			SearchID:  search.ID,
+			PaperID:   ident.Base(),
+			Published: m.Published,
+			CreatedAt: now,
+		})
+	}
+
+	added, err := s.store.Record(ctx, &alertRepo.Mark{
+		SearchID:  search.ID,
+		UserID:    search.UserID,
+		HighWater: highWater,
+		CheckedAt: now,
+	}, alerts)
+	if err != nil {
+		return added, err
+	}
+	return added, fetchErr
+}
+
+// setBaseline sets the first mark of a saved search: its newest match, or
+// now if there is none or the results are not in date order.
+func (s *Impl) setBaseline(ctx context.Context, search *Search, now time.Time) (int, error) {
+	run, err := s.searches.Run(ctx, search, time.Time{}, time.Time{}, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	highWater := now
+	if run.Newest && len(run.Matches) > 0 {
+		highWater = run.Matches[0].Published
+	}
+	return s.store.Record(ctx, &alertRepo.Mark{
+		SearchID:  search.ID,
+		UserID:    search.UserID,
+		HighWater: highWater,
+		CheckedAt: now,
+	}, nil)
+}
+
+// fetchMatches returns the matches of a saved search submitted after since.
+// Results in date order are fetched page by page, each run ending where the
+// previous one left off, until a run comes back short. complete reports
+// whether every match after since was fetched.
+func (s *Impl) fetchMatches(ctx context.Context, search *Search, since time.Time) (matches []*Match, complete bool, err error) {
+	var until time.Time
+	seen := make(map[string]bool)
+	for runs := 1; ; runs++ {
+		run, err := s.searches.Run(ctx, search, since, until, s.cfg.Limit)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, m := range run.Matches {
+			if !seen[m.PaperID] {
+				seen[m.PaperID] = true
+				matches = append(matches, m)
+			}
+		}
+		if !run.Newest {
+			return matches, false, nil
+		}
+		if len(run.Matches) < s.cfg.Limit {
+			return matches, true, nil
+		}
+
+		// Submission dates are matched to the minute, so the next run ends
+		// at the oldest paper of this one and repeats the papers it shares.
+		// A run that cannot move the window back would repeat forever.
+		oldest := run.Matches[len(run.Matches)-1].Published
+		if !oldest.After(since) {
+			return matches, true, nil
+		}
+		if runs == MaxRuns || (!until.IsZero() && !oldest.Before(until)) {
+			return matches, false, fmt.Errorf("%w: %d runs of %d matches did not reach %s",
+				ErrTooManyMatches, runs, s.cfg.Limit, since.Format(time.RFC3339))
+		}
+		until = oldest
+	}
+}
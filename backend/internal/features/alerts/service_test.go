@@ -0,0 +1,450 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	alertRepo "github.com/rrlian/papertok/backend/internal/repository/alert"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockSearches serves saved searches and their matches within the
+// requested window, newest first like arXiv or in the given order like a
+// relevance-ranked index. It records the high-water mark each run was given.
+type mockSearches struct {
+	mu       sync.Mutex
+	searches []*Search
+	matches  map[int64][]*Match
+	ranked   map[int64]bool
+	errs     map[int64]error
+	since    map[int64]time.Time
+	runs     map[int64]int
+}
+
+func newMockSearches(searches ...*Search) *mockSearches {
+	return &mockSearches{
+		searches: searches,
+		matches:  make(map[int64][]*Match),
+		ranked:   make(map[int64]bool),
+		errs:     make(map[int64]error),
+		since:    make(map[int64]time.Time),
+		runs:     make(map[int64]int),
+	}
+}
+
+func (m *mockSearches) SavedSearches(ctx context.Context) ([]*Search, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.searches, nil
+}
+
+func (m *mockSearches) Run(ctx context.Context, search *Search, since, until time.Time, limit int) (*RunResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.since[search.ID] = since
+	m.runs[search.ID]++
+	if err := m.errs[search.ID]; err != nil {
+		return nil, err
+	}
+
+	// Date bounds are inclusive, as in arXiv queries.
+	var matches []*Match
+	for _, match := range m.matches[search.ID] {
+		if match.Published.Before(since) || (!until.IsZero() && match.Published.After(until)) {
+			continue
+		}
+		matches = append(matches, match)
+	}
+	if !m.ranked[search.ID] {
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].Published.After(matches[j].Published)
+		})
+	}
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return &RunResult{Matches: matches, Newest: !m.ranked[search.ID]}, nil
+}
+
+// mockPapers holds the one matched paper that is stored.
+type mockPapers map[string]*paperRepo.Paper
+
+func (m mockPapers) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	p, ok := m[id]
+	return p, ok
+}
+
+// day returns midnight UTC of a day in January 2026.
+func day(d int) time.Time {
+	return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+// newDailyMatches returns n matches submitted on consecutive days from first.
+func newDailyMatches(first, n int) []*Match {
+	matches := make([]*Match, n)
+	for i := range matches {
+		matches[i] = &Match{PaperID: fmt.Sprintf("2601.%05d", first+i), Published: day(first + i)}
+	}
+	return matches
+}
+
+func newTestService(searches *mockSearches) *Impl {
+	return newTestServiceWithLimit(searches, 0)
+}
+
+func newTestServiceWithLimit(searches *mockSearches, limit int) *Impl {
+	papers := mockPapers{
+		"2601.00003": {ID: "2601.00003", Title: "Consistency models"},
+	}
+	return New(searches, alertRepo.NewMemoryRepository(), papers, Config{
+		Limit: limit,
+		Now:   func() time.Time { return day(20) },
+	})
+}
+
+func TestImpl_Check(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7})
+	searches.matches[1] = []*Match{
+		{PaperID: "2601.00001", Published: day(2)},
+		{PaperID: "2601.00002", Published: day(5)},
+	}
+	svc := newTestService(searches)
+	ctx := context.Background()
+
+	// Act
+	first, firstErr := svc.Check(ctx)
+	searches.matches[1] = []*Match{
+		{PaperID: "2601.00002", Published: day(5)},
+		{PaperID: "2601.00003v2", Published: day(8)},
+		{PaperID: "2601.00004", Published: day(9)},
+	}
+	second, secondErr := svc.Check(ctx)
+	third, thirdErr := svc.Check(ctx)
+
+	// Assert
+	if firstErr != nil || secondErr != nil || thirdErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v, %v", firstErr, secondErr, thirdErr)
+	}
+	if first.Alerts != 0 {
+		t.Errorf("Expected the first check to only set the mark, got %d alerts", first.Alerts)
+	}
+	if second.Alerts != 2 || third.Alerts != 0 {
+		t.Errorf("Expected 2 new alerts then none, got: %d, %d", second.Alerts, third.Alerts)
+	}
+	if got := searches.since[1]; !got.Equal(day(9)) {
+		t.Errorf("Expected the mark to be the latest submission seen, got: %v", got)
+	}
+	list, _ := svc.List(ctx, &ListRequest{UserID: 7})
+	if list.Total != 2 || list.Unread != 2 {
+		t.Fatalf("Expected 2 unread alerts, got: %+v", list)
+	}
+	byPaper := map[string]*Alert{}
+	for _, a := range list.Alerts {
+		byPaper[a.PaperID] = a
+	}
+	if a := byPaper["2601.00003"]; a == nil || a.Paper == nil || a.Paper.Title != "Consistency models" || a.SavedSearchID != 1 {
+		t.Errorf("Expected an alert on the base ID with its stored paper, got: %+v", a)
+	}
+	if a := byPaper["2601.00004"]; a == nil || a.Paper != nil {
+		t.Errorf("Expected an alert without paper for an unstored paper, got: %+v", a)
+	}
+}
+
+func TestImpl_CheckWithoutMatches(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7})
+	svc := newTestService(searches)
+	ctx := context.Background()
+
+	// Act
+	svc.Check(ctx)
+	searches.matches[1] = []*Match{
+		{PaperID: "2601.00001", Published: day(19)},
+		{PaperID: "2601.00002", Published: day(21)},
+	}
+	result, err := svc.Check(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := searches.since[1]; !got.Equal(day(20)) {
+		t.Errorf("Expected the mark to start at the first check, got: %v", got)
+	}
+	if result.Alerts != 1 {
+		t.Errorf("Expected only the paper submitted after the first check, got %d alerts", result.Alerts)
+	}
+}
+
+func TestImpl_CheckRunsSearchesInTurn(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 3, UserID: 7}, &Search{ID: 1, UserID: 7}, &Search{ID: 2, UserID: 7})
+	svc := New(searches, alertRepo.NewMemoryRepository(), mockPapers{}, Config{
+		SearchesPerCheck: 2,
+		Now:              func() time.Time { return day(20) },
+	})
+	ctx := context.Background()
+
+	// Act
+	first, _ := svc.Check(ctx)
+	svc.Check(ctx)
+
+	// Assert
+	if first.Searches != 2 || searches.runs[1] != 2 || searches.runs[2] != 1 || searches.runs[3] != 1 {
+		t.Errorf("Expected searches 1 and 2, then 3 and 1, got %d searches and runs %v", first.Searches, searches.runs)
+	}
+}
+
+func TestImpl_CheckPagesPastLimit(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7})
+	searches.matches[1] = newDailyMatches(3, 1)
+	svc := newTestServiceWithLimit(searches, 2)
+	ctx := context.Background()
+	svc.Check(ctx)
+
+	// Act
+	searches.matches[1] = append(newDailyMatches(3, 1), newDailyMatches(21, 5)...)
+	result, err := svc.Check(ctx)
+	svc.Check(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Alerts != 5 {
+		t.Errorf("Expected an alert for each of the 5 new papers, got: %d", result.Alerts)
+	}
+	if got := searches.since[1]; !got.Equal(day(25)) {
+		t.Errorf("Expected the mark to be the latest submission seen, got: %v", got)
+	}
+}
+
+func TestImpl_CheckTooManyMatches(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7})
+	svc := newTestServiceWithLimit(searches, 2)
+	ctx := context.Background()
+	svc.Check(ctx)
+
+	// Act
+	searches.matches[1] = newDailyMatches(21, MaxRuns+2)
+	result, err := svc.Check(ctx)
+	svc.Check(ctx)
+
+	// Assert
+	if !IsTooManyMatches(err) {
+		t.Fatalf("Expected ErrTooManyMatches, got: %v", err)
+	}
+	// Each run repeats the oldest paper of the one before.
+	if result.Alerts != MaxRuns+1 || result.Failed != 1 {
+		t.Errorf("Expected the fetched papers recorded and the search failed, got: %+v", result)
+	}
+	if got := searches.since[1]; !got.Equal(day(20)) {
+		t.Errorf("Expected the mark kept below the unseen papers, got: %v", got)
+	}
+}
+
+func TestImpl_CheckRankedResultsKeepMark(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7})
+	searches.ranked[1] = true
+	searches.matches[1] = newDailyMatches(3, 1)
+	svc := newTestService(searches)
+	ctx := context.Background()
+	svc.Check(ctx)
+
+	// Act
+	searches.matches[1] = newDailyMatches(21, 3)
+	result, err := svc.Check(ctx)
+	svc.Check(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Alerts != 3 {
+		t.Errorf("Expected the new papers recorded, got %d alerts", result.Alerts)
+	}
+	if got := searches.since[1]; !got.Equal(day(20)) {
+		t.Errorf("Expected relevance-ranked results to leave the mark, got: %v", got)
+	}
+}
+
+func TestImpl_CheckFailures(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7}, &Search{ID: 2, UserID: 8})
+	searches.errs[1] = errors.New("arXiv unavailable")
+	svc := newTestService(searches)
+	ctx := context.Background()
+
+	// Act
+	result, err := svc.Check(ctx)
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the failed search's error")
+	}
+	if result.Searches != 2 || result.Failed != 1 {
+		t.Errorf("Expected the other search to run, got: %+v", result)
+	}
+	status := svc.Status()
+	if status.Checks != 1 || status.LastResult.Failed != 1 || status.LastError == "" {
+		t.Errorf("Expected the failure in the status, got: %+v", status)
+	}
+	if _, found := searches.since[2]; !found {
+		t.Errorf("Expected saved search 2 to run")
+	}
+}
+
+func TestImpl_CheckForgetsDeletedSearches(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7})
+	svc := newTestService(searches)
+	ctx := context.Background()
+	svc.Check(ctx)
+	searches.matches[1] = []*Match{{PaperID: "2601.00001", Published: day(21)}}
+	svc.Check(ctx)
+
+	// Act
+	searches.searches = nil
+	_, err := svc.Check(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if list, _ := svc.List(ctx, &ListRequest{UserID: 7}); list.Total != 0 {
+		t.Errorf("Expected the deleted search's alerts removed, got: %+v", list.Alerts)
+	}
+	if marks, _ := svc.store.ListMarks(ctx); len(marks) != 0 {
+		t.Errorf("Expected the deleted search's mark removed, got: %+v", marks)
+	}
+}
+
+func TestImpl_MarkRead(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7}, &Search{ID: 2, UserID: 8})
+	svc := newTestService(searches)
+	ctx := context.Background()
+	svc.Check(ctx)
+	searches.matches[1] = []*Match{
+		{PaperID: "2601.00001", Published: day(21)},
+		{PaperID: "2601.00002", Published: day(22)},
+		{PaperID: "2601.00003", Published: day(23)},
+	}
+	searches.matches[2] = []*Match{{PaperID: "2601.00001", Published: day(21)}}
+	svc.Check(ctx)
+	mine, _ := svc.List(ctx, &ListRequest{UserID: 7})
+	theirs, _ := svc.List(ctx, &ListRequest{UserID: 8})
+
+	// Act
+	marked, markErr := svc.MarkRead(ctx, 7, []int64{mine.Alerts[0].ID, theirs.Alerts[0].ID})
+	unread, _ := svc.List(ctx, &ListRequest{UserID: 7, UnreadOnly: true})
+	all, allErr := svc.MarkAllRead(ctx, 7)
+
+	// Assert
+	if markErr != nil || allErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v", markErr, allErr)
+	}
+	if marked != 1 {
+		t.Errorf("Expected another user's alert to be ignored, got %d marked", marked)
+	}
+	if unread.Total != 2 || unread.Unread != 2 {
+		t.Errorf("Expected 2 unread alerts left, got: %+v", unread)
+	}
+	if all != 2 {
+		t.Errorf("Expected the 2 remaining alerts marked, got: %d", all)
+	}
+	list, _ := svc.List(ctx, &ListRequest{UserID: 7})
+	if list.Unread != 0 || list.Alerts[0].ReadAt == nil || !list.Alerts[0].ReadAt.Equal(day(20)) {
+		t.Errorf("Expected all alerts read, got: %+v", list.Alerts[0])
+	}
+	if other, _ := svc.List(ctx, &ListRequest{UserID: 8}); other.Unread != 1 {
+		t.Errorf("Expected the other user's alert still unread, got: %d", other.Unread)
+	}
+}
+
+func TestImpl_StartStop(t *testing.T) {
+	// Arrange
+	searches := newMockSearches(&Search{ID: 1, UserID: 7})
+	svc := newTestService(searches)
+
+	// Act
+	startErr := svc.Start()
+	againErr := svc.Start()
+	deadline := time.Now().Add(2 * time.Second)
+	for svc.Status().Checks == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	running := svc.Status()
+	stopErr := svc.Stop(context.Background())
+
+	// Assert
+	if startErr != nil || stopErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v", startErr, stopErr)
+	}
+	if !IsAlreadyRunning(againErr) {
+		t.Errorf("Expected ErrAlreadyRunning, got: %v", againErr)
+	}
+	if !running.Running || running.Checks != 1 {
+		t.Errorf("Expected a check right after starting, got: %+v", running)
+	}
+	if status := svc.Status(); status.Running || !status.NextCheck.IsZero() {
+		t.Errorf("Expected a stopped matcher, got: %+v", status)
+	}
+}
+
+func TestImpl_Errors(t *testing.T) {
+	svc := newTestService(newMockSearches())
+	ctx := context.Background()
+	tooMany := make([]int64, MaxLimit+1)
+
+	tests := []struct {
+		name    string
+		call    func() error
+		checkFn func(error) bool
+	}{
+		{
+			name: "no IDs",
+			call: func() error {
+				_, err := svc.MarkRead(ctx, 1, nil)
+				return err
+			},
+			checkFn: IsInvalidIDs,
+		},
+		{
+			name: "too many IDs",
+			call: func() error {
+				_, err := svc.MarkRead(ctx, 1, tooMany)
+				return err
+			},
+			checkFn: IsInvalidIDs,
+		},
+		{
+			name: "stop before start",
+			call: func() error {
+				return svc.Stop(ctx)
+			},
+			checkFn: IsNotRunning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.call()
+
+			// Assert
+			if !tt.checkFn(err) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
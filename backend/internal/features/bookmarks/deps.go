@@ -0,0 +1,29 @@
+package bookmarks
+
+import (
+	"context"
+
+	bookmarkRepo "github.com/rrlian/papertok/backend/internal/repository/bookmark"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// bookmarkStore defines the repository capabilities required for bookmarks.
+type bookmarkStore interface {
+	// Add bookmarks a paper, reporting whether it was newly added.
+	Add(ctx context.Context, b *bookmarkRepo.Bookmark) (bool, error)
+
+	// Remove deletes a bookmark.
+	Remove(ctx context.Context, userID int64, paperID string) error
+
+	// List returns a page of a user's bookmarks and their total number.
+	List(ctx context.Context, q *bookmarkRepo.ListQuery) ([]*bookmarkRepo.Bookmark, int, error)
+
+	// Find returns the user's bookmarks among the given papers.
+	Find(ctx context.Context, userID int64, paperIDs []string) (map[string]*bookmarkRepo.Bookmark, error)
+}
+
+// paperStore defines the repository capability used to hydrate bookmarked papers.
+type paperStore interface {
+	// GetByID retrieves a single paper by ID.
+	GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool)
+}
@@ -0,0 +1,35 @@
+package bookmarks
+
+import "errors"
+
+var (
+	// ErrInvalidID indicates that a paper ID is malformed.
+	ErrInvalidID = errors.New("invalid paper ID")
+
+	// ErrPaperNotFound indicates that the paper to bookmark is not stored.
+	ErrPaperNotFound = errors.New("paper not found")
+
+	// ErrNotBookmarked indicates that the paper is not bookmarked by the user.
+	ErrNotBookmarked = errors.New("paper is not bookmarked")
+
+	// ErrInvalidSort indicates that the sort order is unknown.
+	ErrInvalidSort = errors.New("invalid bookmark sort order")
+
+	// ErrTooManyIDs indicates that a status lookup has more than MaxStatusIDs IDs.
+	ErrTooManyIDs = errors.New("too many paper IDs")
+)
+
+// IsInvalidID checks if the error is ErrInvalidID.
+func IsInvalidID(err error) bool { return errors.Is(err, ErrInvalidID) }
+
+// IsPaperNotFound checks if the error is ErrPaperNotFound.
+func IsPaperNotFound(err error) bool { return errors.Is(err, ErrPaperNotFound) }
+
+// IsNotBookmarked checks if the error is ErrNotBookmarked.
+func IsNotBookmarked(err error) bool { return errors.Is(err, ErrNotBookmarked) }
+
+// IsInvalidSort checks if the error is ErrInvalidSort.
+func IsInvalidSort(err error) bool { return errors.Is(err, ErrInvalidSort) }
+
+// IsTooManyIDs checks if the error is ErrTooManyIDs.
+func IsTooManyIDs(err error) bool { return errors.Is(err, ErrTooManyIDs) }
@@ -0,0 +1,87 @@
+package bookmarks
+
+import (
+	"context"
+	"time"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// Limits on list pages and status lookups.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+	MaxStatusIDs = 100
+)
+
+// Sort orders for listing bookmarks.
+const (
+	SortNewest = "newest" // Most recently bookmarked first (default)
+	SortOldest = "oldest"
+)
+
+// Paper is a bookmarked paper, as stored by the paper repository.
+type Paper = paperRepo.Paper
+
+// Bookmark is a paper saved by a user.
+type Bookmark struct {
+	PaperID   string    `json:"paperId"`
+	CreatedAt time.Time `json:"createdAt"`
+	Paper     *Paper    `json:"paper,omitempty"` // Nil if the paper is no longer stored
+}
+
+// ListRequest selects a page of a user's bookmarks.
+type ListRequest struct {
+	UserID int64
+	Offset int
+	Limit  int    // DefaultLimit if zero, at most MaxLimit
+	Sort   string // SortNewest (default) or SortOldest
+}
+
+// ListResult is a page of bookmarks.
+type ListResult struct {
+	Bookmarks []*Bookmark
+	Total     int // The user's total number of bookmarks
+}
+
+// Service defines the interface for user bookmarks.
+type Service interface {
+	// Add bookmarks a stored paper for a user. Adding a bookmark twice is not an error.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - paperID: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - *Bookmark: the bookmark with its paper
+	//   - bool: true if the bookmark was created, false if it already existed
+	//   - error: ErrInvalidID if the ID is malformed, ErrPaperNotFound if the paper is not stored
+	Add(ctx context.Context, userID int64, paperID string) (*Bookmark, bool, error)
+
+	// Remove deletes a user's bookmark.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - paperID: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - error: ErrInvalidID if the ID is malformed, ErrNotBookmarked if the paper is not bookmarked
+	Remove(ctx context.Context, userID int64, paperID string) error
+
+	// List returns a page of a user's bookmarks with their papers.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - req: user, paging and sort order
+	// @Returns:
+	//   - *ListResult: bookmarks and the user's total
+	//   - error: ErrInvalidSort if the sort order is unknown
+	List(ctx context.Context, req *ListRequest) (*ListResult, error)
+
+	// Status reports which of the given papers a user has bookmarked.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - paperIDs: paper IDs, at most MaxStatusIDs
+	// @Returns:
+	//   - map[string]bool: bookmark status keyed by the IDs as given
+	//   - error: ErrInvalidID if an ID is malformed, ErrTooManyIDs if there are too many
+	Status(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error)
+}
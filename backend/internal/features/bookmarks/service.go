@@ -0,0 +1,129 @@
+package bookmarks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	bookmarkRepo "github.com/rrlian/papertok/backend/internal/repository/bookmark"
+)
+
+// Impl implements the bookmarks Service interface.
+type Impl struct {
+	bookmarks bookmarkStore
+	papers    paperStore
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new bookmarks service instance.
+func New(bookmarks bookmarkStore, papers paperStore) *Impl {
+	return &Impl{
+		bookmarks: bookmarks,
+		papers:    papers,
+	}
+}
+
+// Add bookmarks a stored paper for a user.
+func (s *Impl) Add(ctx context.Context, userID int64, paperID string) (*Bookmark, bool, error) {
+	id, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	paper, ok := s.papers.GetByID(ctx, id)
+	if !ok {
+		return nil, false, fmt.Errorf("%w: %s", ErrPaperNotFound, id)
+	}
+
+	b := &bookmarkRepo.Bookmark{UserID: userID, PaperID: id}
+	created, err := s.bookmarks.Add(ctx, b)
+	if err != nil {
+		return nil, false, err
+	}
+	return &Bookmark{PaperID: id, CreatedAt: b.CreatedAt, Paper: paper}, created, nil
+}
+
+// Remove deletes a user's bookmark.
+func (s *Impl) Remove(ctx context.Context, userID int64, paperID string) error {
+	id, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	if err := s.bookmarks.Remove(ctx, userID, id); err != nil {
+		if errors.Is(err, bookmarkRepo.ErrBookmarkNotFound) {
+			return fmt.Errorf("%w: %s", ErrNotBookmarked, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// List returns a page of a user's bookmarks with their papers.
+func (s *Impl) List(ctx context.Context, req *ListRequest) (*ListResult, error) {
+	var oldestFirst bool
+	switch req.Sort {
+	case "", SortNewest:
+	case SortOldest:
+		oldestFirst = true
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSort, req.Sort)
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	stored, total, err := s.bookmarks.List(ctx, &bookmarkRepo.ListQuery{
+		UserID:      req.UserID,
+		Offset:      offset,
+		Limit:       limit,
+		OldestFirst: oldestFirst,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{Bookmarks: make([]*Bookmark, len(stored)), Total: total}
+	for i, b := range stored {
+		result.Bookmarks[i] = &Bookmark{PaperID: b.PaperID, CreatedAt: b.CreatedAt}
+		// Papers cached without a database may have expired since they were bookmarked.
+		if p, ok := s.papers.GetByID(ctx, b.PaperID); ok {
+			result.Bookmarks[i].Paper = p
+		}
+	}
+	return result, nil
+}
+
+// Status reports which of the given papers a user has bookmarked.
+func (s *Impl) Status(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error) {
+	if len(paperIDs) > MaxStatusIDs {
+		return nil, fmt.Errorf("%w: at most %d, got %d", ErrTooManyIDs, MaxStatusIDs, len(paperIDs))
+	}
+	ids := make([]string, len(paperIDs))
+	for i, paperID := range paperIDs {
+		id, err := arxiv.BaseID(paperID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+		}
+		ids[i] = id
+	}
+
+	found, err := s.bookmarks.Find(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]bool, len(paperIDs))
+	for i, paperID := range paperIDs {
+		_, status[paperID] = found[ids[i]]
+	}
+	return status, nil
+}
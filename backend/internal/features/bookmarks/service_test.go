@@ -0,0 +1,221 @@
+package bookmarks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bookmarkRepo "github.com/rrlian/papertok/backend/internal/repository/bookmark"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockPapers is the paper cache; deleting an entry expires that paper.
+type mockPapers map[string]*paperRepo.Paper
+
+func (m mockPapers) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	p, ok := m[id]
+	return p, ok
+}
+
+func newTestService() (*Impl, *bookmarkRepo.MemoryRepository, mockPapers) {
+	store := bookmarkRepo.NewMemoryRepository()
+	papers := mockPapers{
+		"2401.00001":     {ID: "2401.00001", Title: "First"},
+		"2401.00002":     {ID: "2401.00002"},
+		"2401.00003":     {ID: "2401.00003"},
+		"hep-th/9901001": {ID: "hep-th/9901001"},
+	}
+	return New(store, papers), store, papers
+}
+
+func TestImpl_Add(t *testing.T) {
+	// Arrange
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+
+	// Act
+	first, created, err := svc.Add(ctx, 1, "2401.00001v2")
+	again, createdAgain, errAgain := svc.Add(ctx, 1, "2401.00001")
+
+	// Assert
+	if err != nil || errAgain != nil {
+		t.Fatalf("Expected no error, got: %v, %v", err, errAgain)
+	}
+	if !created || createdAgain {
+		t.Errorf("Expected first add to create and second to be a no-op, got: %v, %v", created, createdAgain)
+	}
+	if first.PaperID != "2401.00001" || first.Paper == nil || first.Paper.Title != "First" {
+		t.Errorf("Expected bookmark on the base ID with its paper, got: %+v", first)
+	}
+	if !again.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("Expected original bookmark time %v, got: %v", first.CreatedAt, again.CreatedAt)
+	}
+}
+
+func TestImpl_Remove(t *testing.T) {
+	// Arrange
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	svc.Add(ctx, 1, "2401.00001")
+
+	// Act
+	err := svc.Remove(ctx, 1, "2401.00001v1")
+	errAgain := svc.Remove(ctx, 1, "2401.00001")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !IsNotBookmarked(errAgain) {
+		t.Errorf("Expected ErrNotBookmarked, got: %v", errAgain)
+	}
+	status, _ := svc.Status(ctx, 1, []string{"2401.00001"})
+	if status["2401.00001"] {
+		t.Error("Expected bookmark to be removed")
+	}
+}
+
+func TestImpl_List(t *testing.T) {
+	// Arrange
+	svc, store, papers := newTestService()
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"2401.00001", "2401.00002", "2401.00003"} {
+		store.Add(ctx, &bookmarkRepo.Bookmark{UserID: 1, PaperID: id, CreatedAt: base.Add(time.Duration(i) * time.Hour)})
+	}
+	store.Add(ctx, &bookmarkRepo.Bookmark{UserID: 2, PaperID: "2401.00001", CreatedAt: base})
+	delete(papers, "2401.00002") // Expired from the paper cache
+
+	tests := []struct {
+		name string
+		req  *ListRequest
+		want []string
+	}{
+		{name: "newest first by default", req: &ListRequest{UserID: 1}, want: []string{"2401.00003", "2401.00002", "2401.00001"}},
+		{name: "oldest first", req: &ListRequest{UserID: 1, Sort: SortOldest}, want: []string{"2401.00001", "2401.00002", "2401.00003"}},
+		{name: "paging", req: &ListRequest{UserID: 1, Offset: 1, Limit: 1}, want: []string{"2401.00002"}},
+		{name: "past the end", req: &ListRequest{UserID: 1, Offset: 5}, want: []string{}},
+		{name: "other user", req: &ListRequest{UserID: 2}, want: []string{"2401.00001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := svc.List(ctx, tt.req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(result.Bookmarks) != len(tt.want) {
+				t.Fatalf("Expected %d bookmarks, got: %d", len(tt.want), len(result.Bookmarks))
+			}
+			for i, b := range result.Bookmarks {
+				if b.PaperID != tt.want[i] {
+					t.Errorf("Expected bookmark %d to be %s, got: %s", i, tt.want[i], b.PaperID)
+				}
+				if (b.Paper == nil) != (b.PaperID == "2401.00002") {
+					t.Errorf("Expected only the expired paper to be missing, got: %+v", b)
+				}
+			}
+		})
+	}
+
+	result, _ := svc.List(ctx, &ListRequest{UserID: 1, Limit: 1})
+	if result.Total != 3 {
+		t.Errorf("Expected total 3, got: %d", result.Total)
+	}
+}
+
+func TestImpl_Status(t *testing.T) {
+	// Arrange
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	svc.Add(ctx, 1, "2401.00001")
+	svc.Add(ctx, 1, "hep-th/9901001")
+	svc.Add(ctx, 2, "2401.00002")
+
+	// Act
+	status, err := svc.Status(ctx, 1, []string{"2401.00001v3", "2401.00002", "hep-th/9901001"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !status["2401.00001v3"] || status["2401.00002"] || !status["hep-th/9901001"] {
+		t.Errorf("Expected status keyed by the requested IDs, got: %v", status)
+	}
+}
+
+func TestImpl_Errors(t *testing.T) {
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	tooMany := make([]string, MaxStatusIDs+1)
+	for i := range tooMany {
+		tooMany[i] = "2401.00001"
+	}
+
+	tests := []struct {
+		name    string
+		run     func() error
+		checkFn func(error) bool
+	}{
+		{
+			name: "add malformed ID",
+			run: func() error {
+				_, _, err := svc.Add(ctx, 1, "not an id")
+				return err
+			},
+			checkFn: IsInvalidID,
+		},
+		{
+			name: "add unstored paper",
+			run: func() error {
+				_, _, err := svc.Add(ctx, 1, "2401.09999")
+				return err
+			},
+			checkFn: IsPaperNotFound,
+		},
+		{
+			name:    "remove malformed ID",
+			run:     func() error { return svc.Remove(ctx, 1, "nope") },
+			checkFn: IsInvalidID,
+		},
+		{
+			name: "unknown sort",
+			run: func() error {
+				_, err := svc.List(ctx, &ListRequest{UserID: 1, Sort: "title"})
+				return err
+			},
+			checkFn: IsInvalidSort,
+		},
+		{
+			name: "status malformed ID",
+			run: func() error {
+				_, err := svc.Status(ctx, 1, []string{"2401.00001", "bad"})
+				return err
+			},
+			checkFn: IsInvalidID,
+		},
+		{
+			name: "status too many IDs",
+			run: func() error {
+				_, err := svc.Status(ctx, 1, tooMany)
+				return err
+			},
+			checkFn: IsTooManyIDs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.run()
+
+			// Assert
+			if !tt.checkFn(err) {
+				t.Errorf("Expected matching error, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package collections
+
+import (
+	"context"
+
+	collectionRepo "github.com/rrlian/papertok/backend/internal/repository/collection"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// collectionStore defines the repository capabilities required for collections.
+type collectionStore interface {
+	// Create stores a new collection.
+	Create(ctx context.Context, c *collectionRepo.Collection) error
+
+	// Get retrieves a collection by ID.
+	Get(ctx context.Context, id int64) (*collectionRepo.Collection, error)
+
+	// GetBySlug retrieves a collection by its share slug.
+	GetBySlug(ctx context.Context, slug string) (*collectionRepo.Collection, error)
+
+	// ListByUser returns a user's collections.
+	ListByUser(ctx context.Context, userID int64) ([]*collectionRepo.Collection, error)
+
+	// ListPublic returns a page of public collections and their total number.
+	ListPublic(ctx context.Context, offset, limit int) ([]*collectionRepo.Collection, int, error)
+
+	// Update saves a collection's name, description and visibility.
+	Update(ctx context.Context, c *collectionRepo.Collection) error
+
+	// Delete removes a collection and its items.
+	Delete(ctx context.Context, id int64) error
+
+	// Items returns a collection's items in order.
+	Items(ctx context.Context, collectionID int64) ([]*collectionRepo.Item, error)
+
+	// AddItem appends a paper to a collection.
+	AddItem(ctx context.Context, item *collectionRepo.Item) (bool, error)
+
+	// UpdateItem saves an item's note.
+	UpdateItem(ctx context.Context, item *collectionRepo.Item) error
+
+	// RemoveItem removes a paper from a collection.
+	RemoveItem(ctx context.Context, collectionID int64, paperID string) error
+
+	// Reorder renumbers a collection's items in the given order.
+	Reorder(ctx context.Context, collectionID int64, paperIDs []string) error
+}
+
+// paperStore defines the repository capability used to hydrate collected papers.
+type paperStore interface {
+	// GetByID retrieves a single paper by ID.
+	GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool)
+}
@@ -0,0 +1,68 @@
+package collections
+
+import "errors"
+
+var (
+	// ErrCollectionNotFound indicates that the collection does not exist or
+	// is not visible to the caller.
+	ErrCollectionNotFound = errors.New("collection not found")
+
+	// ErrInvalidCollection indicates that a name is empty or a name or
+	// description is too long.
+	ErrInvalidCollection = errors.New("invalid collection")
+
+	// ErrInvalidVisibility indicates that the visibility is unknown.
+	ErrInvalidVisibility = errors.New("invalid collection visibility")
+
+	// ErrTooManyCollections indicates that the user already has MaxCollections collections.
+	ErrTooManyCollections = errors.New("too many collections")
+
+	// ErrInvalidID indicates that a paper ID is malformed.
+	ErrInvalidID = errors.New("invalid paper ID")
+
+	// ErrInvalidNote indicates that a note is longer than MaxNoteLength.
+	ErrInvalidNote = errors.New("invalid collection note")
+
+	// ErrPaperNotFound indicates that the paper to add is not stored.
+	ErrPaperNotFound = errors.New("paper not found")
+
+	// ErrNotInCollection indicates that the paper is not in the collection.
+	ErrNotInCollection = errors.New("paper is not in collection")
+
+	// ErrCollectionFull indicates that the collection already has MaxItems papers.
+	ErrCollectionFull = errors.New("collection is full")
+
+	// ErrInvalidOrder indicates that a new order does not list every paper in
+	// the collection exactly once.
+	ErrInvalidOrder = errors.New("invalid collection order")
+)
+
+// IsCollectionNotFound checks if the error is ErrCollectionNotFound.
+func IsCollectionNotFound(err error) bool { return errors.Is(err, ErrCollectionNotFound) }
+
+// IsInvalidCollection checks if the error is ErrInvalidCollection.
+func IsInvalidCollection(err error) bool { return errors.Is(err, ErrInvalidCollection) }
+
+// IsInvalidVisibility checks if the error is ErrInvalidVisibility.
+func IsInvalidVisibility(err error) bool { return errors.Is(err, ErrInvalidVisibility) }
+
+// IsTooManyCollections checks if the error is ErrTooManyCollections.
+func IsTooManyCollections(err error) bool { return errors.Is(err, ErrTooManyCollections) }
+
+// IsInvalidID checks if the error is ErrInvalidID.
+func IsInvalidID(err error) bool { return errors.Is(err, ErrInvalidID) }
+
+// IsInvalidNote checks if the error is ErrInvalidNote.
+func IsInvalidNote(err error) bool { return errors.Is(err, ErrInvalidNote) }
+
+// IsPaperNotFound checks if the error is ErrPaperNotFound.
+func IsPaperNotFound(err error) bool { return errors.Is(err, ErrPaperNotFound) }
+
+// IsNotInCollection checks if the error is ErrNotInCollection.
+func IsNotInCollection(err error) bool { return errors.Is(err, ErrNotInCollection) }
+
+// IsCollectionFull checks if the error is ErrCollectionFull.
+func IsCollectionFull(err error) bool { return errors.Is(err, ErrCollectionFull) }
+
+// IsInvalidOrder checks if the error is ErrInvalidOrder.
+func IsInvalidOrder(err error) bool { return errors.Is(err, ErrInvalidOrder) }
@@ -0,0 +1,194 @@
+package collections
+
+import (
+	"context"
+	"time"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// Visibility levels of a collection.
+const (
+	VisibilityPrivate  = "private"  // Only the owner can see it (default)
+	VisibilityUnlisted = "unlisted" // Anyone with the share link can see it
+	VisibilityPublic   = "public"   // Also listed among public collections
+)
+
+// Limits on collections and their contents.
+const (
+	MaxNameLength        = 100  // Characters
+	MaxDescriptionLength = 1000 // Characters
+	MaxNoteLength        = 2000 // Characters
+	MaxCollections       = 100  // Per user
+	MaxItems             = 500  // Per collection
+	DefaultLimit         = 20   // Public collections per page
+	MaxLimit             = 100
+)
+
+// Paper is a paper in a collection, as stored by the paper repository.
+type Paper = paperRepo.Paper
+
+// Item is a paper in a collection with the owner's note.
+type Item struct {
+	PaperID  string    `json:"paperId"`
+	Position int       `json:"position"` // 1-based
+	Note     string    `json:"note"`
+	AddedAt  time.Time `json:"addedAt"`
+	Paper    *Paper    `json:"paper,omitempty"` // Nil if the paper is no longer stored
+}
+
+// Collection is a named, ordered list of papers.
+type Collection struct {
+	ID          int64     `json:"id"`
+	OwnerID     int64     `json:"-"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Visibility  string    `json:"visibility"`
+	Slug        string    `json:"slug"` // Unguessable share slug
+	ItemCount   int       `json:"itemCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Items       []*Item   `json:"items,omitempty"` // Only filled in when a single collection is read
+}
+
+// CreateRequest describes a new collection.
+type CreateRequest struct {
+	Name        string
+	Description string
+	Visibility  string // VisibilityPrivate if empty
+}
+
+// UpdateRequest changes a collection. Nil fields are left unchanged.
+type UpdateRequest struct {
+	Name        *string
+	Description *string
+	Visibility  *string
+}
+
+// PublicList is a page of public collections.
+type PublicList struct {
+	Collections []*Collection
+	Total       int
+}
+
+// Service defines the interface for paper collections.
+// Collections are only visible to their owner, except through GetShared and
+// ListPublic; other users' collections are reported as not found.
+type Service interface {
+	// Create creates a collection for a user with a new share slug.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	//   - req: name, description and visibility
+	// @Returns:
+	//   - *Collection: the new collection
+	//   - error: ErrInvalidCollection if the name or description is invalid,
+	//     ErrInvalidVisibility if the visibility is unknown, ErrTooManyCollections at MaxCollections
+	Create(ctx context.Context, userID int64, req *CreateRequest) (*Collection, error)
+
+	// Update renames a collection or changes its description or visibility.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	//   - id: collection ID
+	//   - req: fields to change
+	// @Returns:
+	//   - *Collection: the updated collection
+	//   - error: ErrCollectionNotFound, ErrInvalidCollection or ErrInvalidVisibility
+	Update(ctx context.Context, userID, id int64, req *UpdateRequest) (*Collection, error)
+
+	// Delete removes a collection and its items.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	//   - id: collection ID
+	// @Returns:
+	//   - error: ErrCollectionNotFound if the user has no such collection
+	Delete(ctx context.Context, userID, id int64) error
+
+	// List returns a user's collections without their items, most recently updated first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	// @Returns:
+	//   - []*Collection: the collections
+	//   - error: if the collections cannot be read
+	List(ctx context.Context, userID int64) ([]*Collection, error)
+
+	// Get returns one of a user's collections with its items in order.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	//   - id: collection ID
+	// @Returns:
+	//   - *Collection: the collection with items
+	//   - error: ErrCollectionNotFound if the user has no such collection
+	Get(ctx context.Context, userID, id int64) (*Collection, error)
+
+	// GetShared returns an unlisted or public collection by its share slug, with its items.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - slug: share slug
+	// @Returns:
+	//   - *Collection: the collection with items
+	//   - error: ErrCollectionNotFound if there is none or it is private
+	GetShared(ctx context.Context, slug string) (*Collection, error)
+
+	// ListPublic returns a page of public collections without their items.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - offset: collections to skip
+	//   - limit: page size (DefaultLimit if zero, at most MaxLimit)
+	// @Returns:
+	//   - *PublicList: collections, most recently updated first, and their total
+	//   - error: if the collections cannot be read
+	ListPublic(ctx context.Context, offset, limit int) (*PublicList, error)
+
+	// AddItem appends a stored paper to a collection. Adding it twice is not an error.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	//   - id: collection ID
+	//   - paperID: paper ID; any version suffix is ignored
+	//   - note: optional note, at most MaxNoteLength characters
+	// @Returns:
+	//   - *Item: the item with its paper
+	//   - bool: true if the paper was added, false if it was already in the collection
+	//   - error: ErrCollectionNotFound, ErrInvalidID, ErrInvalidNote, ErrPaperNotFound if the paper
+	//     is not stored, ErrCollectionFull at MaxItems
+	AddItem(ctx context.Context, userID, id int64, paperID, note string) (*Item, bool, error)
+
+	// UpdateItem replaces the note on a paper in a collection.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	//   - id: collection ID
+	//   - paperID: paper ID; any version suffix is ignored
+	//   - note: the new note, empty to clear it
+	// @Returns:
+	//   - *Item: the updated item
+	//   - error: ErrCollectionNotFound, ErrInvalidID, ErrInvalidNote or ErrNotInCollection
+	UpdateItem(ctx context.Context, userID, id int64, paperID, note string) (*Item, error)
+
+	// RemoveItem removes a paper from a collection.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	//   - id: collection ID
+	//   - paperID: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - error: ErrCollectionNotFound, ErrInvalidID or ErrNotInCollection
+	RemoveItem(ctx context.Context, userID, id int64, paperID string) error
+
+	// Reorder puts a collection's papers in the given order.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the owner
+	//   - id: collection ID
+	//   - paperIDs: every paper in the collection, once each, in the new order
+	// @Returns:
+	//   - *Collection: the collection with its reordered items
+	//   - error: ErrCollectionNotFound, ErrInvalidID, or ErrInvalidOrder if the IDs are not
+	//     exactly the collection's papers
+	Reorder(ctx context.Context, userID, id int64, paperIDs []string) (*Collection, error)
+}
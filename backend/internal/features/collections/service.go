@@ -0,0 +1,419 @@
+package collections
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	collectionRepo "github.com/rrlian/papertok/backend/internal/repository/collection"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// slugBytes is the amount of randomness in a share slug (96 bits).
+const slugBytes = 12
+
+// maxSlugAttempts bounds the retries when a new slug collides with an existing one.
+const maxSlugAttempts = 3
+
+// Impl implements the collections Service interface.
+type Impl struct {
+	collections collectionStore
+	papers      paperStore
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new collections service instance.
+func New(collections collectionStore, papers paperStore) *Impl {
+	return &Impl{
+		collections: collections,
+		papers:      papers,
+	}
+}
+
+// Create creates a collection for a user with a new share slug.
+func (s *Impl) Create(ctx context.Context, userID int64, req *CreateRequest) (*Collection, error) {
+	name, err := validName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := validDescription(req.Description); err != nil {
+		return nil, err
+	}
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = VisibilityPrivate
+	}
+	if err := validVisibility(visibility); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.collections.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxCollections {
+		return nil, fmt.Errorf("%w: at most %d", ErrTooManyCollections, MaxCollections)
+	}
+
+	c := &collectionRepo.Collection{
+		UserID:      userID,
+		Name:        name,
+		Description: req.Description,
+		Visibility:  visibility,
+	}
+	for attempt := 1; ; attempt++ {
+		if c.Slug, err = newSlug(); err != nil {
+			return nil, err
+		}
+		err = s.collections.Create(ctx, c)
+		if !errors.Is(err, collectionRepo.ErrSlugTaken) || attempt == maxSlugAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertCollection(c), nil
+}
+
+// Update renames a collection or changes its description or visibility.
+func (s *Impl) Update(ctx context.Context, userID, id int64, req *UpdateRequest) (*Collection, error) {
+	c, err := s.owned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		if c.Name, err = validName(*req.Name); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		if err := validDescription(*req.Description); err != nil {
+			return nil, err
+		}
+		c.Description = *req.Description
+	}
+	if req.Visibility != nil {
+		if err := validVisibility(*req.Visibility); err != nil {
+			return nil, err
+		}
+		c.Visibility = *req.Visibility
+	}
+
+	if err := s.collections.Update(ctx, c); err != nil {
+		return nil, s.notFound(err, id)
+	}
+	return convertCollection(c), nil
+}
+
+// Delete removes a collection and its items.
+func (s *Impl) Delete(ctx context.Context, userID, id int64) error {
+	if _, err := s.owned(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.notFound(s.collections.Delete(ctx, id), id)
+}
+
+// List returns a user's collections without their items.
+func (s *Impl) List(ctx context.Context, userID int64) ([]*Collection, error) {
+	stored, err := s.collections.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Collection, len(stored))
+	for i, c := range stored {
+		result[i] = convertCollection(c)
+	}
+	return result, nil
+}
+
+// Get returns one of a user's collections with its items.
+func (s *Impl) Get(ctx context.Context, userID, id int64) (*Collection, error) {
+	c, err := s.owned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.withItems(ctx, c)
+}
+
+// GetShared returns an unlisted or public collection by its share slug.
+func (s *Impl) GetShared(ctx context.Context, slug string) (*Collection, error) {
+	c, err := s.collections.GetBySlug(ctx, slug)
+	if errors.Is(err, collectionRepo.ErrCollectionNotFound) || (err == nil && c.Visibility == VisibilityPrivate) {
+		return nil, fmt.Errorf("%w: %s", ErrCollectionNotFound, slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.withItems(ctx, c)
+}
+
+// ListPublic returns a page of public collections without their items.
+func (s *Impl) ListPublic(ctx context.Context, offset, limit int) (*PublicList, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	stored, total, err := s.collections.ListPublic(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &PublicList{Collections: make([]*Collection, len(stored)), Total: total}
+	for i, c := range stored {
+		result.Collections[i] = convertCollection(c)
+	}
+	return result, nil
+}
+
+// AddItem appends a stored paper to a collection.
+func (s *Impl) AddItem(ctx context.Context, userID, id int64, paperID, note string) (*Item, bool, error) {
+	c, err := s.owned(ctx, userID, id)
+	if err != nil {
+		return nil, false, err
+	}
+	pid, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	if err := validNote(note); err != nil {
+		return nil, false, err
+	}
+	paper, ok := s.papers.GetByID(ctx, pid)
+	if !ok {
+		return nil, false, fmt.Errorf("%w: %s", ErrPaperNotFound, pid)
+	}
+
+	item := &collectionRepo.Item{CollectionID: c.ID, PaperID: pid, Note: note}
+	if c.ItemCount >= MaxItems {
+		// Re-adding a paper that is already there still succeeds.
+		items, err := s.collections.Items(ctx, c.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		if i := indexOfItem(items, pid); i >= 0 {
+			return convertItem(items[i], paper), false, nil
+		}
+		return nil, false, fmt.Errorf("%w: at most %d papers", ErrCollectionFull, MaxItems)
+	}
+
+	added, err := s.collections.AddItem(ctx, item)
+	if err != nil {
+		return nil, false, s.notFound(err, id)
+	}
+	return convertItem(item, paper), added, nil
+}
+
+// UpdateItem replaces the note on a paper in a collection.
+func (s *Impl) UpdateItem(ctx context.Context, userID, id int64, paperID, note string) (*Item, error) {
+	if _, err := s.owned(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	pid, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	if err := validNote(note); err != nil {
+		return nil, err
+	}
+
+	item := &collectionRepo.Item{CollectionID: id, PaperID: pid, Note: note}
+	if err := s.collections.UpdateItem(ctx, item); err != nil {
+		return nil, s.notInCollection(err, pid)
+	}
+	result := convertItem(item, nil)
+	if p, ok := s.papers.GetByID(ctx, pid); ok {
+		result.Paper = p
+	}
+	return result, nil
+}
+
+// RemoveItem removes a paper from a collection.
+func (s *Impl) RemoveItem(ctx context.Context, userID, id int64, paperID string) error {
+	if _, err := s.owned(ctx, userID, id); err != nil {
+		return err
+	}
+	pid, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	return s.notInCollection(s.collections.RemoveItem(ctx, id, pid), pid)
+}
+
+// Reorder puts a collection's papers in the given order.
+func (s *Impl) Reorder(ctx context.Context, userID, id int64, paperIDs []string) (*Collection, error) {
+	c, err := s.owned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(paperIDs))
+	for i, paperID := range paperIDs {
+		if ids[i], err = arxiv.BaseID(paperID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+		}
+	}
+
+	items, err := s.collections.Items(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(items) {
+		return nil, fmt.Errorf("%w: the collection has %d papers, got %d", ErrInvalidOrder, len(items), len(ids))
+	}
+	listed := make(map[string]bool, len(ids))
+	for _, pid := range ids {
+		if listed[pid] || indexOfItem(items, pid) < 0 {
+			return nil, fmt.Errorf("%w: %s is repeated or not in the collection", ErrInvalidOrder, pid)
+		}
+		listed[pid] = true
+	}
+
+	if err := s.collections.Reorder(ctx, id, ids); err != nil {
+		return nil, err
+	}
+	// Re-read to pick up the new positions and update time.
+	if c, err = s.collections.Get(ctx, c.ID); err != nil {
+		return nil, s.notFound(err, id)
+	}
+	return s.withItems(ctx, c)
+}
+
+// owned returns a collection if it belongs to the user. Other users'
+// collections are reported as not found so their IDs reveal nothing.
+func (s *Impl) owned(ctx context.Context, userID, id int64) (*collectionRepo.Collection, error) {
+	c, err := s.collections.Get(ctx, id)
+	if err != nil {
+		return nil, s.notFound(err, id)
+	}
+	if c.UserID != userID {
+		return nil, fmt.Errorf("%w: %d", ErrCollectionNotFound, id)
+	}
+	return c, nil
+}
+
+// withItems converts a collection and loads its items and their papers.
+func (s *Impl) withItems(ctx context.Context, c *collectionRepo.Collection) (*Collection, error) {
+	items, err := s.collections.Items(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := convertCollection(c)
+	result.Items = make([]*Item, len(items))
+	for i, item := range items {
+		// Papers cached without a database may have expired since they were added.
+		p, _ := s.papers.GetByID(ctx, item.PaperID)
+		result.Items[i] = convertItem(item, p)
+	}
+	result.ItemCount = len(items)
+	return result, nil
+}
+
+// notFound maps the repository's not-found error to ErrCollectionNotFound.
+func (s *Impl) notFound(err error, id int64) error {
+	if errors.Is(err, collectionRepo.ErrCollectionNotFound) {
+		return fmt.Errorf("%w: %d", ErrCollectionNotFound, id)
+	}
+	return err
+}
+
+// notInCollection maps the repository's item-not-found error to ErrNotInCollection.
+func (s *Impl) notInCollection(err error, paperID string) error {
+	if errors.Is(err, collectionRepo.ErrItemNotFound) {
+		return fmt.Errorf("%w: %s", ErrNotInCollection, paperID)
+	}
+	return err
+}
+
+// newSlug returns a random, URL-safe share slug.
+func newSlug() (string, error) {
+	b := make([]byte, slugBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate collection slug: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// validName trims a collection name and checks its length.
+func validName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidCollection, MaxNameLength)
+	}
+	return name, nil
+}
+
+// validDescription checks a description's length.
+func validDescription(description string) error {
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d characters", ErrInvalidCollection, MaxDescriptionLength)
+	}
+	return nil
+}
+
+// validVisibility checks that a visibility is known.
+func validVisibility(visibility string) error {
+	switch visibility {
+	case VisibilityPrivate, VisibilityUnlisted, VisibilityPublic:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidVisibility, visibility)
+}
+
+// validNote checks a note's length.
+func validNote(note string) error {
+	if utf8.RuneCountInString(note) > MaxNoteLength {
+		return fmt.Errorf("%w: at most %d characters", ErrInvalidNote, MaxNoteLength)
+	}
+	return nil
+}
+
+// indexOfItem returns the index of the item for a paper, or -1.
+func indexOfItem(items []*collectionRepo.Item, paperID string) int {
+	for i, item := range items {
+		if item.PaperID == paperID {
+			return i
+		}
+	}
+	return -1
+}
+
+// convertCollection converts a repository collection without its items.
+func convertCollection(c *collectionRepo.Collection) *Collection {
+	return &Collection{
+		ID:          c.ID,
+		OwnerID:     c.UserID,
+		Name:        c.Name,
+		Description: c.Description,
+		Visibility:  c.Visibility,
+		Slug:        c.Slug,
+		ItemCount:   c.ItemCount,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// convertItem converts a repository item with its paper, if any.
+func convertItem(item *collectionRepo.Item, p *paperRepo.Paper) *Item {
+	result := &Item{
+		PaperID:  item.PaperID,
+		Position: item.Position,
+		Note:     item.Note,
+		AddedAt:  item.AddedAt,
+	}
+	if p != nil {
+		result.Paper = p
+	}
+	return result
+}
@@ -0,0 +1,292 @@
+package collections
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	collectionRepo "github.com/rrlian/papertok/backend/internal/repository/collection"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockPapers holds the papers items may point at; deleting one expires it.
+type mockPapers map[string]*paperRepo.Paper
+
+func (m mockPapers) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	p, ok := m[id]
+	return p, ok
+}
+
+func newTestService() (*Impl, mockPapers) {
+	papers := mockPapers{
+		"2401.00001": {ID: "2401.00001"},
+		"2401.00002": {ID: "2401.00002"},
+		"2401.00003": {ID: "2401.00003"},
+	}
+	return New(collectionRepo.NewMemoryRepository(), papers), papers
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestImpl_Create(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	// Act
+	first, err := svc.Create(ctx, 1, &CreateRequest{Name: "  Diffusion  ", Description: "Project A"})
+	second, errSecond := svc.Create(ctx, 1, &CreateRequest{Name: "Agents", Visibility: VisibilityPublic})
+
+	// Assert
+	if err != nil || errSecond != nil {
+		t.Fatalf("Expected no error, got: %v, %v", err, errSecond)
+	}
+	if first.Name != "Diffusion" || first.Visibility != VisibilityPrivate || first.OwnerID != 1 {
+		t.Errorf("Expected trimmed private collection owned by user 1, got: %+v", first)
+	}
+	if len(first.Slug) < 16 || first.Slug == second.Slug {
+		t.Errorf("Expected distinct unguessable slugs, got: %q, %q", first.Slug, second.Slug)
+	}
+	list, _ := svc.List(ctx, 1)
+	if len(list) != 2 {
+		t.Errorf("Expected 2 collections, got: %d", len(list))
+	}
+}
+
+func TestImpl_Update(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+	c, _ := svc.Create(ctx, 1, &CreateRequest{Name: "Draft", Description: "Keep me"})
+
+	// Act
+	updated, err := svc.Update(ctx, 1, c.ID, &UpdateRequest{Name: strPtr("Final"), Visibility: strPtr(VisibilityUnlisted)})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if updated.Name != "Final" || updated.Visibility != VisibilityUnlisted || updated.Description != "Keep me" {
+		t.Errorf("Expected only name and visibility to change, got: %+v", updated)
+	}
+	if updated.Slug != c.Slug {
+		t.Errorf("Expected slug to be kept, got: %q", updated.Slug)
+	}
+}
+
+func TestImpl_Items(t *testing.T) {
+	// Arrange
+	svc, papers := newTestService()
+	ctx := context.Background()
+	c, _ := svc.Create(ctx, 1, &CreateRequest{Name: "Reading list"})
+
+	// Act
+	_, added, err := svc.AddItem(ctx, 1, c.ID, "2401.00001v2", "start here")
+	svc.AddItem(ctx, 1, c.ID, "2401.00002", "")
+	svc.AddItem(ctx, 1, c.ID, "2401.00003", "")
+	again, addedAgain, _ := svc.AddItem(ctx, 1, c.ID, "2401.00001", "ignored")
+	noted, noteErr := svc.UpdateItem(ctx, 1, c.ID, "2401.00002", "compare with first")
+	removeErr := svc.RemoveItem(ctx, 1, c.ID, "2401.00003")
+	reordered, reorderErr := svc.Reorder(ctx, 1, c.ID, []string{"2401.00002", "2401.00001v1"})
+	delete(papers, "2401.00001")
+	got, getErr := svc.Get(ctx, 1, c.ID)
+
+	// Assert
+	if err != nil || noteErr != nil || removeErr != nil || reorderErr != nil || getErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v, %v, %v, %v", err, noteErr, removeErr, reorderErr, getErr)
+	}
+	if !added || addedAgain || again.Note != "start here" {
+		t.Errorf("Expected re-adding to keep the original item, got: %v, %v, %+v", added, addedAgain, again)
+	}
+	if noted.Note != "compare with first" || noted.Paper == nil {
+		t.Errorf("Expected updated note with its paper, got: %+v", noted)
+	}
+	if reordered.ItemCount != 2 || reordered.Items[0].PaperID != "2401.00002" || reordered.Items[0].Position != 1 {
+		t.Errorf("Expected [2401.00002 2401.00001], got: %+v", reordered.Items)
+	}
+	if len(got.Items) != 2 || got.Items[1].Paper != nil || got.Items[1].Note != "start here" {
+		t.Errorf("Expected expired paper to be left empty with its note, got: %+v", got.Items[1])
+	}
+}
+
+func TestImpl_Visibility(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+	private, _ := svc.Create(ctx, 1, &CreateRequest{Name: "Private"})
+	unlisted, _ := svc.Create(ctx, 1, &CreateRequest{Name: "Unlisted", Visibility: VisibilityUnlisted})
+	public, _ := svc.Create(ctx, 2, &CreateRequest{Name: "Public", Visibility: VisibilityPublic})
+	svc.AddItem(ctx, 2, public.ID, "2401.00001", "")
+
+	tests := []struct {
+		name      string
+		slug      string
+		wantFound bool
+	}{
+		{name: "private", slug: private.Slug, wantFound: false},
+		{name: "unlisted", slug: unlisted.Slug, wantFound: true},
+		{name: "public", slug: public.Slug, wantFound: true},
+		{name: "unknown", slug: "no-such-slug", wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := svc.GetShared(ctx, tt.slug)
+
+			// Assert
+			if tt.wantFound && (err != nil || got.Slug != tt.slug) {
+				t.Errorf("Expected collection %q, got: %v, %v", tt.slug, got, err)
+			}
+			if !tt.wantFound && !IsCollectionNotFound(err) {
+				t.Errorf("Expected ErrCollectionNotFound, got: %v", err)
+			}
+		})
+	}
+
+	listed, err := svc.ListPublic(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if listed.Total != 1 || listed.Collections[0].ID != public.ID || listed.Collections[0].ItemCount != 1 {
+		t.Errorf("Expected only the public collection with 1 paper, got: %+v", listed.Collections)
+	}
+}
+
+func TestImpl_Ownership(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+	c, _ := svc.Create(ctx, 1, &CreateRequest{Name: "Mine", Visibility: VisibilityPublic})
+
+	// Act
+	_, getErr := svc.Get(ctx, 2, c.ID)
+	_, updateErr := svc.Update(ctx, 2, c.ID, &UpdateRequest{Name: strPtr("Theirs")})
+	_, _, addErr := svc.AddItem(ctx, 2, c.ID, "2401.00001", "")
+	deleteErr := svc.Delete(ctx, 2, c.ID)
+
+	// Assert
+	for _, err := range []error{getErr, updateErr, addErr, deleteErr} {
+		if !IsCollectionNotFound(err) {
+			t.Errorf("Expected ErrCollectionNotFound for another user, got: %v", err)
+		}
+	}
+	if err := svc.Delete(ctx, 1, c.ID); err != nil {
+		t.Errorf("Expected owner to delete, got: %v", err)
+	}
+	if _, err := svc.Get(ctx, 1, c.ID); !IsCollectionNotFound(err) {
+		t.Errorf("Expected deleted collection to be gone, got: %v", err)
+	}
+}
+
+func TestImpl_Errors(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	c, _ := svc.Create(ctx, 1, &CreateRequest{Name: "Errors"})
+	svc.AddItem(ctx, 1, c.ID, "2401.00001", "")
+	svc.AddItem(ctx, 1, c.ID, "2401.00002", "")
+
+	tests := []struct {
+		name    string
+		call    func() error
+		checkFn func(error) bool
+	}{
+		{
+			name: "blank name",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, &CreateRequest{Name: "   "})
+				return err
+			},
+			checkFn: IsInvalidCollection,
+		},
+		{
+			name: "long description",
+			call: func() error {
+				_, err := svc.Update(ctx, 1, c.ID, &UpdateRequest{Description: strPtr(strings.Repeat("x", MaxDescriptionLength+1))})
+				return err
+			},
+			checkFn: IsInvalidCollection,
+		},
+		{
+			name: "unknown visibility",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, &CreateRequest{Name: "x", Visibility: "friends"})
+				return err
+			},
+			checkFn: IsInvalidVisibility,
+		},
+		{
+			name: "invalid paper ID",
+			call: func() error {
+				_, _, err := svc.AddItem(ctx, 1, c.ID, "bad id", "")
+				return err
+			},
+			checkFn: IsInvalidID,
+		},
+		{
+			name: "unknown paper",
+			call: func() error {
+				_, _, err := svc.AddItem(ctx, 1, c.ID, "2401.99999", "")
+				return err
+			},
+			checkFn: IsPaperNotFound,
+		},
+		{
+			name: "long note",
+			call: func() error {
+				_, err := svc.UpdateItem(ctx, 1, c.ID, "2401.00001", strings.Repeat("x", MaxNoteLength+1))
+				return err
+			},
+			checkFn: IsInvalidNote,
+		},
+		{
+			name: "note on missing paper",
+			call: func() error {
+				_, err := svc.UpdateItem(ctx, 1, c.ID, "2401.00003", "note")
+				return err
+			},
+			checkFn: IsNotInCollection,
+		},
+		{
+			name:    "remove missing paper",
+			call:    func() error { return svc.RemoveItem(ctx, 1, c.ID, "2401.00003") },
+			checkFn: IsNotInCollection,
+		},
+		{
+			name: "order missing a paper",
+			call: func() error {
+				_, err := svc.Reorder(ctx, 1, c.ID, []string{"2401.00001"})
+				return err
+			},
+			checkFn: IsInvalidOrder,
+		},
+		{
+			name: "order with a repeat",
+			call: func() error {
+				_, err := svc.Reorder(ctx, 1, c.ID, []string{"2401.00001", "2401.00001v2"})
+				return err
+			},
+			checkFn: IsInvalidOrder,
+		},
+		{
+			name: "unknown collection",
+			call: func() error {
+				_, err := svc.Get(ctx, 1, 999)
+				return err
+			},
+			checkFn: IsCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.call()
+
+			// Assert
+			if !tt.checkFn(err) {
+				t.Errorf("Expected matching error, got: %v", err)
+			}
+		})
+	}
+}
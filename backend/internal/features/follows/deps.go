@@ -0,0 +1,19 @@
+package follows
+
+import (
+	"context"
+
+	followRepo "github.com/rrlian/papertok/backend/internal/repository/follow"
+)
+
+// followStore defines the repository capabilities required for follows.
+type followStore interface {
+	// Add follows an author or category, reporting whether it was newly added.
+	Add(ctx context.Context, f *followRepo.Follow) (bool, error)
+
+	// Remove unfollows an author or category.
+	Remove(ctx context.Context, userID int64, kind followRepo.Kind, key string) error
+
+	// List returns all of a user's follows, most recent first.
+	List(ctx context.Context, userID int64) ([]*followRepo.Follow, error)
+}
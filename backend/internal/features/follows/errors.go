@@ -0,0 +1,30 @@
+package follows
+
+import "errors"
+
+var (
+	// ErrInvalidKind indicates that the follow kind is neither author nor category.
+	ErrInvalidKind = errors.New("invalid follow kind")
+
+	// ErrInvalidName indicates that an author name or category is empty,
+	// too long or cannot be matched on arXiv.
+	ErrInvalidName = errors.New("invalid follow name")
+
+	// ErrNotFollowing indicates that the user does not follow the author or category.
+	ErrNotFollowing = errors.New("not following")
+
+	// ErrTooManyFollows indicates that the user already follows MaxFollows authors and categories.
+	ErrTooManyFollows = errors.New("too many follows")
+)
+
+// IsInvalidKind checks if the error is ErrInvalidKind.
+func IsInvalidKind(err error) bool { return errors.Is(err, ErrInvalidKind) }
+
+// IsInvalidName checks if the error is ErrInvalidName.
+func IsInvalidName(err error) bool { return errors.Is(err, ErrInvalidName) }
+
+// IsNotFollowing checks if the error is ErrNotFollowing.
+func IsNotFollowing(err error) bool { return errors.Is(err, ErrNotFollowing) }
+
+// IsTooManyFollows checks if the error is ErrTooManyFollows.
+func IsTooManyFollows(err error) bool { return errors.Is(err, ErrTooManyFollows) }
@@ -0,0 +1,66 @@
+package follows
+
+import (
+	"context"
+	"time"
+)
+
+// Limits on follows.
+const (
+	MaxFollows    = 50  // Authors and categories a user may follow in total
+	MaxNameLength = 100 // Characters in an author name or category
+)
+
+// Follow kinds.
+const (
+	KindAuthor   = "author"
+	KindCategory = "category"
+)
+
+// Follow is an author or arXiv category followed by a user.
+type Follow struct {
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"` // Author name or category (e.g., "cs.LG" or "cs.*")
+	FollowedAt time.Time `json:"followedAt"`
+}
+
+// Following is everything a user follows, most recently followed first.
+type Following struct {
+	Authors    []*Follow `json:"authors"`
+	Categories []*Follow `json:"categories"`
+}
+
+// Service defines the interface for following authors and categories.
+type Service interface {
+	// Follow follows an author or category. Following twice is not an error.
+	// Author names are matched ignoring case and extra whitespace.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - kind: KindAuthor or KindCategory
+	//   - name: author name or category
+	// @Returns:
+	//   - *Follow: the follow, with the name as first followed
+	//   - bool: true if the follow was created, false if it already existed
+	//   - error: ErrInvalidKind, ErrInvalidName, or ErrTooManyFollows if the user follows MaxFollows already
+	Follow(ctx context.Context, userID int64, kind, name string) (*Follow, bool, error)
+
+	// Unfollow stops following an author or category.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - kind: KindAuthor or KindCategory
+	//   - name: author name or category
+	// @Returns:
+	//   - error: ErrInvalidKind, ErrInvalidName, or ErrNotFollowing if the user does not follow it
+	Unfollow(ctx context.Context, userID int64, kind, name string) error
+
+	// List returns the authors and categories a user follows.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	// @Returns:
+	//   - *Following: followed authors and categories
+	//   - error: if the follows cannot be read
+	List(ctx context.Context, userID int64) (*Following, error)
+}
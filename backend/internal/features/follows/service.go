@@ -0,0 +1,124 @@
+package follows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	followRepo "github.com/rrlian/papertok/backend/internal/repository/follow"
+)
+
+// Impl implements the follows Service interface.
+type Impl struct {
+	follows followStore
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new follows service instance.
+func New(follows followStore) *Impl {
+	return &Impl{
+		follows: follows,
+	}
+}
+
+// Follow follows an author or category.
+func (s *Impl) Follow(ctx context.Context, userID int64, kind, name string) (*Follow, bool, error) {
+	f, err := newFollow(userID, kind, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := s.follows.List(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, e := range existing {
+		if e.Kind == f.Kind && e.Key == f.Key {
+			return convertFollow(e), false, nil
+		}
+	}
+	if len(existing) >= MaxFollows {
+		return nil, false, fmt.Errorf("%w: at most %d", ErrTooManyFollows, MaxFollows)
+	}
+
+	created, err := s.follows.Add(ctx, f)
+	if err != nil {
+		return nil, false, err
+	}
+	return convertFollow(f), created, nil
+}
+
+// Unfollow stops following an author or category.
+func (s *Impl) Unfollow(ctx context.Context, userID int64, kind, name string) error {
+	f, err := newFollow(userID, kind, name)
+	if err != nil {
+		return err
+	}
+	if err := s.follows.Remove(ctx, userID, f.Kind, f.Key); err != nil {
+		if errors.Is(err, followRepo.ErrFollowNotFound) {
+			return fmt.Errorf("%w: %s %q", ErrNotFollowing, kind, f.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// List returns the authors and categories a user follows.
+func (s *Impl) List(ctx context.Context, userID int64) (*Following, error) {
+	stored, err := s.follows.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	following := &Following{Authors: []*Follow{}, Categories: []*Follow{}}
+	for _, f := range stored {
+		switch f.Kind {
+		case followRepo.KindAuthor:
+			following.Authors = append(following.Authors, convertFollow(f))
+		case followRepo.KindCategory:
+			following.Categories = append(following.Categories, convertFollow(f))
+		}
+	}
+	return following, nil
+}
+
+// newFollow validates a follow and derives its key. Author names are keyed
+// ignoring case and extra whitespace; categories are kept as given, since
+// arXiv category names are case-sensitive.
+func newFollow(userID int64, kind, name string) (*followRepo.Follow, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return nil, fmt.Errorf("%w: must be 1 to %d characters", ErrInvalidName, MaxNameLength)
+	}
+
+	var field arxiv.Field
+	f := &followRepo.Follow{UserID: userID, Name: name, Key: name}
+	switch kind {
+	case KindAuthor:
+		field, f.Kind, f.Key = arxiv.FieldAuthor, followRepo.KindAuthor, strings.ToLower(name)
+	case KindCategory:
+		field, f.Kind = arxiv.FieldCategory, followRepo.KindCategory
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKind, kind)
+	}
+
+	// Followed names end up in arXiv queries; reject those that cannot be matched.
+	if _, err := arxiv.NewQuery().Where(field, name).Build(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidName, err)
+	}
+	return f, nil
+}
+
+// convertFollow converts a repository follow to a feature follow.
+func convertFollow(f *followRepo.Follow) *Follow {
+	return &Follow{
+		Kind:       string(f.Kind),
+		Name:       f.Name,
+		FollowedAt: f.CreatedAt,
+	}
+}
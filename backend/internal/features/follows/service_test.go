@@ -0,0 +1,172 @@
+package follows
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	followRepo "github.com/rrlian/papertok/backend/internal/repository/follow"
+)
+
+func newTestService() *Impl {
+	return New(followRepo.NewMemoryRepository())
+}
+
+func TestImpl_Follow(t *testing.T) {
+	// Arrange
+	svc := newTestService()
+	ctx := context.Background()
+
+	// Act
+	first, created, err := svc.Follow(ctx, 1, KindAuthor, "  Jonathan   Ho ")
+	again, createdAgain, errAgain := svc.Follow(ctx, 1, KindAuthor, "jonathan ho")
+
+	// Assert
+	if err != nil || errAgain != nil {
+		t.Fatalf("Expected no error, got: %v, %v", err, errAgain)
+	}
+	if !created || createdAgain {
+		t.Errorf("Expected first follow to create and second to be a no-op, got: %v, %v", created, createdAgain)
+	}
+	if first.Name != "Jonathan Ho" || first.Kind != KindAuthor {
+		t.Errorf("Expected author with collapsed whitespace, got: %+v", first)
+	}
+	if again.Name != "Jonathan Ho" || !again.FollowedAt.Equal(first.FollowedAt) {
+		t.Errorf("Expected the original follow back, got: %+v", again)
+	}
+}
+
+func TestImpl_List(t *testing.T) {
+	// Arrange
+	svc := newTestService()
+	ctx := context.Background()
+	svc.Follow(ctx, 1, KindAuthor, "Jonathan Ho")
+	svc.Follow(ctx, 1, KindCategory, "cs.LG")
+	svc.Follow(ctx, 1, KindCategory, "stat.*")
+	svc.Follow(ctx, 2, KindAuthor, "Someone Else")
+
+	// Act
+	following, err := svc.List(ctx, 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(following.Authors) != 1 || following.Authors[0].Name != "Jonathan Ho" {
+		t.Errorf("Expected the user's one author, got: %+v", following.Authors)
+	}
+	if len(following.Categories) != 2 {
+		t.Errorf("Expected 2 categories, got: %d", len(following.Categories))
+	}
+}
+
+func TestImpl_Unfollow(t *testing.T) {
+	// Arrange
+	svc := newTestService()
+	ctx := context.Background()
+	svc.Follow(ctx, 1, KindAuthor, "Jonathan Ho")
+
+	// Act
+	err := svc.Unfollow(ctx, 1, KindAuthor, "JONATHAN HO")
+	errAgain := svc.Unfollow(ctx, 1, KindAuthor, "Jonathan Ho")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !IsNotFollowing(errAgain) {
+		t.Errorf("Expected ErrNotFollowing, got: %v", errAgain)
+	}
+	if following, _ := svc.List(ctx, 1); len(following.Authors) != 0 {
+		t.Errorf("Expected no authors, got: %+v", following.Authors)
+	}
+}
+
+func TestImpl_Follow_Limit(t *testing.T) {
+	// Arrange
+	svc := newTestService()
+	ctx := context.Background()
+	for i := 0; i < MaxFollows; i++ {
+		if _, _, err := svc.Follow(ctx, 1, KindAuthor, fmt.Sprintf("Author %d", i)); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	// Act
+	_, _, err := svc.Follow(ctx, 1, KindCategory, "cs.LG")
+	_, created, errExisting := svc.Follow(ctx, 1, KindAuthor, "Author 0")
+
+	// Assert
+	if !IsTooManyFollows(err) {
+		t.Errorf("Expected ErrTooManyFollows, got: %v", err)
+	}
+	if errExisting != nil || created {
+		t.Errorf("Expected an existing follow to be returned at the limit, got: %v, %v", created, errExisting)
+	}
+}
+
+func TestImpl_Errors(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		run     func() error
+		checkFn func(error) bool
+	}{
+		{
+			name: "unknown kind",
+			run: func() error {
+				_, _, err := svc.Follow(ctx, 1, "paper", "2401.00001")
+				return err
+			},
+			checkFn: IsInvalidKind,
+		},
+		{
+			name: "empty name",
+			run: func() error {
+				_, _, err := svc.Follow(ctx, 1, KindAuthor, "   ")
+				return err
+			},
+			checkFn: IsInvalidName,
+		},
+		{
+			name: "malformed category",
+			run: func() error {
+				_, _, err := svc.Follow(ctx, 1, KindCategory, "cs.LG OR all:x")
+				return err
+			},
+			checkFn: IsInvalidName,
+		},
+		{
+			name: "author without searchable terms",
+			run: func() error {
+				_, _, err := svc.Follow(ctx, 1, KindAuthor, "()")
+				return err
+			},
+			checkFn: IsInvalidName,
+		},
+		{
+			name:    "unfollow unknown kind",
+			run:     func() error { return svc.Unfollow(ctx, 1, "venue", "NeurIPS") },
+			checkFn: IsInvalidKind,
+		},
+		{
+			name:    "unfollow not followed",
+			run:     func() error { return svc.Unfollow(ctx, 1, KindCategory, "cs.AI") },
+			checkFn: IsNotFollowing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.run()
+
+			// Assert
+			if !tt.checkFn(err) {
+				t.Errorf("Expected matching error, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package harvest
+
+import (
+	"context"
+
+	"github.com/rrlian/papertok/backend/internal/core/oaipmh"
+	harvestRepo "github.com/rrlian/papertok/backend/internal/repository/harvest"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// oaiService defines the OAI-PMH capability required by this feature.
+type oaiService interface {
+	// ListRecords fetches one page of records.
+	ListRecords(ctx context.Context, req *oaipmh.ListRequest) (*oaipmh.ListResponse, error)
+}
+
+// paperStore defines the paper repository capability required by this feature.
+type paperStore interface {
+	// Upsert stores papers permanently.
+	Upsert(ctx context.Context, papers []*paperRepo.Paper) error
+}
+
+// stateStore defines the harvest state capability required by this feature.
+type stateStore interface {
+	// Get retrieves the state for a set and metadata format.
+	Get(ctx context.Context, set, metadataPrefix string) (*harvestRepo.State, error)
+
+	// Save creates or replaces a state.
+	Save(ctx context.Context, state *harvestRepo.State) error
+}
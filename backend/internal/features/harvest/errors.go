@@ -0,0 +1,11 @@
+package harvest
+
+import "errors"
+
+var (
+	// ErrInvalidRequest indicates that the harvest parameters are invalid.
+	ErrInvalidRequest = errors.New("invalid harvest request")
+)
+
+// IsInvalidRequest checks if the error is ErrInvalidRequest.
+func IsInvalidRequest(err error) bool { return errors.Is(err, ErrInvalidRequest) }
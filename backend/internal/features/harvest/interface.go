@@ -0,0 +1,70 @@
+package harvest
+
+import (
+	"context"
+	"time"
+)
+
+// HarvestRequest contains parameters for a harvest run.
+// Without From/Until the run is incremental: it resumes an unfinished run or
+// starts from the stored watermark, and advances the watermark on completion.
+// With From or Until it is a one-off backfill that leaves the stored state untouched.
+type HarvestRequest struct {
+	Set            string    // OAI-PMH set (e.g., "cs"); empty for the whole archive
+	MetadataPrefix string    // "arXiv" (default) or "arXivRaw"
+	From           time.Time // Backfill lower bound (inclusive)
+	Until          time.Time // Backfill upper bound (inclusive)
+	MaxPages       int       // Stop after this many pages (0 for no limit); the run can be continued later
+
+	// OnPage, if set, is called after each page has been stored.
+	OnPage func(progress Progress)
+}
+
+// Progress reports the state of a running harvest.
+type Progress struct {
+	Pages            int // Pages processed in this run
+	Records          int // Records stored in this run
+	Deleted          int // Deleted records seen in this run
+	CompleteListSize int // Total records in the list, if the endpoint reports it
+}
+
+// HarvestResult summarizes a harvest run.
+type HarvestResult struct {
+	Progress
+	From      time.Time // Lower bound the run started from (zero for a full harvest)
+	Watermark time.Time // Watermark after the run (zero for backfills)
+	Resumed   bool      // The run continued an unfinished run
+	Complete  bool      // The list was exhausted; false if MaxPages or cancellation stopped it
+}
+
+// Status is the stored progress for a set and metadata format.
+type Status struct {
+	Set              string    `json:"set"`
+	MetadataPrefix   string    `json:"metadataPrefix"`
+	Watermark        time.Time `json:"watermark"`
+	InProgress       bool      `json:"inProgress"`
+	RecordsHarvested int64     `json:"recordsHarvested"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// Service defines the interface for OAI-PMH harvesting operations.
+type Service interface {
+	// Harvest runs one harvest, storing every page in the paper repository as it arrives.
+	// @Params:
+	//   - ctx: context for cancellation; progress made so far is kept
+	//   - req: harvest parameters
+	// @Returns:
+	//   - *HarvestResult: pages and records processed, and the new watermark
+	//   - error: ErrInvalidRequest for bad parameters, or if harvesting or storing fails
+	Harvest(ctx context.Context, req *HarvestRequest) (*HarvestResult, error)
+
+	// Status returns the stored progress for a set and metadata format.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - set: OAI-PMH set; empty for the whole archive
+	//   - metadataPrefix: metadata format; empty for "arXiv"
+	// @Returns:
+	//   - *Status: the stored progress, or nil if nothing has been harvested
+	//   - error: if the state cannot be read
+	Status(ctx context.Context, set, metadataPrefix string) (*Status, error)
+}
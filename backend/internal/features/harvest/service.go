@@ -0,0 +1,254 @@
+package harvest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/oaipmh"
+	harvestRepo "github.com/rrlian/papertok/backend/internal/repository/harvest"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// Impl implements the harvest Service interface.
+type Impl struct {
+	oai    oaiService
+	papers paperStore
+	states stateStore
+
+	now func() time.Time
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new harvest service instance.
+func New(oai oaiService, papers paperStore, states stateStore) *Impl {
+	return &Impl{
+		oai:    oai,
+		papers: papers,
+		states: states,
+		now:    time.Now,
+	}
+}
+
+// Harvest runs one harvest, storing every page as it arrives.
+// Incremental runs save their resumption token after each page, so a run that
+// is cancelled or stopped by MaxPages continues where it left off next time.
+func (s *Impl) Harvest(ctx context.Context, req *HarvestRequest) (*HarvestResult, error) {
+	prefix, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HarvestResult{From: req.From}
+	listReq := &oaipmh.ListRequest{MetadataPrefix: prefix, Set: req.Set, From: req.From, Until: req.Until}
+
+	// Backfills leave the stored state alone; incremental runs load it.
+	var state *harvestRepo.State
+	if req.From.IsZero() && req.Until.IsZero() {
+		state, err = s.loadState(ctx, req.Set, prefix)
+		if err != nil {
+			return nil, err
+		}
+		result.From = state.Watermark
+		result.Watermark = state.Watermark
+		if state.ResumptionToken != "" {
+			listReq = &oaipmh.ListRequest{ResumptionToken: state.ResumptionToken}
+			result.Resumed = true
+		} else {
+			listReq.From = state.Watermark
+		}
+	}
+
+	restarted := false
+	for {
+		if req.MaxPages > 0 && result.Pages >= req.MaxPages {
+			return result, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		starting := listReq.ResumptionToken == ""
+		resp, err := s.oai.ListRecords(ctx, listReq)
+		if err != nil {
+			// Resumption tokens expire; restart the run from the watermark once.
+			if state != nil && !starting && !restarted && oaipmh.IsBadResumptionToken(err) {
+				restarted = true
+				result.Resumed = false
+				listReq = &oaipmh.ListRequest{MetadataPrefix: prefix, Set: req.Set, From: state.Watermark}
+				continue
+			}
+			if oaipmh.IsInvalidRequest(err) {
+				return result, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+			}
+			return result, err
+		}
+
+		if state != nil && starting {
+			state.RunStartedAt = resp.ResponseDate
+			if state.RunStartedAt.IsZero() {
+				state.RunStartedAt = s.now()
+			}
+		}
+
+		papers, deleted := s.convertRecords(resp.Records)
+		if len(papers) > 0 {
+			if err := s.papers.Upsert(ctx, papers); err != nil {
+				return result, fmt.Errorf("failed to store harvested papers: %w", err)
+			}
+		}
+
+		result.Pages++
+		result.Records += len(papers)
+		result.Deleted += deleted
+		if resp.CompleteListSize > 0 {
+			result.CompleteListSize = resp.CompleteListSize
+		}
+
+		if state != nil {
+			state.ResumptionToken = resp.ResumptionToken
+			state.RecordsHarvested += int64(len(papers))
+			if resp.ResumptionToken == "" {
+				// Datestamps have day granularity; the next run re-reads the start day.
+				state.Watermark = startOfDay(state.RunStartedAt)
+			}
+			if err := s.states.Save(ctx, state); err != nil {
+				return result, fmt.Errorf("failed to save harvest state: %w", err)
+			}
+			result.Watermark = state.Watermark
+		}
+
+		if req.OnPage != nil {
+			req.OnPage(result.Progress)
+		}
+
+		if resp.ResumptionToken == "" {
+			result.Complete = true
+			return result, nil
+		}
+		listReq = &oaipmh.ListRequest{ResumptionToken: resp.ResumptionToken}
+	}
+}
+
+// Status returns the stored progress for a set and metadata format.
+func (s *Impl) Status(ctx context.Context, set, metadataPrefix string) (*Status, error) {
+	if metadataPrefix == "" {
+		metadataPrefix = oaipmh.FormatArXiv
+	}
+
+	state, err := s.states.Get(ctx, set, metadataPrefix)
+	if errors.Is(err, harvestRepo.ErrStateNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load harvest state: %w", err)
+	}
+
+	return &Status{
+		Set:              state.Set,
+		MetadataPrefix:   state.MetadataPrefix,
+		Watermark:        state.Watermark,
+		InProgress:       state.ResumptionToken != "",
+		RecordsHarvested: state.RecordsHarvested,
+		UpdatedAt:        state.UpdatedAt,
+	}, nil
+}
+
+// validate checks the request and returns the metadata format to use.
+func (s *Impl) validate(req *HarvestRequest) (string, error) {
+	if req == nil {
+		return "", fmt.Errorf("%w: request is required", ErrInvalidRequest)
+	}
+
+	prefix := req.MetadataPrefix
+	if prefix == "" {
+		prefix = oaipmh.FormatArXiv
+	}
+	if prefix != oaipmh.FormatArXiv && prefix != oaipmh.FormatArXivRaw {
+		return "", fmt.Errorf("%w: unsupported metadata format %q", ErrInvalidRequest, prefix)
+	}
+	if !req.From.IsZero() && !req.Until.IsZero() && req.Until.Before(req.From) {
+		return "", fmt.Errorf("%w: until is before from", ErrInvalidRequest)
+	}
+	if req.MaxPages < 0 {
+		return "", fmt.Errorf("%w: max pages must not be negative", ErrInvalidRequest)
+	}
+	return prefix, nil
+}
+
+// loadState returns the stored state, or a fresh one if nothing was harvested yet.
+func (s *Impl) loadState(ctx context.Context, set, prefix string) (*harvestRepo.State, error) {
+	state, err := s.states.Get(ctx, set, prefix)
+	if errors.Is(err, harvestRepo.ErrStateNotFound) {
+		return &harvestRepo.State{Set: set, MetadataPrefix: prefix}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load harvest state: %w", err)
+	}
+	return state, nil
+}
+
+// convertRecords converts harvested records to repository papers.
+// Deleted records and records without metadata are counted but not stored.
+func (s *Impl) convertRecords(records []*oaipmh.Record) ([]*paperRepo.Paper, int) {
+	papers := make([]*paperRepo.Paper, 0, len(records))
+	deleted := 0
+	for _, r := range records {
+		if r.Deleted {
+			deleted++
+			continue
+		}
+		if r.Metadata == nil || r.Metadata.ID == "" {
+			continue
+		}
+		papers = append(papers, convertMetadata(r.Metadata))
+	}
+	return papers, deleted
+}
+
+// convertMetadata converts OAI-PMH metadata to a repository paper.
+func convertMetadata(m *oaipmh.Metadata) *paperRepo.Paper {
+	paper := &paperRepo.Paper{
+		ID:         m.ID,
+		Version:    len(m.Versions),
+		Title:      m.Title,
+		Summary:    m.Abstract,
+		Published:  m.Created,
+		Updated:    m.Updated,
+		Categories: m.Categories,
+		ArxivURL:   fmt.Sprintf("https://arxiv.org/abs/%s", m.ID),
+		PDFURL:     fmt.Sprintf("https://arxiv.org/pdf/%s", m.ID),
+		ImageURL:   fmt.Sprintf("https://arxiv.org/html/%s/x1.png", m.ID),
+		DOI:        m.DOI,
+		JournalRef: m.JournalRef,
+		Comment:    m.Comments,
+		License:    m.License,
+	}
+	if paper.Updated.IsZero() {
+		paper.Updated = paper.Published
+	}
+	if len(m.Categories) > 0 {
+		paper.PrimaryCategory = m.Categories[0]
+	}
+
+	for _, v := range m.Versions {
+		paper.Versions = append(paper.Versions, paperRepo.Version{Version: v.Version, Submitted: v.Date})
+	}
+
+	paper.Authors = make([]string, len(m.Authors))
+	paper.AuthorDetails = make([]paperRepo.AuthorDetail, len(m.Authors))
+	for i, a := range m.Authors {
+		paper.Authors[i] = a.Name
+		paper.AuthorDetails[i] = paperRepo.AuthorDetail{Name: a.Name, Affiliations: a.Affiliations}
+	}
+	return paper
+}
+
+// startOfDay truncates t to midnight UTC.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,285 @@
+package harvest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/oaipmh"
+	harvestRepo "github.com/rrlian/papertok/backend/internal/repository/harvest"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockOAIService serves pages keyed by resumption token ("" for the first page).
+type mockOAIService struct {
+	pages    map[string]*oaipmh.ListResponse
+	requests []*oaipmh.ListRequest
+}
+
+func (m *mockOAIService) ListRecords(ctx context.Context, req *oaipmh.ListRequest) (*oaipmh.ListResponse, error) {
+	m.requests = append(m.requests, req)
+	page, ok := m.pages[req.ResumptionToken]
+	if !ok {
+		return nil, &oaipmh.ProtocolError{Code: "badResumptionToken", Message: "expired"}
+	}
+	return page, nil
+}
+
+// mockPaperStore records upserted papers.
+type mockPaperStore struct {
+	papers []*paperRepo.Paper
+	err    error
+}
+
+func (m *mockPaperStore) Upsert(ctx context.Context, papers []*paperRepo.Paper) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.papers = append(m.papers, papers...)
+	return nil
+}
+
+var responseDate = time.Date(2024, 5, 2, 10, 30, 0, 0, time.UTC)
+
+func record(id string) *oaipmh.Record {
+	return &oaipmh.Record{
+		Identifier: "oai:arXiv.org:" + id,
+		Metadata: &oaipmh.Metadata{
+			ID:         id,
+			Title:      "Paper " + id,
+			Abstract:   "Abstract",
+			Authors:    []oaipmh.Author{{Name: "Jane Doe", Affiliations: []string{"MIT"}}},
+			Categories: []string{"cs.LG", "stat.ML"},
+			Created:    time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+// twoPages is a complete list of three records, one of them deleted.
+func twoPages() map[string]*oaipmh.ListResponse {
+	return map[string]*oaipmh.ListResponse{
+		"": {
+			ResponseDate:     responseDate,
+			Records:          []*oaipmh.Record{record("2301.00001"), {Identifier: "oai:arXiv.org:2301.00002", Deleted: true}},
+			ResumptionToken:  "token-1",
+			CompleteListSize: 3,
+		},
+		"token-1": {
+			ResponseDate:     responseDate,
+			Records:          []*oaipmh.Record{record("2301.00003")},
+			CompleteListSize: 3,
+		},
+	}
+}
+
+func TestService_Harvest_Incremental(t *testing.T) {
+	// Arrange
+	oai := &mockOAIService{pages: twoPages()}
+	papers := &mockPaperStore{}
+	states := harvestRepo.NewMemoryRepository()
+	svc := New(oai, papers, states)
+
+	var progress []Progress
+	req := &HarvestRequest{Set: "cs", OnPage: func(p Progress) { progress = append(progress, p) }}
+
+	// Act
+	result, err := svc.Harvest(context.Background(), req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !result.Complete || result.Pages != 2 || result.Records != 2 || result.Deleted != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if len(progress) != 2 || progress[1].CompleteListSize != 3 {
+		t.Errorf("Expected progress after each page, got: %+v", progress)
+	}
+	if !oai.requests[0].From.IsZero() || oai.requests[0].MetadataPrefix != oaipmh.FormatArXiv {
+		t.Errorf("Expected first run to harvest everything in arXiv format, got: %+v", oai.requests[0])
+	}
+
+	if len(papers.papers) != 2 {
+		t.Fatalf("Expected 2 papers stored, got: %d", len(papers.papers))
+	}
+	p := papers.papers[0]
+	if p.ID != "2301.00001" || p.PrimaryCategory != "cs.LG" || p.PDFURL != "https://arxiv.org/pdf/2301.00001" {
+		t.Errorf("Unexpected paper: %+v", p)
+	}
+	if !p.Updated.Equal(p.Published) {
+		t.Errorf("Expected updated to default to published, got: %v", p.Updated)
+	}
+	if len(p.AuthorDetails) != 1 || p.AuthorDetails[0].Affiliations[0] != "MIT" {
+		t.Errorf("Unexpected author details: %+v", p.AuthorDetails)
+	}
+
+	expected := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	if !result.Watermark.Equal(expected) {
+		t.Errorf("Expected watermark %v, got: %v", expected, result.Watermark)
+	}
+	status, _ := svc.Status(context.Background(), "cs", "")
+	if status == nil || status.InProgress || status.RecordsHarvested != 2 || !status.Watermark.Equal(expected) {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+}
+
+func TestService_Harvest_UsesWatermark(t *testing.T) {
+	// Arrange
+	oai := &mockOAIService{pages: twoPages()}
+	states := harvestRepo.NewMemoryRepository()
+	watermark := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	states.Save(context.Background(), &harvestRepo.State{Set: "cs", MetadataPrefix: oaipmh.FormatArXiv, Watermark: watermark})
+	svc := New(oai, &mockPaperStore{}, states)
+
+	// Act
+	result, err := svc.Harvest(context.Background(), &HarvestRequest{Set: "cs"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !oai.requests[0].From.Equal(watermark) || oai.requests[0].Set != "cs" {
+		t.Errorf("Expected request from the watermark, got: %+v", oai.requests[0])
+	}
+	if !result.From.Equal(watermark) {
+		t.Errorf("Expected result to report the watermark it started from, got: %v", result.From)
+	}
+}
+
+func TestService_Harvest_MaxPagesThenResume(t *testing.T) {
+	// Arrange
+	oai := &mockOAIService{pages: twoPages()}
+	papers := &mockPaperStore{}
+	svc := New(oai, papers, harvestRepo.NewMemoryRepository())
+
+	// Act
+	first, err := svc.Harvest(context.Background(), &HarvestRequest{Set: "cs", MaxPages: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	status, _ := svc.Status(context.Background(), "cs", "")
+	second, err := svc.Harvest(context.Background(), &HarvestRequest{Set: "cs"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if first.Complete || first.Pages != 1 || !first.Watermark.IsZero() {
+		t.Errorf("Expected incomplete first run without watermark, got: %+v", first)
+	}
+	if status == nil || !status.InProgress {
+		t.Errorf("Expected run in progress, got: %+v", status)
+	}
+	if !second.Resumed || !second.Complete || second.Pages != 1 {
+		t.Errorf("Expected resumed run to finish, got: %+v", second)
+	}
+	if oai.requests[1].ResumptionToken != "token-1" {
+		t.Errorf("Expected resume with token-1, got: %+v", oai.requests[1])
+	}
+	if len(papers.papers) != 2 {
+		t.Errorf("Expected 2 papers stored, got: %d", len(papers.papers))
+	}
+	if !second.Watermark.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected watermark from the first run's start, got: %v", second.Watermark)
+	}
+}
+
+func TestService_Harvest_ExpiredTokenRestarts(t *testing.T) {
+	// Arrange
+	oai := &mockOAIService{pages: twoPages()}
+	states := harvestRepo.NewMemoryRepository()
+	watermark := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	states.Save(context.Background(), &harvestRepo.State{
+		MetadataPrefix:  oaipmh.FormatArXiv,
+		Watermark:       watermark,
+		ResumptionToken: "expired",
+	})
+	svc := New(oai, &mockPaperStore{}, states)
+
+	// Act
+	result, err := svc.Harvest(context.Background(), &HarvestRequest{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(oai.requests) != 3 {
+		t.Fatalf("Expected expired token then a full restart, got: %d requests", len(oai.requests))
+	}
+	if oai.requests[1].ResumptionToken != "" || !oai.requests[1].From.Equal(watermark) {
+		t.Errorf("Expected restart from the watermark, got: %+v", oai.requests[1])
+	}
+	if result.Resumed || !result.Complete {
+		t.Errorf("Expected a complete fresh run, got: %+v", result)
+	}
+}
+
+func TestService_Harvest_BackfillKeepsState(t *testing.T) {
+	// Arrange
+	oai := &mockOAIService{pages: twoPages()}
+	states := harvestRepo.NewMemoryRepository()
+	svc := New(oai, &mockPaperStore{}, states)
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	result, err := svc.Harvest(context.Background(), &HarvestRequest{From: from, Until: until})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !result.Complete || !result.Watermark.IsZero() {
+		t.Errorf("Expected complete backfill without watermark, got: %+v", result)
+	}
+	if !oai.requests[0].From.Equal(from) || !oai.requests[0].Until.Equal(until) {
+		t.Errorf("Expected backfill range in request, got: %+v", oai.requests[0])
+	}
+	if status, _ := svc.Status(context.Background(), "", ""); status != nil {
+		t.Errorf("Expected no stored state, got: %+v", status)
+	}
+}
+
+func TestService_Harvest_StoreError(t *testing.T) {
+	// Arrange
+	storeErr := errors.New("disk full")
+	states := harvestRepo.NewMemoryRepository()
+	svc := New(&mockOAIService{pages: twoPages()}, &mockPaperStore{err: storeErr}, states)
+
+	// Act
+	_, err := svc.Harvest(context.Background(), &HarvestRequest{})
+
+	// Assert
+	if !errors.Is(err, storeErr) {
+		t.Errorf("Expected store error, got: %v", err)
+	}
+	if _, err := states.Get(context.Background(), "", oaipmh.FormatArXiv); !errors.Is(err, harvestRepo.ErrStateNotFound) {
+		t.Errorf("Expected state not to advance past an unstored page, got: %v", err)
+	}
+}
+
+func TestService_Harvest_InvalidRequest(t *testing.T) {
+	svc := New(&mockOAIService{}, &mockPaperStore{}, harvestRepo.NewMemoryRepository())
+	tests := []struct {
+		name string
+		req  *HarvestRequest
+	}{
+		{"nil request", nil},
+		{"unknown format", &HarvestRequest{MetadataPrefix: "oai_dc"}},
+		{"reversed range", &HarvestRequest{
+			From:  time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"negative max pages", &HarvestRequest{MaxPages: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Harvest(context.Background(), tt.req)
+			if !IsInvalidRequest(err) {
+				t.Errorf("Expected ErrInvalidRequest, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package history
+
+import (
+	"context"
+
+	historyRepo "github.com/rrlian/papertok/backend/internal/repository/history"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// historyStore defines the repository capabilities required for reading history.
+type historyStore interface {
+	// Get retrieves a user's entry for a paper.
+	Get(ctx context.Context, userID int64, paperID string) (*historyRepo.Entry, error)
+
+	// Save creates or replaces an entry.
+	Save(ctx context.Context, entry *historyRepo.Entry) error
+
+	// List returns a page of a user's entries and their total number.
+	List(ctx context.Context, userID int64, offset, limit int) ([]*historyRepo.Entry, int, error)
+
+	// Seen returns which of the given papers a user has read.
+	Seen(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error)
+
+	// Delete removes a user's entry for a paper.
+	Delete(ctx context.Context, userID int64, paperID string) error
+
+	// Clear removes all of a user's entries.
+	Clear(ctx context.Context, userID int64) (int, error)
+}
+
+// paperStore defines the repository capability used to hydrate viewed papers.
+type paperStore interface {
+	// GetByID retrieves a single paper by ID.
+	GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool)
+}
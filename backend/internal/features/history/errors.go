@@ -0,0 +1,30 @@
+package history
+
+import "errors"
+
+var (
+	// ErrInvalidID indicates that a paper ID is malformed.
+	ErrInvalidID = errors.New("invalid paper ID")
+
+	// ErrInvalidView indicates that a view report has negative time, a scroll
+	// depth outside [0, 1] or a negative PDF page.
+	ErrInvalidView = errors.New("invalid view report")
+
+	// ErrPaperNotFound indicates that the viewed paper is not stored.
+	ErrPaperNotFound = errors.New("paper not found")
+
+	// ErrNotInHistory indicates that the paper is not in the user's history.
+	ErrNotInHistory = errors.New("paper is not in history")
+)
+
+// IsInvalidID checks if the error is ErrInvalidID.
+func IsInvalidID(err error) bool { return errors.Is(err, ErrInvalidID) }
+
+// IsInvalidView checks if the error is ErrInvalidView.
+func IsInvalidView(err error) bool { return errors.Is(err, ErrInvalidView) }
+
+// IsPaperNotFound checks if the error is ErrPaperNotFound.
+func IsPaperNotFound(err error) bool { return errors.Is(err, ErrPaperNotFound) }
+
+// IsNotInHistory checks if the error is ErrNotInHistory.
+func IsNotInHistory(err error) bool { return errors.Is(err, ErrNotInHistory) }
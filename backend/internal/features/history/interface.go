@@ -0,0 +1,117 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// Limits on list pages.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Paper is a paper in the reading history, as stored by the paper repository.
+type Paper = paperRepo.Paper
+
+// View is a report from a client reading a paper. Clients report when a
+// paper is opened and then periodically or when it is closed.
+type View struct {
+	UserID      int64
+	PaperID     string        // Paper ID; any version suffix is ignored
+	Dwell       time.Duration // Time spent reading since the previous report
+	ScrollDepth float64       // Scroll position reached, 0 to 1
+	PDFPage     int           // PDF page reached, 0 if the PDF is not open
+}
+
+// Entry is a user's reading history for one paper.
+type Entry struct {
+	PaperID       string    `json:"paperId"`
+	FirstViewedAt time.Time `json:"firstViewedAt"`
+	LastViewedAt  time.Time `json:"lastViewedAt"`
+	Views         int       `json:"views"`        // Reading sessions
+	DwellSeconds  int       `json:"dwellSeconds"` // Total time spent reading
+	ScrollDepth   float64   `json:"scrollDepth"`  // Furthest scroll position reached
+	PDFPage       int       `json:"pdfPage"`      // Last PDF page reached
+	Paper         *Paper    `json:"paper,omitempty"`
+}
+
+// Group is the entries last viewed on one day.
+type Group struct {
+	Date    string   `json:"date"` // YYYY-MM-DD in the requested time zone
+	Entries []*Entry `json:"entries"`
+}
+
+// ListRequest selects a page of a user's history.
+type ListRequest struct {
+	UserID   int64
+	Offset   int
+	Limit    int            // DefaultLimit if zero, at most MaxLimit
+	Location *time.Location // Time zone for grouping by day (UTC if nil)
+}
+
+// ListResult is a page of history grouped by day, most recent first.
+// A day can continue on the next page.
+type ListResult struct {
+	Groups []*Group
+	Total  int // The user's total number of entries
+}
+
+// Config contains the history settings.
+type Config struct {
+	SessionGap time.Duration    // A report after this much inactivity starts a new view (default 30m)
+	MaxDwell   time.Duration    // Time credited per report at most (default 1h)
+	Now        func() time.Time // Clock (time.Now if nil)
+}
+
+// Service defines the interface for reading history.
+type Service interface {
+	// Record adds a view report to a user's history.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - view: the user, paper and reading progress
+	// @Returns:
+	//   - *Entry: the updated entry with its paper
+	//   - error: ErrInvalidID if the ID is malformed, ErrInvalidView if the progress is out of range,
+	//     ErrPaperNotFound if the paper is not stored
+	Record(ctx context.Context, view *View) (*Entry, error)
+
+	// List returns a page of a user's history grouped by the day each paper was last viewed.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - req: user, paging and time zone
+	// @Returns:
+	//   - *ListResult: groups and the user's total
+	//   - error: if the history cannot be read
+	List(ctx context.Context, req *ListRequest) (*ListResult, error)
+
+	// Delete removes a paper from a user's history.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - paperID: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - error: ErrInvalidID if the ID is malformed, ErrNotInHistory if the paper was not viewed
+	Delete(ctx context.Context, userID int64, paperID string) error
+
+	// Clear removes a user's whole history.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	// @Returns:
+	//   - int: number of entries removed
+	//   - error: if the history cannot be cleared
+	Clear(ctx context.Context, userID int64) (int, error)
+
+	// Seen reports which of the given papers a user has viewed.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - paperIDs: paper IDs
+	// @Returns:
+	//   - map[string]bool: true for viewed papers, keyed by the IDs as given
+	//   - error: ErrInvalidID if an ID is malformed
+	Seen(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error)
+}
@@ -0,0 +1,187 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	historyRepo "github.com/rrlian/papertok/backend/internal/repository/history"
+)
+
+// Default settings used when Config leaves them zero.
+const (
+	defaultSessionGap = 30 * time.Minute
+	defaultMaxDwell   = time.Hour
+)
+
+// Impl implements the history Service interface.
+type Impl struct {
+	entries    historyStore
+	papers     paperStore
+	sessionGap time.Duration
+	maxDwell   time.Duration
+	now        func() time.Time
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new history service instance.
+func New(entries historyStore, papers paperStore, cfg Config) *Impl {
+	s := &Impl{
+		entries:    entries,
+		papers:     papers,
+		sessionGap: cfg.SessionGap,
+		maxDwell:   cfg.MaxDwell,
+		now:        cfg.Now,
+	}
+	if s.sessionGap <= 0 {
+		s.sessionGap = defaultSessionGap
+	}
+	if s.maxDwell <= 0 {
+		s.maxDwell = defaultMaxDwell
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	return s
+}
+
+// Record adds a view report to a user's history.
+func (s *Impl) Record(ctx context.Context, view *View) (*Entry, error) {
+	id, err := arxiv.BaseID(view.PaperID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	if view.Dwell < 0 || view.ScrollDepth < 0 || view.ScrollDepth > 1 || view.PDFPage < 0 {
+		return nil, fmt.Errorf("%w: dwell %v, scroll depth %v, PDF page %d",
+			ErrInvalidView, view.Dwell, view.ScrollDepth, view.PDFPage)
+	}
+	paper, ok := s.papers.GetByID(ctx, id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPaperNotFound, id)
+	}
+
+	now := s.now().UTC()
+	entry, err := s.entries.Get(ctx, view.UserID, id)
+	switch {
+	case errors.Is(err, historyRepo.ErrEntryNotFound):
+		entry = &historyRepo.Entry{UserID: view.UserID, PaperID: id, FirstViewedAt: now, Views: 1}
+	case err != nil:
+		return nil, err
+	case now.Sub(entry.LastViewedAt) > s.sessionGap:
+		entry.Views++
+	}
+
+	entry.LastViewedAt = now
+	entry.DwellSeconds += int(min(view.Dwell, s.maxDwell) / time.Second)
+	entry.ScrollDepth = max(entry.ScrollDepth, view.ScrollDepth)
+	if view.PDFPage > 0 {
+		entry.PDFPage = view.PDFPage
+	}
+	if err := s.entries.Save(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	result := convertEntry(entry)
+	result.Paper = paper
+	return result, nil
+}
+
+// List returns a page of a user's history grouped by day.
+func (s *Impl) List(ctx context.Context, req *ListRequest) (*ListResult, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	loc := req.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	stored, total, err := s.entries.List(ctx, req.UserID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{Groups: []*Group{}, Total: total}
+	for _, e := range stored {
+		entry := convertEntry(e)
+		// Papers cached without a database may have expired since they were viewed.
+		if p, ok := s.papers.GetByID(ctx, e.PaperID); ok {
+			entry.Paper = p
+		}
+
+		date := e.LastViewedAt.In(loc).Format(time.DateOnly)
+		if n := len(result.Groups); n == 0 || result.Groups[n-1].Date != date {
+			result.Groups = append(result.Groups, &Group{Date: date})
+		}
+		group := result.Groups[len(result.Groups)-1]
+		group.Entries = append(group.Entries, entry)
+	}
+	return result, nil
+}
+
+// Delete removes a paper from a user's history.
+func (s *Impl) Delete(ctx context.Context, userID int64, paperID string) error {
+	id, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	if err := s.entries.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, historyRepo.ErrEntryNotFound) {
+			return fmt.Errorf("%w: %s", ErrNotInHistory, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// Clear removes a user's whole history.
+func (s *Impl) Clear(ctx context.Context, userID int64) (int, error) {
+	return s.entries.Clear(ctx, userID)
+}
+
+// Seen reports which of the given papers a user has viewed.
+func (s *Impl) Seen(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error) {
+	ids := make([]string, len(paperIDs))
+	for i, paperID := range paperIDs {
+		id, err := arxiv.BaseID(paperID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+		}
+		ids[i] = id
+	}
+
+	found, err := s.entries.Seen(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(paperIDs))
+	for i, paperID := range paperIDs {
+		seen[paperID] = found[ids[i]]
+	}
+	return seen, nil
+}
+
+// convertEntry converts a repository entry to a history entry without its paper.
+func convertEntry(e *historyRepo.Entry) *Entry {
+	return &Entry{
+		PaperID:       e.PaperID,
+		FirstViewedAt: e.FirstViewedAt,
+		LastViewedAt:  e.LastViewedAt,
+		Views:         e.Views,
+		DwellSeconds:  e.DwellSeconds,
+		ScrollDepth:   e.ScrollDepth,
+		PDFPage:       e.PDFPage,
+	}
+}
@@ -0,0 +1,266 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	historyRepo "github.com/rrlian/papertok/backend/internal/repository/history"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockPapers is the paper cache; deleting an entry expires that paper.
+type mockPapers map[string]*paperRepo.Paper
+
+func (m mockPapers) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	p, ok := m[id]
+	return p, ok
+}
+
+// testClock is a settable clock.
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func newTestService() (*Impl, mockPapers, *testClock) {
+	papers := mockPapers{
+		"2401.00001": {ID: "2401.00001", Title: "First"},
+		"2401.00002": {ID: "2401.00002"},
+		"2401.00003": {ID: "2401.00003"},
+	}
+	clock := &testClock{now: time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC)}
+	return New(historyRepo.NewMemoryRepository(), papers, Config{Now: clock.Now}), papers, clock
+}
+
+func TestImpl_Record(t *testing.T) {
+	// Arrange
+	svc, _, clock := newTestService()
+	ctx := context.Background()
+	opened := clock.now
+
+	// Act: open, read on (same session), then come back the next day
+	svc.Record(ctx, &View{UserID: 1, PaperID: "2401.00001v2", ScrollDepth: 0.1})
+	clock.now = opened.Add(5 * time.Minute)
+	svc.Record(ctx, &View{UserID: 1, PaperID: "2401.00001", Dwell: 5 * time.Minute, ScrollDepth: 0.8, PDFPage: 4})
+	clock.now = opened.Add(24 * time.Hour)
+	entry, err := svc.Record(ctx, &View{UserID: 1, PaperID: "2401.00001", Dwell: 3 * time.Hour, ScrollDepth: 0.2})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if entry.PaperID != "2401.00001" || entry.Paper == nil || entry.Paper.Title != "First" {
+		t.Errorf("Expected entry on the base ID with its paper, got: %+v", entry)
+	}
+	if entry.Views != 2 {
+		t.Errorf("Expected 2 views, got: %d", entry.Views)
+	}
+	if want := int((5*time.Minute + time.Hour) / time.Second); entry.DwellSeconds != want {
+		t.Errorf("Expected %d dwell seconds with the long report capped, got: %d", want, entry.DwellSeconds)
+	}
+	if entry.ScrollDepth != 0.8 || entry.PDFPage != 4 {
+		t.Errorf("Expected furthest scroll 0.8 and last PDF page 4, got: %v, %d", entry.ScrollDepth, entry.PDFPage)
+	}
+	if !entry.FirstViewedAt.Equal(opened) || !entry.LastViewedAt.Equal(clock.now) {
+		t.Errorf("Expected first %v and last %v, got: %v, %v", opened, clock.now, entry.FirstViewedAt, entry.LastViewedAt)
+	}
+}
+
+func TestImpl_List(t *testing.T) {
+	// Arrange
+	svc, papers, clock := newTestService()
+	ctx := context.Background()
+	start := clock.now // 2024-03-10 23:30 UTC
+	for i, id := range []string{"2401.00001", "2401.00002", "2401.00003"} {
+		clock.now = start.Add(time.Duration(i) * time.Hour)
+		svc.Record(ctx, &View{UserID: 1, PaperID: id})
+	}
+	svc.Record(ctx, &View{UserID: 2, PaperID: "2401.00001"})
+	delete(papers, "2401.00003")
+	shanghai := time.FixedZone("UTC+8", 8*60*60)
+
+	tests := []struct {
+		name    string
+		req     *ListRequest
+		want    [][]string
+		wantDay []string
+	}{
+		{
+			name:    "utc",
+			req:     &ListRequest{UserID: 1},
+			want:    [][]string{{"2401.00003", "2401.00002"}, {"2401.00001"}},
+			wantDay: []string{"2024-03-11", "2024-03-10"},
+		},
+		{
+			name:    "time zone",
+			req:     &ListRequest{UserID: 1, Location: shanghai},
+			want:    [][]string{{"2401.00003", "2401.00002", "2401.00001"}},
+			wantDay: []string{"2024-03-11"},
+		},
+		{
+			name:    "paged",
+			req:     &ListRequest{UserID: 1, Offset: 1, Limit: 1},
+			want:    [][]string{{"2401.00002"}},
+			wantDay: []string{"2024-03-11"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := svc.List(ctx, tt.req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.Total != 3 {
+				t.Errorf("Expected total 3, got: %d", result.Total)
+			}
+			if len(result.Groups) != len(tt.want) {
+				t.Fatalf("Expected %d groups, got: %d", len(tt.want), len(result.Groups))
+			}
+			for i, g := range result.Groups {
+				if g.Date != tt.wantDay[i] {
+					t.Errorf("Expected group %d on %s, got: %s", i, tt.wantDay[i], g.Date)
+				}
+				if len(g.Entries) != len(tt.want[i]) {
+					t.Fatalf("Expected group %d to have %v, got %d entries", i, tt.want[i], len(g.Entries))
+				}
+				for j, e := range g.Entries {
+					if e.PaperID != tt.want[i][j] {
+						t.Errorf("Expected group %d entry %d to be %s, got: %s", i, j, tt.want[i][j], e.PaperID)
+					}
+					if e.Paper == nil && e.PaperID != "2401.00003" {
+						t.Errorf("Expected stored paper %s to be hydrated", e.PaperID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestImpl_DeleteAndClear(t *testing.T) {
+	// Arrange
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	for _, id := range []string{"2401.00001", "2401.00002", "2401.00003"} {
+		svc.Record(ctx, &View{UserID: 1, PaperID: id})
+	}
+	svc.Record(ctx, &View{UserID: 2, PaperID: "2401.00001"})
+
+	// Act
+	err := svc.Delete(ctx, 1, "2401.00001v3")
+	errAgain := svc.Delete(ctx, 1, "2401.00001")
+	cleared, clearErr := svc.Clear(ctx, 1)
+
+	// Assert
+	if err != nil || clearErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v", err, clearErr)
+	}
+	if !IsNotInHistory(errAgain) {
+		t.Errorf("Expected ErrNotInHistory, got: %v", errAgain)
+	}
+	if cleared != 2 {
+		t.Errorf("Expected 2 entries cleared, got: %d", cleared)
+	}
+	if result, _ := svc.List(ctx, &ListRequest{UserID: 2}); result.Total != 1 {
+		t.Errorf("Expected other user's history to be kept, got total: %d", result.Total)
+	}
+}
+
+func TestImpl_Seen(t *testing.T) {
+	// Arrange
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	svc.Record(ctx, &View{UserID: 1, PaperID: "2401.00001"})
+	svc.Record(ctx, &View{UserID: 2, PaperID: "2401.00002"})
+
+	// Act
+	seen, err := svc.Seen(ctx, 1, []string{"2401.00001v1", "2401.00002"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !seen["2401.00001v1"] || seen["2401.00002"] {
+		t.Errorf("Expected only 2401.00001v1 to be seen, got: %v", seen)
+	}
+}
+
+func TestImpl_Errors(t *testing.T) {
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		call    func() error
+		checkFn func(error) bool
+	}{
+		{
+			name: "record invalid ID",
+			call: func() error {
+				_, err := svc.Record(ctx, &View{UserID: 1, PaperID: "not-an-id"})
+				return err
+			},
+			checkFn: IsInvalidID,
+		},
+		{
+			name: "record unknown paper",
+			call: func() error {
+				_, err := svc.Record(ctx, &View{UserID: 1, PaperID: "2401.99999"})
+				return err
+			},
+			checkFn: IsPaperNotFound,
+		},
+		{
+			name: "record negative dwell",
+			call: func() error {
+				_, err := svc.Record(ctx, &View{UserID: 1, PaperID: "2401.00001", Dwell: -time.Second})
+				return err
+			},
+			checkFn: IsInvalidView,
+		},
+		{
+			name: "record scroll beyond end",
+			call: func() error {
+				_, err := svc.Record(ctx, &View{UserID: 1, PaperID: "2401.00001", ScrollDepth: 1.5})
+				return err
+			},
+			checkFn: IsInvalidView,
+		},
+		{
+			name: "record negative page",
+			call: func() error {
+				_, err := svc.Record(ctx, &View{UserID: 1, PaperID: "2401.00001", PDFPage: -1})
+				return err
+			},
+			checkFn: IsInvalidView,
+		},
+		{
+			name:    "delete invalid ID",
+			call:    func() error { return svc.Delete(ctx, 1, "bad id") },
+			checkFn: IsInvalidID,
+		},
+		{
+			name: "seen invalid ID",
+			call: func() error {
+				_, err := svc.Seen(ctx, 1, []string{"2401.00001", "bad id"})
+				return err
+			},
+			checkFn: IsInvalidID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.call()
+
+			// Assert
+			if !tt.checkFn(err) {
+				t.Errorf("Expected matching error, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package likes
+
+import (
+	"context"
+
+	likeRepo "github.com/rrlian/papertok/backend/internal/repository/like"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// likeStore defines the repository capabilities required for likes.
+type likeStore interface {
+	// Add records a like, reporting whether it was newly added.
+	Add(ctx context.Context, l *likeRepo.Like) (bool, error)
+
+	// Remove deletes a like, reporting whether it existed.
+	Remove(ctx context.Context, userID int64, paperID string) (bool, error)
+
+	// Counts returns the like counts of the given papers.
+	Counts(ctx context.Context, paperIDs []string) (map[string]int, error)
+
+	// Find returns the given papers that a user likes.
+	Find(ctx context.Context, userID int64, paperIDs []string) (map[string]*likeRepo.Like, error)
+
+	// ListByUser returns a user's most recent likes.
+	ListByUser(ctx context.Context, userID int64, limit int) ([]*likeRepo.Like, error)
+}
+
+// paperStore defines the repository capability used to check and hydrate liked papers.
+type paperStore interface {
+	// GetByID retrieves a single paper by ID.
+	GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool)
+}
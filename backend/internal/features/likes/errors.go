@@ -0,0 +1,17 @@
+package likes
+
+import "errors"
+
+var (
+	// ErrInvalidID indicates that a paper ID is malformed.
+	ErrInvalidID = errors.New("invalid paper ID")
+
+	// ErrPaperNotFound indicates that the paper to like is not stored.
+	ErrPaperNotFound = errors.New("paper not found")
+)
+
+// IsInvalidID checks if the error is ErrInvalidID.
+func IsInvalidID(err error) bool { return errors.Is(err, ErrInvalidID) }
+
+// IsPaperNotFound checks if the error is ErrPaperNotFound.
+func IsPaperNotFound(err error) bool { return errors.Is(err, ErrPaperNotFound) }
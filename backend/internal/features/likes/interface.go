@@ -0,0 +1,84 @@
+package likes
+
+import (
+	"context"
+	"time"
+)
+
+// Status is a user's like state for a paper together with its like count.
+type Status struct {
+	PaperID   string `json:"paperId"`
+	Liked     bool   `json:"liked"`
+	LikeCount int    `json:"likeCount"`
+}
+
+// Paper represents a liked paper.
+type Paper struct {
+	ID              string
+	Title           string
+	Authors         []string
+	Categories      []string
+	PrimaryCategory string
+}
+
+// Like is a paper liked by a user.
+type Like struct {
+	PaperID   string
+	CreatedAt time.Time
+	Paper     *Paper // Nil if the paper is not stored
+}
+
+// Service defines the interface for paper likes.
+type Service interface {
+	// Like records that a user likes a paper. Liking a paper twice is not an error.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - paperID: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - *Status: the like state after the change
+	//   - bool: true if the like was added, false if it already existed
+	//   - error: ErrInvalidID if the ID is malformed, ErrPaperNotFound if the paper is not stored
+	Like(ctx context.Context, userID int64, paperID string) (*Status, bool, error)
+
+	// Unlike removes a user's like of a paper. Unliking a paper that is not
+	// liked is not an error.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - paperID: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - *Status: the like state after the change
+	//   - bool: true if the like was removed, false if there was none
+	//   - error: ErrInvalidID if the ID is malformed
+	Unlike(ctx context.Context, userID int64, paperID string) (*Status, bool, error)
+
+	// Status returns whether a user likes a paper and its like count.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the signed-in user
+	//   - paperID: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - *Status: the like state
+	//   - error: ErrInvalidID if the ID is malformed
+	Status(ctx context.Context, userID int64, paperID string) (*Status, error)
+
+	// Counts returns the like counts of several papers with one store lookup.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - paperIDs: paper IDs; version suffixes are ignored
+	// @Returns:
+	//   - map[string]int: like counts keyed by the IDs as given; papers without likes are omitted
+	//   - error: ErrInvalidID if an ID is malformed
+	Counts(ctx context.Context, paperIDs []string) (map[string]int, error)
+
+	// Recent returns a user's most recent likes with their papers, newest first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	//   - limit: maximum number of likes
+	// @Returns:
+	//   - []*Like: likes; Paper is nil for papers no longer stored
+	//   - error: error if the store fails
+	Recent(ctx context.Context, userID int64, limit int) ([]*Like, error)
+}
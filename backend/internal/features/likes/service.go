@@ -0,0 +1,128 @@
+package likes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	likeRepo "github.com/rrlian/papertok/backend/internal/repository/like"
+)
+
+// Impl implements the likes Service interface.
+type Impl struct {
+	likes  likeStore
+	papers paperStore
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new likes service instance.
+func New(likes likeStore, papers paperStore) *Impl {
+	return &Impl{
+		likes:  likes,
+		papers: papers,
+	}
+}
+
+// Like records that a user likes a paper. Only stored papers can be liked,
+// so made-up IDs cannot gain counts.
+func (s *Impl) Like(ctx context.Context, userID int64, paperID string) (*Status, bool, error) {
+	id, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	if _, ok := s.papers.GetByID(ctx, id); !ok {
+		return nil, false, fmt.Errorf("%w: %s", ErrPaperNotFound, id)
+	}
+	added, err := s.likes.Add(ctx, &likeRepo.Like{UserID: userID, PaperID: id})
+	if err != nil {
+		return nil, false, err
+	}
+	status, err := s.status(ctx, id, true)
+	return status, added, err
+}
+
+// Unlike removes a user's like of a paper.
+func (s *Impl) Unlike(ctx context.Context, userID int64, paperID string) (*Status, bool, error) {
+	id, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	removed, err := s.likes.Remove(ctx, userID, id)
+	if err != nil {
+		return nil, false, err
+	}
+	status, err := s.status(ctx, id, false)
+	return status, removed, err
+}
+
+// Status returns whether a user likes a paper and its like count.
+func (s *Impl) Status(ctx context.Context, userID int64, paperID string) (*Status, error) {
+	id, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	found, err := s.likes.Find(ctx, userID, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	_, liked := found[id]
+	return s.status(ctx, id, liked)
+}
+
+// Counts returns the like counts of several papers.
+func (s *Impl) Counts(ctx context.Context, paperIDs []string) (map[string]int, error) {
+	ids := make([]string, len(paperIDs))
+	for i, paperID := range paperIDs {
+		id, err := arxiv.BaseID(paperID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+		}
+		ids[i] = id
+	}
+
+	stored, err := s.likes.Counts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(stored))
+	for i, paperID := range paperIDs {
+		if n, ok := stored[ids[i]]; ok {
+			counts[paperID] = n
+		}
+	}
+	return counts, nil
+}
+
+// Recent returns a user's most recent likes with their papers.
+func (s *Impl) Recent(ctx context.Context, userID int64, limit int) ([]*Like, error) {
+	stored, err := s.likes.ListByUser(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Like, len(stored))
+	for i, l := range stored {
+		result[i] = &Like{PaperID: l.PaperID, CreatedAt: l.CreatedAt}
+		if p, ok := s.papers.GetByID(ctx, l.PaperID); ok {
+			result[i].Paper = &Paper{
+				ID:              p.ID,
+				Title:           p.Title,
+				Authors:         p.Authors,
+				Categories:      p.Categories,
+				PrimaryCategory: p.PrimaryCategory,
+			}
+		}
+	}
+	return result, nil
+}
+
+// status builds the like state of a paper with its current count.
+func (s *Impl) status(ctx context.Context, id string, liked bool) (*Status, error) {
+	counts, err := s.likes.Counts(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	return &Status{PaperID: id, Liked: liked, LikeCount: counts[id]}, nil
+}
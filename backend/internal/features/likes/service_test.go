@@ -0,0 +1,189 @@
+package likes
+
+import (
+	"context"
+	"testing"
+
+	likeRepo "github.com/rrlian/papertok/backend/internal/repository/like"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// countingStore wraps the memory repository and counts Counts lookups.
+type countingStore struct {
+	*likeRepo.MemoryRepository
+	countCalls int
+}
+
+func (s *countingStore) Counts(ctx context.Context, paperIDs []string) (map[string]int, error) {
+	s.countCalls++
+	return s.MemoryRepository.Counts(ctx, paperIDs)
+}
+
+// mockPapers holds the papers that may be liked; Recent deletes one to expire it.
+type mockPapers map[string]*paperRepo.Paper
+
+func (m mockPapers) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	p, ok := m[id]
+	return p, ok
+}
+
+func newTestService() (*Impl, *countingStore) {
+	store := &countingStore{MemoryRepository: likeRepo.NewMemoryRepository()}
+	papers := mockPapers{
+		"2401.00001":     {ID: "2401.00001"},
+		"2401.00002":     {ID: "2401.00002"},
+		"hep-th/9901001": {ID: "hep-th/9901001"},
+	}
+	return New(store, papers), store
+}
+
+func TestImpl_Like(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	// Act
+	first, added, err := svc.Like(ctx, 1, "2401.00001v2")
+	again, addedAgain, errAgain := svc.Like(ctx, 1, "2401.00001")
+	other, _, _ := svc.Like(ctx, 2, "2401.00001")
+
+	// Assert
+	if err != nil || errAgain != nil {
+		t.Fatalf("Expected no error, got: %v, %v", err, errAgain)
+	}
+	if !added || addedAgain {
+		t.Errorf("Expected first like to be added and second to be a no-op, got: %v, %v", added, addedAgain)
+	}
+	if first.PaperID != "2401.00001" || !first.Liked || first.LikeCount != 1 {
+		t.Errorf("Expected liked with count 1 on the base ID, got: %+v", first)
+	}
+	if again.LikeCount != 1 {
+		t.Errorf("Expected repeated like not to count, got: %+v", again)
+	}
+	if other.LikeCount != 2 {
+		t.Errorf("Expected count 2 after a second user, got: %+v", other)
+	}
+}
+
+func TestImpl_Unlike(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+	svc.Like(ctx, 1, "2401.00001")
+	svc.Like(ctx, 2, "2401.00001")
+
+	// Act
+	status, removed, err := svc.Unlike(ctx, 1, "2401.00001")
+	_, removedAgain, _ := svc.Unlike(ctx, 1, "2401.00001")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !removed || removedAgain {
+		t.Errorf("Expected first unlike to remove and second to be a no-op, got: %v, %v", removed, removedAgain)
+	}
+	if status.Liked || status.LikeCount != 1 {
+		t.Errorf("Expected not liked with count 1, got: %+v", status)
+	}
+	if s, _ := svc.Status(ctx, 2, "2401.00001v1"); !s.Liked || s.LikeCount != 1 {
+		t.Errorf("Expected other user's like to remain, got: %+v", s)
+	}
+}
+
+func TestImpl_Counts(t *testing.T) {
+	// Arrange
+	svc, store := newTestService()
+	ctx := context.Background()
+	svc.Like(ctx, 1, "2401.00001")
+	svc.Like(ctx, 2, "2401.00001")
+	svc.Like(ctx, 1, "hep-th/9901001")
+	store.countCalls = 0
+
+	// Act
+	counts, err := svc.Counts(ctx, []string{"2401.00001v3", "hep-th/9901001", "2401.00002"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if counts["2401.00001v3"] != 2 || counts["hep-th/9901001"] != 1 {
+		t.Errorf("Expected counts keyed by the requested IDs, got: %v", counts)
+	}
+	if _, ok := counts["2401.00002"]; ok {
+		t.Errorf("Expected papers without likes to be omitted, got: %v", counts)
+	}
+	if store.countCalls != 1 {
+		t.Errorf("Expected a single store lookup, got: %d", store.countCalls)
+	}
+}
+
+func TestImpl_Recent(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+	svc.Like(ctx, 1, "2401.00001")
+	svc.Like(ctx, 1, "2401.00002")
+	delete(svc.papers.(mockPapers), "2401.00002")
+
+	// Act
+	recent, err := svc.Recent(ctx, 1, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(recent) != 2 {
+		t.Fatalf("Expected 2 likes, got: %d", len(recent))
+	}
+	for _, l := range recent {
+		if (l.Paper != nil) != (l.PaperID == "2401.00001") {
+			t.Errorf("Expected only the stored paper to be hydrated, got: %+v", l)
+		}
+	}
+}
+
+func TestImpl_Like_UnstoredPaper(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	// Act
+	_, added, err := svc.Like(ctx, 1, "2401.09999")
+	counts, _ := svc.Counts(ctx, []string{"2401.09999"})
+
+	// Assert
+	if !IsPaperNotFound(err) {
+		t.Fatalf("Expected ErrPaperNotFound, got: %v", err)
+	}
+	if added || len(counts) != 0 {
+		t.Errorf("Expected no like recorded, got: %v, %v", added, counts)
+	}
+}
+
+func TestImpl_InvalidID(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{name: "like", run: func() error { _, _, err := svc.Like(ctx, 1, "bad"); return err }},
+		{name: "unlike", run: func() error { _, _, err := svc.Unlike(ctx, 1, "bad"); return err }},
+		{name: "status", run: func() error { _, err := svc.Status(ctx, 1, "bad"); return err }},
+		{name: "counts", run: func() error { _, err := svc.Counts(ctx, []string{"2401.00001", "bad"}); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.run()
+
+			// Assert
+			if !IsInvalidID(err) {
+				t.Errorf("Expected ErrInvalidID, got: %v", err)
+			}
+		})
+	}
+}
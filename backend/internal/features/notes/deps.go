@@ -0,0 +1,36 @@
+package notes
+
+import (
+	"context"
+
+	noteRepo "github.com/rrlian/papertok/backend/internal/repository/note"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// noteStore defines the repository capabilities required for notes.
+type noteStore interface {
+	// Create stores a new note.
+	Create(ctx context.Context, n *noteRepo.Note) error
+
+	// Get retrieves a note by ID.
+	Get(ctx context.Context, id int64) (*noteRepo.Note, error)
+
+	// ListByPaper returns a user's notes on a paper, oldest first.
+	ListByPaper(ctx context.Context, userID int64, paperID string) ([]*noteRepo.Note, error)
+
+	// Update saves a note's body and anchor.
+	Update(ctx context.Context, n *noteRepo.Note) error
+
+	// Delete removes a note.
+	Delete(ctx context.Context, id int64) error
+
+	// Search returns a page of a user's notes containing every term.
+	Search(ctx context.Context, userID int64, terms []string, offset, limit int) ([]*noteRepo.Note, int, error)
+}
+
+// paperStore defines the repository capability used to check papers and
+// resolve abstract anchors.
+type paperStore interface {
+	// GetByID retrieves a single paper by ID.
+	GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool)
+}
@@ -0,0 +1,51 @@
+package notes
+
+import "errors"
+
+var (
+	// ErrNoteNotFound indicates that the note does not exist or belongs to
+	// another user or paper.
+	ErrNoteNotFound = errors.New("note not found")
+
+	// ErrInvalidID indicates that a paper ID is malformed.
+	ErrInvalidID = errors.New("invalid paper ID")
+
+	// ErrInvalidNote indicates that a body is too long, or empty on a note
+	// without an anchor.
+	ErrInvalidNote = errors.New("invalid note")
+
+	// ErrInvalidAnchor indicates that an anchor is outside the abstract or
+	// page, or does not set exactly one kind of anchor.
+	ErrInvalidAnchor = errors.New("invalid note anchor")
+
+	// ErrPaperNotFound indicates that the paper is not stored.
+	ErrPaperNotFound = errors.New("paper not found")
+
+	// ErrTooManyNotes indicates that the user already has MaxNotesPerPaper
+	// notes on the paper.
+	ErrTooManyNotes = errors.New("too many notes")
+
+	// ErrInvalidQuery indicates that a search query is empty or has too many terms.
+	ErrInvalidQuery = errors.New("invalid note query")
+)
+
+// IsNoteNotFound checks if the error is ErrNoteNotFound.
+func IsNoteNotFound(err error) bool { return errors.Is(err, ErrNoteNotFound) }
+
+// IsInvalidID checks if the error is ErrInvalidID.
+func IsInvalidID(err error) bool { return errors.Is(err, ErrInvalidID) }
+
+// IsInvalidNote checks if the error is ErrInvalidNote.
+func IsInvalidNote(err error) bool { return errors.Is(err, ErrInvalidNote) }
+
+// IsInvalidAnchor checks if the error is ErrInvalidAnchor.
+func IsInvalidAnchor(err error) bool { return errors.Is(err, ErrInvalidAnchor) }
+
+// IsPaperNotFound checks if the error is ErrPaperNotFound.
+func IsPaperNotFound(err error) bool { return errors.Is(err, ErrPaperNotFound) }
+
+// IsTooManyNotes checks if the error is ErrTooManyNotes.
+func IsTooManyNotes(err error) bool { return errors.Is(err, ErrTooManyNotes) }
+
+// IsInvalidQuery checks if the error is ErrInvalidQuery.
+func IsInvalidQuery(err error) bool { return errors.Is(err, ErrInvalidQuery) }
@@ -0,0 +1,158 @@
+package notes
+
+import (
+	"context"
+	"time"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// Limits on notes and searches.
+const (
+	MaxBodyLength    = 20000 // Characters
+	MaxNotesPerPaper = 200   // Per user
+	MaxRects         = 50    // Per PDF anchor
+	MaxQueryTerms    = 10
+	DefaultLimit     = 20 // Search results per page
+	MaxLimit         = 100
+)
+
+// Paper is the paper a note belongs to, as stored by the paper repository.
+type Paper = paperRepo.Paper
+
+// TextRange is a range of a paper's abstract.
+type TextRange struct {
+	Start int    `json:"start"` // First character, counted in Unicode code points
+	End   int    `json:"end"`   // End of the range (exclusive)
+	Quote string `json:"quote"` // The highlighted text; filled in by the service
+}
+
+// Rect is a region of a PDF page, as fractions of the page size measured
+// from the top-left corner.
+type Rect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// PDFRegion is a set of regions on one page of a paper's PDF.
+type PDFRegion struct {
+	Page  int    `json:"page"` // 1-based
+	Rects []Rect `json:"rects"`
+}
+
+// Anchor ties a note to part of a paper. Exactly one field is set.
+type Anchor struct {
+	Abstract *TextRange `json:"abstract,omitempty"`
+	PDF      *PDFRegion `json:"pdf,omitempty"`
+}
+
+// Note is a user's markdown note on a paper. An anchored note is a
+// highlight, and its body may be empty.
+type Note struct {
+	ID        int64     `json:"id"`
+	PaperID   string    `json:"paperId"`
+	Body      string    `json:"body"`             // Markdown
+	Anchor    *Anchor   `json:"anchor,omitempty"` // Nil for a note on the whole paper
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Paper     *Paper    `json:"paper,omitempty"` // Only filled in by Search; nil if the paper is no longer stored
+}
+
+// NoteRequest describes a new note.
+type NoteRequest struct {
+	Body   string
+	Anchor *Anchor // Optional
+}
+
+// UpdateRequest changes a note. Nil fields are left unchanged.
+type UpdateRequest struct {
+	Body        *string
+	Anchor      *Anchor // Replaces the anchor
+	ClearAnchor bool    // Turns a highlight into a note on the whole paper
+}
+
+// SearchRequest searches a user's own notes.
+type SearchRequest struct {
+	UserID int64
+	Query  string // Whitespace-separated terms, all of which must match
+	Offset int
+	Limit  int // DefaultLimit if zero, at most MaxLimit
+}
+
+// SearchResult is a page of matching notes.
+type SearchResult struct {
+	Notes []*Note
+	Total int
+}
+
+// Service defines the interface for paper notes and highlights.
+// Notes are private: other users' notes are reported as not found.
+type Service interface {
+	// Create adds a note to a stored paper.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the author
+	//   - paperID: paper ID; any version suffix is ignored
+	//   - req: body and optional anchor
+	// @Returns:
+	//   - *Note: the new note, with the quote of an abstract anchor filled in
+	//   - error: ErrInvalidID, ErrInvalidNote if the body is too long or empty without an anchor,
+	//     ErrInvalidAnchor, ErrPaperNotFound if the paper is not stored, ErrTooManyNotes at MaxNotesPerPaper
+	Create(ctx context.Context, userID int64, paperID string, req *NoteRequest) (*Note, error)
+
+	// List returns a user's notes on a paper, oldest first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the author
+	//   - paperID: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - []*Note: the notes
+	//   - error: ErrInvalidID, or if the notes cannot be read
+	List(ctx context.Context, userID int64, paperID string) ([]*Note, error)
+
+	// Get returns one of a user's notes on a paper.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the author
+	//   - paperID: paper ID; any version suffix is ignored
+	//   - id: note ID
+	// @Returns:
+	//   - *Note: the note
+	//   - error: ErrInvalidID, or ErrNoteNotFound if the user has no such note on the paper
+	Get(ctx context.Context, userID int64, paperID string, id int64) (*Note, error)
+
+	// Update changes a note's body or anchor.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the author
+	//   - paperID: paper ID; any version suffix is ignored
+	//   - id: note ID
+	//   - req: fields to change
+	// @Returns:
+	//   - *Note: the updated note
+	//   - error: ErrInvalidID, ErrNoteNotFound, ErrInvalidNote, ErrInvalidAnchor, or ErrPaperNotFound
+	//     if a new abstract anchor is given and the paper is not stored
+	Update(ctx context.Context, userID int64, paperID string, id int64, req *UpdateRequest) (*Note, error)
+
+	// Delete removes a note.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the author
+	//   - paperID: paper ID; any version suffix is ignored
+	//   - id: note ID
+	// @Returns:
+	//   - error: ErrInvalidID, or ErrNoteNotFound if the user has no such note on the paper
+	Delete(ctx context.Context, userID int64, paperID string, id int64) error
+
+	// Search finds a user's notes whose body or highlighted text contains
+	// every query term, ignoring case.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - req: user, query and page
+	// @Returns:
+	//   - *SearchResult: matching notes with their papers, most recently updated first, and their total
+	//   - error: ErrInvalidQuery if the query is empty or has more than MaxQueryTerms terms
+	Search(ctx context.Context, req *SearchRequest) (*SearchResult, error)
+}
@@ -0,0 +1,301 @@
+package notes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	noteRepo "github.com/rrlian/papertok/backend/internal/repository/note"
+)
+
+// Impl implements the notes Service interface.
+type Impl struct {
+	notes  noteStore
+	papers paperStore
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new notes service instance.
+func New(notes noteStore, papers paperStore) *Impl {
+	return &Impl{
+		notes:  notes,
+		papers: papers,
+	}
+}
+
+// Create adds a note to a stored paper.
+func (s *Impl) Create(ctx context.Context, userID int64, paperID string, req *NoteRequest) (*Note, error) {
+	pid, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	if err := validBody(req.Body, req.Anchor != nil); err != nil {
+		return nil, err
+	}
+	if _, ok := s.papers.GetByID(ctx, pid); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPaperNotFound, pid)
+	}
+
+	n := &noteRepo.Note{UserID: userID, PaperID: pid, Body: req.Body}
+	if req.Anchor != nil {
+		if err := s.setAnchor(ctx, n, req.Anchor); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := s.notes.ListByPaper(ctx, userID, pid)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxNotesPerPaper {
+		return nil, fmt.Errorf("%w: at most %d per paper", ErrTooManyNotes, MaxNotesPerPaper)
+	}
+
+	if err := s.notes.Create(ctx, n); err != nil {
+		return nil, err
+	}
+	return convertNote(n), nil
+}
+
+// List returns a user's notes on a paper, oldest first.
+func (s *Impl) List(ctx context.Context, userID int64, paperID string) ([]*Note, error) {
+	pid, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	stored, err := s.notes.ListByPaper(ctx, userID, pid)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Note, len(stored))
+	for i, n := range stored {
+		result[i] = convertNote(n)
+	}
+	return result, nil
+}
+
+// Get returns one of a user's notes on a paper.
+func (s *Impl) Get(ctx context.Context, userID int64, paperID string, id int64) (*Note, error) {
+	n, err := s.owned(ctx, userID, paperID, id)
+	if err != nil {
+		return nil, err
+	}
+	return convertNote(n), nil
+}
+
+// Update changes a note's body or anchor.
+func (s *Impl) Update(ctx context.Context, userID int64, paperID string, id int64, req *UpdateRequest) (*Note, error) {
+	n, err := s.owned(ctx, userID, paperID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.ClearAnchor && req.Anchor != nil {
+		return nil, fmt.Errorf("%w: cannot both replace and clear the anchor", ErrInvalidAnchor)
+	}
+
+	if req.Body != nil {
+		n.Body = *req.Body
+	}
+	if req.ClearAnchor {
+		clearAnchor(n)
+	}
+	if req.Anchor != nil {
+		if err := s.setAnchor(ctx, n, req.Anchor); err != nil {
+			return nil, err
+		}
+	}
+	if err := validBody(n.Body, n.AnchorType != noteRepo.AnchorNone); err != nil {
+		return nil, err
+	}
+
+	if err := s.notes.Update(ctx, n); err != nil {
+		return nil, notFound(err, id)
+	}
+	return convertNote(n), nil
+}
+
+// Delete removes a note.
+func (s *Impl) Delete(ctx context.Context, userID int64, paperID string, id int64) error {
+	if _, err := s.owned(ctx, userID, paperID, id); err != nil {
+		return err
+	}
+	return notFound(s.notes.Delete(ctx, id), id)
+}
+
+// Search finds a user's notes containing every query term.
+func (s *Impl) Search(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
+	terms := queryTerms(req.Query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: empty query", ErrInvalidQuery)
+	}
+	if len(terms) > MaxQueryTerms {
+		return nil, fmt.Errorf("%w: at most %d terms", ErrInvalidQuery, MaxQueryTerms)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	stored, total, err := s.notes.Search(ctx, req.UserID, terms, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{Notes: make([]*Note, len(stored)), Total: total}
+	papers := make(map[string]*Paper)
+	for i, n := range stored {
+		note := convertNote(n)
+		p, seen := papers[n.PaperID]
+		if !seen {
+			// Papers cached without a database may have expired since the note was written.
+			if paper, ok := s.papers.GetByID(ctx, n.PaperID); ok {
+				p = paper
+			}
+			papers[n.PaperID] = p
+		}
+		note.Paper = p
+		result.Notes[i] = note
+	}
+	return result, nil
+}
+
+// owned returns a note if it belongs to the user and paper. Other notes are
+// reported as not found so their IDs reveal nothing.
+func (s *Impl) owned(ctx context.Context, userID int64, paperID string, id int64) (*noteRepo.Note, error) {
+	pid, err := arxiv.BaseID(paperID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	n, err := s.notes.Get(ctx, id)
+	if err != nil {
+		return nil, notFound(err, id)
+	}
+	if n.UserID != userID || n.PaperID != pid {
+		return nil, fmt.Errorf("%w: %d", ErrNoteNotFound, id)
+	}
+	return n, nil
+}
+
+// setAnchor validates an anchor and stores it on a note. Abstract anchors
+// are checked against the stored paper's abstract, which supplies the quote.
+func (s *Impl) setAnchor(ctx context.Context, n *noteRepo.Note, a *Anchor) error {
+	if (a.Abstract == nil) == (a.PDF == nil) {
+		return fmt.Errorf("%w: set exactly one of abstract or pdf", ErrInvalidAnchor)
+	}
+	clearAnchor(n)
+
+	if r := a.Abstract; r != nil {
+		p, ok := s.papers.GetByID(ctx, n.PaperID)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrPaperNotFound, n.PaperID)
+		}
+		abstract := []rune(p.Summary)
+		if r.Start < 0 || r.End <= r.Start || r.End > len(abstract) {
+			return fmt.Errorf("%w: abstract range must be within 0 to %d", ErrInvalidAnchor, len(abstract))
+		}
+		n.AnchorType = noteRepo.AnchorAbstract
+		n.Start = r.Start
+		n.End = r.End
+		n.Quote = string(abstract[r.Start:r.End])
+		return nil
+	}
+
+	region := a.PDF
+	if region.Page < 1 {
+		return fmt.Errorf("%w: page must be at least 1", ErrInvalidAnchor)
+	}
+	if len(region.Rects) == 0 || len(region.Rects) > MaxRects {
+		return fmt.Errorf("%w: a pdf anchor needs 1 to %d rects", ErrInvalidAnchor, MaxRects)
+	}
+	n.AnchorType = noteRepo.AnchorPDF
+	n.Page = region.Page
+	n.Rects = make([]noteRepo.Rect, len(region.Rects))
+	for i, rect := range region.Rects {
+		if !validRect(rect) {
+			return fmt.Errorf("%w: rect %d must lie within the page", ErrInvalidAnchor, i)
+		}
+		n.Rects[i] = noteRepo.Rect{X: rect.X, Y: rect.Y, Width: rect.Width, Height: rect.Height}
+	}
+	return nil
+}
+
+// clearAnchor makes a note a note on the whole paper.
+func clearAnchor(n *noteRepo.Note) {
+	n.AnchorType = noteRepo.AnchorNone
+	n.Start, n.End, n.Quote = 0, 0, ""
+	n.Page, n.Rects = 0, nil
+}
+
+// validRect checks that a rect is non-empty and lies within the page.
+// The comparisons are written so that NaN fails them.
+func validRect(r Rect) bool {
+	return r.X >= 0 && r.Y >= 0 && r.Width > 0 && r.Height > 0 &&
+		r.X+r.Width <= 1 && r.Y+r.Height <= 1
+}
+
+// validBody checks a body's length; a note without an anchor must have text.
+func validBody(body string, anchored bool) error {
+	if utf8.RuneCountInString(body) > MaxBodyLength {
+		return fmt.Errorf("%w: body must be at most %d characters", ErrInvalidNote, MaxBodyLength)
+	}
+	if !anchored && strings.TrimSpace(body) == "" {
+		return fmt.Errorf("%w: a note without an anchor needs a body", ErrInvalidNote)
+	}
+	return nil
+}
+
+// queryTerms splits a query into distinct lower-cased terms.
+func queryTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// notFound maps the repository's not-found error to ErrNoteNotFound.
+func notFound(err error, id int64) error {
+	if errors.Is(err, noteRepo.ErrNoteNotFound) {
+		return fmt.Errorf("%w: %d", ErrNoteNotFound, id)
+	}
+	return err
+}
+
+// convertNote converts a repository note without its paper.
+func convertNote(n *noteRepo.Note) *Note {
+	result := &Note{
+		ID:        n.ID,
+		PaperID:   n.PaperID,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+	switch n.AnchorType {
+	case noteRepo.AnchorAbstract:
+		result.Anchor = &Anchor{Abstract: &TextRange{Start: n.Start, End: n.End, Quote: n.Quote}}
+	case noteRepo.AnchorPDF:
+		rects := make([]Rect, len(n.Rects))
+		for i, r := range n.Rects {
+			rects[i] = Rect{X: r.X, Y: r.Y, Width: r.Width, Height: r.Height}
+		}
+		result.Anchor = &Anchor{PDF: &PDFRegion{Page: n.Page, Rects: rects}}
+	}
+	return result
+}
@@ -0,0 +1,313 @@
+package notes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	noteRepo "github.com/rrlian/papertok/backend/internal/repository/note"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockPapers supplies the abstracts that note anchors quote.
+type mockPapers map[string]*paperRepo.Paper
+
+func (m mockPapers) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	p, ok := m[id]
+	return p, ok
+}
+
+func newTestService() (*Impl, mockPapers) {
+	papers := mockPapers{
+		"2401.00001": {ID: "2401.00001", Title: "Diffusion", Summary: "We study score-based diffusion models."},
+		"2401.00002": {ID: "2401.00002", Summary: "Ein Überblick über Agenten."},
+	}
+	return New(noteRepo.NewMemoryRepository(), papers), papers
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestImpl_Create(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	// Act
+	note, noteErr := svc.Create(ctx, 1, "2401.00001v2", &NoteRequest{Body: "**Key idea**: scores"})
+	highlight, highlightErr := svc.Create(ctx, 1, "2401.00002", &NoteRequest{
+		Anchor: &Anchor{Abstract: &TextRange{Start: 4, End: 13, Quote: "ignored"}},
+	})
+	region, regionErr := svc.Create(ctx, 1, "2401.00001", &NoteRequest{
+		Body:   "Figure 2",
+		Anchor: &Anchor{PDF: &PDFRegion{Page: 3, Rects: []Rect{{X: 0.1, Y: 0.2, Width: 0.5, Height: 0.1}}}},
+	})
+
+	// Assert
+	if noteErr != nil || highlightErr != nil || regionErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v, %v", noteErr, highlightErr, regionErr)
+	}
+	if note.PaperID != "2401.00001" || note.Anchor != nil {
+		t.Errorf("Expected unanchored note on the base ID, got: %+v", note)
+	}
+	if got := highlight.Anchor.Abstract.Quote; got != "Überblick" {
+		t.Errorf("Expected quote counted in characters, got: %q", got)
+	}
+	if region.Anchor.PDF == nil || region.Anchor.PDF.Page != 3 || len(region.Anchor.PDF.Rects) != 1 {
+		t.Errorf("Expected pdf anchor on page 3, got: %+v", region.Anchor)
+	}
+	list, _ := svc.List(ctx, 1, "2401.00001")
+	if len(list) != 2 || list[0].ID != note.ID {
+		t.Errorf("Expected 2 notes oldest first, got: %+v", list)
+	}
+}
+
+func TestImpl_Update(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+	n, _ := svc.Create(ctx, 1, "2401.00001", &NoteRequest{
+		Body:   "first",
+		Anchor: &Anchor{Abstract: &TextRange{Start: 0, End: 8}},
+	})
+
+	// Act
+	moved, moveErr := svc.Update(ctx, 1, "2401.00001", n.ID, &UpdateRequest{
+		Anchor: &Anchor{PDF: &PDFRegion{Page: 1, Rects: []Rect{{X: 0, Y: 0, Width: 1, Height: 1}}}},
+	})
+	cleared, clearErr := svc.Update(ctx, 1, "2401.00001", n.ID, &UpdateRequest{Body: strPtr("second"), ClearAnchor: true})
+	_, emptyErr := svc.Update(ctx, 1, "2401.00001", n.ID, &UpdateRequest{Body: strPtr("  ")})
+
+	// Assert
+	if moveErr != nil || clearErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v", moveErr, clearErr)
+	}
+	if moved.Body != "first" || moved.Anchor.Abstract != nil || moved.Anchor.PDF == nil {
+		t.Errorf("Expected body kept and anchor replaced, got: %+v", moved)
+	}
+	if cleared.Body != "second" || cleared.Anchor != nil {
+		t.Errorf("Expected unanchored note with new body, got: %+v", cleared)
+	}
+	if !IsInvalidNote(emptyErr) {
+		t.Errorf("Expected ErrInvalidNote for an empty unanchored note, got: %v", emptyErr)
+	}
+}
+
+func TestImpl_Search(t *testing.T) {
+	// Arrange
+	svc, papers := newTestService()
+	ctx := context.Background()
+	svc.Create(ctx, 1, "2401.00001", &NoteRequest{Body: "Compare with DDPM sampling"})
+	svc.Create(ctx, 1, "2401.00001", &NoteRequest{Anchor: &Anchor{Abstract: &TextRange{Start: 9, End: 28}}})
+	svc.Create(ctx, 1, "2401.00002", &NoteRequest{Body: "Agent sampling loop"})
+	svc.Create(ctx, 2, "2401.00001", &NoteRequest{Body: "sampling, but someone else's"})
+	delete(papers, "2401.00002")
+
+	// Act
+	sampling, err := svc.Search(ctx, &SearchRequest{UserID: 1, Query: "SAMPLING"})
+	both, bothErr := svc.Search(ctx, &SearchRequest{UserID: 1, Query: "ddpm sampling"})
+	quoted, quotedErr := svc.Search(ctx, &SearchRequest{UserID: 1, Query: "score-based"})
+
+	// Assert
+	if err != nil || bothErr != nil || quotedErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v, %v", err, bothErr, quotedErr)
+	}
+	if sampling.Total != 2 || sampling.Notes[0].PaperID != "2401.00002" || sampling.Notes[0].Paper != nil {
+		t.Errorf("Expected the user's 2 notes, newest first, expired paper left empty, got: %+v", sampling.Notes)
+	}
+	if both.Total != 1 || both.Notes[0].Paper == nil || both.Notes[0].Paper.Title != "Diffusion" {
+		t.Errorf("Expected 1 note matching all terms with its paper, got: %+v", both.Notes)
+	}
+	if quoted.Total != 1 || quoted.Notes[0].Anchor == nil {
+		t.Errorf("Expected highlight matched by its quote, got: %+v", quoted.Notes)
+	}
+}
+
+func TestImpl_Ownership(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+	n, _ := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Body: "mine"})
+
+	// Act
+	_, otherUserErr := svc.Get(ctx, 2, "2401.00001", n.ID)
+	_, otherPaperErr := svc.Get(ctx, 1, "2401.00002", n.ID)
+	_, updateErr := svc.Update(ctx, 2, "2401.00001", n.ID, &UpdateRequest{Body: strPtr("theirs")})
+	deleteErr := svc.Delete(ctx, 2, "2401.00001", n.ID)
+
+	// Assert
+	for _, err := range []error{otherUserErr, otherPaperErr, updateErr, deleteErr} {
+		if !IsNoteNotFound(err) {
+			t.Errorf("Expected ErrNoteNotFound, got: %v", err)
+		}
+	}
+	if err := svc.Delete(ctx, 1, "2401.00001v1", n.ID); err != nil {
+		t.Errorf("Expected author to delete, got: %v", err)
+	}
+	if _, err := svc.Get(ctx, 1, "2401.00001", n.ID); !IsNoteNotFound(err) {
+		t.Errorf("Expected deleted note to be gone, got: %v", err)
+	}
+}
+
+func TestImpl_Errors(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	rect := Rect{X: 0.5, Y: 0.5, Width: 0.25, Height: 0.25}
+
+	tests := []struct {
+		name    string
+		call    func() error
+		checkFn func(error) bool
+	}{
+		{
+			name: "malformed paper ID",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "not-an-id", &NoteRequest{Body: "x"})
+				return err
+			},
+			checkFn: IsInvalidID,
+		},
+		{
+			name: "paper not stored",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.99999", &NoteRequest{Body: "x"})
+				return err
+			},
+			checkFn: IsPaperNotFound,
+		},
+		{
+			name: "empty unanchored note",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Body: " \n"})
+				return err
+			},
+			checkFn: IsInvalidNote,
+		},
+		{
+			name: "body too long",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Body: strings.Repeat("x", MaxBodyLength+1)})
+				return err
+			},
+			checkFn: IsInvalidNote,
+		},
+		{
+			name: "no anchor kind",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Anchor: &Anchor{}})
+				return err
+			},
+			checkFn: IsInvalidAnchor,
+		},
+		{
+			name: "both anchor kinds",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Anchor: &Anchor{
+					Abstract: &TextRange{Start: 0, End: 1},
+					PDF:      &PDFRegion{Page: 1, Rects: []Rect{rect}},
+				}})
+				return err
+			},
+			checkFn: IsInvalidAnchor,
+		},
+		{
+			name: "range past the abstract",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Anchor: &Anchor{Abstract: &TextRange{Start: 10, End: 1000}}})
+				return err
+			},
+			checkFn: IsInvalidAnchor,
+		},
+		{
+			name: "empty range",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Anchor: &Anchor{Abstract: &TextRange{Start: 3, End: 3}}})
+				return err
+			},
+			checkFn: IsInvalidAnchor,
+		},
+		{
+			name: "page zero",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Anchor: &Anchor{PDF: &PDFRegion{Page: 0, Rects: []Rect{rect}}}})
+				return err
+			},
+			checkFn: IsInvalidAnchor,
+		},
+		{
+			name: "rect off the page",
+			call: func() error {
+				off := Rect{X: 0.9, Y: 0.1, Width: 0.2, Height: 0.1}
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Anchor: &Anchor{PDF: &PDFRegion{Page: 1, Rects: []Rect{off}}}})
+				return err
+			},
+			checkFn: IsInvalidAnchor,
+		},
+		{
+			name: "no rects",
+			call: func() error {
+				_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Anchor: &Anchor{PDF: &PDFRegion{Page: 1}}})
+				return err
+			},
+			checkFn: IsInvalidAnchor,
+		},
+		{
+			name: "unknown note",
+			call: func() error {
+				_, err := svc.Get(ctx, 1, "2401.00001", 42)
+				return err
+			},
+			checkFn: IsNoteNotFound,
+		},
+		{
+			name: "empty query",
+			call: func() error {
+				_, err := svc.Search(ctx, &SearchRequest{UserID: 1, Query: "   "})
+				return err
+			},
+			checkFn: IsInvalidQuery,
+		},
+		{
+			name: "too many terms",
+			call: func() error {
+				_, err := svc.Search(ctx, &SearchRequest{UserID: 1, Query: "a b c d e f g h i j k"})
+				return err
+			},
+			checkFn: IsInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.call()
+
+			// Assert
+			if !tt.checkFn(err) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestImpl_TooManyNotes(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService()
+	ctx := context.Background()
+	for i := 0; i < MaxNotesPerPaper; i++ {
+		if _, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Body: "n"}); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	// Act
+	_, err := svc.Create(ctx, 1, "2401.00001", &NoteRequest{Body: "one more"})
+	_, otherPaperErr := svc.Create(ctx, 1, "2401.00002", &NoteRequest{Body: "elsewhere"})
+
+	// Assert
+	if !IsTooManyNotes(err) {
+		t.Errorf("Expected ErrTooManyNotes, got: %v", err)
+	}
+	if otherPaperErr != nil {
+		t.Errorf("Expected the limit to be per paper, got: %v", otherPaperErr)
+	}
+}
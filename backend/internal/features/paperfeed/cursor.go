@@ -0,0 +1,105 @@
+package paperfeed
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// cursor marks the last paper delivered by a feed page.
+// It is sent to clients as a signed, opaque string.
+type cursor struct {
+	Feed   string    `json:"f"` // Normalized categories of the feed
+	SortBy string    `json:"s"`
+	Offset int       `json:"o"`           // Upstream position of the last delivered paper
+	LastID string    `json:"id"`          // ID of the last delivered paper
+	Anchor time.Time `json:"t,omitempty"` // Sort time of the last delivered paper (zero for relevance)
+}
+
+// cursorKey derives the cursor signing key from a secret.
+// An empty secret yields a random key, so cursors do not survive a restart.
+func cursorKey(secret string) []byte {
+	if secret == "" {
+		key := make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			panic(fmt.Sprintf("paperfeed: generating cursor key: %v", err))
+		}
+		return key
+	}
+	// Derive a separate key so the secret is not used directly for another purpose.
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("paperfeed cursor"))
+	return mac.Sum(nil)
+}
+
+// encodeCursor serializes and signs a cursor as "payload.signature".
+func (s *Impl) encodeCursor(c *cursor) string {
+	data, _ := json.Marshal(c) // Cannot fail: plain struct
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload))
+}
+
+// decodeCursor verifies and parses a cursor produced by encodeCursor.
+func (s *Impl) decodeCursor(token string) (*cursor, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, s.sign(payload)) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidCursor)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Offset < 0 || c.LastID == "" {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+	return &c, nil
+}
+
+// sign computes the signature of an encoded cursor payload.
+func (s *Impl) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.cursorKey)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// seenPrefix counts the leading papers that sort at or above the last
+// delivered paper, i.e. papers the client has already been shown or that
+// arrived at the top of the feed after the cursor was issued.
+func (c *cursor) seenPrefix(papers []*paperRepo.Paper) int {
+	if c.Anchor.IsZero() {
+		return 0
+	}
+	for i, p := range papers {
+		t := sortTime(p, c.SortBy)
+		if t.Before(c.Anchor) || (t.Equal(c.Anchor) && p.ID != c.LastID) {
+			return i
+		}
+	}
+	return len(papers)
+}
+
+// sortTime returns the time a feed sorted by sortBy is ordered on,
+// or the zero time if the order is not by time.
+func sortTime(p *paperRepo.Paper, sortBy string) time.Time {
+	switch sortBy {
+	case "lastUpdatedDate":
+		return p.Updated
+	case "submittedDate":
+		return p.Published
+	default:
+		return time.Time{}
+	}
+}
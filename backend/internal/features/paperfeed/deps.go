@@ -10,22 +10,41 @@ import (
 // arxivService defines the arXiv service capability required by this feature.
 type arxivService interface {
 	// FetchByCategory fetches papers from arXiv by category.
-	FetchByCategory(ctx context.Context, req *arxiv.FetchRequest) ([]*arxiv.Paper, error)
+	FetchByCategory(ctx context.Context, req *arxiv.FetchRequest) (*arxiv.Result, error)
 }
 
 // paperRepository defines the paper repository capability required by this feature.
 type paperRepository interface {
 	// GetByCategory retrieves cached papers by category.
-	GetByCategory(ctx context.Context, category string) ([]*repositoryPaper, bool)
+	GetByCategory(ctx context.Context, category string) (*repositoryPaperList, bool)
 
-	// SaveByCategory stores papers for a category with TTL.
-	SaveByCategory(ctx context.Context, category string, papers []*repositoryPaper, ttl time.Duration)
+	// SaveByCategory stores papers for a category with TTL, and each paper by ID.
+	SaveByCategory(ctx context.Context, category string, list *repositoryPaperList, ttl time.Duration)
+}
+
+// profileSource defines the user signal capability required by personal ranking.
+type profileSource interface {
+	// Profile gathers the user's interactions and follows.
+	Profile(ctx context.Context, userID int64) (*Profile, error)
+}
+
+// seenSource defines the reading history capability required to exclude seen papers.
+type seenSource interface {
+	// Seen reports which of the given papers the user has viewed.
+	Seen(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error)
+}
+
+// followSource defines the follow capability required by the following feed.
+type followSource interface {
+	// Follows returns the categories and authors the user follows.
+	Follows(ctx context.Context, userID int64) (categories, authors []string, err error)
 }
 
 // repositoryPaper represents a paper in the repository layer.
 // This is a local alias to avoid import cycles.
 type repositoryPaper struct {
 	ID              string
+	Version         int
 	Title           string
 	Authors         []string
 	Summary         string
@@ -36,4 +55,13 @@ type repositoryPaper struct {
 	ArxivURL        string
 	PDFURL          string
 	ImageURL        string
+	DOI             string
+	JournalRef      string
+	Comment         string
+}
+
+// repositoryPaperList represents a page of papers in the repository layer.
+type repositoryPaperList struct {
+	Papers []*repositoryPaper
+	Total  int
 }
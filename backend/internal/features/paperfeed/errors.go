@@ -0,0 +1,37 @@
+package paperfeed
+
+import "errors"
+
+var (
+	// ErrInvalidCursor indicates that a cursor is malformed, was not issued by
+	// this server, or belongs to a different feed.
+	ErrInvalidCursor = errors.New("invalid feed cursor")
+
+	// ErrInvalidCategory indicates that a category is malformed or too many were requested.
+	ErrInvalidCategory = errors.New("invalid feed category")
+
+	// ErrInvalidMode indicates that the feed mode is unknown.
+	ErrInvalidMode = errors.New("invalid feed mode")
+
+	// ErrInvalidRanking indicates that the ranking mode is unknown.
+	ErrInvalidRanking = errors.New("invalid feed ranking")
+
+	// ErrUserRequired indicates that the following feed, personal ranking or
+	// excluding seen papers was requested without a user.
+	ErrUserRequired = errors.New("feed option requires a user")
+)
+
+// IsInvalidCursor checks if the error is ErrInvalidCursor.
+func IsInvalidCursor(err error) bool { return errors.Is(err, ErrInvalidCursor) }
+
+// IsInvalidCategory checks if the error is ErrInvalidCategory.
+func IsInvalidCategory(err error) bool { return errors.Is(err, ErrInvalidCategory) }
+
+// IsInvalidMode checks if the error is ErrInvalidMode.
+func IsInvalidMode(err error) bool { return errors.Is(err, ErrInvalidMode) }
+
+// IsInvalidRanking checks if the error is ErrInvalidRanking.
+func IsInvalidRanking(err error) bool { return errors.Is(err, ErrInvalidRanking) }
+
+// IsUserRequired checks if the error is ErrUserRequired.
+func IsUserRequired(err error) bool { return errors.Is(err, ErrUserRequired) }
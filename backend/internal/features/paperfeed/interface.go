@@ -7,25 +7,117 @@ import (
 
 // Paper represents a paper in the feed response.
 type Paper struct {
-	ID              string    `json:"id"`
-	Title           string    `json:"title"`
-	Authors         []string  `json:"authors"`
-	Summary         string    `json:"summary"`
-	Published       time.Time `json:"published"`
-	Updated         time.Time `json:"updated"`
-	Categories      []string  `json:"categories"`
-	PrimaryCategory string    `json:"primaryCategory"`
-	ArxivURL        string    `json:"arxivUrl"`
-	PDFURL          string    `json:"pdfUrl"`
-	ImageURL        string    `json:"imageUrl"`
+	ID              string         `json:"id"`
+	Version         int            `json:"version,omitempty"`
+	Title           string         `json:"title"`
+	Authors         []string       `json:"authors"`
+	AuthorDetails   []AuthorDetail `json:"authorDetails,omitempty"`
+	Summary         string         `json:"summary"`
+	Published       time.Time      `json:"published"`
+	Updated         time.Time      `json:"updated"`
+	Categories      []string       `json:"categories"`
+	PrimaryCategory string         `json:"primaryCategory"`
+	ArxivURL        string         `json:"arxivUrl"`
+	PDFURL          string         `json:"pdfUrl"`
+	ImageURL        string         `json:"imageUrl"`
+	DOI             string         `json:"doi,omitempty"`
+	JournalRef      string         `json:"journalRef,omitempty"`
+	Comment         string         `json:"comment,omitempty"`
 }
 
+// AuthorDetail is an author together with their stated affiliations.
+type AuthorDetail struct {
+	Name         string   `json:"name"`
+	Affiliations []string `json:"affiliations,omitempty"`
+}
+
+// FeedResult is a page of the feed together with the total number of papers available.
+type FeedResult struct {
+	Papers       []*Paper
+	Total        int
+	NextCursor   string // Opaque cursor for the next page, empty on the last page
+	RankingScope string // RankingScopePage if the papers were reordered, empty in date order
+}
+
+// MaxCategories is the maximum number of categories merged into one feed.
+const MaxCategories = 10
+
 // FetchRequest contains parameters for fetching the paper feed.
 type FetchRequest struct {
-	Category   string
+	Categories []string // Categories or archives (e.g., "cs.*") merged into one feed; empty for all of cs, stat and math
+	Mode       string   // ModeCategories (default) or ModeFollowing
 	Limit      int
 	Offset     int
 	SortBy     string
+	Cursor     string // NextCursor of the previous page; takes precedence over Offset
+	Ranking    string // RankingChronological (default) or RankingPersonal
+	UserID     int64  // User the feed is for; required for ModeFollowing, RankingPersonal and ExcludeSeen
+
+	// ExcludeSeen drops papers the user has viewed. Pages can then hold
+	// fewer papers than Limit, or none, while NextCursor still continues.
+	ExcludeSeen bool
+}
+
+// Feed modes.
+const (
+	// ModeCategories builds the feed from the requested categories.
+	ModeCategories = "categories"
+	// ModeFollowing builds the feed from the latest papers of the user's
+	// followed authors and categories; Categories is ignored.
+	ModeFollowing = "following"
+)
+
+// Ranking modes.
+const (
+	// RankingChronological keeps the upstream date order.
+	RankingChronological = "chronological"
+	// RankingPersonal reorders each page by the user's interests.
+	RankingPersonal = "personal"
+)
+
+// RankingScopePage means papers were reordered within the page only: a more
+// relevant paper on a later page does not move ahead of this one.
+const RankingScopePage = "page"
+
+// InteractionKind is the kind of signal a user gave about a paper.
+type InteractionKind string
+
+// Interaction kinds.
+const (
+	InteractionLike     InteractionKind = "like"
+	InteractionBookmark InteractionKind = "bookmark"
+	InteractionView     InteractionKind = "view"
+)
+
+// Interaction is one signal of a user's interest in a paper.
+type Interaction struct {
+	Kind       InteractionKind
+	PaperID    string
+	Categories []string      // Categories of the paper
+	Authors    []string      // Authors of the paper
+	Dwell      time.Duration // Time spent reading (views)
+	At         time.Time
+}
+
+// Profile gathers the signals a Ranker scores papers with.
+type Profile struct {
+	UserID             int64
+	Interactions       []Interaction
+	FollowedCategories []string // Categories or archives (e.g., "cs.*")
+	FollowedAuthors    []string
+	Now                time.Time // Reference time for decaying old interactions
+}
+
+// Ranker orders a page of candidate papers for a user.
+// Implementations must be deterministic and must return every paper exactly once.
+type Ranker interface {
+	// Rank returns the papers reordered by the profile's interests.
+	// @Params:
+	//   - profile: the user's signals
+	//   - papers: candidate papers in date order
+	// @Returns:
+	//   - []*Paper: the same papers, best first
+	Rank(profile *Profile, papers []*Paper) []*Paper
 }
 
 // Service defines the interface for paper feed operations.
@@ -35,7 +127,17 @@ type Service interface {
 	//   - ctx: context for cancellation and tracing
 	//   - req: fetch request parameters
 	// @Returns:
-	//   - []*Paper: list of papers for the feed
+	//   - *FeedResult: papers for the feed, the total available upstream and the next cursor
+	//   - error: ErrInvalidCursor, ErrInvalidCategory, ErrInvalidMode or ErrInvalidRanking if the request is invalid, or if fetch fails
+	GetFeed(ctx context.Context, req *FetchRequest) (*FeedResult, error)
+
+	// Refresh fetches a feed page from arXiv and caches it, even if a cached
+	// copy has not expired yet. It is used to warm the cache ahead of expiry.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - req: fetch request parameters
+	// @Returns:
+	//   - *FeedResult: the fetched papers and the total available upstream
 	//   - error: if fetch fails
-	GetFeed(ctx context.Context, req *FetchRequest) ([]*Paper, error)
+	Refresh(ctx context.Context, req *FetchRequest) (*FeedResult, error)
 }
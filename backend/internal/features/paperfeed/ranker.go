@@ -0,0 +1,199 @@
+package paperfeed
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RankWeights tunes DefaultRanker.
+type RankWeights struct {
+	Like             float64       // Interest added by a like
+	Bookmark         float64       // Interest added by a bookmark
+	DwellPerMinute   float64       // Interest added per minute spent reading
+	MaxDwell         time.Duration // Reading time beyond this adds nothing
+	HalfLife         time.Duration // Age at which an interaction counts half
+	Category         float64       // Score for the best-matching category interest (0..1 scaled)
+	Author           float64       // Score for the best-matching author interest (0..1 scaled)
+	FollowedCategory float64       // Score for a paper in a followed category
+	FollowedAuthor   float64       // Score for a paper by a followed author
+	Freshness        float64       // Score for the newest paper, falling off by day
+	Seen             float64       // Penalty for papers already liked or bookmarked
+}
+
+// DefaultRankWeights returns the weights used when none are configured.
+func DefaultRankWeights() RankWeights {
+	return RankWeights{
+		Like:             3,
+		Bookmark:         5,
+		DwellPerMinute:   1,
+		MaxDwell:         5 * time.Minute,
+		HalfLife:         30 * 24 * time.Hour,
+		Category:         2,
+		Author:           3,
+		FollowedCategory: 2,
+		FollowedAuthor:   4,
+		Freshness:        1,
+		Seen:             3,
+	}
+}
+
+// DefaultRanker scores papers by how well their categories and authors match
+// the categories and authors of papers the user interacted with, plus bonuses
+// for follows and freshness. Ties keep the date order.
+type DefaultRanker struct {
+	weights RankWeights
+}
+
+// Ensure DefaultRanker implements Ranker interface
+var _ Ranker = (*DefaultRanker)(nil)
+
+// NewDefaultRanker creates a ranker with the given weights.
+func NewDefaultRanker(weights RankWeights) *DefaultRanker {
+	return &DefaultRanker{weights: weights}
+}
+
+// Rank returns the papers reordered by the profile's interests.
+func (r *DefaultRanker) Rank(profile *Profile, papers []*Paper) []*Paper {
+	if profile == nil || len(papers) < 2 {
+		return papers
+	}
+
+	categories, authors, seen := r.interests(profile)
+	followedCategories := toSet(profile.FollowedCategories, strings.TrimSpace)
+	followedAuthors := toSet(profile.FollowedAuthors, normalizeAuthor)
+	newest := newestUpdate(papers)
+
+	scores := make(map[*Paper]float64, len(papers))
+	for _, p := range papers {
+		var score float64
+		for _, c := range p.Categories {
+			score = math.Max(score, categories[c])
+		}
+		score *= r.weights.Category
+
+		var author float64
+		for _, a := range p.Authors {
+			author = math.Max(author, authors[normalizeAuthor(a)])
+		}
+		score += author * r.weights.Author
+
+		if inCategories(p.Categories, followedCategories) {
+			score += r.weights.FollowedCategory
+		}
+		for _, a := range p.Authors {
+			if followedAuthors[normalizeAuthor(a)] {
+				score += r.weights.FollowedAuthor
+				break
+			}
+		}
+
+		ageDays := newest.Sub(p.Updated).Hours() / 24
+		score += r.weights.Freshness / (1 + math.Max(ageDays, 0))
+
+		if seen[p.ID] {
+			score -= r.weights.Seen
+		}
+		scores[p] = score
+	}
+
+	ranked := append([]*Paper(nil), papers...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i]] > scores[ranked[j]]
+	})
+	return ranked
+}
+
+// interests turns interactions into category and author affinities scaled to
+// [0, 1], and collects the papers the user already liked or bookmarked.
+func (r *DefaultRanker) interests(profile *Profile) (categories, authors map[string]float64, seen map[string]bool) {
+	categories = make(map[string]float64)
+	authors = make(map[string]float64)
+	seen = make(map[string]bool)
+
+	for _, in := range profile.Interactions {
+		var weight float64
+		switch in.Kind {
+		case InteractionLike:
+			weight = r.weights.Like
+			seen[in.PaperID] = true
+		case InteractionBookmark:
+			weight = r.weights.Bookmark
+			seen[in.PaperID] = true
+		case InteractionView:
+			dwell := min(in.Dwell, r.weights.MaxDwell)
+			weight = r.weights.DwellPerMinute * dwell.Minutes()
+		}
+		if r.weights.HalfLife > 0 && !in.At.IsZero() && in.At.Before(profile.Now) {
+			weight *= math.Exp2(-float64(profile.Now.Sub(in.At)) / float64(r.weights.HalfLife))
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		for _, c := range in.Categories {
+			categories[c] += weight
+		}
+		for _, a := range in.Authors {
+			authors[normalizeAuthor(a)] += weight
+		}
+	}
+
+	scaleToUnit(categories)
+	scaleToUnit(authors)
+	return categories, authors, seen
+}
+
+// inCategories reports whether any category is in the set, directly or
+// through its archive (cs.LG through cs.*).
+func inCategories(categories []string, set map[string]bool) bool {
+	for _, c := range categories {
+		archive, _, _ := strings.Cut(c, ".")
+		if set[c] || set[archive+".*"] || set[archive] {
+			return true
+		}
+	}
+	return false
+}
+
+// newestUpdate returns the latest update time among the papers.
+func newestUpdate(papers []*Paper) time.Time {
+	var newest time.Time
+	for _, p := range papers {
+		if p.Updated.After(newest) {
+			newest = p.Updated
+		}
+	}
+	return newest
+}
+
+// scaleToUnit divides every value by the largest one.
+func scaleToUnit(values map[string]float64) {
+	var largest float64
+	for _, v := range values {
+		largest = math.Max(largest, v)
+	}
+	if largest == 0 {
+		return
+	}
+	for k, v := range values {
+		values[k] = v / largest
+	}
+}
+
+// toSet builds a set from normalized values, skipping empty ones.
+func toSet(values []string, normalize func(string) string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v = normalize(v); v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
+
+// normalizeAuthor makes author names comparable.
+func normalizeAuthor(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
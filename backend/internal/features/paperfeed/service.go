@@ -2,54 +2,364 @@ package paperfeed
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/rrlian/papertok/backend/internal/core/arxiv"
 	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
 )
 
+// maxCursorAttempts bounds the upstream fetches made for one cursor page.
+const maxCursorAttempts = 4
+
 // Impl implements the paperfeed Service interface.
 type Impl struct {
 	arxivSvc  arxiv.Service
 	paperRepo paperRepo.Repository
 	cacheTTL  time.Duration
+	cursorKey []byte
+	ranker    Ranker
+	profiles  profileSource
+	seen      seenSource
+	follows   followSource
 }
 
 // Ensure Impl implements Service interface
 var _ Service = (*Impl)(nil)
 
 // New creates a new paperfeed service instance.
-func New(arxivSvc arxiv.Service, repo paperRepo.Repository, cacheTTL time.Duration) *Impl {
+// cursorSecret signs the cursors handed to clients; if empty, a random key
+// is used and cursors stop working when the process restarts.
+// A nil ranker uses DefaultRanker; nil profiles rank every user by freshness only;
+// nil seen treats every paper as unseen; nil follows leaves the following feed empty.
+func New(arxivSvc arxiv.Service, repo paperRepo.Repository, cacheTTL time.Duration, cursorSecret string, ranker Ranker, profiles profileSource, seen seenSource, follows followSource) *Impl {
+	if ranker == nil {
+		ranker = NewDefaultRanker(DefaultRankWeights())
+	}
 	return &Impl{
 		arxivSvc:  arxivSvc,
 		paperRepo: repo,
 		cacheTTL:  cacheTTL,
+		cursorKey: cursorKey(cursorSecret),
+		ranker:    ranker,
+		profiles:  profiles,
+		seen:      seen,
+		follows:   follows,
 	}
 }
 
 // GetFeed fetches papers for the feed.
-func (s *Impl) GetFeed(ctx context.Context, req *FetchRequest) ([]*Paper, error) {
-	// Try cache first
-	if cachedPapers, found := s.paperRepo.GetByCategory(ctx, req.Category); found {
-		return s.convertRepoPapers(cachedPapers), nil
+// Personal ranking reorders papers within each page and excluding seen papers
+// drops them from each page; paging, caching and cursors always follow the
+// date order, so pages never overlap.
+func (s *Impl) GetFeed(ctx context.Context, req *FetchRequest) (*FeedResult, error) {
+	switch req.Mode {
+	case "", ModeCategories, ModeFollowing:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidMode, req.Mode)
+	}
+	switch req.Ranking {
+	case "", RankingChronological, RankingPersonal:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRanking, req.Ranking)
+	}
+	if req.UserID == 0 && (req.Mode == ModeFollowing || req.Ranking == RankingPersonal || req.ExcludeSeen) {
+		return nil, ErrUserRequired
+	}
+
+	result, err := s.getFeed(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExcludeSeen && s.seen != nil {
+		if result.Papers, err = s.excludeSeen(ctx, req.UserID, result.Papers); err != nil {
+			return nil, err
+		}
+	}
+	if req.Ranking != RankingPersonal {
+		return result, nil
+	}
+
+	profile := &Profile{UserID: req.UserID, Now: time.Now()}
+	if s.profiles != nil {
+		if profile, err = s.profiles.Profile(ctx, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+	result.Papers = s.ranker.Rank(profile, result.Papers)
+	result.RankingScope = RankingScopePage
+	return result, nil
+}
+
+// excludeSeen drops the papers the user has already viewed.
+func (s *Impl) excludeSeen(ctx context.Context, userID int64, papers []*Paper) ([]*Paper, error) {
+	ids := make([]string, len(papers))
+	for i, p := range papers {
+		ids[i] = p.ID
+	}
+	seen, err := s.seen.Seen(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	unseen := make([]*Paper, 0, len(papers))
+	for _, p := range papers {
+		if !seen[p.ID] {
+			unseen = append(unseen, p)
+		}
+	}
+	return unseen, nil
+}
+
+// getFeed fetches a page of the feed in date order.
+func (s *Impl) getFeed(ctx context.Context, req *FetchRequest) (*FeedResult, error) {
+	categories, authors, err := s.sources(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Mode == ModeFollowing && len(categories) == 0 && len(authors) == 0 {
+		// Nothing followed: an empty upstream query would return every paper.
+		return &FeedResult{Papers: []*Paper{}}, nil
+	}
+
+	if req.Cursor == "" {
+		list, _, err := s.fetchPage(ctx, categories, authors, req.SortBy, req.Offset, req.Limit, false)
+		if err != nil {
+			return nil, err
+		}
+		return s.newResult(req, categories, authors, list.Papers, list.Total, req.Offset, nil), nil
+	}
+
+	c, err := s.decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if c.Feed != feedKey(categories, authors) || c.SortBy != req.SortBy {
+		return nil, fmt.Errorf("%w: issued for a different feed", ErrInvalidCursor)
+	}
+	return s.continueFeed(ctx, req, categories, authors, c)
+}
+
+// sources returns the normalized categories and authors merged into the
+// feed: the requested categories, or everything the user follows.
+func (s *Impl) sources(ctx context.Context, req *FetchRequest) (categories, authors []string, err error) {
+	if req.Mode != ModeFollowing {
+		categories, err = normalizeCategories(req.Categories, MaxCategories)
+		return categories, nil, err
+	}
+	if s.follows == nil {
+		return nil, nil, nil
+	}
+
+	categories, authors, err = s.follows.Follows(ctx, req.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Follows are limited where they are stored, not by MaxCategories.
+	if categories, err = normalizeCategories(categories, 0); err != nil {
+		return nil, nil, err
+	}
+	return categories, normalizeAuthors(authors), nil
+}
+
+// continueFeed fetches the page after a cursor. The page is fetched starting
+// at the last delivered paper, so papers that arrived at the top of the feed
+// since (pushing everything down) are detected and skipped.
+func (s *Impl) continueFeed(ctx context.Context, req *FetchRequest, categories, authors []string, c *cursor) (*FeedResult, error) {
+	offset, fresh := c.Offset, false
+	for attempt := 1; ; attempt++ {
+		retry := attempt < maxCursorAttempts
+		list, cached, err := s.fetchPage(ctx, categories, authors, req.SortBy, offset, req.Limit+1, fresh)
+		if err != nil {
+			return nil, err
+		}
+
+		if i := indexOfPaper(list.Papers, c.LastID); i >= 0 {
+			// Papers above the last delivered one were added since; skip them.
+			if i == len(list.Papers)-1 && i > 0 && retry {
+				offset += i
+				continue
+			}
+			return s.newResult(req, categories, authors, list.Papers[i+1:], list.Total, offset+i+1, c), nil
+		}
+		if cached && !fresh && retry {
+			// The cached page is older than the one the cursor came from.
+			fresh = true
+			continue
+		}
+
+		// The last delivered paper is not on the page: either more papers
+		// arrived than the page holds, or it moved (e.g. it was updated).
+		// Skip papers that sort above it.
+		skip := c.seenPrefix(list.Papers)
+		if skip == len(list.Papers) && skip > 0 && retry {
+			offset += skip
+			continue
+		}
+		return s.newResult(req, categories, authors, list.Papers[skip:], list.Total, offset+skip, c), nil
+	}
+}
+
+// Refresh fetches a feed page from arXiv and caches it, replacing any cached copy.
+func (s *Impl) Refresh(ctx context.Context, req *FetchRequest) (*FeedResult, error) {
+	categories, err := normalizeCategories(req.Categories, MaxCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	list, _, err := s.fetchPage(ctx, categories, nil, req.SortBy, req.Offset, req.Limit, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.newResult(req, categories, nil, list.Papers, list.Total, req.Offset, nil), nil
+}
+
+// fetchPage returns a page of the feed, from the cache unless fresh is set.
+// The returned bool reports whether the page came from the cache.
+func (s *Impl) fetchPage(ctx context.Context, categories, authors []string, sortBy string, offset, limit int, fresh bool) (*paperRepo.PaperList, bool, error) {
+	key := cacheKey(categories, authors, sortBy, offset, limit)
+	if !fresh {
+		if cached, found := s.paperRepo.GetByCategory(ctx, key); found {
+			return cached, true, nil
+		}
 	}
 
 	// Fetch from arXiv
-	arxivPapers, err := s.arxivSvc.FetchByCategory(ctx, &arxiv.FetchRequest{
-		Category:   req.Category,
-		MaxResults: req.Limit,
-		SortBy:     req.SortBy,
-		Offset:     req.Offset,
+	// arXiv merges the categories and authors into one list sorted by date.
+	result, err := s.arxivSvc.FetchByCategory(ctx, &arxiv.FetchRequest{
+		Categories: categories,
+		Authors:    authors,
+		MaxResults: limit,
+		SortBy:     sortBy,
+		Offset:     offset,
 	})
 	if err != nil {
-		return nil, err
+		if arxiv.IsInvalidQuery(err) {
+			return nil, false, fmt.Errorf("%w: %w", ErrInvalidCategory, err)
+		}
+		return nil, false, err
 	}
 
 	// Convert and cache
-	repoPapers := s.convertArxivPapers(arxivPapers)
-	s.paperRepo.SaveByCategory(ctx, req.Category, repoPapers, s.cacheTTL)
+	list := &paperRepo.PaperList{
+		Papers: s.convertArxivPapers(dedupePapers(result.Papers)),
+		Total:  result.TotalResults,
+	}
+	s.paperRepo.SaveByCategory(ctx, key, list, s.cacheTTL)
+	return list, false, nil
+}
 
-	return s.convertRepoPapers(repoPapers), nil
+// newResult builds a feed result from papers starting at the given upstream
+// position, with a cursor for the next page if there are more papers.
+// If no papers are left to deliver, the previous cursor is carried forward.
+func (s *Impl) newResult(req *FetchRequest, categories, authors []string, papers []*paperRepo.Paper, total, start int, prev *cursor) *FeedResult {
+	if len(papers) > req.Limit && req.Limit > 0 {
+		papers = papers[:req.Limit]
+	}
+	result := &FeedResult{Papers: s.convertRepoPapers(papers), Total: total}
+
+	switch {
+	case len(papers) > 0 && start+len(papers) < total:
+		last := papers[len(papers)-1]
+		result.NextCursor = s.encodeCursor(&cursor{
+			Feed:   feedKey(categories, authors),
+			SortBy: req.SortBy,
+			Offset: start + len(papers) - 1,
+			LastID: last.ID,
+			Anchor: sortTime(last, req.SortBy),
+		})
+	case len(papers) == 0 && prev != nil && start < total:
+		next := *prev
+		next.Offset = start - 1
+		result.NextCursor = s.encodeCursor(&next)
+	}
+	return result
+}
+
+// cacheKey identifies a cached feed page. Every parameter that changes the
+// page is part of the key.
+func cacheKey(categories, authors []string, sortBy string, offset, limit int) string {
+	return fmt.Sprintf("%s|%s|%d|%d", feedKey(categories, authors), sortBy, offset, limit)
+}
+
+// feedKey identifies the merged feed of normalized categories and authors.
+// Feeds without authors keep the key of their categories alone.
+func feedKey(categories, authors []string) string {
+	key := strings.Join(categories, ",")
+	if len(authors) > 0 {
+		key += ";au:" + strings.Join(authors, ",")
+	}
+	return key
+}
+
+// normalizeCategories trims, de-duplicates and sorts categories, so the same
+// set always maps to the same feed. Categories covered by an archive in the
+// list (cs.LG by cs.*) are dropped. More than max distinct categories are
+// rejected, unless limit is zero.
+func normalizeCategories(categories []string, limit int) ([]string, error) {
+	seen := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		if category = strings.TrimSpace(category); category != "" {
+			seen[category] = true
+		}
+	}
+	if limit > 0 && len(seen) > limit {
+		return nil, fmt.Errorf("%w: at most %d categories, got %d", ErrInvalidCategory, limit, len(seen))
+	}
+
+	result := make([]string, 0, len(seen))
+	for category := range seen {
+		archive, subject, found := strings.Cut(category, ".")
+		if found && subject != "*" && seen[archive+".*"] {
+			continue
+		}
+		result = append(result, category)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// normalizeAuthors lowercases author names, collapses their whitespace and
+// drops duplicates, sorted so the same set always maps to the same feed.
+// arXiv matches author names ignoring case.
+func normalizeAuthors(authors []string) []string {
+	seen := make(map[string]bool, len(authors))
+	result := make([]string, 0, len(authors))
+	for _, author := range authors {
+		author = strings.ToLower(strings.Join(strings.Fields(author), " "))
+		if author != "" && !seen[author] {
+			seen[author] = true
+			result = append(result, author)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// dedupePapers drops repeated papers, keeping the first occurrence.
+func dedupePapers(papers []*arxiv.Paper) []*arxiv.Paper {
+	seen := make(map[string]bool, len(papers))
+	result := make([]*arxiv.Paper, 0, len(papers))
+	for _, p := range papers {
+		if seen[p.ID] {
+			continue
+		}
+		seen[p.ID] = true
+		result = append(result, p)
+	}
+	return result
+}
+
+// indexOfPaper returns the position of a paper in a page, or -1.
+func indexOfPaper(papers []*paperRepo.Paper, id string) int {
+	for i, p := range papers {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
 }
 
 // convertArxivPapers converts arXiv papers to repository papers.
@@ -58,8 +368,10 @@ func (s *Impl) convertArxivPapers(papers []*arxiv.Paper) []*paperRepo.Paper {
 	for i, p := range papers {
 		result[i] = &paperRepo.Paper{
 			ID:              p.ID,
+			Version:         p.Version,
 			Title:           p.Title,
 			Authors:         p.Authors,
+			AuthorDetails:   convertArxivAuthors(p.AuthorDetails),
 			Summary:         p.Summary,
 			Published:       p.Published,
 			Updated:         p.Updated,
@@ -68,6 +380,9 @@ func (s *Impl) convertArxivPapers(papers []*arxiv.Paper) []*paperRepo.Paper {
 			ArxivURL:        p.ArxivURL,
 			PDFURL:          p.PDFURL,
 			ImageURL:        p.ImageURL,
+			DOI:             p.DOI,
+			JournalRef:      p.JournalRef,
+			Comment:         p.Comment,
 		}
 	}
 	return result
@@ -79,8 +394,10 @@ func (s *Impl) convertRepoPapers(papers []*paperRepo.Paper) []*Paper {
 	for i, p := range papers {
 		result[i] = &Paper{
 			ID:              p.ID,
+			Version:         p.Version,
 			Title:           p.Title,
 			Authors:         p.Authors,
+			AuthorDetails:   convertRepoAuthors(p.AuthorDetails),
 			Summary:         p.Summary,
 			Published:       p.Published,
 			Updated:         p.Updated,
@@ -89,7 +406,34 @@ func (s *Impl) convertRepoPapers(papers []*paperRepo.Paper) []*Paper {
 			ArxivURL:        p.ArxivURL,
 			PDFURL:          p.PDFURL,
 			ImageURL:        p.ImageURL,
+			DOI:             p.DOI,
+			JournalRef:      p.JournalRef,
+			Comment:         p.Comment,
 		}
 	}
 	return result
 }
+
+// convertArxivAuthors converts arXiv author details to repository author details.
+func convertArxivAuthors(authors []arxiv.AuthorDetail) []paperRepo.AuthorDetail {
+	if len(authors) == 0 {
+		return nil
+	}
+	result := make([]paperRepo.AuthorDetail, len(authors))
+	for i, a := range authors {
+		result[i] = paperRepo.AuthorDetail{Name: a.Name, Affiliations: a.Affiliations}
+	}
+	return result
+}
+
+// convertRepoAuthors converts repository author details to feature author details.
+func convertRepoAuthors(authors []paperRepo.AuthorDetail) []AuthorDetail {
+	if len(authors) == 0 {
+		return nil
+	}
+	result := make([]AuthorDetail, len(authors))
+	for i, a := range authors {
+		result[i] = AuthorDetail{Name: a.Name, Affiliations: a.Affiliations}
+	}
+	return result
+}
@@ -12,18 +12,33 @@ import (
 // mockArxivService is a mock implementation for testing.
 type mockArxivService struct {
 	papers []*arxiv.Paper
+	total  int
 	err    error
+	feed   []*arxiv.Paper // if set, FetchByCategory pages through it
+	calls  int
+	last   *arxiv.FetchRequest
 }
 
-func (m *mockArxivService) FetchByCategory(ctx context.Context, req *arxiv.FetchRequest) ([]*arxiv.Paper, error) {
+func (m *mockArxivService) FetchByCategory(ctx context.Context, req *arxiv.FetchRequest) (*arxiv.Result, error) {
+	m.calls++
+	m.last = req
 	if m.err != nil {
 		return nil, m.err
 	}
-	return m.papers, nil
+	if m.feed != nil {
+		start := min(req.Offset, len(m.feed))
+		end := min(start+req.MaxResults, len(m.feed))
+		return &arxiv.Result{Papers: m.feed[start:end], TotalResults: len(m.feed)}, nil
+	}
+	return &arxiv.Result{Papers: m.papers, TotalResults: m.total}, nil
 }
 
-func (m *mockArxivService) Search(ctx context.Context, query string, limit int) ([]*arxiv.Paper, error) {
-	return m.papers, m.err
+func (m *mockArxivService) Search(ctx context.Context, query string, limit int) (*arxiv.Result, error) {
+	return &arxiv.Result{Papers: m.papers, TotalResults: m.total}, m.err
+}
+
+func (m *mockArxivService) SearchQuery(ctx context.Context, query *arxiv.Query, limit int) (*arxiv.Result, error) {
+	return &arxiv.Result{Papers: m.papers, TotalResults: m.total}, m.err
 }
 
 func (m *mockArxivService) GetByID(ctx context.Context, id string) (*arxiv.Paper, error) {
@@ -33,24 +48,37 @@ func (m *mockArxivService) GetByID(ctx context.Context, id string) (*arxiv.Paper
 	return nil, m.err
 }
 
+func (m *mockArxivService) GetByIDs(ctx context.Context, ids []string) ([]*arxiv.Paper, error) {
+	return m.papers, m.err
+}
+
+func (m *mockArxivService) GetVersions(ctx context.Context, id string) ([]*arxiv.Version, error) {
+	return nil, m.err
+}
+
 // mockPaperRepository is a mock implementation for testing.
 type mockPaperRepository struct {
-	papers map[string][]*paperRepo.Paper
+	papers map[string]*paperRepo.PaperList
+	saved  map[string]*paperRepo.Paper
 }
 
 func newMockPaperRepository() *mockPaperRepository {
 	return &mockPaperRepository{
-		papers: make(map[string][]*paperRepo.Paper),
+		papers: make(map[string]*paperRepo.PaperList),
+		saved:  make(map[string]*paperRepo.Paper),
 	}
 }
 
-func (m *mockPaperRepository) GetByCategory(ctx context.Context, category string) ([]*paperRepo.Paper, bool) {
-	papers, found := m.papers[category]
-	return papers, found
+func (m *mockPaperRepository) GetByCategory(ctx context.Context, category string) (*paperRepo.PaperList, bool) {
+	list, found := m.papers[category]
+	return list, found
 }
 
-func (m *mockPaperRepository) SaveByCategory(ctx context.Context, category string, papers []*paperRepo.Paper, ttl time.Duration) {
-	m.papers[category] = papers
+func (m *mockPaperRepository) SaveByCategory(ctx context.Context, category string, list *paperRepo.PaperList, ttl time.Duration) {
+	m.papers[category] = list
+	for _, p := range list.Papers {
+		m.saved[p.ID] = p
+	}
 }
 
 func (m *mockPaperRepository) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
@@ -58,6 +86,15 @@ func (m *mockPaperRepository) GetByID(ctx context.Context, id string) (*paperRep
 }
 
 func (m *mockPaperRepository) Save(ctx context.Context, paper *paperRepo.Paper, ttl time.Duration) {
+	m.saved[paper.ID] = paper
+}
+
+func (m *mockPaperRepository) Upsert(ctx context.Context, papers []*paperRepo.Paper) error {
+	return nil
+}
+
+func (m *mockPaperRepository) Find(ctx context.Context, q *paperRepo.Query) (*paperRepo.PaperList, error) {
+	return &paperRepo.PaperList{}, nil
 }
 
 func (m *mockPaperRepository) InvalidateCategory(ctx context.Context, category string) {
@@ -65,7 +102,7 @@ func (m *mockPaperRepository) InvalidateCategory(ctx context.Context, category s
 }
 
 func (m *mockPaperRepository) Clear(ctx context.Context) {
-	m.papers = make(map[string][]*paperRepo.Paper)
+	m.papers = make(map[string]*paperRepo.PaperList)
 }
 
 func TestImpl_GetFeed_FromArxiv(t *testing.T) {
@@ -78,29 +115,45 @@ func TestImpl_GetFeed_FromArxiv(t *testing.T) {
 				Authors:         []string{"John Doe"},
 				Summary:         "Test summary",
 				PrimaryCategory: "cs.AI",
+				DOI:             "10.1000/xyz123",
+				AuthorDetails:   []arxiv.AuthorDetail{{Name: "John Doe", Affiliations: []string{"MIT"}}},
 			},
 		},
+		total: 4821,
 	}
 	mockRepo := newMockPaperRepository()
-	svc := New(mockArxiv, mockRepo, 5*time.Minute)
+	svc := New(mockArxiv, mockRepo, 5*time.Minute, "test-secret", nil, nil, nil, nil)
 
 	// Act
-	papers, err := svc.GetFeed(context.Background(), &FetchRequest{
-		Category: "cs.AI",
-		Limit:    10,
-		SortBy:   "lastUpdatedDate",
+	result, err := svc.GetFeed(context.Background(), &FetchRequest{
+		Categories: []string{"cs.AI"},
+		Limit:      10,
+		SortBy:     "lastUpdatedDate",
 	})
 
 	// Assert
 	if err != nil {
-		t.Errorf("Expected no error, got: %v", err)
+		t.Fatalf("Expected no error, got: %v", err)
 	}
+	papers := result.Papers
 	if len(papers) != 1 {
-		t.Errorf("Expected 1 paper, got: %d", len(papers))
+		t.Fatalf("Expected 1 paper, got: %d", len(papers))
 	}
 	if papers[0].ID != "2301.12345" {
 		t.Errorf("Expected ID '2301.12345', got: %s", papers[0].ID)
 	}
+	if result.Total != 4821 {
+		t.Errorf("Expected total 4821, got: %d", result.Total)
+	}
+	if papers[0].DOI != "10.1000/xyz123" || papers[0].AuthorDetails[0].Affiliations[0] != "MIT" {
+		t.Errorf("Expected DOI and affiliations to be carried through, got: %+v", papers[0])
+	}
+	if cached := mockRepo.papers[cacheKey([]string{"cs.AI"}, nil, "lastUpdatedDate", 0, 10)]; cached == nil || cached.Total != 4821 {
+		t.Errorf("Expected total to be cached, got: %+v", cached)
+	}
+	if mockRepo.saved["2301.12345"] == nil {
+		t.Error("Expected paper to be cached by ID")
+	}
 }
 
 func TestImpl_GetFeed_FromCache(t *testing.T) {
@@ -109,30 +162,560 @@ func TestImpl_GetFeed_FromCache(t *testing.T) {
 		papers: []*arxiv.Paper{}, // Empty - should not be called
 	}
 	mockRepo := newMockPaperRepository()
-	mockRepo.papers["cs.AI"] = []*paperRepo.Paper{
-		{
-			ID:              "cached-paper",
-			Title:           "Cached Paper",
-			PrimaryCategory: "cs.AI",
+	mockRepo.papers[cacheKey([]string{"cs.AI"}, nil, "lastUpdatedDate", 0, 10)] = &paperRepo.PaperList{
+		Papers: []*paperRepo.Paper{
+			{
+				ID:              "cached-paper",
+				Title:           "Cached Paper",
+				PrimaryCategory: "cs.AI",
+			},
 		},
+		Total: 1,
 	}
-	svc := New(mockArxiv, mockRepo, 5*time.Minute)
+	svc := New(mockArxiv, mockRepo, 5*time.Minute, "test-secret", nil, nil, nil, nil)
 
 	// Act
-	papers, err := svc.GetFeed(context.Background(), &FetchRequest{
-		Category: "cs.AI",
-		Limit:    10,
-		SortBy:   "lastUpdatedDate",
+	result, err := svc.GetFeed(context.Background(), &FetchRequest{
+		Categories: []string{"cs.AI"},
+		Limit:      10,
+		SortBy:     "lastUpdatedDate",
 	})
 
 	// Assert
 	if err != nil {
-		t.Errorf("Expected no error, got: %v", err)
+		t.Fatalf("Expected no error, got: %v", err)
 	}
+	papers := result.Papers
 	if len(papers) != 1 {
-		t.Errorf("Expected 1 paper, got: %d", len(papers))
+		t.Fatalf("Expected 1 paper, got: %d", len(papers))
 	}
 	if papers[0].ID != "cached-paper" {
 		t.Errorf("Expected ID 'cached-paper', got: %s", papers[0].ID)
 	}
 }
+
+func TestImpl_Refresh_ReplacesCache(t *testing.T) {
+	// Arrange
+	mockArxiv := &mockArxivService{
+		papers: []*arxiv.Paper{{ID: "2401.00001", Title: "Fresh Paper", PrimaryCategory: "cs.AI"}},
+		total:  1,
+	}
+	mockRepo := newMockPaperRepository()
+	mockRepo.papers[cacheKey([]string{"cs.AI"}, nil, "", 0, 10)] = &paperRepo.PaperList{
+		Papers: []*paperRepo.Paper{{ID: "cached-paper", Title: "Cached Paper"}},
+		Total:  1,
+	}
+	svc := New(mockArxiv, mockRepo, 5*time.Minute, "test-secret", nil, nil, nil, nil)
+
+	// Act
+	result, err := svc.Refresh(context.Background(), &FetchRequest{Categories: []string{"cs.AI"}, Limit: 10})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result.Papers) != 1 || result.Papers[0].ID != "2401.00001" {
+		t.Fatalf("Expected the fresh paper, got: %v", result.Papers)
+	}
+	if cached := mockRepo.papers[cacheKey([]string{"cs.AI"}, nil, "", 0, 10)]; cached.Papers[0].ID != "2401.00001" {
+		t.Errorf("Expected cache to be replaced, got: %s", cached.Papers[0].ID)
+	}
+	if _, ok := mockRepo.saved["2401.00001"]; !ok {
+		t.Error("Expected fresh paper to be saved")
+	}
+}
+
+// newFeed returns papers with the given IDs, most recently updated first.
+func newFeed(ids ...string) []*arxiv.Paper {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	papers := make([]*arxiv.Paper, len(ids))
+	for i, id := range ids {
+		papers[i] = &arxiv.Paper{ID: id, Updated: base.Add(-time.Duration(i) * time.Hour)}
+	}
+	return papers
+}
+
+// paperIDs returns the IDs of papers in order.
+func paperIDs(papers []*Paper) []string {
+	ids := make([]string, len(papers))
+	for i, p := range papers {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestImpl_GetFeed_CacheKeyedByRequest(t *testing.T) {
+	// Arrange
+	mockArxiv := &mockArxivService{feed: newFeed("p1", "p2", "p3", "p4")}
+	svc := New(mockArxiv, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, nil, nil, nil)
+	ctx := context.Background()
+
+	// Act
+	first, _ := svc.GetFeed(ctx, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 2, SortBy: "lastUpdatedDate"})
+	second, _ := svc.GetFeed(ctx, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 2, Offset: 2, SortBy: "lastUpdatedDate"})
+	again, _ := svc.GetFeed(ctx, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 2, Offset: 2, SortBy: "lastUpdatedDate"})
+
+	// Assert
+	if got := paperIDs(first.Papers); got[0] != "p1" || got[1] != "p2" {
+		t.Errorf("Expected first page [p1 p2], got: %v", got)
+	}
+	if got := paperIDs(second.Papers); got[0] != "p3" || got[1] != "p4" {
+		t.Errorf("Expected second page [p3 p4], got: %v", got)
+	}
+	if got := paperIDs(again.Papers); got[0] != "p3" {
+		t.Errorf("Expected cached second page, got: %v", got)
+	}
+	if mockArxiv.calls != 2 {
+		t.Errorf("Expected 2 upstream calls, got: %d", mockArxiv.calls)
+	}
+}
+
+func TestImpl_GetFeed_Cursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		arrived []string // papers added to the top of the feed after the first page
+		want    []string
+	}{
+		{name: "unchanged feed", want: []string{"p1", "p2", "p3", "p4", "p5"}},
+		{name: "new papers arrive", arrived: []string{"n1", "n2", "n3"}, want: []string{"p1", "p2", "p3", "p4", "p5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockArxiv := &mockArxivService{feed: newFeed("p1", "p2", "p3", "p4", "p5")}
+			svc := New(mockArxiv, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, nil, nil, nil)
+			req := &FetchRequest{Categories: []string{"cs.AI"}, Limit: 2, SortBy: "lastUpdatedDate"}
+
+			// Act
+			var got []string
+			for pages := 0; pages < 10; pages++ {
+				result, err := svc.GetFeed(context.Background(), req)
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				got = append(got, paperIDs(result.Papers)...)
+				if pages == 0 && tt.arrived != nil {
+					arrived := newFeed(tt.arrived...)
+					for _, p := range arrived {
+						p.Updated = p.Updated.Add(time.Hour * 24)
+					}
+					mockArxiv.feed = append(arrived, mockArxiv.feed...)
+				}
+				if result.NextCursor == "" {
+					break
+				}
+				req = &FetchRequest{Categories: []string{"cs.AI"}, Limit: 2, SortBy: "lastUpdatedDate", Cursor: result.NextCursor}
+			}
+
+			// Assert
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got: %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expected %v, got: %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestImpl_GetFeed_CursorRefetchesStaleCache(t *testing.T) {
+	// Arrange: the second page was cached before n1 arrived, the first page after.
+	mockArxiv := &mockArxivService{feed: newFeed("p1", "p2", "p3", "p4")}
+	mockRepo := newMockPaperRepository()
+	svc := New(mockArxiv, mockRepo, 5*time.Minute, "test-secret", nil, nil, nil, nil)
+	ctx := context.Background()
+	mockRepo.papers[cacheKey([]string{"cs.AI"}, nil, "lastUpdatedDate", 1, 3)] = &paperRepo.PaperList{
+		Papers: []*paperRepo.Paper{{ID: "p2"}, {ID: "p3"}, {ID: "p4"}},
+		Total:  4,
+	}
+	n1 := newFeed("n1")[0]
+	n1.Updated = n1.Updated.Add(time.Hour)
+	mockArxiv.feed = append([]*arxiv.Paper{n1}, mockArxiv.feed...)
+	first, err := svc.GetFeed(ctx, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 2, SortBy: "lastUpdatedDate"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Act
+	second, err := svc.GetFeed(ctx, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 2, SortBy: "lastUpdatedDate", Cursor: first.NextCursor})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := paperIDs(second.Papers); len(got) != 2 || got[0] != "p2" || got[1] != "p3" {
+		t.Errorf("Expected [p2 p3] after [n1 p1], got: %v", got)
+	}
+}
+
+func TestImpl_GetFeed_InvalidCursor(t *testing.T) {
+	// Arrange
+	mockArxiv := &mockArxivService{feed: newFeed("p1", "p2", "p3")}
+	svc := New(mockArxiv, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, nil, nil, nil)
+	first, _ := svc.GetFeed(context.Background(), &FetchRequest{Categories: []string{"cs.AI"}, Limit: 1, SortBy: "lastUpdatedDate"})
+	other := New(mockArxiv, newMockPaperRepository(), 5*time.Minute, "other-secret", nil, nil, nil, nil)
+
+	tests := []struct {
+		name string
+		svc  *Impl
+		req  *FetchRequest
+	}{
+		{"malformed", svc, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 1, SortBy: "lastUpdatedDate", Cursor: "not-a-cursor"}},
+		{"tampered", svc, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 1, SortBy: "lastUpdatedDate", Cursor: "x" + first.NextCursor}},
+		{"different secret", other, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 1, SortBy: "lastUpdatedDate", Cursor: first.NextCursor}},
+		{"different category", svc, &FetchRequest{Categories: []string{"cs.LG"}, Limit: 1, SortBy: "lastUpdatedDate", Cursor: first.NextCursor}},
+		{"different sort", svc, &FetchRequest{Categories: []string{"cs.AI"}, Limit: 1, SortBy: "submittedDate", Cursor: first.NextCursor}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := tt.svc.GetFeed(context.Background(), tt.req)
+
+			// Assert
+			if !IsInvalidCursor(err) {
+				t.Errorf("Expected ErrInvalidCursor, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestImpl_GetFeed_MergesCategories(t *testing.T) {
+	// Arrange
+	mockArxiv := &mockArxivService{
+		feed: []*arxiv.Paper{{ID: "p1"}, {ID: "p2"}, {ID: "p1"}, {ID: "p3"}}, // p1 cross-listed
+	}
+	mockRepo := newMockPaperRepository()
+	svc := New(mockArxiv, mockRepo, 5*time.Minute, "test-secret", nil, nil, nil, nil)
+
+	// Act
+	result, err := svc.GetFeed(context.Background(), &FetchRequest{
+		Categories: []string{" stat.ML", "cs.LG", "cs.*", "stat.ML", ""},
+		Limit:      10,
+		SortBy:     "lastUpdatedDate",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := mockArxiv.last.Categories; len(got) != 2 || got[0] != "cs.*" || got[1] != "stat.ML" {
+		t.Errorf("Expected normalized categories [cs.* stat.ML], got: %v", got)
+	}
+	if got := paperIDs(result.Papers); len(got) != 3 || got[0] != "p1" || got[1] != "p2" || got[2] != "p3" {
+		t.Errorf("Expected de-duplicated papers [p1 p2 p3], got: %v", got)
+	}
+
+	// The same set in another order is served from the same cache entry.
+	svc.GetFeed(context.Background(), &FetchRequest{Categories: []string{"stat.ML", "cs.*"}, Limit: 10, SortBy: "lastUpdatedDate"})
+	if mockArxiv.calls != 1 {
+		t.Errorf("Expected 1 upstream call, got: %d", mockArxiv.calls)
+	}
+}
+
+func TestImpl_GetFeed_InvalidCategory(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		upstream   error
+	}{
+		{name: "too many", categories: []string{"a.A", "a.B", "a.C", "a.D", "a.E", "a.F", "a.G", "a.H", "a.I", "a.J", "a.K"}},
+		{name: "rejected upstream", categories: []string{"cs.AI OR all:x"}, upstream: arxiv.ErrInvalidQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			svc := New(&mockArxivService{err: tt.upstream}, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, nil, nil, nil)
+
+			// Act
+			_, err := svc.GetFeed(context.Background(), &FetchRequest{Categories: tt.categories, Limit: 10})
+
+			// Assert
+			if !IsInvalidCategory(err) {
+				t.Errorf("Expected ErrInvalidCategory, got: %v", err)
+			}
+		})
+	}
+}
+
+// mockProfileSource returns a fixed profile.
+type mockProfileSource struct {
+	profile *Profile
+	userID  int64
+}
+
+func (m *mockProfileSource) Profile(ctx context.Context, userID int64) (*Profile, error) {
+	m.userID = userID
+	return m.profile, nil
+}
+
+// mockSeenSource reports a fixed set of papers as seen.
+type mockSeenSource map[string]bool
+
+func (m mockSeenSource) Seen(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error) {
+	seen := make(map[string]bool)
+	for _, id := range paperIDs {
+		seen[id] = m[id]
+	}
+	return seen, nil
+}
+
+// mockFollowSource returns fixed follows and records the user asked for.
+type mockFollowSource struct {
+	categories []string
+	authors    []string
+	userID     int64
+}
+
+func (m *mockFollowSource) Follows(ctx context.Context, userID int64) ([]string, []string, error) {
+	m.userID = userID
+	return m.categories, m.authors, nil
+}
+
+func TestDefaultRanker_Rank(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	candidates := func() []*Paper {
+		return []*Paper{
+			{ID: "a", Categories: []string{"cs.AI"}, Authors: []string{"Ann"}, Updated: now},
+			{ID: "b", Categories: []string{"cs.CL"}, Authors: []string{"Bob"}, Updated: now.Add(-time.Hour)},
+			{ID: "c", Categories: []string{"cs.LG", "stat.ML"}, Authors: []string{"Cat Lee"}, Updated: now.Add(-2 * time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		profile  *Profile
+		expected []string
+	}{
+		{
+			name:     "no signals keeps date order",
+			profile:  &Profile{Now: now},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name: "liked categories rank first",
+			profile: &Profile{Now: now, Interactions: []Interaction{
+				{Kind: InteractionLike, PaperID: "x", Categories: []string{"stat.ML"}, At: now},
+			}},
+			expected: []string{"c", "a", "b"},
+		},
+		{
+			name: "bookmarks outweigh short views",
+			profile: &Profile{Now: now, Interactions: []Interaction{
+				{Kind: InteractionView, PaperID: "x", Categories: []string{"cs.AI"}, Dwell: 30 * time.Second, At: now},
+				{Kind: InteractionBookmark, PaperID: "y", Categories: []string{"cs.CL"}, At: now},
+			}},
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			name: "old interactions decay",
+			profile: &Profile{Now: now, Interactions: []Interaction{
+				{Kind: InteractionBookmark, PaperID: "x", Categories: []string{"cs.CL"}, At: now.AddDate(-1, 0, 0)},
+				{Kind: InteractionLike, PaperID: "y", Categories: []string{"cs.LG"}, At: now},
+			}},
+			expected: []string{"c", "a", "b"},
+		},
+		{
+			name:     "followed authors and archives",
+			profile:  &Profile{Now: now, FollowedAuthors: []string{"cat  LEE"}, FollowedCategories: []string{"cs.*"}},
+			expected: []string{"c", "a", "b"},
+		},
+		{
+			name: "papers already liked move down",
+			profile: &Profile{Now: now, Interactions: []Interaction{
+				{Kind: InteractionLike, PaperID: "a", Categories: []string{"cs.AI"}, At: now},
+			}},
+			expected: []string{"b", "c", "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ranker := NewDefaultRanker(DefaultRankWeights())
+			papers := candidates()
+
+			// Act
+			ranked := ranker.Rank(tt.profile, papers)
+
+			// Assert
+			got := paperIDs(ranked)
+			for i := range tt.expected {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("Expected %v, got: %v", tt.expected, got)
+				}
+			}
+			if again := paperIDs(ranker.Rank(tt.profile, candidates())); again[0] != got[0] || again[2] != got[2] {
+				t.Errorf("Expected deterministic ranking, got %v then %v", got, again)
+			}
+			if papers[0].ID != "a" {
+				t.Error("Expected the input order to be left unchanged")
+			}
+		})
+	}
+}
+
+func TestImpl_GetFeed_PersonalRanking(t *testing.T) {
+	// Arrange
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockArxiv := &mockArxivService{feed: []*arxiv.Paper{
+		{ID: "p1", Categories: []string{"cs.AI"}, Updated: base},
+		{ID: "p2", Categories: []string{"cs.CL"}, Updated: base.Add(-time.Hour)},
+		{ID: "p3", Categories: []string{"cs.AI"}, Updated: base.Add(-2 * time.Hour)},
+	}}
+	profiles := &mockProfileSource{profile: &Profile{Now: base, FollowedCategories: []string{"cs.CL"}}}
+	svc := New(mockArxiv, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, profiles, nil, nil)
+	req := &FetchRequest{Categories: []string{"cs.AI", "cs.CL"}, Limit: 2, SortBy: "lastUpdatedDate"}
+
+	// Act
+	chronological, _ := svc.GetFeed(context.Background(), req)
+	personalReq := *req
+	personalReq.Ranking, personalReq.UserID = RankingPersonal, 42
+	personal, err := svc.GetFeed(context.Background(), &personalReq)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := paperIDs(personal.Papers); got[0] != "p2" || got[1] != "p1" {
+		t.Errorf("Expected followed category first [p2 p1], got: %v", got)
+	}
+	if profiles.userID != 42 {
+		t.Errorf("Expected profile of user 42, got: %d", profiles.userID)
+	}
+	if personal.NextCursor != chronological.NextCursor {
+		t.Error("Expected ranking not to change the cursor")
+	}
+	if personal.RankingScope != RankingScopePage || chronological.RankingScope != "" {
+		t.Errorf("Expected page scope only when ranked, got: %q and %q", personal.RankingScope, chronological.RankingScope)
+	}
+}
+
+func TestImpl_GetFeed_ExcludeSeen(t *testing.T) {
+	// Arrange
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockArxiv := &mockArxivService{feed: []*arxiv.Paper{
+		{ID: "p1", Categories: []string{"cs.AI"}, Updated: base},
+		{ID: "p2", Categories: []string{"cs.AI"}, Updated: base.Add(-time.Hour)},
+		{ID: "p3", Categories: []string{"cs.AI"}, Updated: base.Add(-2 * time.Hour)},
+		{ID: "p4", Categories: []string{"cs.AI"}, Updated: base.Add(-3 * time.Hour)},
+	}}
+	seen := mockSeenSource{"p1": true, "p2": true, "p4": true}
+	svc := New(mockArxiv, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, nil, seen, nil)
+	req := &FetchRequest{Categories: []string{"cs.AI"}, Limit: 2, SortBy: "lastUpdatedDate", UserID: 42, ExcludeSeen: true}
+
+	// Act
+	first, err := svc.GetFeed(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	nextReq := *req
+	nextReq.Cursor = first.NextCursor
+	second, err := svc.GetFeed(context.Background(), &nextReq)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(first.Papers) != 0 || first.NextCursor == "" {
+		t.Errorf("Expected an empty first page that still continues, got: %v, cursor %q", paperIDs(first.Papers), first.NextCursor)
+	}
+	if got := paperIDs(second.Papers); len(got) != 1 || got[0] != "p3" {
+		t.Errorf("Expected only unseen [p3] on the second page, got: %v", got)
+	}
+}
+
+func TestImpl_GetFeed_Following(t *testing.T) {
+	// Arrange
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockArxiv := &mockArxivService{feed: []*arxiv.Paper{
+		{ID: "p1", Authors: []string{"Jonathan Ho"}, Updated: base},
+		{ID: "p2", Categories: []string{"cs.LG"}, Updated: base.Add(-time.Hour)},
+		{ID: "p3", Categories: []string{"cs.LG"}, Updated: base.Add(-2 * time.Hour)},
+	}}
+	follows := &mockFollowSource{categories: []string{"cs.LG", " cs.LG"}, authors: []string{"Jonathan  Ho", "jonathan ho"}}
+	svc := New(mockArxiv, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, nil, nil, follows)
+	req := &FetchRequest{Mode: ModeFollowing, Categories: []string{"cs.AI"}, Limit: 2, SortBy: "lastUpdatedDate", UserID: 42}
+
+	// Act
+	first, err := svc.GetFeed(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	upstream := mockArxiv.last
+	nextReq := *req
+	nextReq.Cursor = first.NextCursor
+	second, err := svc.GetFeed(context.Background(), &nextReq)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if follows.userID != 42 {
+		t.Errorf("Expected follows of user 42, got: %d", follows.userID)
+	}
+	if len(upstream.Categories) != 1 || upstream.Categories[0] != "cs.LG" || len(upstream.Authors) != 1 || upstream.Authors[0] != "jonathan ho" {
+		t.Errorf("Expected followed cs.LG and jonathan ho instead of requested categories, got: %v, %v", upstream.Categories, upstream.Authors)
+	}
+	if got := paperIDs(first.Papers); len(got) != 2 || got[0] != "p1" {
+		t.Errorf("Expected [p1 p2], got: %v", got)
+	}
+	if got := paperIDs(second.Papers); len(got) != 1 || got[0] != "p3" {
+		t.Errorf("Expected [p3] on the second page, got: %v", got)
+	}
+
+	// A cursor from the following feed does not continue a category feed.
+	categoryReq := &FetchRequest{Categories: []string{"cs.LG"}, Limit: 2, SortBy: "lastUpdatedDate", Cursor: first.NextCursor}
+	if _, err := svc.GetFeed(context.Background(), categoryReq); !IsInvalidCursor(err) {
+		t.Errorf("Expected ErrInvalidCursor, got: %v", err)
+	}
+}
+
+func TestImpl_GetFeed_FollowingNothing(t *testing.T) {
+	// Arrange
+	mockArxiv := &mockArxivService{}
+	svc := New(mockArxiv, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, nil, nil, &mockFollowSource{})
+
+	// Act
+	result, err := svc.GetFeed(context.Background(), &FetchRequest{Mode: ModeFollowing, Limit: 10, UserID: 42})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result.Papers) != 0 || result.Total != 0 || result.NextCursor != "" {
+		t.Errorf("Expected an empty feed, got: %+v", result)
+	}
+	if mockArxiv.calls != 0 {
+		t.Errorf("Expected no upstream fetch, got: %d", mockArxiv.calls)
+	}
+}
+
+func TestImpl_GetFeed_RankingErrors(t *testing.T) {
+	svc := New(&mockArxivService{}, newMockPaperRepository(), 5*time.Minute, "test-secret", nil, nil, nil, nil)
+
+	_, err := svc.GetFeed(context.Background(), &FetchRequest{Limit: 10, Ranking: RankingPersonal})
+	if !IsUserRequired(err) {
+		t.Errorf("Expected ErrUserRequired, got: %v", err)
+	}
+	_, err = svc.GetFeed(context.Background(), &FetchRequest{Limit: 10, ExcludeSeen: true})
+	if !IsUserRequired(err) {
+		t.Errorf("Expected ErrUserRequired for ExcludeSeen, got: %v", err)
+	}
+	_, err = svc.GetFeed(context.Background(), &FetchRequest{Limit: 10, Ranking: "popular", UserID: 1})
+	if !IsInvalidRanking(err) {
+		t.Errorf("Expected ErrInvalidRanking, got: %v", err)
+	}
+	_, err = svc.GetFeed(context.Background(), &FetchRequest{Limit: 10, Mode: ModeFollowing})
+	if !IsUserRequired(err) {
+		t.Errorf("Expected ErrUserRequired for the following feed, got: %v", err)
+	}
+	_, err = svc.GetFeed(context.Background(), &FetchRequest{Limit: 10, Mode: "trending", UserID: 1})
+	if !IsInvalidMode(err) {
+		t.Errorf("Expected ErrInvalidMode, got: %v", err)
+	}
+}
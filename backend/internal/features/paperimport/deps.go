@@ -0,0 +1,13 @@
+package paperimport
+
+import (
+	"context"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// paperStore defines the paper repository capability required by this feature.
+type paperStore interface {
+	// Upsert stores papers permanently.
+	Upsert(ctx context.Context, papers []*paperRepo.Paper) error
+}
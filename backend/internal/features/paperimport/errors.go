@@ -0,0 +1,11 @@
+package paperimport
+
+import "errors"
+
+var (
+	// ErrInvalidRequest indicates that the import options are invalid.
+	ErrInvalidRequest = errors.New("invalid import request")
+)
+
+// IsInvalidRequest checks if the error is ErrInvalidRequest.
+func IsInvalidRequest(err error) bool { return errors.Is(err, ErrInvalidRequest) }
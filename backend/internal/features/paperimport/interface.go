@@ -0,0 +1,44 @@
+package paperimport
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ImportRequest contains parameters for importing a metadata snapshot.
+type ImportRequest struct {
+	// Categories keeps papers listed in any of these categories. An archive
+	// name such as "cs" matches all of its categories (cs.AI, cs.LG, ...).
+	// Empty keeps every category.
+	Categories []string
+	From       time.Time // Keep papers whose latest version was submitted on or after this time (zero for no bound)
+	Until      time.Time // Keep papers whose latest version was submitted on or before this time (zero for no bound)
+	BatchSize  int       // Papers per upsert (default 500)
+	Limit      int       // Stop after importing this many papers (0 for no limit)
+
+	// OnBatch, if set, is called after each batch has been stored.
+	OnBatch func(progress Progress)
+}
+
+// Progress reports the state of a running import.
+type Progress struct {
+	Lines    int // Lines read so far
+	Imported int // Papers stored
+	Filtered int // Papers skipped by the category or date filter
+	Invalid  int // Lines that could not be parsed
+}
+
+// Service defines the interface for offline metadata imports.
+type Service interface {
+	// Import streams an arXiv metadata snapshot (arxiv-metadata-oai-snapshot.json,
+	// one JSON object per line) into the paper repository.
+	// @Params:
+	//   - ctx: context for cancellation; batches stored so far are kept
+	//   - r: the snapshot contents
+	//   - req: filters and batching options
+	// @Returns:
+	//   - *Progress: lines read and papers imported, filtered or rejected
+	//   - error: ErrInvalidRequest for bad options, or if reading or storing fails
+	Import(ctx context.Context, r io.Reader, req *ImportRequest) (*Progress, error)
+}
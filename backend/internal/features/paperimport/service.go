@@ -0,0 +1,273 @@
+package paperimport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// defaultBatchSize is the number of papers per upsert when none is given.
+const defaultBatchSize = 500
+
+// versionLayout is the date format of snapshot version entries
+// (e.g., "Mon, 2 Apr 2007 19:18:42 GMT").
+const versionLayout = "Mon, 2 Jan 2006 15:04:05 MST"
+
+// snapshotRecord is one line of arxiv-metadata-oai-snapshot.json.
+// Optional fields are null in the snapshot and decode as empty strings.
+type snapshotRecord struct {
+	ID            string            `json:"id"`
+	Authors       string            `json:"authors"`
+	Title         string            `json:"title"`
+	Comments      string            `json:"comments"`
+	JournalRef    string            `json:"journal-ref"`
+	DOI           string            `json:"doi"`
+	Categories    string            `json:"categories"`
+	License       string            `json:"license"`
+	Abstract      string            `json:"abstract"`
+	Versions      []snapshotVersion `json:"versions"`
+	UpdateDate    string            `json:"update_date"`
+	AuthorsParsed [][]string        `json:"authors_parsed"`
+}
+
+type snapshotVersion struct {
+	Version string `json:"version"`
+	Created string `json:"created"`
+}
+
+// Impl implements the paperimport Service interface.
+type Impl struct {
+	papers paperStore
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new paper import service instance.
+func New(papers paperStore) *Impl {
+	return &Impl{papers: papers}
+}
+
+// Import streams the snapshot line by line, so memory use is bounded by the batch size.
+func (s *Impl) Import(ctx context.Context, r io.Reader, req *ImportRequest) (*Progress, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	batchSize := req.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+
+	progress := &Progress{}
+	batch := make([]*paperRepo.Paper, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.papers.Upsert(ctx, batch); err != nil {
+			return fmt.Errorf("failed to store imported papers: %w", err)
+		}
+		progress.Imported += len(batch)
+		batch = batch[:0]
+		if req.OnBatch != nil {
+			req.OnBatch(*progress)
+		}
+		return nil
+	}
+
+	reader := bufio.NewReaderSize(r, 64*1024)
+	for {
+		if req.Limit > 0 && progress.Imported+len(batch) >= req.Limit {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return progress, fmt.Errorf("failed to read snapshot: %w", readErr)
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			progress.Lines++
+			paper, err := parseLine(line)
+			switch {
+			case err != nil:
+				progress.Invalid++
+			case !matches(paper, req):
+				progress.Filtered++
+			default:
+				batch = append(batch, paper)
+			}
+		}
+
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return progress, err
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	if err := flush(); err != nil {
+		return progress, err
+	}
+	return progress, nil
+}
+
+// validate checks the import options.
+func validate(req *ImportRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w: request is required", ErrInvalidRequest)
+	}
+	if req.BatchSize < 0 || req.Limit < 0 {
+		return fmt.Errorf("%w: batch size and limit must not be negative", ErrInvalidRequest)
+	}
+	if !req.From.IsZero() && !req.Until.IsZero() && req.Until.Before(req.From) {
+		return fmt.Errorf("%w: until is before from", ErrInvalidRequest)
+	}
+	for _, c := range req.Categories {
+		if strings.TrimSpace(c) == "" {
+			return fmt.Errorf("%w: empty category", ErrInvalidRequest)
+		}
+	}
+	return nil
+}
+
+// matches reports whether a paper passes the category and date filters.
+// Dates are compared against the latest version, the same order the feed uses.
+func matches(paper *paperRepo.Paper, req *ImportRequest) bool {
+	if !req.From.IsZero() && paper.Updated.Before(req.From) {
+		return false
+	}
+	if !req.Until.IsZero() && paper.Updated.After(req.Until) {
+		return false
+	}
+	if len(req.Categories) == 0 {
+		return true
+	}
+	for _, category := range paper.Categories {
+		for _, want := range req.Categories {
+			if category == want || strings.HasPrefix(category, want+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseLine decodes one snapshot line into a repository paper.
+func parseLine(line []byte) (*paperRepo.Paper, error) {
+	var rec snapshotRecord
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return nil, err
+	}
+
+	id, err := arxiv.ParseIdentifier(rec.ID)
+	if err != nil {
+		return nil, err
+	}
+	base := id.Base()
+
+	paper := &paperRepo.Paper{
+		ID:         base,
+		Title:      cleanText(rec.Title),
+		Summary:    cleanText(rec.Abstract),
+		Categories: strings.Fields(rec.Categories),
+		ArxivURL:   fmt.Sprintf("https://arxiv.org/abs/%s", base),
+		PDFURL:     fmt.Sprintf("https://arxiv.org/pdf/%s", base),
+		ImageURL:   fmt.Sprintf("https://arxiv.org/html/%s/x1.png", base),
+		DOI:        strings.TrimSpace(rec.DOI),
+		JournalRef: cleanText(rec.JournalRef),
+		Comment:    cleanText(rec.Comments),
+		License:    strings.TrimSpace(rec.License),
+	}
+	if len(paper.Categories) > 0 {
+		paper.PrimaryCategory = paper.Categories[0]
+	}
+
+	for _, v := range rec.Versions {
+		number, err := strconv.Atoi(strings.TrimPrefix(v.Version, "v"))
+		if err != nil {
+			continue
+		}
+		submitted, _ := time.Parse(versionLayout, v.Created)
+		paper.Versions = append(paper.Versions, paperRepo.Version{Version: number, Submitted: submitted.UTC()})
+	}
+	paper.Version = len(paper.Versions)
+
+	if len(paper.Versions) > 0 {
+		paper.Published = paper.Versions[0].Submitted
+		paper.Updated = paper.Versions[len(paper.Versions)-1].Submitted
+	} else if t, err := time.Parse("2006-01-02", rec.UpdateDate); err == nil {
+		paper.Published, paper.Updated = t, t
+	}
+	if paper.Published.IsZero() {
+		return nil, errors.New("paper has no dates")
+	}
+
+	paper.AuthorDetails = parseAuthors(rec)
+	paper.Authors = make([]string, len(paper.AuthorDetails))
+	for i, a := range paper.AuthorDetails {
+		paper.Authors[i] = a.Name
+	}
+	return paper, nil
+}
+
+// parseAuthors reads authors_parsed entries of the form
+// [keyname, forenames, suffix, affiliation...], falling back to the raw author string.
+func parseAuthors(rec snapshotRecord) []paperRepo.AuthorDetail {
+	authors := make([]paperRepo.AuthorDetail, 0, len(rec.AuthorsParsed))
+	for _, parts := range rec.AuthorsParsed {
+		if len(parts) == 0 {
+			continue
+		}
+		names := []string{}
+		for _, i := range []int{1, 0, 2} {
+			if i < len(parts) && strings.TrimSpace(parts[i]) != "" {
+				names = append(names, strings.TrimSpace(parts[i]))
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		author := paperRepo.AuthorDetail{Name: cleanText(strings.Join(names, " "))}
+		if len(parts) > 3 {
+			for _, affiliation := range parts[3:] {
+				if affiliation = cleanText(affiliation); affiliation != "" {
+					author.Affiliations = append(author.Affiliations, affiliation)
+				}
+			}
+		}
+		authors = append(authors, author)
+	}
+	if len(authors) > 0 {
+		return authors
+	}
+
+	raw := strings.ReplaceAll(cleanText(rec.Authors), " and ", ", ")
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			authors = append(authors, paperRepo.AuthorDetail{Name: name})
+		}
+	}
+	return authors
+}
+
+// cleanText removes extra whitespace from text.
+func cleanText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
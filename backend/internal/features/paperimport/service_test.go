@@ -0,0 +1,170 @@
+package paperimport
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockPaperStore records upserted batches.
+type mockPaperStore struct {
+	batches [][]*paperRepo.Paper
+	err     error
+}
+
+func (m *mockPaperStore) Upsert(ctx context.Context, papers []*paperRepo.Paper) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.batches = append(m.batches, append([]*paperRepo.Paper(nil), papers...))
+	return nil
+}
+
+func (m *mockPaperStore) all() []*paperRepo.Paper {
+	var papers []*paperRepo.Paper
+	for _, b := range m.batches {
+		papers = append(papers, b...)
+	}
+	return papers
+}
+
+// snapshot holds lines in the format of arxiv-metadata-oai-snapshot.json.
+const snapshot = `{"id":"0704.0001","submitter":"Pavel Nadolsky","authors":"C. Bal\\'azs, E. L. Berger, P. M. Nadolsky, C.-P. Yuan","title":"Calculation of prompt diphoton production cross sections at Tevatron and\n  LHC energies","comments":"37 pages, 15 figures","journal-ref":"Phys.Rev.D76:013009,2007","doi":"10.1103/PhysRevD.76.013009","report-no":"ANL-HEP-PR-07-12","categories":"hep-ph","license":null,"abstract":"  A fully differential calculation.\n","versions":[{"version":"v1","created":"Mon, 2 Apr 2007 19:18:42 GMT"},{"version":"v2","created":"Tue, 24 Jul 2007 20:10:27 GMT"}],"update_date":"2008-11-13","authors_parsed":[["Balázs","C.",""],["Berger","E. L.","","Argonne"],["Nadolsky","P. M.",""],["Yuan","C. -P.",""]]}
+{"id":"2301.12345","authors":"Jane Doe","title":"Deep Learning","categories":"cs.LG stat.ML","license":"http://creativecommons.org/licenses/by/4.0/","abstract":"Abstract.","versions":[{"version":"v1","created":"Mon, 16 Jan 2023 10:00:00 GMT"}],"update_date":"2023-01-17","authors_parsed":[["Doe","Jane",""]]}
+not json
+
+{"id":"math/0309136","authors":"A. Author and B. Author","title":"Old Style","categories":"math.GT cs.CG","abstract":"Abstract.","versions":[],"update_date":"2003-09-08","authors_parsed":[]}
+{"id":"bogus id","title":"Invalid","categories":"cs.AI","versions":[{"version":"v1","created":"Mon, 16 Jan 2023 10:00:00 GMT"}]}`
+
+func TestService_Import(t *testing.T) {
+	// Arrange
+	store := &mockPaperStore{}
+	svc := New(store)
+
+	var progress []Progress
+	req := &ImportRequest{BatchSize: 2, OnBatch: func(p Progress) { progress = append(progress, p) }}
+
+	// Act
+	result, err := svc.Import(context.Background(), strings.NewReader(snapshot), req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Lines != 5 || result.Imported != 3 || result.Invalid != 2 || result.Filtered != 0 {
+		t.Errorf("Unexpected progress: %+v", result)
+	}
+	if len(store.batches) != 2 || len(progress) != 2 || progress[0].Imported != 2 {
+		t.Errorf("Expected two batches with progress after each, got: %d batches, %+v", len(store.batches), progress)
+	}
+
+	papers := store.all()
+	p := papers[0]
+	if p.ID != "0704.0001" || p.Version != 2 || len(p.Versions) != 2 {
+		t.Errorf("Unexpected paper: %+v", p)
+	}
+	if p.Title != "Calculation of prompt diphoton production cross sections at Tevatron and LHC energies" {
+		t.Errorf("Expected cleaned title, got: %q", p.Title)
+	}
+	if p.Summary != "A fully differential calculation." || p.DOI != "10.1103/PhysRevD.76.013009" || p.JournalRef != "Phys.Rev.D76:013009,2007" {
+		t.Errorf("Unexpected metadata: %+v", p)
+	}
+	if !p.Published.Equal(time.Date(2007, 4, 2, 19, 18, 42, 0, time.UTC)) || !p.Updated.Equal(time.Date(2007, 7, 24, 20, 10, 27, 0, time.UTC)) {
+		t.Errorf("Expected dates from versions, got: %v / %v", p.Published, p.Updated)
+	}
+	if strings.Join(p.Authors, "|") != "C. Balázs|E. L. Berger|P. M. Nadolsky|C. -P. Yuan" {
+		t.Errorf("Unexpected authors: %v", p.Authors)
+	}
+	if len(p.AuthorDetails[1].Affiliations) != 1 || p.AuthorDetails[1].Affiliations[0] != "Argonne" {
+		t.Errorf("Expected affiliation Argonne, got: %+v", p.AuthorDetails[1])
+	}
+
+	if papers[1].License != "http://creativecommons.org/licenses/by/4.0/" || papers[1].PrimaryCategory != "cs.LG" {
+		t.Errorf("Unexpected paper: %+v", papers[1])
+	}
+
+	old := papers[2]
+	if old.ID != "math/0309136" || !old.Published.Equal(time.Date(2003, 9, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected old-style paper dated by update_date, got: %+v", old)
+	}
+	if len(old.Authors) != 2 || old.Authors[1] != "B. Author" {
+		t.Errorf("Expected authors from the raw string, got: %v", old.Authors)
+	}
+}
+
+func TestService_Import_Filters(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *ImportRequest
+		expected []string
+	}{
+		{"archive", &ImportRequest{Categories: []string{"cs"}}, []string{"2301.12345", "math/0309136"}},
+		{"exact category", &ImportRequest{Categories: []string{"hep-ph"}}, []string{"0704.0001"}},
+		{"date range", &ImportRequest{
+			From:  time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2007, 12, 31, 0, 0, 0, 0, time.UTC),
+		}, []string{"0704.0001", "math/0309136"}},
+		{"limit", &ImportRequest{Limit: 1}, []string{"0704.0001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockPaperStore{}
+			_, err := New(store).Import(context.Background(), strings.NewReader(snapshot), tt.req)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			var ids []string
+			for _, p := range store.all() {
+				ids = append(ids, p.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got: %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestService_Import_StoreError(t *testing.T) {
+	// Arrange
+	storeErr := errors.New("disk full")
+	svc := New(&mockPaperStore{err: storeErr})
+
+	// Act
+	_, err := svc.Import(context.Background(), strings.NewReader(snapshot), &ImportRequest{})
+
+	// Assert
+	if !errors.Is(err, storeErr) {
+		t.Errorf("Expected store error, got: %v", err)
+	}
+}
+
+func TestService_Import_InvalidRequest(t *testing.T) {
+	svc := New(&mockPaperStore{})
+	tests := []struct {
+		name string
+		req  *ImportRequest
+	}{
+		{"nil request", nil},
+		{"negative batch size", &ImportRequest{BatchSize: -1}},
+		{"empty category", &ImportRequest{Categories: []string{" "}}},
+		{"reversed range", &ImportRequest{
+			From:  time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+			Until: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Import(context.Background(), strings.NewReader(snapshot), tt.req)
+			if !IsInvalidRequest(err) {
+				t.Errorf("Expected ErrInvalidRequest, got: %v", err)
+			}
+		})
+	}
+}
@@ -4,13 +4,63 @@ import (
 	"context"
 
 	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+	"github.com/rrlian/papertok/backend/internal/core/vectorindex"
 )
 
 // arxivService defines the arXiv service capability required by this feature.
 type arxivService interface {
-	// Search searches papers by keyword.
-	Search(ctx context.Context, query string, limit int) ([]*arxiv.Paper, error)
+	// SearchQuery searches papers with a structured query.
+	SearchQuery(ctx context.Context, query *arxiv.Query, limit int) (*arxiv.Result, error)
 
 	// GetByID fetches a single paper by ID.
 	GetByID(ctx context.Context, id string) (*arxiv.Paper, error)
+
+	// GetByIDs fetches several papers by ID.
+	GetByIDs(ctx context.Context, ids []string) ([]*arxiv.Paper, error)
+
+	// GetVersions lists every version of a paper.
+	GetVersions(ctx context.Context, id string) ([]*arxiv.Version, error)
+}
+
+// searchIndex defines the full-text index capability used by the local backend.
+type searchIndex interface {
+	// Add indexes documents, replacing any with the same ID.
+	Add(docs ...*searchindex.Document)
+
+	// Remove drops documents from the index.
+	Remove(ids ...string)
+
+	// Search returns the matching documents, best match first.
+	Search(q *searchindex.Query) (*searchindex.Result, error)
+
+	// Len returns the number of indexed documents.
+	Len() int
+
+	// Reset removes every document.
+	Reset()
+}
+
+// embedder defines the text embedding capability used by semantic search.
+type embedder interface {
+	// Embed returns one unit vector per text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// vectorIndex defines the nearest neighbor capability used by semantic search.
+type vectorIndex interface {
+	// Add indexes vectors, replacing any with the same ID.
+	Add(items ...vectorindex.Item) error
+
+	// Remove drops vectors from the index.
+	Remove(ids ...string)
+
+	// Search returns the vectors closest to a query vector, closest first.
+	Search(vector []float32, limit int) ([]vectorindex.Hit, error)
+
+	// Has reports whether a vector is indexed under an ID.
+	Has(id string) bool
+
+	// Reset removes every vector.
+	Reset()
 }
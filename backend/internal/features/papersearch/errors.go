@@ -0,0 +1,29 @@
+package papersearch
+
+import "errors"
+
+var (
+	// ErrInvalidQuery indicates that the search request is empty or contains invalid terms.
+	ErrInvalidQuery = errors.New("invalid search query")
+
+	// ErrInvalidID indicates that a paper ID is not a valid arXiv identifier.
+	ErrInvalidID = errors.New("invalid paper ID")
+
+	// ErrIndexDisabled indicates that the local search index is not configured.
+	ErrIndexDisabled = errors.New("local search index is disabled")
+
+	// ErrSemanticDisabled indicates that semantic search is not configured.
+	ErrSemanticDisabled = errors.New("semantic search is disabled")
+)
+
+// IsInvalidQuery checks if the error is ErrInvalidQuery.
+func IsInvalidQuery(err error) bool { return errors.Is(err, ErrInvalidQuery) }
+
+// IsInvalidID checks if the error is ErrInvalidID.
+func IsInvalidID(err error) bool { return errors.Is(err, ErrInvalidID) }
+
+// IsIndexDisabled checks if the error is ErrIndexDisabled.
+func IsIndexDisabled(err error) bool { return errors.Is(err, ErrIndexDisabled) }
+
+// IsSemanticDisabled checks if the error is ErrSemanticDisabled.
+func IsSemanticDisabled(err error) bool { return errors.Is(err, ErrSemanticDisabled) }
@@ -0,0 +1,193 @@
+package papersearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// Fields of the documents in the local search index.
+const (
+	indexFieldTitle    = "title"
+	indexFieldAuthors  = "authors"
+	indexFieldAbstract = "abstract"
+	indexFieldCategory = "category" // keyword
+	indexFieldID       = "id"       // keyword
+)
+
+// rebuildBatchSize is the number of stored papers read per page when rebuilding.
+const rebuildBatchSize = 500
+
+// IndexConfig returns the ranking configuration for the paper index.
+// A title match weighs three times an abstract match, an author match twice.
+// Shared categories also count toward similarity between papers.
+func IndexConfig() searchindex.Config {
+	cfg := searchindex.DefaultConfig()
+	cfg.FieldBoosts = map[string]float64{
+		indexFieldTitle:    3,
+		indexFieldAuthors:  2,
+		indexFieldAbstract: 1,
+	}
+	cfg.KeywordWeights = map[string]float64{
+		indexFieldCategory: 1,
+	}
+	return cfg
+}
+
+// IndexDocument converts a stored paper into a search index document.
+func IndexDocument(p *paperRepo.Paper) *searchindex.Document {
+	return &searchindex.Document{
+		ID: p.ID,
+		Fields: map[string]string{
+			indexFieldTitle:    p.Title,
+			indexFieldAuthors:  strings.Join(p.Authors, ", "),
+			indexFieldAbstract: p.Summary,
+		},
+		Keywords: map[string][]string{
+			indexFieldCategory: p.Categories,
+			indexFieldID:       {p.ID},
+		},
+		Date: p.Published,
+	}
+}
+
+// RebuildIndex clears the local search index and refills it from the repository.
+func (s *Impl) RebuildIndex(ctx context.Context) (int, error) {
+	if s.index == nil {
+		return 0, ErrIndexDisabled
+	}
+
+	s.index.Reset()
+	return s.indexStored(ctx, time.Time{})
+}
+
+// SyncIndex adds the papers stored or changed since the given time to the
+// local search index, replacing their earlier documents.
+func (s *Impl) SyncIndex(ctx context.Context, since time.Time) (int, error) {
+	if s.index == nil {
+		return 0, ErrIndexDisabled
+	}
+	return s.indexStored(ctx, since)
+}
+
+// indexStored indexes the stored papers changed since the given time (all
+// of them if zero), one page at a time.
+func (s *Impl) indexStored(ctx context.Context, since time.Time) (int, error) {
+	query := &paperRepo.Query{StoredSince: since, Limit: rebuildBatchSize}
+	indexed := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return indexed, err
+		}
+
+		page, err := s.paperRepo.Find(ctx, query)
+		if err != nil {
+			return indexed, fmt.Errorf("failed to list stored papers: %w", err)
+		}
+
+		docs := make([]*searchindex.Document, len(page.Papers))
+		for i, p := range page.Papers {
+			docs[i] = IndexDocument(p)
+		}
+		s.index.Add(docs...)
+		indexed += len(docs)
+
+		if len(page.Papers) < query.Limit {
+			return indexed, nil
+		}
+		query.Offset += len(page.Papers)
+	}
+}
+
+// searchLocal answers a search request from the local index, loading the
+// matching papers from the repository.
+func (s *Impl) searchLocal(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
+	if s.index == nil {
+		return nil, ErrIndexDisabled
+	}
+
+	query, err := buildIndexQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.index.Search(query)
+	if err != nil {
+		if searchindex.IsEmptyQuery(err) {
+			return nil, fmt.Errorf("%w: a keyword, field term or date range is required", ErrInvalidQuery)
+		}
+		return nil, err
+	}
+
+	papers := make([]*Paper, 0, len(result.Hits))
+	total := result.Total
+	for _, hit := range result.Hits {
+		p, found := s.paperRepo.GetByID(ctx, hit.ID)
+		if !found || p == nil {
+			// Cached papers expire; forget them once they are gone.
+			s.index.Remove(hit.ID)
+			total--
+			continue
+		}
+		papers = append(papers, s.convertRepoPaper(p))
+	}
+
+	return &SearchResult{Papers: papers, Total: total, Backend: BackendLocal}, nil
+}
+
+// buildIndexQuery translates a search request into a local index query,
+// with the same shape as buildQuery: keywords AND (include...) ANDNOT exclude...
+func buildIndexQuery(req *SearchRequest) (*searchindex.Query, error) {
+	query := &searchindex.Query{
+		Text:     req.Query,
+		MatchAny: req.MatchAny,
+		From:     req.SubmittedFrom,
+		Until:    req.SubmittedTo,
+		Limit:    req.Limit,
+	}
+
+	for _, term := range req.Include {
+		clause, err := toIndexClause(term)
+		if err != nil {
+			return nil, err
+		}
+		query.Must = append(query.Must, clause)
+	}
+	for _, term := range req.Exclude {
+		clause, err := toIndexClause(term)
+		if err != nil {
+			return nil, err
+		}
+		query.MustNot = append(query.MustNot, clause)
+	}
+
+	return query, nil
+}
+
+// toIndexClause maps a feature search term to an index clause.
+func toIndexClause(term Term) (searchindex.Clause, error) {
+	switch term.Field {
+	case FieldTitle:
+		return searchindex.Clause{Field: indexFieldTitle, Text: term.Value}, nil
+	case FieldAuthor:
+		return searchindex.Clause{Field: indexFieldAuthors, Text: term.Value}, nil
+	case FieldAbstract:
+		return searchindex.Clause{Field: indexFieldAbstract, Text: term.Value}, nil
+	case FieldCategory:
+		return searchindex.Clause{Field: indexFieldCategory, Text: term.Value}, nil
+	case FieldID:
+		// The index holds bare IDs; drop any version suffix.
+		value := term.Value
+		if ident, err := arxiv.ParseIdentifier(value); err == nil {
+			value = ident.Base()
+		}
+		return searchindex.Clause{Field: indexFieldID, Text: value}, nil
+	default:
+		return searchindex.Clause{}, fmt.Errorf("%w: unknown field %q", ErrInvalidQuery, term.Field)
+	}
+}
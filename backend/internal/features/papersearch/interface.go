@@ -7,37 +7,179 @@ import (
 
 // Paper represents a paper in the search response.
 type Paper struct {
-	ID              string    `json:"id"`
-	Title           string    `json:"title"`
-	Authors         []string  `json:"authors"`
-	Summary         string    `json:"summary"`
-	Published       time.Time `json:"published"`
-	Updated         time.Time `json:"updated"`
-	Categories      []string  `json:"categories"`
-	PrimaryCategory string    `json:"primaryCategory"`
-	ArxivURL        string    `json:"arxivUrl"`
-	PDFURL          string    `json:"pdfUrl"`
-	ImageURL        string    `json:"imageUrl"`
+	ID              string         `json:"id"`
+	Version         int            `json:"version,omitempty"`
+	Title           string         `json:"title"`
+	Authors         []string       `json:"authors"`
+	AuthorDetails   []AuthorDetail `json:"authorDetails,omitempty"`
+	Summary         string         `json:"summary"`
+	Published       time.Time      `json:"published"`
+	Updated         time.Time      `json:"updated"`
+	Categories      []string       `json:"categories"`
+	PrimaryCategory string         `json:"primaryCategory"`
+	ArxivURL        string         `json:"arxivUrl"`
+	PDFURL          string         `json:"pdfUrl"`
+	ImageURL        string         `json:"imageUrl"`
+	DOI             string         `json:"doi,omitempty"`
+	JournalRef      string         `json:"journalRef,omitempty"`
+	Comment         string         `json:"comment,omitempty"`
+}
+
+// AuthorDetail is an author together with their stated affiliations.
+type AuthorDetail struct {
+	Name         string   `json:"name"`
+	Affiliations []string `json:"affiliations,omitempty"`
+}
+
+// Version describes one submitted version of a paper.
+type Version struct {
+	ID        string    `json:"id"`
+	Version   int       `json:"version"`
+	Submitted time.Time `json:"submitted"`
+	Title     string    `json:"title"`
+	Comment   string    `json:"comment,omitempty"`
+}
+
+// SearchResult is a page of search results together with the total number of matches.
+type SearchResult struct {
+	Papers []*Paper
+	Total  int
+
+	// Backend is the backend that answered a keyword search, which may be
+	// the local index when arXiv was unavailable. Only BackendArxiv results
+	// are ordered newest first; the others are ranked by relevance.
+	Backend string
+}
+
+// BatchResult holds the papers found by a batch lookup and the IDs that were not.
+type BatchResult struct {
+	Papers  []*Paper // Found papers, in request order
+	Missing []string // Requested IDs that are malformed or do not exist, in request order
+}
+
+// Field names accepted in search terms.
+const (
+	FieldTitle    = "title"
+	FieldAuthor   = "author"
+	FieldAbstract = "abstract"
+	FieldCategory = "category"
+	FieldID       = "id"
+)
+
+// Search backends.
+const (
+	BackendArxiv = "arxiv" // Proxy the arXiv search API
+	BackendLocal = "local" // Search the local full-text index of stored papers
+)
+
+// Search modes.
+const (
+	ModeKeyword  = "keyword"  // Match keywords and fielded terms through the chosen backend
+	ModeSemantic = "semantic" // Rank stored papers by embedding similarity to the query
+	ModeHybrid   = "hybrid"   // Fuse the keyword and semantic rankings
+)
+
+// Term is a single fielded search term.
+type Term struct {
+	Field string // One of the Field* constants
+	Value string
+}
+
+// SearchRequest contains parameters for searching papers.
+// At least one of Query, Include or a submission date bound is required.
+type SearchRequest struct {
+	Query         string    // Free-text keywords matched against all fields
+	Include       []Term    // Fielded terms that results must match
+	Exclude       []Term    // Fielded terms that results must not match
+	MatchAny      bool      // Join Include terms with OR instead of AND
+	SubmittedFrom time.Time // Inclusive lower bound on submission date (zero for none)
+	SubmittedTo   time.Time // Inclusive upper bound on submission date (zero for none)
+	Limit         int
+	Backend       string // BackendArxiv or BackendLocal (empty for the service default)
+	Mode          string // ModeKeyword (default), ModeSemantic or ModeHybrid
 }
 
 // Service defines the interface for paper search operations.
 type Service interface {
-	// Search searches papers by keyword.
+	// Search searches papers by keyword and fielded terms, by meaning, or both.
+	// Semantic and hybrid modes require a free-text query; fielded terms and
+	// dates filter the semantic matches through the local index.
 	// @Params:
 	//   - ctx: context for cancellation and tracing
-	//   - query: search keyword
-	//   - limit: maximum number of results
+	//   - req: search request parameters
 	// @Returns:
-	//   - []*Paper: list of matching papers
-	//   - error: if search fails
-	Search(ctx context.Context, query string, limit int) ([]*Paper, error)
+	//   - *SearchResult: matching papers and the total number of matches
+	//     (for semantic and hybrid modes, the number of candidates ranked)
+	//   - error: ErrInvalidQuery if the request cannot be turned into a query,
+	//     ErrIndexDisabled if the local backend is requested without an index,
+	//     ErrSemanticDisabled if a semantic mode is requested without embeddings, or if search fails
+	Search(ctx context.Context, req *SearchRequest) (*SearchResult, error)
 
 	// GetByID retrieves a single paper by ID.
 	// @Params:
 	//   - ctx: context for cancellation and tracing
-	//   - id: paper ID
+	//   - id: paper ID, optionally versioned (e.g., "2301.12345v2")
 	// @Returns:
 	//   - *Paper: the paper if found, nil otherwise
-	//   - error: if fetch fails
+	//   - error: ErrInvalidID if the ID is malformed, or if fetch fails
 	GetByID(ctx context.Context, id string) (*Paper, error)
+
+	// GetByIDs retrieves several papers at once, serving cached papers first.
+	// Duplicate IDs are returned once.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - ids: paper IDs, optionally versioned
+	// @Returns:
+	//   - *BatchResult: found papers in request order and the missing IDs
+	//   - error: if fetch fails
+	GetByIDs(ctx context.Context, ids []string) (*BatchResult, error)
+
+	// GetVersions lists every version of a paper, oldest first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - id: paper ID; any version suffix is ignored
+	// @Returns:
+	//   - []*Version: the versions, nil if the paper does not exist
+	//   - error: ErrInvalidID if the ID is malformed, or if fetch fails
+	GetVersions(ctx context.Context, id string) ([]*Version, error)
+
+	// RebuildIndex clears the local search index and refills it with every
+	// paper stored in the repository.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	// @Returns:
+	//   - int: number of papers indexed
+	//   - error: ErrIndexDisabled if the service has no index, or if listing papers fails
+	RebuildIndex(ctx context.Context) (int, error)
+
+	// SyncIndex adds the papers stored or changed since the given time to the
+	// local search index without clearing it, picking up papers stored by
+	// other processes.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - since: earliest storage time of the papers to index
+	// @Returns:
+	//   - int: number of papers indexed
+	//   - error: ErrIndexDisabled if the service has no index, or if listing papers fails
+	SyncIndex(ctx context.Context, since time.Time) (int, error)
+
+	// RebuildVectorIndex clears the semantic index and refills it with the
+	// embedding of every paper stored in the repository.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	// @Returns:
+	//   - int: number of papers embedded
+	//   - error: ErrSemanticDisabled if semantic search is disabled, or if listing or embedding fails
+	RebuildVectorIndex(ctx context.Context) (int, error)
+
+	// SyncVectorIndex embeds the papers stored or changed since the given
+	// time that are not in the semantic index yet, picking up papers stored
+	// by other processes or not embedded when they were stored.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - since: earliest storage time of the papers to embed
+	// @Returns:
+	//   - int: number of papers embedded
+	//   - error: ErrSemanticDisabled if semantic search is disabled, or if listing or embedding fails
+	SyncVectorIndex(ctx context.Context, since time.Time) (int, error)
 }
@@ -0,0 +1,255 @@
+package papersearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+	"github.com/rrlian/papertok/backend/internal/core/vectorindex"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+const (
+	// semanticOverfetch is how many more neighbors are fetched when fielded
+	// terms or dates filter them, so a page can still be filled.
+	semanticOverfetch = 5
+
+	// rrfK damps the weight of top ranks in reciprocal rank fusion; 60 is
+	// the customary value.
+	rrfK = 60
+
+	// maxFusionDepth caps how many results of each ranking are fused.
+	maxFusionDepth = 100
+)
+
+// EmbeddingText returns the text of a stored paper that is embedded for
+// semantic search: its title and abstract.
+func EmbeddingText(p *paperRepo.Paper) string {
+	return p.Title + "\n\n" + p.Summary
+}
+
+// RebuildVectorIndex clears the semantic index and refills it from the repository.
+func (s *Impl) RebuildVectorIndex(ctx context.Context) (int, error) {
+	if s.embedder == nil || s.vectors == nil {
+		return 0, ErrSemanticDisabled
+	}
+
+	s.vectors.Reset()
+	return s.embedStored(ctx, time.Time{})
+}
+
+// SyncVectorIndex embeds the papers stored or changed since the given time
+// that are not in the semantic index yet.
+func (s *Impl) SyncVectorIndex(ctx context.Context, since time.Time) (int, error) {
+	if s.embedder == nil || s.vectors == nil {
+		return 0, ErrSemanticDisabled
+	}
+	return s.embedStored(ctx, since)
+}
+
+// embedStored embeds the stored papers changed since the given time (all of
+// them if zero), one page at a time, skipping papers already embedded.
+func (s *Impl) embedStored(ctx context.Context, since time.Time) (int, error) {
+	query := &paperRepo.Query{StoredSince: since, Limit: rebuildBatchSize}
+	indexed := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return indexed, err
+		}
+
+		page, err := s.paperRepo.Find(ctx, query)
+		if err != nil {
+			return indexed, fmt.Errorf("failed to list stored papers: %w", err)
+		}
+
+		var papers []*paperRepo.Paper
+		for _, p := range page.Papers {
+			if !s.vectors.Has(p.ID) {
+				papers = append(papers, p)
+			}
+		}
+		if len(papers) > 0 {
+			texts := make([]string, len(papers))
+			for i, p := range papers {
+				texts[i] = EmbeddingText(p)
+			}
+			vectors, err := s.embedder.Embed(ctx, texts)
+			if err != nil {
+				return indexed, fmt.Errorf("failed to embed stored papers: %w", err)
+			}
+			items := make([]vectorindex.Item, len(papers))
+			for i, p := range papers {
+				items[i] = vectorindex.Item{ID: p.ID, Vector: vectors[i]}
+			}
+			if err := s.vectors.Add(items...); err != nil {
+				return indexed, err
+			}
+			indexed += len(items)
+		}
+
+		if len(page.Papers) < query.Limit {
+			return indexed, nil
+		}
+		query.Offset += len(page.Papers)
+	}
+}
+
+// searchSemantic answers a search request with the stored papers closest
+// in meaning to the query.
+func (s *Impl) searchSemantic(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
+	papers, err := s.semanticMatches(ctx, req, searchLimit(req))
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResult{Papers: papers, Total: len(papers)}, nil
+}
+
+// searchHybrid fuses the keyword and semantic rankings of a search request.
+func (s *Impl) searchHybrid(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
+	limit := searchLimit(req)
+	depth := limit * 2
+	if depth > maxFusionDepth {
+		depth = maxFusionDepth
+	}
+
+	// The semantic side runs first: it rejects unusable requests without
+	// spending an upstream keyword search.
+	semantic, err := s.semanticMatches(ctx, req, depth)
+	if err != nil {
+		return nil, err
+	}
+	keywordReq := *req
+	keywordReq.Limit = depth
+	keyword, err := s.searchKeyword(ctx, &keywordReq)
+	if err != nil {
+		return nil, err
+	}
+
+	papers := fuseRankings(keyword.Papers, semantic)
+	total := len(papers)
+	if len(papers) > limit {
+		papers = papers[:limit]
+	}
+	return &SearchResult{Papers: papers, Total: total}, nil
+}
+
+// semanticMatches returns up to limit stored papers closest in meaning to
+// the query text, restricted by the request's fielded terms and dates.
+func (s *Impl) semanticMatches(ctx context.Context, req *SearchRequest, limit int) ([]*Paper, error) {
+	if s.embedder == nil || s.vectors == nil {
+		return nil, ErrSemanticDisabled
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, fmt.Errorf("%w: %s search requires a free-text query", ErrInvalidQuery, req.Mode)
+	}
+	filtered := len(req.Include) > 0 || len(req.Exclude) > 0 || !req.SubmittedFrom.IsZero() || !req.SubmittedTo.IsZero()
+	if filtered && s.index == nil {
+		return nil, ErrIndexDisabled
+	}
+
+	vectors, err := s.embedder.Embed(ctx, []string{req.Query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	depth := limit
+	if filtered {
+		depth *= semanticOverfetch
+	}
+	hits, err := s.vectors.Search(vectors[0], depth)
+	if err != nil {
+		return nil, err
+	}
+	if filtered {
+		if hits, err = s.filterHits(req, hits); err != nil {
+			return nil, err
+		}
+	}
+
+	papers := make([]*Paper, 0, limit)
+	for _, hit := range hits {
+		if len(papers) == limit {
+			break
+		}
+		p, found := s.paperRepo.GetByID(ctx, hit.ID)
+		if !found || p == nil {
+			// Cached papers expire; forget them once they are gone.
+			s.vectors.Remove(hit.ID)
+			continue
+		}
+		papers = append(papers, s.convertRepoPaper(p))
+	}
+	return papers, nil
+}
+
+// filterHits keeps the hits that match the request's fielded terms and
+// dates in the local index, preserving their order.
+func (s *Impl) filterHits(req *SearchRequest, hits []vectorindex.Hit) ([]vectorindex.Hit, error) {
+	if len(hits) == 0 {
+		return hits, nil
+	}
+
+	query, err := buildIndexQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	// The query text was matched by meaning; only the filters apply here.
+	query.Text = ""
+	query.IDs = make([]string, len(hits))
+	for i, hit := range hits {
+		query.IDs[i] = hit.ID
+	}
+	query.Limit = len(hits)
+
+	result, err := s.index.Search(query)
+	if err != nil {
+		if searchindex.IsEmptyQuery(err) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		return nil, err
+	}
+	allowed := make(map[string]bool, len(result.Hits))
+	for _, hit := range result.Hits {
+		allowed[hit.ID] = true
+	}
+
+	kept := hits[:0]
+	for _, hit := range hits {
+		if allowed[hit.ID] {
+			kept = append(kept, hit)
+		}
+	}
+	return kept, nil
+}
+
+// fuseRankings merges ranked lists with reciprocal rank fusion: a paper
+// scores the sum of 1/(rrfK + rank) over the lists it appears in. Ties keep
+// the order in which papers were first seen.
+func fuseRankings(rankings ...[]*Paper) []*Paper {
+	scores := make(map[string]float64)
+	var papers []*Paper
+	for _, ranking := range rankings {
+		for rank, p := range ranking {
+			if _, seen := scores[p.ID]; !seen {
+				papers = append(papers, p)
+			}
+			scores[p.ID] += 1 / float64(rrfK+rank+1)
+		}
+	}
+
+	sort.SliceStable(papers, func(i, j int) bool {
+		return scores[papers[i].ID] > scores[papers[j].ID]
+	})
+	return papers
+}
+
+// searchLimit returns the page size of a request.
+func searchLimit(req *SearchRequest) int {
+	if req.Limit <= 0 {
+		return searchindex.DefaultLimit
+	}
+	return req.Limit
+}
@@ -2,39 +2,116 @@ package papersearch
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
 )
 
 // Impl implements the papersearch Service interface.
 type Impl struct {
-	arxivSvc arxiv.Service
+	arxivSvc  arxiv.Service
+	paperRepo paperRepo.Repository
+	index     searchIndex // Local full-text index (nil if disabled)
+	embedder  embedder    // Text embedder for semantic search (nil if disabled)
+	vectors   vectorIndex // Paper embeddings for semantic search (nil if disabled)
+	backend   string      // Default search backend
+	cacheTTL  time.Duration
 }
 
 // Ensure Impl implements Service interface
 var _ Service = (*Impl)(nil)
 
 // New creates a new papersearch service instance.
-func New(arxivSvc arxiv.Service) *Impl {
+// index may be nil to disable the local backend, and embedder or vectors
+// nil to disable semantic search; backend is the default used by requests
+// that do not choose one (BackendArxiv if empty).
+func New(arxivSvc arxiv.Service, repo paperRepo.Repository, index searchIndex, embedder embedder, vectors vectorIndex, backend string, cacheTTL time.Duration) *Impl {
+	if backend == "" {
+		backend = BackendArxiv
+	}
 	return &Impl{
-		arxivSvc: arxivSvc,
+		arxivSvc:  arxivSvc,
+		paperRepo: repo,
+		index:     index,
+		embedder:  embedder,
+		vectors:   vectors,
+		backend:   backend,
+		cacheTTL:  cacheTTL,
+	}
+}
+
+// Search searches papers by keyword, by meaning, or both.
+func (s *Impl) Search(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request is required", ErrInvalidQuery)
+	}
+
+	switch req.Mode {
+	case "", ModeKeyword:
+		return s.searchKeyword(ctx, req)
+	case ModeSemantic:
+		return s.searchSemantic(ctx, req)
+	case ModeHybrid:
+		return s.searchHybrid(ctx, req)
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidQuery, req.Mode)
 	}
 }
 
-// Search searches papers by keyword.
-func (s *Impl) Search(ctx context.Context, query string, limit int) ([]*Paper, error) {
-	arxivPapers, err := s.arxivSvc.Search(ctx, query, limit)
+// searchKeyword searches papers by keyword and fielded terms.
+// arXiv searches fall back to the local index while arXiv is unavailable.
+func (s *Impl) searchKeyword(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
+	backend := req.Backend
+	if backend == "" {
+		backend = s.backend
+	}
+	switch backend {
+	case BackendLocal:
+		return s.searchLocal(ctx, req)
+	case BackendArxiv:
+	default:
+		return nil, fmt.Errorf("%w: unknown backend %q", ErrInvalidQuery, backend)
+	}
+
+	query, err := s.buildQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.arxivSvc.SearchQuery(ctx, query, req.Limit)
 	if err != nil {
+		if arxiv.IsInvalidQuery(err) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
+		if arxiv.IsUnavailable(err) && s.index != nil && s.index.Len() > 0 {
+			return s.searchLocal(ctx, req)
+		}
 		return nil, err
 	}
 
-	return s.convertArxivPapers(arxivPapers), nil
+	return &SearchResult{
+		Papers:  s.convertArxivPapers(result.Papers),
+		Total:   result.TotalResults,
+		Backend: BackendArxiv,
+	}, nil
 }
 
-// GetByID retrieves a single paper by ID.
+// GetByID retrieves a single paper by ID, serving it from the repository when cached.
 func (s *Impl) GetByID(ctx context.Context, id string) (*Paper, error) {
+	ident, parseErr := arxiv.ParseIdentifier(id)
+	if parseErr == nil {
+		if cached, found := s.getCached(ctx, ident); found {
+			return s.convertRepoPaper(cached), nil
+		}
+	}
+
 	arxivPaper, err := s.arxivSvc.GetByID(ctx, id)
 	if err != nil {
+		if arxiv.IsInvalidID(err) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+		}
 		return nil, err
 	}
 
@@ -42,7 +119,200 @@ func (s *Impl) GetByID(ctx context.Context, id string) (*Paper, error) {
 		return nil, nil
 	}
 
-	return s.convertArxivPaper(arxivPaper), nil
+	repoPaper := s.convertArxivToRepo(arxivPaper)
+	if parseErr == nil && !ident.HasVersion() {
+		s.paperRepo.Save(ctx, repoPaper, s.cacheTTL)
+	}
+
+	return s.convertRepoPaper(repoPaper), nil
+}
+
+// GetByIDs retrieves several papers in request order.
+// Cached papers come from the repository; the rest are fetched from arXiv
+// in one batched call. Malformed and unknown IDs are reported as missing.
+func (s *Impl) GetByIDs(ctx context.Context, ids []string) (*BatchResult, error) {
+	// Resolve every requested ID to its canonical form, once.
+	keys := make([]string, len(ids))
+	found := make(map[string]*paperRepo.Paper, len(ids))
+	requested := make(map[string]bool, len(ids))
+	var toFetch []string
+	for i, id := range ids {
+		ident, err := arxiv.ParseIdentifier(id)
+		if err != nil {
+			continue
+		}
+		key := ident.String()
+		keys[i] = key
+		if requested[key] {
+			continue
+		}
+		requested[key] = true
+
+		if cached, ok := s.getCached(ctx, ident); ok {
+			found[key] = cached
+			continue
+		}
+		toFetch = append(toFetch, key)
+	}
+
+	if len(toFetch) > 0 {
+		fetched, err := s.arxivSvc.GetByIDs(ctx, toFetch)
+		if err != nil {
+			if arxiv.IsInvalidID(err) {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+			}
+			return nil, err
+		}
+
+		for _, p := range fetched {
+			repoPaper := s.convertArxivToRepo(p)
+
+			versioned := fmt.Sprintf("%sv%d", p.ID, p.Version)
+			if requested[versioned] {
+				found[versioned] = repoPaper
+			}
+			// An unversioned request is answered by the latest version returned.
+			if requested[p.ID] {
+				if current, ok := found[p.ID]; !ok || current.Version < p.Version {
+					found[p.ID] = repoPaper
+				}
+			}
+		}
+
+		// Only latest versions are cached, since the repository is keyed by bare ID.
+		for _, key := range toFetch {
+			if p, ok := found[key]; ok && key == p.ID {
+				s.paperRepo.Save(ctx, p, s.cacheTTL)
+			}
+		}
+	}
+
+	result := &BatchResult{
+		Papers:  make([]*Paper, 0, len(ids)),
+		Missing: []string{},
+	}
+	emitted := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		key := keys[i]
+		if key == "" {
+			result.Missing = append(result.Missing, id)
+			continue
+		}
+		if emitted[key] {
+			continue
+		}
+		emitted[key] = true
+
+		if p, ok := found[key]; ok {
+			result.Papers = append(result.Papers, s.convertRepoPaper(p))
+		} else {
+			result.Missing = append(result.Missing, id)
+		}
+	}
+
+	return result, nil
+}
+
+// getCached returns the cached paper for ident.
+// A versioned ID only matches if the cached copy is that exact version.
+func (s *Impl) getCached(ctx context.Context, ident arxiv.Identifier) (*paperRepo.Paper, bool) {
+	cached, found := s.paperRepo.GetByID(ctx, ident.Base())
+	if !found || cached == nil {
+		return nil, false
+	}
+	if ident.HasVersion() && cached.Version != ident.Version {
+		return nil, false
+	}
+	return cached, true
+}
+
+// GetVersions lists every version of a paper, oldest first.
+func (s *Impl) GetVersions(ctx context.Context, id string) ([]*Version, error) {
+	arxivVersions, err := s.arxivSvc.GetVersions(ctx, id)
+	if err != nil {
+		if arxiv.IsInvalidID(err) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+		}
+		return nil, err
+	}
+
+	if len(arxivVersions) == 0 {
+		return nil, nil
+	}
+
+	versions := make([]*Version, len(arxivVersions))
+	for i, v := range arxivVersions {
+		versions[i] = &Version{
+			ID:        v.ID,
+			Version:   v.Version,
+			Submitted: v.Submitted,
+			Title:     v.Title,
+			Comment:   v.Comment,
+		}
+	}
+	return versions, nil
+}
+
+// buildQuery translates a search request into a structured arXiv query.
+// The resulting shape is: keywords AND (include...) ANDNOT exclude..., within the date range.
+func (s *Impl) buildQuery(req *SearchRequest) (*arxiv.Query, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request is required", ErrInvalidQuery)
+	}
+
+	query := arxiv.NewQuery()
+	if req.Query != "" {
+		query.Where(arxiv.FieldAll, req.Query)
+	}
+
+	if len(req.Include) > 0 {
+		group := arxiv.NewQuery()
+		for _, term := range req.Include {
+			field, err := toArxivField(term.Field)
+			if err != nil {
+				return nil, err
+			}
+			if req.MatchAny {
+				group.Or(field, term.Value)
+			} else {
+				group.And(field, term.Value)
+			}
+		}
+		query.Group(arxiv.OpAnd, group)
+	}
+
+	for _, term := range req.Exclude {
+		field, err := toArxivField(term.Field)
+		if err != nil {
+			return nil, err
+		}
+		query.AndNot(field, term.Value)
+	}
+
+	query.SubmittedBetween(req.SubmittedFrom, req.SubmittedTo)
+	if query.IsEmpty() {
+		return nil, fmt.Errorf("%w: a keyword, field term or date range is required", ErrInvalidQuery)
+	}
+
+	return query, nil
+}
+
+// toArxivField maps a feature field name to the arXiv query field.
+func toArxivField(field string) (arxiv.Field, error) {
+	switch field {
+	case FieldTitle:
+		return arxiv.FieldTitle, nil
+	case FieldAuthor:
+		return arxiv.FieldAuthor, nil
+	case FieldAbstract:
+		return arxiv.FieldAbstract, nil
+	case FieldCategory:
+		return arxiv.FieldCategory, nil
+	case FieldID:
+		return arxiv.FieldID, nil
+	default:
+		return "", fmt.Errorf("%w: unknown field %q", ErrInvalidQuery, field)
+	}
 }
 
 // convertArxivPapers converts arXiv papers to feature papers.
@@ -56,10 +326,80 @@ func (s *Impl) convertArxivPapers(papers []*arxiv.Paper) []*Paper {
 
 // convertArxivPaper converts a single arXiv paper to feature paper.
 func (s *Impl) convertArxivPaper(p *arxiv.Paper) *Paper {
+	var authors []AuthorDetail
+	if len(p.AuthorDetails) > 0 {
+		authors = make([]AuthorDetail, len(p.AuthorDetails))
+		for i, a := range p.AuthorDetails {
+			authors[i] = AuthorDetail{Name: a.Name, Affiliations: a.Affiliations}
+		}
+	}
+
+	return &Paper{
+		ID:              p.ID,
+		Version:         p.Version,
+		Title:           p.Title,
+		Authors:         p.Authors,
+		AuthorDetails:   authors,
+		Summary:         p.Summary,
+		Published:       p.Published,
+		Updated:         p.Updated,
+		Categories:      p.Categories,
+		PrimaryCategory: p.PrimaryCategory,
+		ArxivURL:        p.ArxivURL,
+		PDFURL:          p.PDFURL,
+		ImageURL:        p.ImageURL,
+		DOI:             p.DOI,
+		JournalRef:      p.JournalRef,
+		Comment:         p.Comment,
+	}
+}
+
+// convertArxivToRepo converts an arXiv paper to a repository paper.
+func (s *Impl) convertArxivToRepo(p *arxiv.Paper) *paperRepo.Paper {
+	var authors []paperRepo.AuthorDetail
+	if len(p.AuthorDetails) > 0 {
+		authors = make([]paperRepo.AuthorDetail, len(p.AuthorDetails))
+		for i, a := range p.AuthorDetails {
+			authors[i] = paperRepo.AuthorDetail{Name: a.Name, Affiliations: a.Affiliations}
+		}
+	}
+
+	return &paperRepo.Paper{
+		ID:              p.ID,
+		Version:         p.Version,
+		Title:           p.Title,
+		Authors:         p.Authors,
+		AuthorDetails:   authors,
+		Summary:         p.Summary,
+		Published:       p.Published,
+		Updated:         p.Updated,
+		Categories:      p.Categories,
+		PrimaryCategory: p.PrimaryCategory,
+		ArxivURL:        p.ArxivURL,
+		PDFURL:          p.PDFURL,
+		ImageURL:        p.ImageURL,
+		DOI:             p.DOI,
+		JournalRef:      p.JournalRef,
+		Comment:         p.Comment,
+	}
+}
+
+// convertRepoPaper converts a repository paper to feature paper.
+func (s *Impl) convertRepoPaper(p *paperRepo.Paper) *Paper {
+	var authors []AuthorDetail
+	if len(p.AuthorDetails) > 0 {
+		authors = make([]AuthorDetail, len(p.AuthorDetails))
+		for i, a := range p.AuthorDetails {
+			authors[i] = AuthorDetail{Name: a.Name, Affiliations: a.Affiliations}
+		}
+	}
+
 	return &Paper{
 		ID:              p.ID,
+		Version:         p.Version,
 		Title:           p.Title,
 		Authors:         p.Authors,
+		AuthorDetails:   authors,
 		Summary:         p.Summary,
 		Published:       p.Published,
 		Updated:         p.Updated,
@@ -68,5 +408,8 @@ func (s *Impl) convertArxivPaper(p *arxiv.Paper) *Paper {
 		ArxivURL:        p.ArxivURL,
 		PDFURL:          p.PDFURL,
 		ImageURL:        p.ImageURL,
+		DOI:             p.DOI,
+		JournalRef:      p.JournalRef,
+		Comment:         p.Comment,
 	}
 }
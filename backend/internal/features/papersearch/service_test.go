@@ -2,30 +2,116 @@ package papersearch
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	"github.com/rrlian/papertok/backend/internal/core/embedding"
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+	"github.com/rrlian/papertok/backend/internal/core/vectorindex"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
 )
 
 // mockArxivService is a mock implementation for testing.
 type mockArxivService struct {
 	searchPapers []*arxiv.Paper
+	searchTotal  int
 	getPaper     *arxiv.Paper
+	batchPapers  []*arxiv.Paper
+	batchIDs     []string
+	versions     []*arxiv.Version
 	err          error
+	lastQuery    *arxiv.Query
 }
 
-func (m *mockArxivService) FetchByCategory(ctx context.Context, req *arxiv.FetchRequest) ([]*arxiv.Paper, error) {
-	return m.searchPapers, m.err
+func (m *mockArxivService) FetchByCategory(ctx context.Context, req *arxiv.FetchRequest) (*arxiv.Result, error) {
+	return &arxiv.Result{Papers: m.searchPapers, TotalResults: m.searchTotal}, m.err
 }
 
-func (m *mockArxivService) Search(ctx context.Context, query string, limit int) ([]*arxiv.Paper, error) {
-	return m.searchPapers, m.err
+func (m *mockArxivService) Search(ctx context.Context, query string, limit int) (*arxiv.Result, error) {
+	return &arxiv.Result{Papers: m.searchPapers, TotalResults: m.searchTotal}, m.err
+}
+
+func (m *mockArxivService) SearchQuery(ctx context.Context, query *arxiv.Query, limit int) (*arxiv.Result, error) {
+	m.lastQuery = query
+	if _, err := query.Build(); err != nil {
+		return nil, err
+	}
+	return &arxiv.Result{Papers: m.searchPapers, TotalResults: m.searchTotal}, m.err
 }
 
 func (m *mockArxivService) GetByID(ctx context.Context, id string) (*arxiv.Paper, error) {
 	return m.getPaper, m.err
 }
 
+func (m *mockArxivService) GetByIDs(ctx context.Context, ids []string) ([]*arxiv.Paper, error) {
+	m.batchIDs = ids
+	return m.batchPapers, m.err
+}
+
+func (m *mockArxivService) GetVersions(ctx context.Context, id string) ([]*arxiv.Version, error) {
+	return m.versions, m.err
+}
+
+// mockPaperRepository is an in-memory paper repository for testing.
+type mockPaperRepository struct {
+	papers    map[string]*paperRepo.Paper
+	lastQuery *paperRepo.Query
+}
+
+func newMockPaperRepository() *mockPaperRepository {
+	return &mockPaperRepository{papers: make(map[string]*paperRepo.Paper)}
+}
+
+func (m *mockPaperRepository) GetByCategory(ctx context.Context, category string) (*paperRepo.PaperList, bool) {
+	return nil, false
+}
+
+func (m *mockPaperRepository) SaveByCategory(ctx context.Context, category string, list *paperRepo.PaperList, ttl time.Duration) {
+}
+
+func (m *mockPaperRepository) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	paper, found := m.papers[id]
+	return paper, found
+}
+
+func (m *mockPaperRepository) Save(ctx context.Context, paper *paperRepo.Paper, ttl time.Duration) {
+	m.papers[paper.ID] = paper
+}
+
+func (m *mockPaperRepository) Upsert(ctx context.Context, papers []*paperRepo.Paper) error {
+	for _, p := range papers {
+		m.papers[p.ID] = p
+	}
+	return nil
+}
+
+// Find pages through every stored paper in ID order; filters are ignored.
+func (m *mockPaperRepository) Find(ctx context.Context, q *paperRepo.Query) (*paperRepo.PaperList, error) {
+	m.lastQuery = q
+	ids := make([]string, 0, len(m.papers))
+	for id := range m.papers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	list := &paperRepo.PaperList{Papers: []*paperRepo.Paper{}, Total: len(ids)}
+	for i := q.Offset; i < len(ids) && i < q.Offset+q.Limit; i++ {
+		list.Papers = append(list.Papers, m.papers[ids[i]])
+	}
+	return list, nil
+}
+
+func (m *mockPaperRepository) InvalidateCategory(ctx context.Context, category string) {}
+
+func (m *mockPaperRepository) Clear(ctx context.Context) {
+	m.papers = make(map[string]*paperRepo.Paper)
+}
+
 func TestImpl_Search(t *testing.T) {
 	// Arrange
 	mockArxiv := &mockArxivService{
@@ -45,18 +131,73 @@ func TestImpl_Search(t *testing.T) {
 				PrimaryCategory: "cs.LG",
 			},
 		},
+		searchTotal: 357,
 	}
-	svc := New(mockArxiv)
+	svc := New(mockArxiv, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
 
 	// Act
-	papers, err := svc.Search(context.Background(), "machine learning", 10)
+	result, err := svc.Search(context.Background(), &SearchRequest{Query: "machine learning", Limit: 10})
 
 	// Assert
 	if err != nil {
-		t.Errorf("Expected no error, got: %v", err)
+		t.Fatalf("Expected no error, got: %v", err)
 	}
-	if len(papers) != 2 {
-		t.Errorf("Expected 2 papers, got: %d", len(papers))
+	if len(result.Papers) != 2 {
+		t.Errorf("Expected 2 papers, got: %d", len(result.Papers))
+	}
+	if result.Total != 357 {
+		t.Errorf("Expected total 357, got: %d", result.Total)
+	}
+}
+
+func TestImpl_Search_Fielded(t *testing.T) {
+	// Arrange
+	mockArxiv := &mockArxivService{}
+	svc := New(mockArxiv, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := svc.Search(context.Background(), &SearchRequest{
+		Include: []Term{
+			{Field: FieldAuthor, Value: "Jane Doe"},
+			{Field: FieldCategory, Value: "cs.LG"},
+		},
+		Exclude:       []Term{{Field: FieldTitle, Value: "survey"}},
+		SubmittedFrom: since,
+		Limit:         10,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := `((au:"Jane Doe" AND cat:cs.LG) ANDNOT ti:survey) AND submittedDate:[202405010000 TO 999912312359]`
+	if got := mockArxiv.lastQuery.String(); got != expected {
+		t.Errorf("Expected query %q, got: %q", expected, got)
+	}
+}
+
+func TestImpl_Search_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *SearchRequest
+	}{
+		{"empty request", &SearchRequest{Limit: 10}},
+		{"unknown field", &SearchRequest{Include: []Term{{Field: "venue", Value: "NeurIPS"}}}},
+		{"invalid category", &SearchRequest{Include: []Term{{Field: FieldCategory, Value: "cs LG"}}}},
+		{"exclude only", &SearchRequest{Exclude: []Term{{Field: FieldTitle, Value: "survey"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := New(&mockArxivService{}, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
+
+			_, err := svc.Search(context.Background(), tt.req)
+
+			if !IsInvalidQuery(err) {
+				t.Errorf("Expected ErrInvalidQuery, got: %v", err)
+			}
+		})
 	}
 }
 
@@ -71,7 +212,7 @@ func TestImpl_GetByID(t *testing.T) {
 			PrimaryCategory: "cs.AI",
 		},
 	}
-	svc := New(mockArxiv)
+	svc := New(mockArxiv, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
 
 	// Act
 	paper, err := svc.GetByID(context.Background(), "2301.12345")
@@ -93,7 +234,7 @@ func TestImpl_GetByID_NotFound(t *testing.T) {
 	mockArxiv := &mockArxivService{
 		getPaper: nil,
 	}
-	svc := New(mockArxiv)
+	svc := New(mockArxiv, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
 
 	// Act
 	paper, err := svc.GetByID(context.Background(), "nonexistent")
@@ -106,3 +247,510 @@ func TestImpl_GetByID_NotFound(t *testing.T) {
 		t.Errorf("Expected nil paper, got: %v", paper)
 	}
 }
+
+func TestImpl_GetByID_InvalidID(t *testing.T) {
+	// Arrange
+	svc := New(&mockArxivService{err: fmt.Errorf("%w: %q", arxiv.ErrInvalidID, "2301.12345 OR x")}, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
+
+	// Act
+	_, err := svc.GetByID(context.Background(), "2301.12345 OR x")
+
+	// Assert
+	if !IsInvalidID(err) {
+		t.Errorf("Expected ErrInvalidID, got: %v", err)
+	}
+}
+
+func TestImpl_GetVersions(t *testing.T) {
+	// Arrange
+	submitted := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	mockArxiv := &mockArxivService{
+		versions: []*arxiv.Version{
+			{ID: "2301.12345v1", Version: 1, Submitted: submitted.AddDate(0, -1, 0), Title: "Draft"},
+			{ID: "2301.12345v2", Version: 2, Submitted: submitted, Title: "Final", Comment: "camera ready"},
+		},
+	}
+	svc := New(mockArxiv, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
+
+	// Act
+	versions, err := svc.GetVersions(context.Background(), "2301.12345")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 versions, got: %d", len(versions))
+	}
+	if versions[1].ID != "2301.12345v2" || !versions[1].Submitted.Equal(submitted) || versions[1].Comment != "camera ready" {
+		t.Errorf("Expected v2 details to be carried over, got: %+v", versions[1])
+	}
+}
+
+func TestImpl_GetVersions_NotFound(t *testing.T) {
+	// Arrange
+	svc := New(&mockArxivService{}, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
+
+	// Act
+	versions, err := svc.GetVersions(context.Background(), "2301.99999")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if versions != nil {
+		t.Errorf("Expected nil versions, got: %v", versions)
+	}
+}
+
+func TestImpl_GetByID_FromCache(t *testing.T) {
+	// Arrange
+	mockArxiv := &mockArxivService{err: errors.New("should not be called")}
+	mockRepo := newMockPaperRepository()
+	mockRepo.papers["2301.12345"] = &paperRepo.Paper{ID: "2301.12345", Version: 2, Title: "Cached"}
+	svc := New(mockArxiv, mockRepo, nil, nil, nil, "", time.Minute)
+
+	// Act
+	paper, err := svc.GetByID(context.Background(), "2301.12345v2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if paper == nil || paper.Title != "Cached" {
+		t.Errorf("Expected cached paper, got: %+v", paper)
+	}
+}
+
+func TestImpl_GetByIDs(t *testing.T) {
+	// Arrange
+	mockArxiv := &mockArxivService{
+		batchPapers: []*arxiv.Paper{
+			{ID: "hep-th/9901001", Version: 1, Title: "Old"},
+			{ID: "2301.11111", Version: 3, Title: "Fetched"},
+			{ID: "2301.22222", Version: 1, Title: "First version"},
+		},
+	}
+	mockRepo := newMockPaperRepository()
+	mockRepo.papers["2301.33333"] = &paperRepo.Paper{ID: "2301.33333", Version: 1, Title: "Cached"}
+	svc := New(mockArxiv, mockRepo, nil, nil, nil, "", time.Minute)
+
+	// Act
+	result, err := svc.GetByIDs(context.Background(), []string{
+		"2301.33333", "2301.11111", "bogus", "2301.22222v1", "2301.99999", "hep-th/9901001", "2301.11111",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	var titles []string
+	for _, p := range result.Papers {
+		titles = append(titles, p.Title)
+	}
+	expected := []string{"Cached", "Fetched", "First version", "Old"}
+	if strings.Join(titles, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected papers %v in request order, got: %v", expected, titles)
+	}
+	if strings.Join(result.Missing, ",") != "bogus,2301.99999" {
+		t.Errorf("Expected missing [bogus 2301.99999], got: %v", result.Missing)
+	}
+	if strings.Join(mockArxiv.batchIDs, ",") != "2301.11111,2301.22222v1,2301.99999,hep-th/9901001" {
+		t.Errorf("Expected only uncached IDs to be fetched, got: %v", mockArxiv.batchIDs)
+	}
+	if _, cached := mockRepo.papers["2301.11111"]; !cached {
+		t.Error("Expected latest fetched paper to be cached")
+	}
+	if _, cached := mockRepo.papers["2301.22222"]; cached {
+		t.Error("Expected explicitly versioned paper not to be cached as latest")
+	}
+}
+
+// newLocalSearchFixture returns a repository with stored papers and an empty index.
+func newLocalSearchFixture(t *testing.T) (*mockPaperRepository, *searchindex.Impl) {
+	t.Helper()
+	repo := newMockPaperRepository()
+	repo.Upsert(context.Background(), []*paperRepo.Paper{
+		{
+			ID:         "2401.00001",
+			Title:      "Graph Neural Networks for Molecules",
+			Authors:    []string{"Jane Doe"},
+			Summary:    "We learn molecular properties.",
+			Published:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			Categories: []string{"cs.LG"},
+		},
+		{
+			ID:         "2401.00002",
+			Title:      "A Survey of Language Models",
+			Authors:    []string{"John Smith"},
+			Summary:    "Language models and graph neural networks are reviewed.",
+			Published:  time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			Categories: []string{"cs.CL"},
+		},
+		{
+			ID:         "2401.00003",
+			Title:      "Quantum Error Correction",
+			Authors:    []string{"Jane Doe"},
+			Summary:    "Surface codes.",
+			Published:  time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+			Categories: []string{"quant-ph"},
+		},
+	})
+
+	index := searchindex.New(IndexConfig())
+	return repo, index
+}
+
+func TestImpl_RebuildIndex(t *testing.T) {
+	// Arrange
+	repo, index := newLocalSearchFixture(t)
+	index.Add(&searchindex.Document{ID: "stale", Fields: map[string]string{"title": "stale"}})
+	svc := New(&mockArxivService{}, repo, index, nil, nil, BackendLocal, time.Minute)
+
+	// Act
+	n, err := svc.RebuildIndex(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if n != 3 || index.Len() != 3 {
+		t.Errorf("Expected 3 papers indexed, got: %d (index holds %d)", n, index.Len())
+	}
+}
+
+func TestImpl_SyncIndex(t *testing.T) {
+	// Arrange
+	repo, index := newLocalSearchFixture(t)
+	index.Add(&searchindex.Document{ID: "kept", Fields: map[string]string{"title": "kept"}})
+	svc := New(&mockArxivService{}, repo, index, nil, nil, BackendLocal, time.Minute)
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	n, err := svc.SyncIndex(context.Background(), since)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if n != 3 || index.Len() != 4 {
+		t.Errorf("Expected 3 papers added to the existing one, got: %d (index holds %d)", n, index.Len())
+	}
+	if !repo.lastQuery.StoredSince.Equal(since) {
+		t.Errorf("Expected papers stored since %v to be listed, got: %v", since, repo.lastQuery.StoredSince)
+	}
+}
+
+func TestImpl_Search_Local(t *testing.T) {
+	repo, index := newLocalSearchFixture(t)
+	mockArxiv := &mockArxivService{err: errors.New("should not be called")}
+	svc := New(mockArxiv, repo, index, nil, nil, BackendArxiv, time.Minute)
+	if _, err := svc.RebuildIndex(context.Background()); err != nil {
+		t.Fatalf("Expected no error rebuilding, got: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *SearchRequest
+		want []string
+	}{
+		{
+			name: "title match ranks first",
+			req:  &SearchRequest{Query: "graph neural networks", Backend: BackendLocal},
+			want: []string{"2401.00001", "2401.00002"},
+		},
+		{
+			name: "fielded author and category",
+			req: &SearchRequest{
+				Include: []Term{
+					{Field: FieldAuthor, Value: "jane doe"},
+					{Field: FieldCategory, Value: "quant-ph"},
+				},
+				Backend: BackendLocal,
+			},
+			want: []string{"2401.00003"},
+		},
+		{
+			name: "exclude category",
+			req: &SearchRequest{
+				Query:   "graph",
+				Exclude: []Term{{Field: FieldCategory, Value: "cs.CL"}},
+				Backend: BackendLocal,
+			},
+			want: []string{"2401.00001"},
+		},
+		{
+			name: "versioned ID",
+			req:  &SearchRequest{Include: []Term{{Field: FieldID, Value: "2401.00002v3"}}, Backend: BackendLocal},
+			want: []string{"2401.00002"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.Search(context.Background(), tt.req)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result.Total != len(tt.want) || len(result.Papers) != len(tt.want) {
+				t.Fatalf("Expected %v, got %d papers (total %d)", tt.want, len(result.Papers), result.Total)
+			}
+			for i, id := range tt.want {
+				if result.Papers[i].ID != id {
+					t.Errorf("Expected paper %d to be %s, got: %s", i, id, result.Papers[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestImpl_Search_LocalSkipsMissingPapers(t *testing.T) {
+	// Arrange
+	repo, index := newLocalSearchFixture(t)
+	svc := New(&mockArxivService{}, repo, index, nil, nil, BackendLocal, time.Minute)
+	svc.RebuildIndex(context.Background())
+	delete(repo.papers, "2401.00002")
+
+	// Act
+	result, err := svc.Search(context.Background(), &SearchRequest{Query: "graph"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Total != 1 || len(result.Papers) != 1 || result.Papers[0].ID != "2401.00001" {
+		t.Errorf("Expected only 2401.00001, got %d papers (total %d)", len(result.Papers), result.Total)
+	}
+	if index.Len() != 2 {
+		t.Errorf("Expected missing paper to be dropped from the index, got %d documents", index.Len())
+	}
+}
+
+func TestImpl_Search_FallsBackToLocal(t *testing.T) {
+	// Arrange
+	repo, index := newLocalSearchFixture(t)
+	mockArxiv := &mockArxivService{err: &arxiv.UnavailableError{Reason: arxiv.ErrCircuitOpen}}
+	svc := New(mockArxiv, repo, index, nil, nil, BackendArxiv, time.Minute)
+	svc.RebuildIndex(context.Background())
+
+	// Act
+	result, err := svc.Search(context.Background(), &SearchRequest{Query: "quantum"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected fallback without error, got: %v", err)
+	}
+	if len(result.Papers) != 1 || result.Papers[0].ID != "2401.00003" {
+		t.Errorf("Expected local result 2401.00003, got: %d papers", len(result.Papers))
+	}
+}
+
+func TestImpl_Search_BackendErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *SearchRequest
+		checkFn func(error) bool
+	}{
+		{
+			name:    "local without index",
+			req:     &SearchRequest{Query: "graph", Backend: BackendLocal},
+			checkFn: IsIndexDisabled,
+		},
+		{
+			name:    "unknown backend",
+			req:     &SearchRequest{Query: "graph", Backend: "google"},
+			checkFn: IsInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := New(&mockArxivService{}, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
+
+			_, err := svc.Search(context.Background(), tt.req)
+
+			if !tt.checkFn(err) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// newSemanticService returns a service over the local search fixture with
+// both indexes rebuilt.
+func newSemanticService(t *testing.T) (*Impl, *mockPaperRepository, *vectorindex.Impl) {
+	t.Helper()
+	repo, index := newLocalSearchFixture(t)
+	embedder := embedding.NewHashEmbedder(0)
+	vectors := vectorindex.New(vectorindex.DefaultConfig(embedder.Dimension()))
+	svc := New(&mockArxivService{err: errors.New("should not be called")}, repo, index, embedder, vectors, BackendLocal, time.Minute)
+	if _, err := svc.RebuildIndex(context.Background()); err != nil {
+		t.Fatalf("Expected no error rebuilding, got: %v", err)
+	}
+	return svc, repo, vectors
+}
+
+func TestImpl_RebuildVectorIndex(t *testing.T) {
+	// Arrange
+	svc, _, vectors := newSemanticService(t)
+
+	// Act
+	n, err := svc.RebuildVectorIndex(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if n != 3 || vectors.Len() != 3 {
+		t.Errorf("Expected 3 papers embedded, got: %d (index holds %d)", n, vectors.Len())
+	}
+}
+
+func TestImpl_SyncVectorIndex(t *testing.T) {
+	// Arrange
+	svc, repo, vectors := newSemanticService(t)
+	embedder := embedding.NewHashEmbedder(0)
+	kept, _ := embedder.Embed(context.Background(), []string{"kept"})
+	vectors.Add(vectorindex.Item{ID: "2401.00001", Vector: kept[0]})
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	n, err := svc.SyncVectorIndex(context.Background(), since)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if n != 2 || vectors.Len() != 3 {
+		t.Errorf("Expected the 2 papers not embedded yet to be added, got: %d (index holds %d)", n, vectors.Len())
+	}
+	if !repo.lastQuery.StoredSince.Equal(since) {
+		t.Errorf("Expected papers stored since %v to be listed, got: %v", since, repo.lastQuery.StoredSince)
+	}
+}
+
+func TestImpl_Search_Semantic(t *testing.T) {
+	svc, _, _ := newSemanticService(t)
+	svc.RebuildVectorIndex(context.Background())
+
+	tests := []struct {
+		name  string
+		req   *SearchRequest
+		first string
+		skip  string
+	}{
+		{
+			name:  "different wording",
+			req:   &SearchRequest{Query: "molecule graph network", Mode: ModeSemantic},
+			first: "2401.00001",
+		},
+		{
+			name: "filtered by category",
+			req: &SearchRequest{
+				Query:   "molecule graph network",
+				Exclude: []Term{{Field: FieldCategory, Value: "cs.LG"}},
+				Mode:    ModeSemantic,
+			},
+			first: "2401.00002",
+			skip:  "2401.00001",
+		},
+		{
+			name:  "hybrid",
+			req:   &SearchRequest{Query: "quantum error", Mode: ModeHybrid},
+			first: "2401.00003",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.Search(context.Background(), tt.req)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(result.Papers) == 0 || result.Papers[0].ID != tt.first {
+				t.Fatalf("Expected %s first, got %d papers", tt.first, len(result.Papers))
+			}
+			for _, p := range result.Papers {
+				if p.ID == tt.skip {
+					t.Errorf("Expected %s to be filtered out", tt.skip)
+				}
+			}
+		})
+	}
+}
+
+func TestImpl_Search_SemanticSkipsMissingPapers(t *testing.T) {
+	// Arrange
+	svc, repo, vectors := newSemanticService(t)
+	svc.RebuildVectorIndex(context.Background())
+	delete(repo.papers, "2401.00001")
+
+	// Act
+	result, err := svc.Search(context.Background(), &SearchRequest{Query: "graph neural networks", Mode: ModeSemantic})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	for _, p := range result.Papers {
+		if p.ID == "2401.00001" {
+			t.Error("Expected missing paper to be skipped")
+		}
+	}
+	if vectors.Len() != 2 {
+		t.Errorf("Expected missing paper to be dropped from the vector index, got %d vectors", vectors.Len())
+	}
+}
+
+func TestFuseRankings(t *testing.T) {
+	a, b, c := &Paper{ID: "a"}, &Paper{ID: "b"}, &Paper{ID: "c"}
+
+	got := fuseRankings([]*Paper{a, b}, []*Paper{c, b})
+
+	if len(got) != 3 || got[0] != b || got[1] != a || got[2] != c {
+		t.Errorf("Expected [b a c], got: %v, %v, %v", got[0].ID, got[1].ID, got[2].ID)
+	}
+}
+
+func TestImpl_Search_ModeErrors(t *testing.T) {
+	semantic, _, _ := newSemanticService(t)
+	keywordOnly := New(&mockArxivService{}, newMockPaperRepository(), nil, nil, nil, "", time.Minute)
+
+	tests := []struct {
+		name    string
+		svc     *Impl
+		req     *SearchRequest
+		checkFn func(error) bool
+	}{
+		{
+			name:    "semantic disabled",
+			svc:     keywordOnly,
+			req:     &SearchRequest{Query: "graph", Mode: ModeSemantic},
+			checkFn: IsSemanticDisabled,
+		},
+		{
+			name:    "no free text",
+			svc:     semantic,
+			req:     &SearchRequest{Include: []Term{{Field: FieldTitle, Value: "graph"}}, Mode: ModeHybrid},
+			checkFn: IsInvalidQuery,
+		},
+		{
+			name:    "unknown mode",
+			svc:     semantic,
+			req:     &SearchRequest{Query: "graph", Mode: "fuzzy"},
+			checkFn: IsInvalidQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.svc.Search(context.Background(), tt.req)
+
+			if !tt.checkFn(err) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
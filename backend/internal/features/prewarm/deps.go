@@ -0,0 +1,10 @@
+package prewarm
+
+import "context"
+
+// feedRefresher defines the feed capability required by this feature.
+type feedRefresher interface {
+	// Refresh fetches the first page of a feed from upstream and caches it,
+	// returning the number of papers fetched.
+	Refresh(ctx context.Context, category, sortBy string, limit int) (int, error)
+}
@@ -0,0 +1,23 @@
+package prewarm
+
+import "errors"
+
+var (
+	// ErrAlreadyRunning indicates that Start was called on a running scheduler.
+	ErrAlreadyRunning = errors.New("prewarm scheduler is already running")
+
+	// ErrNotRunning indicates that the scheduler has not been started.
+	ErrNotRunning = errors.New("prewarm scheduler is not running")
+
+	// ErrUnknownJob indicates that no configured job matches a trigger.
+	ErrUnknownJob = errors.New("unknown prewarm job")
+)
+
+// IsAlreadyRunning checks if the error is ErrAlreadyRunning.
+func IsAlreadyRunning(err error) bool { return errors.Is(err, ErrAlreadyRunning) }
+
+// IsNotRunning checks if the error is ErrNotRunning.
+func IsNotRunning(err error) bool { return errors.Is(err, ErrNotRunning) }
+
+// IsUnknownJob checks if the error is ErrUnknownJob.
+func IsUnknownJob(err error) bool { return errors.Is(err, ErrUnknownJob) }
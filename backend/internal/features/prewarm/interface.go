@@ -0,0 +1,65 @@
+package prewarm
+
+import (
+	"context"
+	"time"
+)
+
+// Job identifies a feed page kept warm by the scheduler.
+type Job struct {
+	Category string // arXiv category, e.g. "cs.AI"
+	SortBy   string // arXiv sort order (DefaultSortBy if empty)
+}
+
+// Config contains the scheduler settings.
+type Config struct {
+	Jobs     []Job
+	Interval time.Duration // Time between refreshes of a job; keep it below the cache TTL
+	Jitter   time.Duration // Up to this much is taken off each delay, spreading jobs apart
+	Limit    int           // Papers fetched per refresh (DefaultLimit if zero)
+}
+
+// JobStatus reports the state of one job.
+type JobStatus struct {
+	Category    string    `json:"category"`
+	SortBy      string    `json:"sortBy"`
+	Running     bool      `json:"running"`
+	Runs        int       `json:"runs"`
+	Failures    int       `json:"failures"`
+	LastStarted time.Time `json:"lastStarted"`
+	LastSuccess time.Time `json:"lastSuccess"`
+	LastError   string    `json:"lastError,omitempty"` // Error of the last run, empty if it succeeded
+	LastPapers  int       `json:"lastPapers"`          // Papers fetched by the last successful run
+	NextRun     time.Time `json:"nextRun"`             // Zero while the scheduler is stopped
+}
+
+// Service defines the interface for the feed pre-warming scheduler.
+type Service interface {
+	// Start begins refreshing every job in the background: once right away
+	// (spread by the jitter), then every interval.
+	// @Returns:
+	//   - error: ErrAlreadyRunning if the scheduler is running
+	Start() error
+
+	// Stop cancels pending runs and waits for running refreshes to return.
+	// @Params:
+	//   - ctx: bounds how long to wait for running refreshes
+	// @Returns:
+	//   - error: ErrNotRunning if the scheduler is stopped, or ctx.Err() if waiting timed out
+	Stop(ctx context.Context) error
+
+	// Trigger runs jobs now instead of waiting for their next run.
+	// A trigger for a job that is already due or running is coalesced.
+	// @Params:
+	//   - category: the job category, or empty for every job
+	//   - sortBy: the job sort order, or empty for every sort order of the category
+	// @Returns:
+	//   - int: number of jobs triggered
+	//   - error: ErrNotRunning if the scheduler is stopped, ErrUnknownJob if no job matches
+	Trigger(category, sortBy string) (int, error)
+
+	// Status reports every job in configuration order.
+	// @Returns:
+	//   - []JobStatus: one entry per job
+	Status() []JobStatus
+}
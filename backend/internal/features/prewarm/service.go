@@ -0,0 +1,229 @@
+package prewarm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Defaults applied by New.
+const (
+	DefaultInterval = 4 * time.Minute
+	DefaultLimit    = 20
+	DefaultSortBy   = "lastUpdatedDate"
+)
+
+// Impl implements the prewarm Service interface.
+type Impl struct {
+	feed   feedRefresher
+	cfg    Config
+	jitter func(max time.Duration) time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	jobs   []*jobState
+	cancel context.CancelFunc // nil while stopped
+	wg     sync.WaitGroup
+}
+
+// jobState is a job together with its trigger channel and status.
+// status is guarded by Impl.mu.
+type jobState struct {
+	job     Job
+	trigger chan struct{}
+	status  JobStatus
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new prewarm scheduler. Duplicate jobs are dropped.
+func New(feed feedRefresher, cfg Config) *Impl {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Jitter < 0 || cfg.Jitter >= cfg.Interval {
+		cfg.Jitter = 0
+	}
+	if cfg.Limit <= 0 {
+		cfg.Limit = DefaultLimit
+	}
+
+	s := &Impl{
+		feed:   feed,
+		cfg:    cfg,
+		jitter: randomJitter,
+		now:    time.Now,
+	}
+	seen := make(map[Job]bool, len(cfg.Jobs))
+	for _, job := range cfg.Jobs {
+		if job.SortBy == "" {
+			job.SortBy = DefaultSortBy
+		}
+		if job.Category == "" || seen[job] {
+			continue
+		}
+		seen[job] = true
+		s.jobs = append(s.jobs, &jobState{
+			job:     job,
+			trigger: make(chan struct{}, 1),
+			status:  JobStatus{Category: job.Category, SortBy: job.SortBy},
+		})
+	}
+	return s
+}
+
+// Start begins refreshing every job in the background.
+func (s *Impl) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return ErrAlreadyRunning
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, j := range s.jobs {
+		// Drop triggers left over from a previous run.
+		select {
+		case <-j.trigger:
+		default:
+		}
+
+		s.wg.Add(1)
+		go s.run(ctx, j)
+	}
+	return nil
+}
+
+// Stop cancels pending runs and waits for running refreshes to return.
+func (s *Impl) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+	if cancel == nil {
+		return ErrNotRunning
+	}
+
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Trigger runs matching jobs now.
+func (s *Impl) Trigger(category, sortBy string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel == nil {
+		return 0, ErrNotRunning
+	}
+
+	triggered := 0
+	for _, j := range s.jobs {
+		if (category != "" && j.job.Category != category) || (sortBy != "" && j.job.SortBy != sortBy) {
+			continue
+		}
+		select {
+		case j.trigger <- struct{}{}:
+		default: // Already triggered
+		}
+		triggered++
+	}
+
+	if triggered == 0 {
+		return 0, fmt.Errorf("%w: category %q, sort %q", ErrUnknownJob, category, sortBy)
+	}
+	return triggered, nil
+}
+
+// Status reports every job in configuration order.
+func (s *Impl) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, len(s.jobs))
+	for i, j := range s.jobs {
+		statuses[i] = j.status
+	}
+	return statuses
+}
+
+// run refreshes one job until ctx is cancelled. The first run is delayed
+// only by the jitter; later runs come at most one interval apart, so a
+// page cached for longer than the interval is refreshed before it expires.
+func (s *Impl) run(ctx context.Context, j *jobState) {
+	defer s.wg.Done()
+	defer s.setNextRun(j, time.Time{})
+
+	delay := s.jitter(s.cfg.Jitter)
+	for {
+		s.setNextRun(j, s.now().Add(delay))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-j.trigger:
+			timer.Stop()
+		case <-timer.C:
+		}
+
+		s.refresh(ctx, j)
+		delay = s.cfg.Interval - s.jitter(s.cfg.Jitter)
+	}
+}
+
+// refresh runs one job and records the outcome.
+func (s *Impl) refresh(ctx context.Context, j *jobState) {
+	s.mu.Lock()
+	j.status.Running = true
+	j.status.LastStarted = s.now()
+	s.mu.Unlock()
+
+	n, err := s.feed.Refresh(ctx, j.job.Category, j.job.SortBy, s.cfg.Limit)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j.status.Running = false
+	if err != nil && ctx.Err() != nil {
+		// Interrupted by Stop; not a failure of the job.
+		return
+	}
+	j.status.Runs++
+	if err != nil {
+		j.status.Failures++
+		j.status.LastError = err.Error()
+		return
+	}
+	j.status.LastError = ""
+	j.status.LastSuccess = s.now()
+	j.status.LastPapers = n
+}
+
+// setNextRun records when a job will run next.
+func (s *Impl) setNextRun(j *jobState, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j.status.NextRun = at
+}
+
+// randomJitter returns a random duration in [0, max).
+func randomJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
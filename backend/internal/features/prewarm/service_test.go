@@ -0,0 +1,220 @@
+package prewarm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockFeedRefresher records refreshes and can block until released.
+type mockFeedRefresher struct {
+	mu    sync.Mutex
+	calls map[Job]int
+	limit int
+	err   error
+	block chan struct{} // if set, Refresh waits for it or for cancellation
+}
+
+func newMockFeedRefresher() *mockFeedRefresher {
+	return &mockFeedRefresher{calls: make(map[Job]int)}
+}
+
+func (m *mockFeedRefresher) Refresh(ctx context.Context, category, sortBy string, limit int) (int, error) {
+	m.mu.Lock()
+	m.calls[Job{Category: category, SortBy: sortBy}]++
+	m.limit = limit
+	block, err := m.block, m.err
+	m.mu.Unlock()
+
+	if block != nil {
+		select {
+		case <-block:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return limit, nil
+}
+
+func (m *mockFeedRefresher) count(job Job) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[job]
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// stop stops the scheduler, failing the test on error.
+func stop(t *testing.T, svc *Impl) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := svc.Stop(ctx); err != nil {
+		t.Fatalf("Expected no error stopping, got: %v", err)
+	}
+}
+
+func TestImpl_Start_RefreshesEveryJob(t *testing.T) {
+	// Arrange
+	feed := newMockFeedRefresher()
+	svc := New(feed, Config{
+		Jobs: []Job{
+			{Category: "cs.AI"},
+			{Category: "cs.LG", SortBy: "submittedDate"},
+			{Category: "cs.AI", SortBy: DefaultSortBy}, // duplicate
+		},
+		Interval: time.Hour,
+		Limit:    30,
+	})
+
+	// Act
+	if err := svc.Start(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	defer stop(t, svc)
+
+	// Assert
+	ai := Job{Category: "cs.AI", SortBy: DefaultSortBy}
+	lg := Job{Category: "cs.LG", SortBy: "submittedDate"}
+	waitFor(t, "both jobs to run", func() bool {
+		statuses := svc.Status()
+		return len(statuses) == 2 && statuses[0].Runs == 1 && statuses[1].Runs == 1
+	})
+	if feed.count(ai) != 1 || feed.count(lg) != 1 {
+		t.Errorf("Expected one refresh per job, got: %v", feed.calls)
+	}
+	status := svc.Status()[0]
+	if status.LastPapers != 30 || status.LastSuccess.IsZero() || status.LastError != "" {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+	if !status.NextRun.After(time.Now().Add(50 * time.Minute)) {
+		t.Errorf("Expected next run about an interval away, got: %v", status.NextRun)
+	}
+}
+
+func TestImpl_RepeatsEveryInterval(t *testing.T) {
+	feed := newMockFeedRefresher()
+	svc := New(feed, Config{Jobs: []Job{{Category: "cs.AI"}}, Interval: 5 * time.Millisecond})
+
+	svc.Start()
+	defer stop(t, svc)
+
+	waitFor(t, "repeated refreshes", func() bool {
+		return feed.count(Job{Category: "cs.AI", SortBy: DefaultSortBy}) >= 3
+	})
+}
+
+func TestImpl_Trigger(t *testing.T) {
+	// Arrange
+	feed := newMockFeedRefresher()
+	svc := New(feed, Config{
+		Jobs:     []Job{{Category: "cs.AI"}, {Category: "cs.AI", SortBy: "submittedDate"}, {Category: "cs.LG"}},
+		Interval: time.Hour,
+	})
+	svc.Start()
+	defer stop(t, svc)
+	waitFor(t, "initial runs", func() bool {
+		for _, s := range svc.Status() {
+			if s.Runs != 1 {
+				return false
+			}
+		}
+		return true
+	})
+
+	// Act
+	n, err := svc.Trigger("cs.AI", "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 jobs triggered, got: %d", n)
+	}
+	waitFor(t, "triggered runs", func() bool {
+		statuses := svc.Status()
+		return statuses[0].Runs == 2 && statuses[1].Runs == 2
+	})
+	if runs := svc.Status()[2].Runs; runs != 1 {
+		t.Errorf("Expected cs.LG not to be triggered, got %d runs", runs)
+	}
+}
+
+func TestImpl_Trigger_Errors(t *testing.T) {
+	feed := newMockFeedRefresher()
+	svc := New(feed, Config{Jobs: []Job{{Category: "cs.AI"}}, Interval: time.Hour})
+
+	if _, err := svc.Trigger("", ""); !IsNotRunning(err) {
+		t.Errorf("Expected ErrNotRunning before Start, got: %v", err)
+	}
+
+	svc.Start()
+	defer stop(t, svc)
+	if _, err := svc.Trigger("cs.CV", ""); !IsUnknownJob(err) {
+		t.Errorf("Expected ErrUnknownJob, got: %v", err)
+	}
+	if err := svc.Start(); !IsAlreadyRunning(err) {
+		t.Errorf("Expected ErrAlreadyRunning, got: %v", err)
+	}
+}
+
+func TestImpl_RecordsFailures(t *testing.T) {
+	// Arrange
+	feed := newMockFeedRefresher()
+	feed.err = errors.New("arXiv is down")
+	svc := New(feed, Config{Jobs: []Job{{Category: "cs.AI"}}, Interval: time.Hour})
+
+	// Act
+	svc.Start()
+	defer stop(t, svc)
+
+	// Assert
+	waitFor(t, "failed run", func() bool { return svc.Status()[0].Runs == 1 })
+	status := svc.Status()[0]
+	if status.Failures != 1 || status.LastError != "arXiv is down" || !status.LastSuccess.IsZero() {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+}
+
+func TestImpl_Stop_WaitsForRunningRefresh(t *testing.T) {
+	// Arrange
+	feed := newMockFeedRefresher()
+	feed.block = make(chan struct{})
+	svc := New(feed, Config{Jobs: []Job{{Category: "cs.AI"}}, Interval: time.Hour})
+	svc.Start()
+	waitFor(t, "refresh to start", func() bool { return svc.Status()[0].Running })
+
+	// Act
+	stop(t, svc)
+
+	// Assert
+	status := svc.Status()[0]
+	if status.Running {
+		t.Error("Expected no refresh running after Stop")
+	}
+	if status.Failures != 0 || status.Runs != 0 {
+		t.Errorf("Expected the interrupted run not to count, got: %+v", status)
+	}
+	if !status.NextRun.IsZero() {
+		t.Errorf("Expected no next run while stopped, got: %v", status.NextRun)
+	}
+	if err := svc.Stop(context.Background()); !IsNotRunning(err) {
+		t.Errorf("Expected ErrNotRunning on second Stop, got: %v", err)
+	}
+}
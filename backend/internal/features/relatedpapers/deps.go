@@ -0,0 +1,30 @@
+package relatedpapers
+
+import (
+	"context"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// similarityIndex defines the index capability required by this feature.
+type similarityIndex interface {
+	// Similar returns the documents most similar to an indexed document.
+	Similar(id string, limit int) (*searchindex.Result, error)
+}
+
+// paperStore defines the repository capability used to hydrate matches.
+type paperStore interface {
+	// GetByID retrieves a single paper by ID.
+	GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool)
+}
+
+// matchCache defines the cache capability used to keep matches per paper.
+type matchCache interface {
+	// Get retrieves a value from the cache.
+	Get(key string) (interface{}, bool)
+
+	// Set stores a value in the cache with the given TTL.
+	Set(key string, value interface{}, ttl time.Duration)
+}
@@ -0,0 +1,17 @@
+package relatedpapers
+
+import "errors"
+
+var (
+	// ErrInvalidID indicates that a paper ID is not a valid arXiv identifier.
+	ErrInvalidID = errors.New("invalid paper ID")
+
+	// ErrNotIndexed indicates that the paper is not known to the repository.
+	ErrNotIndexed = errors.New("paper is not indexed")
+)
+
+// IsInvalidID checks if the error is ErrInvalidID.
+func IsInvalidID(err error) bool { return errors.Is(err, ErrInvalidID) }
+
+// IsNotIndexed checks if the error is ErrNotIndexed.
+func IsNotIndexed(err error) bool { return errors.Is(err, ErrNotIndexed) }
@@ -0,0 +1,33 @@
+package relatedpapers
+
+import (
+	"context"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// Limits on the number of related papers returned.
+const (
+	DefaultLimit = 10
+	MaxLimit     = 50 // Also the number of matches cached per paper
+)
+
+// Paper is a related paper: a stored paper and its similarity to the source paper.
+type Paper struct {
+	*paperRepo.Paper
+	Score float64 // Cosine similarity to the source paper, in (0, 1]
+}
+
+// Service defines the interface for finding papers related to a paper.
+type Service interface {
+	// Find returns the stored papers most similar to a paper, most similar first.
+	// Matches are computed over titles, abstracts and categories and cached per paper.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - id: paper ID; any version suffix is ignored
+	//   - limit: maximum number of papers (DefaultLimit if zero, at most MaxLimit)
+	// @Returns:
+	//   - []*Paper: related papers with their similarity scores
+	//   - error: ErrInvalidID if the ID is malformed, ErrNotIndexed if the paper is not stored
+	Find(ctx context.Context, id string, limit int) ([]*Paper, error)
+}
@@ -0,0 +1,97 @@
+package relatedpapers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+)
+
+// match is a cached similarity match.
+type match struct {
+	ID    string
+	Score float64
+}
+
+// Impl implements the relatedpapers Service interface.
+type Impl struct {
+	index    similarityIndex
+	papers   paperStore
+	cache    matchCache
+	cacheTTL time.Duration
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new relatedpapers service instance.
+// Matches for a paper are cached for cacheTTL, so papers stored in the
+// meantime show up once the entry expires.
+func New(index similarityIndex, papers paperStore, cache matchCache, cacheTTL time.Duration) *Impl {
+	return &Impl{
+		index:    index,
+		papers:   papers,
+		cache:    cache,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// Find returns the stored papers most similar to a paper.
+func (s *Impl) Find(ctx context.Context, id string, limit int) ([]*Paper, error) {
+	ident, err := arxiv.ParseIdentifier(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	matches, err := s.matches(ident.Base())
+	if err != nil {
+		return nil, err
+	}
+
+	papers := make([]*Paper, 0, limit)
+	for _, m := range matches {
+		if len(papers) == limit {
+			break
+		}
+		// Papers may have expired from the repository since they were indexed.
+		p, ok := s.papers.GetByID(ctx, m.ID)
+		if !ok {
+			continue
+		}
+		papers = append(papers, &Paper{Paper: p, Score: m.Score})
+	}
+	return papers, nil
+}
+
+// matches returns the cached matches of a paper, computing them on a miss.
+func (s *Impl) matches(id string) ([]match, error) {
+	key := "related:" + id
+	if cached, ok := s.cache.Get(key); ok {
+		if matches, ok := cached.([]match); ok {
+			return matches, nil
+		}
+	}
+
+	result, err := s.index.Similar(id, MaxLimit)
+	if err != nil {
+		if searchindex.IsNotIndexed(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotIndexed, id)
+		}
+		return nil, err
+	}
+
+	matches := make([]match, len(result.Hits))
+	for i, h := range result.Hits {
+		matches[i] = match{ID: h.ID, Score: h.Score}
+	}
+	s.cache.Set(key, matches, s.cacheTTL)
+	return matches, nil
+}
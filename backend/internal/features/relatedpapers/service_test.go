@@ -0,0 +1,142 @@
+package relatedpapers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/searchindex"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockIndex returns fixed hits and counts calls.
+type mockIndex struct {
+	hits  map[string][]searchindex.Hit
+	calls int
+	limit int
+}
+
+func (m *mockIndex) Similar(id string, limit int) (*searchindex.Result, error) {
+	m.calls++
+	m.limit = limit
+	hits, ok := m.hits[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", searchindex.ErrNotIndexed, id)
+	}
+	return &searchindex.Result{Hits: hits, Total: len(hits)}, nil
+}
+
+// mockPapers holds the index hits that are still stored.
+type mockPapers map[string]*paperRepo.Paper
+
+func (m mockPapers) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	p, ok := m[id]
+	return p, ok
+}
+
+// mockCache is a map-backed cache that ignores TTLs.
+type mockCache map[string]interface{}
+
+func (m mockCache) Get(key string) (interface{}, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+func (m mockCache) Set(key string, value interface{}, ttl time.Duration) { m[key] = value }
+
+func newTestService() (*Impl, *mockIndex) {
+	index := &mockIndex{hits: map[string][]searchindex.Hit{
+		"2401.00001": {
+			{ID: "2401.00002", Score: 0.9},
+			{ID: "2401.00003", Score: 0.5},
+			{ID: "2401.00004", Score: 0.2},
+		},
+	}}
+	papers := mockPapers{
+		"2401.00002": {ID: "2401.00002"},
+		"2401.00004": {ID: "2401.00004"},
+	}
+	return New(index, papers, mockCache{}, time.Hour), index
+}
+
+func TestImpl_Find(t *testing.T) {
+	// Arrange
+	svc, index := newTestService()
+
+	// Act
+	papers, err := svc.Find(context.Background(), "2401.00001v2", 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(papers) != 2 {
+		t.Fatalf("Expected 2 stored papers, got: %d", len(papers))
+	}
+	if papers[0].ID != "2401.00002" || papers[0].Score != 0.9 {
+		t.Errorf("Expected most similar paper first, got: %+v", papers[0])
+	}
+	if papers[1].ID != "2401.00004" {
+		t.Errorf("Expected paper missing from the repository to be skipped, got: %+v", papers[1])
+	}
+	if index.limit != MaxLimit {
+		t.Errorf("Expected %d matches requested, got: %d", MaxLimit, index.limit)
+	}
+}
+
+func TestImpl_Find_Limit(t *testing.T) {
+	svc, _ := newTestService()
+
+	papers, err := svc.Find(context.Background(), "2401.00001", 1)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(papers) != 1 || papers[0].ID != "2401.00002" {
+		t.Errorf("Expected only the best match, got: %v", papers)
+	}
+}
+
+func TestImpl_Find_CachesPerPaper(t *testing.T) {
+	// Arrange
+	svc, index := newTestService()
+
+	// Act
+	svc.Find(context.Background(), "2401.00001", 1)
+	papers, err := svc.Find(context.Background(), "2401.00001v1", 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if index.calls != 1 {
+		t.Errorf("Expected 1 index call, got: %d", index.calls)
+	}
+	if len(papers) != 2 {
+		t.Errorf("Expected cached matches to serve a larger limit, got: %d papers", len(papers))
+	}
+}
+
+func TestImpl_Find_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		id    string
+		check func(error) bool
+	}{
+		{name: "malformed ID", id: "not-an-id", check: IsInvalidID},
+		{name: "unknown paper", id: "2401.09999", check: IsNotIndexed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService()
+
+			_, err := svc.Find(context.Background(), tt.id, 10)
+
+			if !tt.check(err) {
+				t.Errorf("Expected matching error, got: %v", err)
+			}
+		})
+	}
+}
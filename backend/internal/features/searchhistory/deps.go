@@ -0,0 +1,47 @@
+package searchhistory
+
+import (
+	"context"
+
+	searchHistoryRepo "github.com/rrlian/papertok/backend/internal/repository/searchhistory"
+)
+
+// historyStore defines the repository capabilities required for search
+// history and saved searches.
+type historyStore interface {
+	// Record adds a search to a user's history, merging repeats.
+	Record(ctx context.Context, entry *searchHistoryRepo.Entry) error
+
+	// GetEntry retrieves a history entry by ID.
+	GetEntry(ctx context.Context, id int64) (*searchHistoryRepo.Entry, error)
+
+	// ListEntries returns a page of a user's history and its total size.
+	ListEntries(ctx context.Context, userID int64, offset, limit int) ([]*searchHistoryRepo.Entry, int, error)
+
+	// DeleteEntry removes a history entry.
+	DeleteEntry(ctx context.Context, id int64) error
+
+	// ClearEntries removes a user's whole history.
+	ClearEntries(ctx context.Context, userID int64) (int, error)
+
+	// PruneEntries removes all but a user's keep most recent entries.
+	PruneEntries(ctx context.Context, userID int64, keep int) error
+
+	// CreateSaved stores a new saved search.
+	CreateSaved(ctx context.Context, s *searchHistoryRepo.SavedSearch) error
+
+	// GetSaved retrieves a saved search by ID.
+	GetSaved(ctx context.Context, id int64) (*searchHistoryRepo.SavedSearch, error)
+
+	// ListSaved returns a user's saved searches.
+	ListSaved(ctx context.Context, userID int64) ([]*searchHistoryRepo.SavedSearch, error)
+
+	// ListAllSaved returns every user's saved searches.
+	ListAllSaved(ctx context.Context) ([]*searchHistoryRepo.SavedSearch, error)
+
+	// RenameSaved saves a saved search's name.
+	RenameSaved(ctx context.Context, s *searchHistoryRepo.SavedSearch) error
+
+	// DeleteSaved removes a saved search.
+	DeleteSaved(ctx context.Context, id int64) error
+}
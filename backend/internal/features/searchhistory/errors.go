@@ -0,0 +1,43 @@
+package searchhistory
+
+import "errors"
+
+var (
+	// ErrEmptyQuery indicates that a query has no text, terms or dates.
+	ErrEmptyQuery = errors.New("empty search query")
+
+	// ErrEntryNotFound indicates that the history entry does not exist or
+	// belongs to another user.
+	ErrEntryNotFound = errors.New("search history entry not found")
+
+	// ErrSavedNotFound indicates that the saved search does not exist or
+	// belongs to another user.
+	ErrSavedNotFound = errors.New("saved search not found")
+
+	// ErrInvalidName indicates that a saved search name is empty or too long.
+	ErrInvalidName = errors.New("invalid saved search name")
+
+	// ErrNameTaken indicates that the user already has a saved search with the name.
+	ErrNameTaken = errors.New("saved search name already taken")
+
+	// ErrTooManySaved indicates that the user already has MaxSaved saved searches.
+	ErrTooManySaved = errors.New("too many saved searches")
+)
+
+// IsEmptyQuery checks if the error is ErrEmptyQuery.
+func IsEmptyQuery(err error) bool { return errors.Is(err, ErrEmptyQuery) }
+
+// IsEntryNotFound checks if the error is ErrEntryNotFound.
+func IsEntryNotFound(err error) bool { return errors.Is(err, ErrEntryNotFound) }
+
+// IsSavedNotFound checks if the error is ErrSavedNotFound.
+func IsSavedNotFound(err error) bool { return errors.Is(err, ErrSavedNotFound) }
+
+// IsInvalidName checks if the error is ErrInvalidName.
+func IsInvalidName(err error) bool { return errors.Is(err, ErrInvalidName) }
+
+// IsNameTaken checks if the error is ErrNameTaken.
+func IsNameTaken(err error) bool { return errors.Is(err, ErrNameTaken) }
+
+// IsTooManySaved checks if the error is ErrTooManySaved.
+func IsTooManySaved(err error) bool { return errors.Is(err, ErrTooManySaved) }
@@ -0,0 +1,169 @@
+package searchhistory
+
+import (
+	"context"
+	"time"
+)
+
+// Limits on search history and saved searches.
+const (
+	MaxNameLength = 100 // Characters
+	MaxSaved      = 50  // Saved searches per user
+	DefaultLimit  = 20  // History entries per page
+	MaxLimit      = 100
+)
+
+// Term is a fielded search term.
+type Term struct {
+	Field string `json:"field"` // title, author, abstract, category or id
+	Value string `json:"value"`
+}
+
+// Query is a search with its filters, as accepted by the paper search.
+type Query struct {
+	Text          string     `json:"text,omitempty"`    // Free-text keywords
+	Include       []Term     `json:"include,omitempty"` // Fielded terms results must match
+	Exclude       []Term     `json:"exclude,omitempty"` // Fielded terms results must not match
+	MatchAny      bool       `json:"matchAny,omitempty"`
+	SubmittedFrom *time.Time `json:"submittedFrom,omitempty"`
+	SubmittedTo   *time.Time `json:"submittedTo,omitempty"`
+	Backend       string     `json:"backend,omitempty"`
+	Mode          string     `json:"mode,omitempty"`
+}
+
+// Entry is a search in a user's history. Repeats of the same query share
+// an entry.
+type Entry struct {
+	ID          int64     `json:"id"`
+	Query       *Query    `json:"query"`
+	ResultCount int       `json:"resultCount"` // Matches on the latest run
+	SearchCount int       `json:"searchCount"` // Times the query was run
+	SearchedAt  time.Time `json:"searchedAt"`  // Latest run
+}
+
+// EntryList is a page of a user's search history.
+type EntryList struct {
+	Entries []*Entry
+	Total   int
+}
+
+// SavedSearch is a named query kept by a user.
+type SavedSearch struct {
+	ID        int64     `json:"id"`
+	OwnerID   int64     `json:"-"`
+	Name      string    `json:"name"`
+	Query     *Query    `json:"query"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Config configures the search history service.
+type Config struct {
+	MaxEntries int              // History entries kept per user (default 100)
+	Now        func() time.Time // Clock (time.Now if nil)
+}
+
+// Service defines the interface for search history and saved searches.
+// Other users' entries and saved searches are reported as not found.
+type Service interface {
+	// Record adds a query to a user's history, merging it with an earlier
+	// run of the same query. The oldest entries beyond the limit are dropped.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user who searched
+	//   - query: the query and filters
+	//   - resultCount: the number of matches
+	// @Returns:
+	//   - *Entry: the recorded entry
+	//   - error: ErrEmptyQuery if the query has no text, terms or dates
+	Record(ctx context.Context, userID int64, query *Query, resultCount int) (*Entry, error)
+
+	// List returns a page of a user's history, most recent first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	//   - offset: entries to skip
+	//   - limit: page size (DefaultLimit if zero, at most MaxLimit)
+	// @Returns:
+	//   - *EntryList: the entries and the user's total
+	//   - error: if the history cannot be read
+	List(ctx context.Context, userID int64, offset, limit int) (*EntryList, error)
+
+	// Delete removes an entry from a user's history.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	//   - id: entry ID
+	// @Returns:
+	//   - error: ErrEntryNotFound if the user has no such entry
+	Delete(ctx context.Context, userID, id int64) error
+
+	// Clear removes a user's whole history.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	// @Returns:
+	//   - int: the number of entries removed
+	//   - error: if the history cannot be cleared
+	Clear(ctx context.Context, userID int64) (int, error)
+
+	// Save promotes a history entry to a named saved search. The saved
+	// search keeps its own copy of the query, so it outlives the entry.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	//   - entryID: history entry ID
+	//   - name: name of the saved search
+	// @Returns:
+	//   - *SavedSearch: the new saved search
+	//   - error: ErrEntryNotFound, ErrInvalidName, ErrNameTaken, or ErrTooManySaved at MaxSaved
+	Save(ctx context.Context, userID, entryID int64, name string) (*SavedSearch, error)
+
+	// ListSaved returns a user's saved searches, most recently created first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	// @Returns:
+	//   - []*SavedSearch: the saved searches
+	//   - error: if they cannot be read
+	ListSaved(ctx context.Context, userID int64) ([]*SavedSearch, error)
+
+	// GetSaved returns one of a user's saved searches.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	//   - id: saved search ID
+	// @Returns:
+	//   - *SavedSearch: the saved search
+	//   - error: ErrSavedNotFound if the user has no such saved search
+	GetSaved(ctx context.Context, userID, id int64) (*SavedSearch, error)
+
+	// ListAllSaved returns every user's saved searches, oldest first, with
+	// their owners set. It is meant for background jobs such as alerts.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	// @Returns:
+	//   - []*SavedSearch: the saved searches
+	//   - error: if they cannot be read
+	ListAllSaved(ctx context.Context) ([]*SavedSearch, error)
+
+	// RenameSaved renames one of a user's saved searches.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	//   - id: saved search ID
+	//   - name: the new name
+	// @Returns:
+	//   - *SavedSearch: the renamed saved search
+	//   - error: ErrSavedNotFound, ErrInvalidName or ErrNameTaken
+	RenameSaved(ctx context.Context, userID, id int64, name string) (*SavedSearch, error)
+
+	// DeleteSaved removes one of a user's saved searches.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - userID: the user
+	//   - id: saved search ID
+	// @Returns:
+	//   - error: ErrSavedNotFound if the user has no such saved search
+	DeleteSaved(ctx context.Context, userID, id int64) error
+}
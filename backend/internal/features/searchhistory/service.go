@@ -0,0 +1,338 @@
+package searchhistory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	searchHistoryRepo "github.com/rrlian/papertok/backend/internal/repository/searchhistory"
+)
+
+// defaultMaxEntries is the history kept per user when Config leaves it zero.
+const defaultMaxEntries = 100
+
+// Impl implements the searchhistory Service interface.
+type Impl struct {
+	store      historyStore
+	maxEntries int
+	now        func() time.Time
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new search history service instance.
+func New(store historyStore, cfg Config) *Impl {
+	s := &Impl{
+		store:      store,
+		maxEntries: cfg.MaxEntries,
+		now:        cfg.Now,
+	}
+	if s.maxEntries <= 0 {
+		s.maxEntries = defaultMaxEntries
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	return s
+}
+
+// Record adds a query to a user's history.
+func (s *Impl) Record(ctx context.Context, userID int64, query *Query, resultCount int) (*Entry, error) {
+	q := normalize(query)
+	if q.Text == "" && len(q.Include) == 0 && q.SubmittedFrom == nil && q.SubmittedTo == nil {
+		return nil, ErrEmptyQuery
+	}
+	params, err := json.Marshal(q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode search query: %w", err)
+	}
+	sum := sha256.Sum256(params)
+
+	entry := &searchHistoryRepo.Entry{
+		UserID:      userID,
+		Key:         hex.EncodeToString(sum[:]),
+		Params:      string(params),
+		ResultCount: resultCount,
+		SearchedAt:  s.now(),
+	}
+	if err := s.store.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	if err := s.store.PruneEntries(ctx, userID, s.maxEntries); err != nil {
+		return nil, err
+	}
+	return convertEntry(entry)
+}
+
+// List returns a page of a user's history, most recent first.
+func (s *Impl) List(ctx context.Context, userID int64, offset, limit int) (*EntryList, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	stored, total, err := s.store.ListEntries(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	list := &EntryList{Entries: make([]*Entry, len(stored)), Total: total}
+	for i, e := range stored {
+		if list.Entries[i], err = convertEntry(e); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// Delete removes an entry from a user's history.
+func (s *Impl) Delete(ctx context.Context, userID, id int64) error {
+	if _, err := s.ownedEntry(ctx, userID, id); err != nil {
+		return err
+	}
+	return entryNotFound(s.store.DeleteEntry(ctx, id), id)
+}
+
+// Clear removes a user's whole history.
+func (s *Impl) Clear(ctx context.Context, userID int64) (int, error) {
+	return s.store.ClearEntries(ctx, userID)
+}
+
+// Save promotes a history entry to a named saved search.
+func (s *Impl) Save(ctx context.Context, userID, entryID int64, name string) (*SavedSearch, error) {
+	entry, err := s.ownedEntry(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if name, err = validName(name); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.ListSaved(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxSaved {
+		return nil, fmt.Errorf("%w: at most %d", ErrTooManySaved, MaxSaved)
+	}
+
+	saved := &searchHistoryRepo.SavedSearch{UserID: userID, Name: name, Params: entry.Params}
+	if err := s.store.CreateSaved(ctx, saved); err != nil {
+		return nil, nameTaken(err, name)
+	}
+	return convertSaved(saved)
+}
+
+// ListSaved returns a user's saved searches, most recently created first.
+func (s *Impl) ListSaved(ctx context.Context, userID int64) ([]*SavedSearch, error) {
+	stored, err := s.store.ListSaved(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*SavedSearch, len(stored))
+	for i, saved := range stored {
+		if result[i], err = convertSaved(saved); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ListAllSaved returns every user's saved searches, oldest first.
+func (s *Impl) ListAllSaved(ctx context.Context) ([]*SavedSearch, error) {
+	stored, err := s.store.ListAllSaved(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*SavedSearch, len(stored))
+	for i, saved := range stored {
+		if result[i], err = convertSaved(saved); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// GetSaved returns one of a user's saved searches.
+func (s *Impl) GetSaved(ctx context.Context, userID, id int64) (*SavedSearch, error) {
+	saved, err := s.ownedSaved(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return convertSaved(saved)
+}
+
+// RenameSaved renames one of a user's saved searches.
+func (s *Impl) RenameSaved(ctx context.Context, userID, id int64, name string) (*SavedSearch, error) {
+	saved, err := s.ownedSaved(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if saved.Name, err = validName(name); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.RenameSaved(ctx, saved); err != nil {
+		if errors.Is(err, searchHistoryRepo.ErrSavedNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrSavedNotFound, id)
+		}
+		return nil, nameTaken(err, saved.Name)
+	}
+	return convertSaved(saved)
+}
+
+// DeleteSaved removes one of a user's saved searches.
+func (s *Impl) DeleteSaved(ctx context.Context, userID, id int64) error {
+	if _, err := s.ownedSaved(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.store.DeleteSaved(ctx, id); err != nil {
+		if errors.Is(err, searchHistoryRepo.ErrSavedNotFound) {
+			return fmt.Errorf("%w: %d", ErrSavedNotFound, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// ownedEntry returns a history entry if it belongs to the user.
+func (s *Impl) ownedEntry(ctx context.Context, userID, id int64) (*searchHistoryRepo.Entry, error) {
+	entry, err := s.store.GetEntry(ctx, id)
+	if err != nil {
+		return nil, entryNotFound(err, id)
+	}
+	if entry.UserID != userID {
+		return nil, fmt.Errorf("%w: %d", ErrEntryNotFound, id)
+	}
+	return entry, nil
+}
+
+// ownedSaved returns a saved search if it belongs to the user.
+func (s *Impl) ownedSaved(ctx context.Context, userID, id int64) (*searchHistoryRepo.SavedSearch, error) {
+	saved, err := s.store.GetSaved(ctx, id)
+	if errors.Is(err, searchHistoryRepo.ErrSavedNotFound) || (err == nil && saved.UserID != userID) {
+		return nil, fmt.Errorf("%w: %d", ErrSavedNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// normalize returns a copy of a query in canonical form, so that the same
+// search always encodes the same way: whitespace is collapsed, empty terms
+// are dropped, terms are sorted and dates are in UTC.
+func normalize(q *Query) *Query {
+	n := &Query{
+		Text:     strings.Join(strings.Fields(q.Text), " "),
+		Include:  normalizeTerms(q.Include),
+		Exclude:  normalizeTerms(q.Exclude),
+		MatchAny: q.MatchAny,
+		Backend:  q.Backend,
+		Mode:     q.Mode,
+	}
+	if q.SubmittedFrom != nil && !q.SubmittedFrom.IsZero() {
+		t := q.SubmittedFrom.UTC()
+		n.SubmittedFrom = &t
+	}
+	if q.SubmittedTo != nil && !q.SubmittedTo.IsZero() {
+		t := q.SubmittedTo.UTC()
+		n.SubmittedTo = &t
+	}
+	return n
+}
+
+// normalizeTerms trims and sorts terms, dropping empty ones.
+func normalizeTerms(terms []Term) []Term {
+	var result []Term
+	for _, t := range terms {
+		value := strings.Join(strings.Fields(t.Value), " ")
+		if value != "" {
+			result = append(result, Term{Field: t.Field, Value: value})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Field != result[j].Field {
+			return result[i].Field < result[j].Field
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
+
+// validName trims a saved search name and checks its length.
+func validName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidName, MaxNameLength)
+	}
+	return name, nil
+}
+
+// entryNotFound maps the repository's not-found error to ErrEntryNotFound.
+func entryNotFound(err error, id int64) error {
+	if errors.Is(err, searchHistoryRepo.ErrEntryNotFound) {
+		return fmt.Errorf("%w: %d", ErrEntryNotFound, id)
+	}
+	return err
+}
+
+// nameTaken maps the repository's duplicate-name error to ErrNameTaken.
+func nameTaken(err error, name string) error {
+	if errors.Is(err, searchHistoryRepo.ErrNameTaken) {
+		return fmt.Errorf("%w: %q", ErrNameTaken, name)
+	}
+	return err
+}
+
+// decodeQuery decodes stored query params.
+func decodeQuery(params string) (*Query, error) {
+	var q Query
+	if err := json.Unmarshal([]byte(params), &q); err != nil {
+		return nil, fmt.Errorf("failed to decode search query: %w", err)
+	}
+	return &q, nil
+}
+
+// convertEntry converts a repository history entry.
+func convertEntry(e *searchHistoryRepo.Entry) (*Entry, error) {
+	q, err := decodeQuery(e.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{
+		ID:          e.ID,
+		Query:       q,
+		ResultCount: e.ResultCount,
+		SearchCount: e.SearchCount,
+		SearchedAt:  e.SearchedAt,
+	}, nil
+}
+
+// convertSaved converts a repository saved search.
+func convertSaved(s *searchHistoryRepo.SavedSearch) (*SavedSearch, error) {
+	q, err := decodeQuery(s.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &SavedSearch{
+		ID:        s.ID,
+		OwnerID:   s.UserID,
+		Name:      s.Name,
+		Query:     q,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}, nil
+}
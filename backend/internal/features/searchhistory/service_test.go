@@ -0,0 +1,292 @@
+package searchhistory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	searchHistoryRepo "github.com/rrlian/papertok/backend/internal/repository/searchhistory"
+)
+
+func newTestService(maxEntries int) (*Impl, *time.Time) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := New(searchHistoryRepo.NewMemoryRepository(), Config{
+		MaxEntries: maxEntries,
+		Now:        func() time.Time { return now },
+	})
+	return svc, &now
+}
+
+func TestImpl_Record(t *testing.T) {
+	// Arrange
+	svc, now := newTestService(0)
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	// Act
+	first, firstErr := svc.Record(ctx, 1, &Query{
+		Text:          "  diffusion   models ",
+		Include:       []Term{{Field: "author", Value: "Ho"}, {Field: "category", Value: "cs.LG"}},
+		SubmittedFrom: &from,
+	}, 12)
+	*now = now.Add(time.Minute)
+	again, againErr := svc.Record(ctx, 1, &Query{
+		Text:          "diffusion models",
+		Include:       []Term{{Field: "category", Value: "cs.LG"}, {Field: "author", Value: " Ho "}, {Field: "title", Value: " "}},
+		SubmittedFrom: &from,
+	}, 15)
+	other, otherErr := svc.Record(ctx, 1, &Query{Text: "diffusion models", MatchAny: true}, 40)
+
+	// Assert
+	if firstErr != nil || againErr != nil || otherErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v, %v", firstErr, againErr, otherErr)
+	}
+	if again.ID != first.ID || again.SearchCount != 2 || again.ResultCount != 15 {
+		t.Errorf("Expected the repeat to merge into entry %d, got: %+v", first.ID, again)
+	}
+	if !again.SearchedAt.Equal(*now) {
+		t.Errorf("Expected searchedAt to move to the latest run, got: %v", again.SearchedAt)
+	}
+	if got := again.Query; got.Text != "diffusion models" || len(got.Include) != 2 || got.Include[0].Field != "author" {
+		t.Errorf("Expected a normalized query, got: %+v", got)
+	}
+	if got := again.Query.SubmittedFrom; got == nil || got.Location() != time.UTC || !got.Equal(from) {
+		t.Errorf("Expected submittedFrom in UTC, got: %v", got)
+	}
+	if other.ID == first.ID {
+		t.Errorf("Expected a different filter to get its own entry")
+	}
+	list, _ := svc.List(ctx, 1, 0, 0)
+	if list.Total != 2 || list.Entries[0].ID != other.ID {
+		t.Errorf("Expected 2 entries newest first, got: %+v", list.Entries)
+	}
+}
+
+func TestImpl_RecordPrunes(t *testing.T) {
+	// Arrange
+	svc, now := newTestService(3)
+	ctx := context.Background()
+
+	// Act
+	for i := 0; i < 5; i++ {
+		*now = now.Add(time.Minute)
+		if _, err := svc.Record(ctx, 1, &Query{Text: fmt.Sprintf("query %d", i)}, i); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+	list, err := svc.List(ctx, 1, 0, 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if list.Total != 3 || list.Entries[2].Query.Text != "query 2" {
+		t.Errorf("Expected the 3 newest entries, got: %d, %+v", list.Total, list.Entries)
+	}
+}
+
+func TestImpl_DeleteAndClear(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService(0)
+	ctx := context.Background()
+	a, _ := svc.Record(ctx, 1, &Query{Text: "a"}, 1)
+	svc.Record(ctx, 1, &Query{Text: "b"}, 1)
+	theirs, _ := svc.Record(ctx, 2, &Query{Text: "c"}, 1)
+
+	// Act
+	deleteErr := svc.Delete(ctx, 1, a.ID)
+	otherErr := svc.Delete(ctx, 1, theirs.ID)
+	cleared, clearErr := svc.Clear(ctx, 1)
+
+	// Assert
+	if deleteErr != nil || clearErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v", deleteErr, clearErr)
+	}
+	if !IsEntryNotFound(otherErr) {
+		t.Errorf("Expected ErrEntryNotFound for another user's entry, got: %v", otherErr)
+	}
+	if cleared != 1 {
+		t.Errorf("Expected 1 entry cleared, got: %d", cleared)
+	}
+	if list, _ := svc.List(ctx, 2, 0, 0); list.Total != 1 {
+		t.Errorf("Expected the other user's history untouched, got: %d", list.Total)
+	}
+}
+
+func TestImpl_SavedSearches(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService(0)
+	ctx := context.Background()
+	entry, _ := svc.Record(ctx, 1, &Query{Text: "diffusion", Include: []Term{{Field: "author", Value: "Ho"}}}, 5)
+
+	// Act
+	saved, saveErr := svc.Save(ctx, 1, entry.ID, "  Ho diffusion ")
+	clearErr := svc.Delete(ctx, 1, entry.ID)
+	renamed, renameErr := svc.RenameSaved(ctx, 1, saved.ID, "Score models")
+	got, getErr := svc.GetSaved(ctx, 1, saved.ID)
+	_, otherErr := svc.GetSaved(ctx, 2, saved.ID)
+
+	// Assert
+	if saveErr != nil || clearErr != nil || renameErr != nil || getErr != nil {
+		t.Fatalf("Expected no error, got: %v, %v, %v, %v", saveErr, clearErr, renameErr, getErr)
+	}
+	if saved.Name != "Ho diffusion" || saved.Query.Include[0].Value != "Ho" {
+		t.Errorf("Expected a trimmed name and the entry's query, got: %+v", saved)
+	}
+	if renamed.Name != "Score models" || got.Name != "Score models" {
+		t.Errorf("Expected the rename to stick, got: %q, %q", renamed.Name, got.Name)
+	}
+	if got.Query.Text != "diffusion" {
+		t.Errorf("Expected the saved search to outlive its history entry, got: %+v", got.Query)
+	}
+	if !IsSavedNotFound(otherErr) {
+		t.Errorf("Expected ErrSavedNotFound for another user, got: %v", otherErr)
+	}
+	if err := svc.DeleteSaved(ctx, 1, saved.ID); err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if list, _ := svc.ListSaved(ctx, 1); len(list) != 0 {
+		t.Errorf("Expected no saved searches, got: %+v", list)
+	}
+}
+
+func TestImpl_ListAllSaved(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService(0)
+	ctx := context.Background()
+	mine, _ := svc.Record(ctx, 1, &Query{Text: "agents"}, 3)
+	theirs, _ := svc.Record(ctx, 2, &Query{Text: "robots"}, 3)
+	svc.Save(ctx, 1, mine.ID, "Agents")
+	svc.Save(ctx, 2, theirs.ID, "Robots")
+
+	// Act
+	all, err := svc.ListAllSaved(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(all) != 2 || all[0].OwnerID != 1 || all[1].OwnerID != 2 || all[1].Query.Text != "robots" {
+		t.Errorf("Expected both users' saved searches oldest first, got: %+v", all)
+	}
+}
+
+func TestImpl_Errors(t *testing.T) {
+	svc, _ := newTestService(0)
+	ctx := context.Background()
+	entry, _ := svc.Record(ctx, 1, &Query{Text: "agents"}, 3)
+	theirs, _ := svc.Record(ctx, 2, &Query{Text: "agents"}, 3)
+	saved, _ := svc.Save(ctx, 1, entry.ID, "Agents")
+	other, _ := svc.Save(ctx, 1, entry.ID, "LLM agents")
+
+	tests := []struct {
+		name    string
+		call    func() error
+		checkFn func(error) bool
+	}{
+		{
+			name: "empty query",
+			call: func() error {
+				_, err := svc.Record(ctx, 1, &Query{Text: "  ", Exclude: []Term{{Field: "title", Value: "survey"}}}, 0)
+				return err
+			},
+			checkFn: IsEmptyQuery,
+		},
+		{
+			name: "delete missing entry",
+			call: func() error {
+				return svc.Delete(ctx, 1, 999)
+			},
+			checkFn: IsEntryNotFound,
+		},
+		{
+			name: "save another user's entry",
+			call: func() error {
+				_, err := svc.Save(ctx, 1, theirs.ID, "Theirs")
+				return err
+			},
+			checkFn: IsEntryNotFound,
+		},
+		{
+			name: "blank name",
+			call: func() error {
+				_, err := svc.Save(ctx, 1, entry.ID, " ")
+				return err
+			},
+			checkFn: IsInvalidName,
+		},
+		{
+			name: "name too long",
+			call: func() error {
+				_, err := svc.Save(ctx, 1, entry.ID, strings.Repeat("n", MaxNameLength+1))
+				return err
+			},
+			checkFn: IsInvalidName,
+		},
+		{
+			name: "duplicate name ignoring case",
+			call: func() error {
+				_, err := svc.Save(ctx, 1, entry.ID, "AGENTS")
+				return err
+			},
+			checkFn: IsNameTaken,
+		},
+		{
+			name: "rename to a taken name",
+			call: func() error {
+				_, err := svc.RenameSaved(ctx, 1, other.ID, saved.Name)
+				return err
+			},
+			checkFn: IsNameTaken,
+		},
+		{
+			name: "rename another user's saved search",
+			call: func() error {
+				_, err := svc.RenameSaved(ctx, 2, saved.ID, "Mine now")
+				return err
+			},
+			checkFn: IsSavedNotFound,
+		},
+		{
+			name: "delete missing saved search",
+			call: func() error {
+				return svc.DeleteSaved(ctx, 1, 999)
+			},
+			checkFn: IsSavedNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.call()
+
+			// Assert
+			if !tt.checkFn(err) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestImpl_TooManySaved(t *testing.T) {
+	// Arrange
+	svc, _ := newTestService(0)
+	ctx := context.Background()
+	entry, _ := svc.Record(ctx, 1, &Query{Text: "agents"}, 3)
+	for i := 0; i < MaxSaved; i++ {
+		if _, err := svc.Save(ctx, 1, entry.ID, fmt.Sprintf("search %d", i)); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	// Act
+	_, err := svc.Save(ctx, 1, entry.ID, "one more")
+
+	// Assert
+	if !IsTooManySaved(err) {
+		t.Errorf("Expected ErrTooManySaved, got: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package trending
+
+import (
+	"context"
+	"time"
+
+	bookmarkRepo "github.com/rrlian/papertok/backend/internal/repository/bookmark"
+	likeRepo "github.com/rrlian/papertok/backend/internal/repository/like"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// paperStore defines the repository capability used to check signalled
+// papers and hydrate trending papers.
+type paperStore interface {
+	// GetByID retrieves a single paper by ID.
+	GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool)
+}
+
+// likeStore defines the repository capability used to seed likes.
+type likeStore interface {
+	// ListSince returns every user's likes made at or after since.
+	ListSince(ctx context.Context, since time.Time) ([]*likeRepo.Like, error)
+}
+
+// bookmarkStore defines the repository capability used to seed bookmarks.
+type bookmarkStore interface {
+	// ListSince returns every user's bookmarks made at or after since.
+	ListSince(ctx context.Context, since time.Time) ([]*bookmarkRepo.Bookmark, error)
+}
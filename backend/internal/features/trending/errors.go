@@ -0,0 +1,23 @@
+package trending
+
+import "errors"
+
+var (
+	// ErrInvalidSignal indicates that a signal has a malformed paper ID or an unknown kind.
+	ErrInvalidSignal = errors.New("invalid engagement signal")
+
+	// ErrPaperNotFound indicates that a signal's paper is not in the paper repository.
+	ErrPaperNotFound = errors.New("paper not found")
+
+	// ErrInvalidWindow indicates that the trending window is unknown.
+	ErrInvalidWindow = errors.New("invalid trending window")
+)
+
+// IsInvalidSignal checks if the error is ErrInvalidSignal.
+func IsInvalidSignal(err error) bool { return errors.Is(err, ErrInvalidSignal) }
+
+// IsPaperNotFound checks if the error is ErrPaperNotFound.
+func IsPaperNotFound(err error) bool { return errors.Is(err, ErrPaperNotFound) }
+
+// IsInvalidWindow checks if the error is ErrInvalidWindow.
+func IsInvalidWindow(err error) bool { return errors.Is(err, ErrInvalidWindow) }
@@ -0,0 +1,98 @@
+package trending
+
+import (
+	"context"
+	"time"
+
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// SignalKind is a kind of engagement with a paper.
+type SignalKind string
+
+// Engagement signals, from weakest to strongest by default.
+const (
+	SignalView     SignalKind = "view"
+	SignalLike     SignalKind = "like"
+	SignalBookmark SignalKind = "bookmark"
+	SignalShare    SignalKind = "share"
+)
+
+// Trending windows.
+const (
+	Window24h = "24h"
+	Window7d  = "7d"
+)
+
+// Limits on the number of trending papers returned.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Signal is an engagement event.
+type Signal struct {
+	PaperID string // Paper ID; any version suffix is ignored
+	Kind    SignalKind
+	Count   int       // Occurrences (1 if zero); negative retracts earlier ones, e.g. an unlike
+	Actor   string    // Optional user or client key; repeated views and shares by one actor are counted once
+	At      time.Time // When it happened (now if zero)
+}
+
+// Request selects a trending list.
+type Request struct {
+	Window   string // Window24h (default) or Window7d
+	Category string // Only papers listed in this category (e.g., "cs.AI"); empty for all
+	Limit    int    // Maximum papers (DefaultLimit if zero, at most MaxLimit)
+}
+
+// Paper is a trending paper: a stored paper and its engagement in the window.
+type Paper struct {
+	*paperRepo.Paper
+	Score   float64            // Decayed, weighted engagement in the window
+	Signals map[SignalKind]int // Undecayed counts per kind in the window
+}
+
+// Config contains the scoring settings.
+type Config struct {
+	Weights map[SignalKind]float64 // Score per signal (DefaultWeights for missing kinds)
+	Dedupe  time.Duration          // Repeated views or shares by one actor within this time count once (default 30m)
+	Now     func() time.Time       // Clock (time.Now if nil)
+}
+
+// Service defines the interface for trending papers.
+type Service interface {
+	// Record adds engagement signals. Signals are kept in hourly buckets for
+	// the longest window, in memory.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - signals: engagement events
+	// @Returns:
+	//   - error: ErrInvalidSignal if a signal has a malformed paper ID or unknown kind,
+	//     ErrPaperNotFound if its paper is not in the paper repository (retractions
+	//     are exempt and only apply to papers already tracked);
+	//     no signal is recorded in either case
+	Record(ctx context.Context, signals ...Signal) error
+
+	// Seed records the likes and bookmarks kept in their repositories that
+	// fall within the longest window, so a restart does not reset their
+	// engagement. Views and shares are not stored anywhere and start afresh.
+	// Call it once, before any signal is recorded.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	// @Returns:
+	//   - int: number of likes and bookmarks recorded; those of papers that are
+	//     no longer stored are skipped
+	//   - error: error if a repository cannot be read
+	Seed(ctx context.Context) (int, error)
+
+	// Trending returns the papers with the most engagement in a window, each
+	// signal decaying with its age, highest score first.
+	// @Params:
+	//   - ctx: context for cancellation and tracing
+	//   - req: window, category and limit
+	// @Returns:
+	//   - []*Paper: trending papers stored in the paper repository
+	//   - error: ErrInvalidWindow if the window is unknown
+	Trending(ctx context.Context, req *Request) ([]*Paper, error)
+}
@@ -0,0 +1,387 @@
+package trending
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/core/arxiv"
+)
+
+// kinds lists the signal kinds in counter order.
+var kinds = [...]SignalKind{SignalView, SignalLike, SignalBookmark, SignalShare}
+
+// DefaultWeights are the scores of one signal of each kind.
+var DefaultWeights = map[SignalKind]float64{
+	SignalView:     1,
+	SignalLike:     3,
+	SignalBookmark: 4,
+	SignalShare:    5,
+}
+
+// window is a trending window. A signal's weight halves every halfLife.
+type window struct {
+	span     time.Duration
+	halfLife time.Duration
+}
+
+var windows = map[string]window{
+	Window24h: {span: 24 * time.Hour, halfLife: 6 * time.Hour},
+	Window7d:  {span: 7 * 24 * time.Hour, halfLife: 36 * time.Hour},
+}
+
+const (
+	// bucketSize is the granularity at which signals are counted.
+	bucketSize = time.Hour
+
+	// retention is how long signals are kept: the longest window.
+	retention = 7 * 24 * time.Hour
+
+	// pruneInterval is how often expired buckets and actor markers are dropped.
+	pruneInterval = time.Hour
+
+	defaultDedupe = 30 * time.Minute
+)
+
+// counts holds one counter per signal kind, in kinds order.
+type counts [len(kinds)]int
+
+// activity is the recorded engagement with one paper.
+type activity struct {
+	buckets    map[int64]*counts // Keyed by bucket start, in bucketSize units since the epoch
+	categories []string          // From the paper repository, as of the latest signal
+}
+
+// candidate is a paper scored for a window.
+type candidate struct {
+	id         string
+	score      float64
+	signals    map[SignalKind]int
+	categories []string
+}
+
+// Impl implements the trending Service interface.
+type Impl struct {
+	papers    paperStore
+	likes     likeStore
+	bookmarks bookmarkStore
+	weights   [len(kinds)]float64
+	dedupe    time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	activity  map[string]*activity // Keyed by base paper ID
+	counted   map[string]time.Time // Last counted view or share, keyed by actor, kind and paper ID
+	lastPrune time.Time
+}
+
+// Ensure Impl implements Service interface
+var _ Service = (*Impl)(nil)
+
+// New creates a new trending service instance.
+func New(papers paperStore, likes likeStore, bookmarks bookmarkStore, cfg Config) *Impl {
+	s := &Impl{
+		papers:    papers,
+		likes:     likes,
+		bookmarks: bookmarks,
+		dedupe:    cfg.Dedupe,
+		now:       cfg.Now,
+		activity:  make(map[string]*activity),
+		counted:   make(map[string]time.Time),
+	}
+	for i, k := range kinds {
+		w, ok := cfg.Weights[k]
+		if !ok {
+			w = DefaultWeights[k]
+		}
+		s.weights[i] = w
+	}
+	if s.dedupe <= 0 {
+		s.dedupe = defaultDedupe
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	return s
+}
+
+// Record adds engagement signals. Signals for papers that are not stored
+// are rejected, so made-up IDs cannot take up memory or push scores.
+// Retractions are not checked against the repository, which may have dropped
+// the paper since it was counted; they only apply to papers already tracked.
+func (s *Impl) Record(ctx context.Context, signals ...Signal) error {
+	ids := make([]string, len(signals))
+	categories := make(map[string][]string, len(signals))
+	for i, sig := range signals {
+		ident, err := arxiv.ParseIdentifier(sig.PaperID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSignal, err)
+		}
+		if kindIndex(sig.Kind) < 0 {
+			return fmt.Errorf("%w: unknown kind %q", ErrInvalidSignal, sig.Kind)
+		}
+		ids[i] = ident.Base()
+		if _, ok := categories[ids[i]]; ok || sig.Count < 0 {
+			continue
+		}
+		p, ok := s.papers.GetByID(ctx, ids[i])
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrPaperNotFound, ids[i])
+		}
+		categories[ids[i]] = p.Categories
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maybePrune(now)
+
+	for i, sig := range signals {
+		at := sig.At
+		if at.IsZero() || at.After(now) {
+			at = now
+		}
+		if now.Sub(at) >= retention {
+			continue
+		}
+		count := sig.Count
+		if count == 0 {
+			count = 1
+		}
+		deduped := sig.Kind == SignalView || sig.Kind == SignalShare
+		if deduped && sig.Actor != "" && !s.countOnce(sig.Actor, sig.Kind, ids[i], at) {
+			continue
+		}
+
+		a, ok := s.activity[ids[i]]
+		if !ok {
+			if count < 0 {
+				continue // Nothing to retract
+			}
+			a = &activity{buckets: make(map[int64]*counts)}
+			s.activity[ids[i]] = a
+		}
+		if cats, ok := categories[ids[i]]; ok {
+			a.categories = cats
+		}
+		b := bucketOf(at)
+		c, ok := a.buckets[b]
+		if !ok {
+			c = &counts{}
+			a.buckets[b] = c
+		}
+		c[kindIndex(sig.Kind)] += count
+	}
+	return nil
+}
+
+// Seed records the stored likes and bookmarks within the retention period.
+// Each paper's signals are recorded together, so a paper that is no longer
+// stored is skipped without dropping the others.
+func (s *Impl) Seed(ctx context.Context) (int, error) {
+	since := s.now().Add(-retention)
+	likes, err := s.likes.ListSince(ctx, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load likes: %w", err)
+	}
+	bookmarks, err := s.bookmarks.ListSince(ctx, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load bookmarks: %w", err)
+	}
+
+	var order []string
+	byPaper := make(map[string][]Signal)
+	add := func(paperID string, kind SignalKind, at time.Time) {
+		if _, ok := byPaper[paperID]; !ok {
+			order = append(order, paperID)
+		}
+		byPaper[paperID] = append(byPaper[paperID], Signal{PaperID: paperID, Kind: kind, At: at})
+	}
+	for _, l := range likes {
+		add(l.PaperID, SignalLike, l.CreatedAt)
+	}
+	for _, b := range bookmarks {
+		add(b.PaperID, SignalBookmark, b.CreatedAt)
+	}
+
+	seeded := 0
+	for _, id := range order {
+		if err := s.Record(ctx, byPaper[id]...); err != nil {
+			continue // Malformed or no longer stored
+		}
+		seeded += len(byPaper[id])
+	}
+	return seeded, nil
+}
+
+// Trending returns the papers with the most engagement in a window.
+func (s *Impl) Trending(ctx context.Context, req *Request) ([]*Paper, error) {
+	name := req.Window
+	if name == "" {
+		name = Window24h
+	}
+	win, ok := windows[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidWindow, req.Window)
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	candidates := s.score(win, s.now())
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].id > candidates[j].id // Newer arXiv IDs first
+	})
+
+	papers := make([]*Paper, 0, limit)
+	for _, c := range candidates {
+		if len(papers) == limit {
+			break
+		}
+		if !matchCategory(c.categories, req.Category) {
+			continue
+		}
+		// Cached papers may have expired since they were signalled.
+		p, ok := s.papers.GetByID(ctx, c.id)
+		if !ok {
+			continue
+		}
+		papers = append(papers, &Paper{Paper: p, Score: c.score, Signals: c.signals})
+	}
+	return papers, nil
+}
+
+// score computes the decayed score of every paper with engagement in a window.
+func (s *Impl) score(win window, now time.Time) []candidate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maybePrune(now)
+
+	from := bucketOf(now.Add(-win.span))
+	candidates := make([]candidate, 0, len(s.activity))
+	for id, a := range s.activity {
+		var decayed [len(kinds)]float64
+		var total counts
+		for b, c := range a.buckets {
+			if b < from {
+				continue
+			}
+			// Age is measured from the middle of the bucket.
+			age := now.Sub(bucketStart(b).Add(bucketSize / 2))
+			if age < 0 {
+				age = 0
+			}
+			decay := math.Exp2(-float64(age) / float64(win.halfLife))
+			for k, n := range c {
+				decayed[k] += float64(n) * decay
+				total[k] += n
+			}
+		}
+
+		// Retractions cannot push a kind below zero.
+		var score float64
+		signals := make(map[SignalKind]int)
+		for k := range kinds {
+			if total[k] > 0 {
+				signals[kinds[k]] = total[k]
+			}
+			if decayed[k] > 0 {
+				score += s.weights[k] * decayed[k]
+			}
+		}
+		if score <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			id:         id,
+			score:      score,
+			signals:    signals,
+			categories: a.categories,
+		})
+	}
+	return candidates
+}
+
+// countOnce reports whether a signal by an actor counts, i.e. the actor has
+// not had a signal of that kind for the paper counted within the dedupe
+// interval. The caller must hold s.mu.
+func (s *Impl) countOnce(actor string, kind SignalKind, id string, at time.Time) bool {
+	key := actor + "\x00" + string(kind) + "\x00" + id
+	if last, ok := s.counted[key]; ok && at.Sub(last) < s.dedupe {
+		return false
+	}
+	s.counted[key] = at
+	return true
+}
+
+// maybePrune drops buckets older than the retention and expired actor
+// markers, at most once per pruneInterval. The caller must hold s.mu.
+func (s *Impl) maybePrune(now time.Time) {
+	if now.Sub(s.lastPrune) < pruneInterval {
+		return
+	}
+	s.lastPrune = now
+
+	oldest := bucketOf(now.Add(-retention))
+	for id, a := range s.activity {
+		for b := range a.buckets {
+			if b < oldest {
+				delete(a.buckets, b)
+			}
+		}
+		if len(a.buckets) == 0 {
+			delete(s.activity, id)
+		}
+	}
+	for key, at := range s.counted {
+		if now.Sub(at) >= s.dedupe {
+			delete(s.counted, key)
+		}
+	}
+}
+
+// kindIndex returns the counter index of a signal kind, or -1 if it is unknown.
+func kindIndex(kind SignalKind) int {
+	for i, k := range kinds {
+		if k == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// bucketOf returns the bucket containing a time.
+func bucketOf(t time.Time) int64 {
+	return t.Unix() / int64(bucketSize/time.Second)
+}
+
+// bucketStart returns the start time of a bucket.
+func bucketStart(b int64) time.Time {
+	return time.Unix(b*int64(bucketSize/time.Second), 0)
+}
+
+// matchCategory reports whether a paper's categories match a filter.
+// An empty filter matches every paper; "cs.*" matches every cs category.
+func matchCategory(categories []string, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	prefix, wildcard := strings.CutSuffix(filter, "*")
+	for _, c := range categories {
+		if c == filter || (wildcard && strings.HasPrefix(c, prefix)) {
+			return true
+		}
+	}
+	return false
+}
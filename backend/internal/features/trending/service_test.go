@@ -0,0 +1,413 @@
+package trending
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bookmarkRepo "github.com/rrlian/papertok/backend/internal/repository/bookmark"
+	likeRepo "github.com/rrlian/papertok/backend/internal/repository/like"
+	paperRepo "github.com/rrlian/papertok/backend/internal/repository/paper"
+)
+
+// mockPapers serves papers from a map and counts lookups.
+type mockPapers struct {
+	papers  map[string]*paperRepo.Paper
+	lookups int
+}
+
+func (m *mockPapers) GetByID(ctx context.Context, id string) (*paperRepo.Paper, bool) {
+	m.lookups++
+	p, ok := m.papers[id]
+	return p, ok
+}
+
+// testClock is a settable clock.
+type testClock struct{ t time.Time }
+
+func (c *testClock) Now() time.Time { return c.t }
+
+func newTestService() (*Impl, *mockPapers, *testClock) {
+	papers := &mockPapers{papers: map[string]*paperRepo.Paper{
+		"2401.00001": {ID: "2401.00001", Title: "First", Categories: []string{"cs.AI"}},
+		"2401.00002": {ID: "2401.00002", Title: "Second", Categories: []string{"cs.LG", "stat.ML"}},
+		"2401.00003": {ID: "2401.00003", Title: "Third", Categories: []string{"math.CO"}},
+	}}
+	clock := &testClock{t: time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC)}
+	svc := New(papers, likeRepo.NewMemoryRepository(), bookmarkRepo.NewMemoryRepository(), Config{Now: clock.Now})
+	return svc, papers, clock
+}
+
+func ids(papers []*Paper) []string {
+	out := make([]string, len(papers))
+	for i, p := range papers {
+		out[i] = p.ID
+	}
+	return out
+}
+
+func TestImpl_Trending(t *testing.T) {
+	// Arrange
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	err := svc.Record(ctx,
+		Signal{PaperID: "2401.00001v2", Kind: SignalView, Count: 3},
+		Signal{PaperID: "2401.00002", Kind: SignalShare},
+		Signal{PaperID: "2401.00002", Kind: SignalLike},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Act
+	papers, err := svc.Trending(ctx, &Request{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := ids(papers); len(got) != 2 || got[0] != "2401.00002" || got[1] != "2401.00001" {
+		t.Fatalf("Expected share and like to outrank three views, got: %v", got)
+	}
+	if papers[0].Signals[SignalShare] != 1 || papers[0].Signals[SignalLike] != 1 {
+		t.Errorf("Expected signal counts, got: %v", papers[0].Signals)
+	}
+	if papers[1].Signals[SignalView] != 3 {
+		t.Errorf("Expected version suffix to be ignored, got: %v", papers[1].Signals)
+	}
+	if papers[0].Title != "Second" {
+		t.Errorf("Expected paper to be hydrated from the repository, got: %+v", papers[0])
+	}
+}
+
+func TestImpl_Trending_Windows(t *testing.T) {
+	// Arrange
+	svc, _, clock := newTestService()
+	ctx := context.Background()
+	now := clock.t
+	svc.Record(ctx,
+		Signal{PaperID: "2401.00001", Kind: SignalLike, Count: 2, At: now.Add(-20 * time.Hour)},
+		Signal{PaperID: "2401.00002", Kind: SignalLike, At: now.Add(-30 * time.Minute)},
+		Signal{PaperID: "2401.00003", Kind: SignalLike, Count: 5, At: now.Add(-3 * 24 * time.Hour)},
+		Signal{PaperID: "2401.00003", Kind: SignalLike, Count: 100, At: now.Add(-8 * 24 * time.Hour)},
+	)
+
+	tests := []struct {
+		name   string
+		window string
+		want   []string
+	}{
+		{name: "24h favours recent signals", window: Window24h, want: []string{"2401.00002", "2401.00001"}},
+		{name: "7d decays more slowly", window: Window7d, want: []string{"2401.00001", "2401.00003", "2401.00002"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			papers, err := svc.Trending(ctx, &Request{Window: tt.window})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			got := ids(papers)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got: %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got: %v", tt.want, got)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestImpl_Trending_Category(t *testing.T) {
+	// Arrange
+	svc, papers, _ := newTestService()
+	ctx := context.Background()
+	svc.Record(ctx,
+		Signal{PaperID: "2401.00001", Kind: SignalShare},
+		Signal{PaperID: "2401.00002", Kind: SignalLike},
+		Signal{PaperID: "2401.00003", Kind: SignalView},
+	)
+
+	tests := []struct {
+		name     string
+		category string
+		want     []string
+	}{
+		{name: "exact category", category: "cs.LG", want: []string{"2401.00002"}},
+		{name: "cross-listed category", category: "stat.ML", want: []string{"2401.00002"}},
+		{name: "archive wildcard", category: "cs.*", want: []string{"2401.00001", "2401.00002"}},
+		{name: "no matches", category: "q-bio.GN", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := svc.Trending(ctx, &Request{Category: tt.category})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			got := ids(result)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got: %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got: %v", tt.want, got)
+					break
+				}
+			}
+		})
+	}
+
+	// Categories are remembered, so non-matching papers are not looked up again.
+	papers.lookups = 0
+	svc.Trending(ctx, &Request{Category: "math.CO"})
+	if papers.lookups != 1 {
+		t.Errorf("Expected 1 lookup, got: %d", papers.lookups)
+	}
+}
+
+func TestImpl_Trending_SkipsExpiredPapers(t *testing.T) {
+	// Arrange
+	svc, papers, _ := newTestService()
+	ctx := context.Background()
+	svc.Record(ctx,
+		Signal{PaperID: "2401.00002", Kind: SignalShare, Count: 10},
+		Signal{PaperID: "2401.00001", Kind: SignalView},
+	)
+	delete(papers.papers, "2401.00002")
+
+	// Act
+	result, err := svc.Trending(ctx, &Request{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := ids(result); len(got) != 1 || got[0] != "2401.00001" {
+		t.Errorf("Expected only the stored paper, got: %v", got)
+	}
+}
+
+func TestImpl_Record_UnstoredPaper(t *testing.T) {
+	// Arrange
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+
+	// Act
+	err := svc.Record(ctx,
+		Signal{PaperID: "2401.00001", Kind: SignalView},
+		Signal{PaperID: "2401.09999", Kind: SignalShare, Count: 10},
+	)
+
+	// Assert
+	if !IsPaperNotFound(err) {
+		t.Fatalf("Expected ErrPaperNotFound, got: %v", err)
+	}
+	if len(svc.activity) != 0 {
+		t.Errorf("Expected no signal recorded, got: %d papers", len(svc.activity))
+	}
+}
+
+func TestImpl_Seed(t *testing.T) {
+	// Arrange
+	_, papers, clock := newTestService()
+	ctx := context.Background()
+	likes := likeRepo.NewMemoryRepository()
+	bookmarks := bookmarkRepo.NewMemoryRepository()
+	likes.Add(ctx, &likeRepo.Like{UserID: 1, PaperID: "2401.00001", CreatedAt: clock.t.Add(-2 * time.Hour)})
+	likes.Add(ctx, &likeRepo.Like{UserID: 1, PaperID: "2401.00003", CreatedAt: clock.t.Add(-8 * 24 * time.Hour)})
+	likes.Add(ctx, &likeRepo.Like{UserID: 2, PaperID: "2401.09999", CreatedAt: clock.t.Add(-time.Hour)})
+	bookmarks.Add(ctx, &bookmarkRepo.Bookmark{UserID: 2, PaperID: "2401.00002", CreatedAt: clock.t.Add(-30 * time.Hour)})
+	svc := New(papers, likes, bookmarks, Config{Now: clock.Now})
+
+	// Act
+	seeded, err := svc.Seed(ctx)
+	day, _ := svc.Trending(ctx, &Request{Window: Window24h})
+	week, _ := svc.Trending(ctx, &Request{Window: Window7d})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if seeded != 2 {
+		t.Errorf("Expected the old like and the unstored paper to be skipped, got: %d seeded", seeded)
+	}
+	if got := ids(day); len(got) != 1 || got[0] != "2401.00001" {
+		t.Errorf("Expected only the recent like in 24h, got: %v", got)
+	}
+	if got := ids(week); len(got) != 2 || week[1].Signals[SignalBookmark] != 1 {
+		t.Errorf("Expected the like and the bookmark in 7d, got: %v", got)
+	}
+}
+
+func TestImpl_Trending_Limit(t *testing.T) {
+	// Arrange
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	svc.Record(ctx,
+		Signal{PaperID: "2401.00001", Kind: SignalView},
+		Signal{PaperID: "2401.00002", Kind: SignalView, Count: 2},
+		Signal{PaperID: "2401.00003", Kind: SignalView, Count: 3},
+	)
+
+	// Act
+	papers, err := svc.Trending(ctx, &Request{Limit: 2})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := ids(papers); len(got) != 2 || got[0] != "2401.00003" || got[1] != "2401.00002" {
+		t.Errorf("Expected top 2 papers, got: %v", got)
+	}
+}
+
+func TestImpl_Record_Retraction(t *testing.T) {
+	// Arrange
+	svc, _, clock := newTestService()
+	ctx := context.Background()
+	svc.Record(ctx, Signal{PaperID: "2401.00001", Kind: SignalLike})
+	clock.t = clock.t.Add(2 * time.Hour)
+
+	// Act
+	err := svc.Record(ctx, Signal{PaperID: "2401.00001", Kind: SignalLike, Count: -1})
+	papers, _ := svc.Trending(ctx, &Request{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(papers) != 0 {
+		t.Errorf("Expected retracted like to leave no trending papers, got: %v", ids(papers))
+	}
+}
+
+func TestImpl_Record_RetractionOfUnstoredPaper(t *testing.T) {
+	// Arrange
+	svc, papers, _ := newTestService()
+	ctx := context.Background()
+	svc.Record(ctx, Signal{PaperID: "2401.00001", Kind: SignalLike})
+	stored := papers.papers["2401.00001"]
+	delete(papers.papers, "2401.00001")
+
+	// Act
+	err := svc.Record(ctx,
+		Signal{PaperID: "2401.00001", Kind: SignalLike, Count: -1},
+		Signal{PaperID: "2401.09999", Kind: SignalBookmark, Count: -1},
+	)
+	papers.papers["2401.00001"] = stored
+	result, _ := svc.Trending(ctx, &Request{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result) != 0 {
+		t.Errorf("Expected the like to be retracted, got: %v", ids(result))
+	}
+	if _, ok := svc.activity["2401.09999"]; ok {
+		t.Errorf("Expected a retraction for an untracked paper to be ignored")
+	}
+}
+
+func TestImpl_Record_Dedupe(t *testing.T) {
+	for _, kind := range []SignalKind{SignalView, SignalShare} {
+		t.Run(string(kind), func(t *testing.T) {
+			// Arrange
+			svc, _, clock := newTestService()
+			ctx := context.Background()
+
+			// Act
+			svc.Record(ctx, Signal{PaperID: "2401.00001", Kind: kind, Actor: "user-1"})
+			svc.Record(ctx, Signal{PaperID: "2401.00001v2", Kind: kind, Actor: "user-1"})
+			svc.Record(ctx, Signal{PaperID: "2401.00001", Kind: kind, Actor: "user-2"})
+			clock.t = clock.t.Add(time.Hour)
+			svc.Record(ctx, Signal{PaperID: "2401.00001", Kind: kind, Actor: "user-1"})
+			papers, _ := svc.Trending(ctx, &Request{})
+
+			// Assert
+			if len(papers) != 1 {
+				t.Fatalf("Expected 1 paper, got: %d", len(papers))
+			}
+			if got := papers[0].Signals[kind]; got != 3 {
+				t.Errorf("Expected a repeat within the dedupe interval to count once, got: %d", got)
+			}
+		})
+	}
+}
+
+func TestImpl_Record_Pruning(t *testing.T) {
+	// Arrange
+	svc, _, clock := newTestService()
+	ctx := context.Background()
+	svc.Record(ctx, Signal{PaperID: "2401.00001", Kind: SignalView})
+
+	// Act
+	clock.t = clock.t.Add(8 * 24 * time.Hour)
+	svc.Trending(ctx, &Request{Window: Window7d})
+
+	// Assert
+	if len(svc.activity) != 0 {
+		t.Errorf("Expected expired activity to be pruned, got: %d papers", len(svc.activity))
+	}
+}
+
+func TestImpl_Errors(t *testing.T) {
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		run     func() error
+		checkFn func(error) bool
+	}{
+		{
+			name:    "malformed paper ID",
+			run:     func() error { return svc.Record(ctx, Signal{PaperID: "not an id", Kind: SignalView}) },
+			checkFn: IsInvalidSignal,
+		},
+		{
+			name:    "unknown kind",
+			run:     func() error { return svc.Record(ctx, Signal{PaperID: "2401.00001", Kind: "click"}) },
+			checkFn: IsInvalidSignal,
+		},
+		{
+			name: "unknown window",
+			run: func() error {
+				_, err := svc.Trending(ctx, &Request{Window: "1y"})
+				return err
+			},
+			checkFn: IsInvalidWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.run()
+
+			// Assert
+			if !tt.checkFn(err) {
+				t.Errorf("Expected matching error, got: %v", err)
+			}
+		})
+	}
+
+	// A rejected batch records nothing.
+	svc.Record(ctx,
+		Signal{PaperID: "2401.00001", Kind: SignalView},
+		Signal{PaperID: "2401.00002", Kind: "click"},
+	)
+	if papers, _ := svc.Trending(ctx, &Request{}); len(papers) != 0 {
+		t.Errorf("Expected no signals recorded, got: %v", ids(papers))
+	}
+}
@@ -21,6 +21,9 @@ type DB interface {
 	// BeginTx starts a transaction with the given options.
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 
+	// Conn returns a single dedicated connection, for session state such as locks.
+	Conn(ctx context.Context) (*sql.Conn, error)
+
 	// Close closes the database connection.
 	Close() error
 
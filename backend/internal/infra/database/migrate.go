@@ -0,0 +1,431 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Migrations returns the migration files compiled into the binary.
+func Migrations() fs.FS {
+	sub, _ := fs.Sub(embeddedMigrations, "migrations")
+	return sub
+}
+
+// Section markers inside a migration file. A file without markers is all "up".
+const (
+	upMarker   = "-- +migrate Up"
+	downMarker = "-- +migrate Down"
+)
+
+// migrationsTable records applied migrations.
+const migrationsTable = "schema_migrations"
+
+// lockName is the MySQL advisory lock held while migrating, so that
+// several instances starting together do not migrate concurrently.
+const lockName = "papertok_schema_migrations"
+
+// defaultLockTimeout is how long to wait for another instance to finish.
+const defaultLockTimeout = 60 * time.Second
+
+// migrationFilePattern matches file names such as "003_papers.sql".
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// nameSeparatorPattern matches runs of characters not allowed in migration names.
+var nameSeparatorPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+var (
+	// ErrChecksumMismatch indicates that an applied migration file was changed afterwards.
+	ErrChecksumMismatch = errors.New("applied migration has been modified")
+
+	// ErrIrreversible indicates that a migration has no down section.
+	ErrIrreversible = errors.New("migration cannot be reverted")
+
+	// ErrLockTimeout indicates that another process held the migration lock too long.
+	ErrLockTimeout = errors.New("timed out waiting for migration lock")
+)
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // SHA-256 of the file contents
+}
+
+// MigrationStatus describes a migration together with its applied state.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	Modified  bool // Applied, but the file no longer matches the recorded checksum
+}
+
+// Migrator applies and reverts migrations.
+type Migrator struct {
+	db          DB
+	migrations  []*Migration
+	lockTimeout time.Duration
+}
+
+// NewMigrator creates a migrator for the migration files in source
+// (usually Migrations()).
+func NewMigrator(db DB, source fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:          db,
+		migrations:  migrations,
+		lockTimeout: defaultLockTimeout,
+	}, nil
+}
+
+// Up applies all pending migrations in version order and returns them.
+// It refuses to run if an applied migration has been modified.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var applied []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		records, err := m.appliedRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(records); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, done := records[migration.Version]; done {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the n most recently applied migrations and returns them.
+func (m *Migrator) Down(ctx context.Context, n int) ([]*Migration, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("number of migrations to revert must be positive, got %d", n)
+	}
+
+	var reverted []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		records, err := m.appliedRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < n; i-- {
+			migration := m.migrations[i]
+			if _, done := records[migration.Version]; !done {
+				continue
+			}
+			if err := m.revert(ctx, conn, migration); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every migration with its applied state, oldest first.
+// It only reads: without a migrations table, nothing has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	exists, err := m.tableExists(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	records := make(map[int]appliedRecord)
+	if exists {
+		if records, err = m.appliedRecords(ctx, m.db); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{Migration: *migration}
+		if record, done := records[migration.Version]; done {
+			status.Applied = true
+			status.AppliedAt = record.appliedAt
+			status.Modified = record.checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// CreateMigration writes an empty migration file with the next version to dir
+// and returns its path.
+func CreateMigration(dir, name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = nameSeparatorPattern.ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return "", errors.New("migration name is required")
+	}
+
+	existing, err := loadMigrations(os.DirFS(dir))
+	if err != nil {
+		return "", err
+	}
+	version := 1
+	if len(existing) > 0 {
+		version = existing[len(existing)-1].Version + 1
+	}
+
+	base := fmt.Sprintf("%03d_%s", version, name)
+	content := fmt.Sprintf("-- Migration: %s\n-- Description: TODO\n\n%s\n\n%s\n", base, upMarker, downMarker)
+	path := filepath.Join(dir, base+".sql")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write migration: %w", err)
+	}
+	return path, nil
+}
+
+// apply runs a migration's up statements and records it.
+// MySQL commits DDL implicitly, so a failing migration may be partly applied;
+// statements should be written to be re-runnable (IF NOT EXISTS).
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	for _, stmt := range splitStatements(migration.Up) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to apply migration %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	query := `INSERT INTO ` + migrationsTable + ` (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`
+	if _, err := conn.ExecContext(ctx, query, migration.Version, migration.Name, migration.Checksum, time.Now()); err != nil {
+		return fmt.Errorf("failed to record migration %03d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// revert runs a migration's down statements and removes its record.
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	statements := splitStatements(migration.Down)
+	if len(statements) == 0 {
+		return fmt.Errorf("%w: %03d_%s has no down section", ErrIrreversible, migration.Version, migration.Name)
+	}
+
+	for _, stmt := range statements {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to revert migration %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	query := `DELETE FROM ` + migrationsTable + ` WHERE version = ?`
+	if _, err := conn.ExecContext(ctx, query, migration.Version); err != nil {
+		return fmt.Errorf("failed to unrecord migration %03d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// verify fails if an applied migration's file has changed.
+func (m *Migrator) verify(records map[int]appliedRecord) error {
+	for _, migration := range m.migrations {
+		record, done := records[migration.Version]
+		if done && record.checksum != migration.Checksum {
+			return fmt.Errorf("%w: %03d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// withLock runs fn on a single connection holding the migration lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	timeout := int(m.lockTimeout / time.Second)
+	if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, lockName, timeout).Scan(&acquired); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if acquired.Int64 != 1 {
+		return ErrLockTimeout
+	}
+	defer conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, lockName)
+
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// ensureTable creates the migrations table if needed.
+func (m *Migrator) ensureTable(ctx context.Context, db Executor) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS ` + migrationsTable + ` (
+			version INT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum CHAR(64) NOT NULL,
+			applied_at DATETIME NOT NULL
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+	`
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return nil
+}
+
+// tableExists reports whether the migrations table has been created.
+func (m *Migrator) tableExists(ctx context.Context, db Executor) (bool, error) {
+	query := `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?`
+	var n int
+	if err := db.QueryRowContext(ctx, query, migrationsTable).Scan(&n); err != nil {
+		return false, fmt.Errorf("failed to look up migrations table: %w", err)
+	}
+	return n > 0, nil
+}
+
+// appliedRecord is a row of the migrations table.
+type appliedRecord struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// appliedRecords loads the migrations table keyed by version.
+func (m *Migrator) appliedRecords(ctx context.Context, db Executor) (map[int]appliedRecord, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version, checksum, applied_at FROM `+migrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	records := make(map[int]appliedRecord)
+	for rows.Next() {
+		var version int
+		var record appliedRecord
+		if err := rows.Scan(&version, &record.checksum, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		records[version] = record
+	}
+	return records, rows.Err()
+}
+
+// loadMigrations reads and parses every migration file in source, sorted by version.
+func loadMigrations(source fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []*Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		if other, dup := seen[version]; dup {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, other, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, parseMigration(version, match[2], string(content)))
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// parseMigration splits a migration file into its up and down sections.
+func parseMigration(version int, name, content string) *Migration {
+	sum := sha256.Sum256([]byte(content))
+	migration := &Migration{
+		Version:  version,
+		Name:     name,
+		Checksum: hex.EncodeToString(sum[:]),
+	}
+
+	up, down := content, ""
+	if i := strings.Index(content, downMarker); i >= 0 {
+		up, down = content[:i], content[i+len(downMarker):]
+	}
+	if i := strings.Index(up, upMarker); i >= 0 {
+		up = up[i+len(upMarker):]
+	}
+	migration.Up = strings.TrimSpace(up)
+	migration.Down = strings.TrimSpace(down)
+	return migration
+}
+
+// splitStatements splits SQL into statements on semicolons outside quotes,
+// dropping "--" comment lines. A backslash escapes the next character inside
+// string literals, as in MySQL; doubled quotes need no special handling.
+// The MySQL driver runs one statement per call.
+func splitStatements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	script = strings.Join(lines, "\n")
+
+	var statements []string
+	var current strings.Builder
+	var quote rune
+	var escaped bool
+	for _, r := range script {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if r == '\\' && quote != '`' {
+				escaped = true
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == ';':
+			if stmt := strings.TrimSpace(current.String()); stmt != "" {
+				statements = append(statements, stmt)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if stmt := strings.TrimSpace(current.String()); stmt != "" {
+		statements = append(statements, stmt)
+	}
+	return statements
+}
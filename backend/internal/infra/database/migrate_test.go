@@ -0,0 +1,258 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestParseMigration(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantUp   string
+		wantDown string
+	}{
+		{
+			name:     "up and down sections",
+			content:  "-- Migration: 001_init\n-- +migrate Up\nCREATE TABLE a (id INT);\n\n-- +migrate Down\nDROP TABLE a;\n",
+			wantUp:   "CREATE TABLE a (id INT);",
+			wantDown: "DROP TABLE a;",
+		},
+		{
+			name:     "no markers is all up",
+			content:  "CREATE TABLE a (id INT);\n",
+			wantUp:   "CREATE TABLE a (id INT);",
+			wantDown: "",
+		},
+		{
+			name:     "up marker only",
+			content:  "-- header\n-- +migrate Up\nCREATE TABLE a (id INT);\n",
+			wantUp:   "CREATE TABLE a (id INT);",
+			wantDown: "",
+		},
+		{
+			name:     "empty down section",
+			content:  "-- +migrate Up\nCREATE TABLE a (id INT);\n-- +migrate Down\n",
+			wantUp:   "CREATE TABLE a (id INT);",
+			wantDown: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := parseMigration(1, "init", tt.content)
+
+			if m.Up != tt.wantUp {
+				t.Errorf("Expected up %q, got: %q", tt.wantUp, m.Up)
+			}
+			if m.Down != tt.wantDown {
+				t.Errorf("Expected down %q, got: %q", tt.wantDown, m.Down)
+			}
+		})
+	}
+}
+
+func TestParseMigration_Checksum(t *testing.T) {
+	a := parseMigration(1, "init", "CREATE TABLE a (id INT);")
+	b := parseMigration(1, "init", "CREATE TABLE a (id INT);")
+	c := parseMigration(1, "init", "CREATE TABLE a (id BIGINT);")
+
+	if len(a.Checksum) != 64 {
+		t.Errorf("Expected a hex SHA-256 checksum, got: %q", a.Checksum)
+	}
+	if a.Checksum != b.Checksum {
+		t.Errorf("Expected equal files to have equal checksums")
+	}
+	if a.Checksum == c.Checksum {
+		t.Errorf("Expected changed files to have different checksums")
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "two statements",
+			script: "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);",
+			want:   []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name:   "no trailing semicolon",
+			script: "DROP TABLE a",
+			want:   []string{"DROP TABLE a"},
+		},
+		{
+			name:   "empty statements are dropped",
+			script: ";\n  ;\nDROP TABLE a;;",
+			want:   []string{"DROP TABLE a"},
+		},
+		{
+			name:   "semicolon in single quotes",
+			script: "INSERT INTO a VALUES ('x;y'); DROP TABLE b;",
+			want:   []string{"INSERT INTO a VALUES ('x;y')", "DROP TABLE b"},
+		},
+		{
+			name:   "semicolon in double quotes and backticks",
+			script: "INSERT INTO `a;b` VALUES (\"x;y\"); DROP TABLE c;",
+			want:   []string{"INSERT INTO `a;b` VALUES (\"x;y\")", "DROP TABLE c"},
+		},
+		{
+			name:   "backslash-escaped quote",
+			script: `INSERT INTO a VALUES ('it\'s;'); DROP TABLE b;`,
+			want:   []string{`INSERT INTO a VALUES ('it\'s;')`, "DROP TABLE b"},
+		},
+		{
+			name:   "escaped backslash ends before the quote",
+			script: `INSERT INTO a VALUES ('x\\'); DROP TABLE b;`,
+			want:   []string{`INSERT INTO a VALUES ('x\\')`, "DROP TABLE b"},
+		},
+		{
+			name:   "doubled quote",
+			script: "INSERT INTO a VALUES ('it''s;'); DROP TABLE b;",
+			want:   []string{"INSERT INTO a VALUES ('it''s;')", "DROP TABLE b"},
+		},
+		{
+			name:   "backslash in backticks does not escape",
+			script: "CREATE TABLE `a\\`; DROP TABLE b;",
+			want:   []string{"CREATE TABLE `a\\`", "DROP TABLE b"},
+		},
+		{
+			name:   "comment lines are dropped",
+			script: "-- create a; then b\nCREATE TABLE a (id INT);\n  -- indented; comment\nCREATE TABLE b (id INT);",
+			want:   []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name:   "only comments",
+			script: "-- nothing to do;\n",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitStatements(tt.script)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	// Arrange
+	source := fstest.MapFS{
+		"002_second.sql": {Data: []byte("CREATE TABLE b (id INT);")},
+		"001_first.sql":  {Data: []byte("CREATE TABLE a (id INT);")},
+		"README.md":      {Data: []byte("not a migration")},
+	}
+
+	// Act
+	migrations, err := loadMigrations(source)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Name != "first" || migrations[1].Version != 2 {
+		t.Errorf("Expected 001_first then 002_second, got: %+v", migrations)
+	}
+}
+
+func TestLoadMigrations_DuplicateVersion(t *testing.T) {
+	source := fstest.MapFS{
+		"001_first.sql": {Data: []byte("CREATE TABLE a (id INT);")},
+		"001_other.sql": {Data: []byte("CREATE TABLE b (id INT);")},
+	}
+
+	if _, err := loadMigrations(source); err == nil {
+		t.Error("Expected an error for duplicate versions")
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := loadMigrations(Migrations())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	for _, m := range migrations {
+		if len(splitStatements(m.Up)) == 0 {
+			t.Errorf("Expected %03d_%s to have up statements", m.Version, m.Name)
+		}
+	}
+}
+
+func TestMigrator_Verify(t *testing.T) {
+	first := parseMigration(1, "first", "CREATE TABLE a (id INT);")
+	second := parseMigration(2, "second", "CREATE TABLE b (id INT);")
+	m := &Migrator{migrations: []*Migration{first, second}}
+
+	tests := []struct {
+		name    string
+		records map[int]appliedRecord
+		wantErr error
+	}{
+		{
+			name:    "nothing applied",
+			records: map[int]appliedRecord{},
+		},
+		{
+			name:    "applied unchanged",
+			records: map[int]appliedRecord{1: {checksum: first.Checksum}},
+		},
+		{
+			name: "applied then modified",
+			records: map[int]appliedRecord{
+				1: {checksum: first.Checksum},
+				2: {checksum: "0000"},
+			},
+			wantErr: ErrChecksumMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.verify(tt.records)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMigrator_Status_NoTable(t *testing.T) {
+	// Arrange
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+	mock.ExpectQuery(`FROM information_schema.tables`).
+		WithArgs(migrationsTable).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	m := &Migrator{db: db, migrations: []*Migration{parseMigration(1, "first", "CREATE TABLE a (id INT);")}}
+
+	// Act
+	statuses, err := m.Status(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Applied {
+		t.Errorf("Expected one pending migration, got: %+v", statuses)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Expected only the table lookup, got: %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 -- Migration: 001_init_users
 -- Description: Create users table for authentication
 
+-- +migrate Up
+
 -- Create users table
 CREATE TABLE IF NOT EXISTS users (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
@@ -12,3 +14,7 @@ CREATE TABLE IF NOT EXISTS users (
     INDEX idx_email (email),
     INDEX idx_username (username)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS users;
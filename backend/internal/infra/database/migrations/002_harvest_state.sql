@@ -0,0 +1,20 @@
+-- Migration: 002_harvest_state
+-- Description: Create harvest_state table for incremental OAI-PMH harvesting
+
+-- +migrate Up
+
+-- Create harvest_state table
+CREATE TABLE IF NOT EXISTS harvest_state (
+    set_spec VARCHAR(64) NOT NULL DEFAULT '',
+    metadata_prefix VARCHAR(32) NOT NULL,
+    watermark DATETIME NULL,
+    resumption_token VARCHAR(512) NOT NULL DEFAULT '',
+    run_started_at DATETIME NULL,
+    records_harvested BIGINT NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (set_spec, metadata_prefix)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS harvest_state;
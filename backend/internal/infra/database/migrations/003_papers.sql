@@ -0,0 +1,73 @@
+-- Migration: 003_papers
+-- Description: Create papers with normalized versions, authors and categories
+
+-- +migrate Up
+
+-- Create papers table
+CREATE TABLE IF NOT EXISTS papers (
+    id VARCHAR(64) PRIMARY KEY,
+    version INT NOT NULL DEFAULT 0,
+    title TEXT NOT NULL,
+    summary TEXT NOT NULL,
+    published DATETIME NULL,
+    updated DATETIME NULL,
+    primary_category VARCHAR(32) NOT NULL DEFAULT '',
+    arxiv_url VARCHAR(255) NOT NULL DEFAULT '',
+    pdf_url VARCHAR(255) NOT NULL DEFAULT '',
+    image_url VARCHAR(255) NOT NULL DEFAULT '',
+    doi VARCHAR(255) NOT NULL DEFAULT '',
+    journal_ref VARCHAR(512) NOT NULL DEFAULT '',
+    comment TEXT NOT NULL,
+    license VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_updated (updated, id),
+    INDEX idx_published (published)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create paper_versions table
+CREATE TABLE IF NOT EXISTS paper_versions (
+    paper_id VARCHAR(64) NOT NULL,
+    version INT NOT NULL,
+    submitted DATETIME NULL,
+    PRIMARY KEY (paper_id, version),
+    CONSTRAINT fk_paper_versions_paper FOREIGN KEY (paper_id) REFERENCES papers (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create authors table
+CREATE TABLE IF NOT EXISTS authors (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create paper_authors table
+CREATE TABLE IF NOT EXISTS paper_authors (
+    paper_id VARCHAR(64) NOT NULL,
+    position INT NOT NULL,
+    author_id BIGINT NOT NULL,
+    affiliations TEXT NULL,
+    PRIMARY KEY (paper_id, position),
+    INDEX idx_author (author_id, paper_id),
+    CONSTRAINT fk_paper_authors_paper FOREIGN KEY (paper_id) REFERENCES papers (id) ON DELETE CASCADE,
+    CONSTRAINT fk_paper_authors_author FOREIGN KEY (author_id) REFERENCES authors (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create paper_categories table
+CREATE TABLE IF NOT EXISTS paper_categories (
+    paper_id VARCHAR(64) NOT NULL,
+    category VARCHAR(32) NOT NULL,
+    position INT NOT NULL,
+    PRIMARY KEY (paper_id, category),
+    INDEX idx_category (category, paper_id),
+    CONSTRAINT fk_paper_categories_paper FOREIGN KEY (paper_id) REFERENCES papers (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS paper_categories;
+DROP TABLE IF EXISTS paper_authors;
+DROP TABLE IF EXISTS authors;
+DROP TABLE IF EXISTS paper_versions;
+DROP TABLE IF EXISTS papers;
@@ -0,0 +1,19 @@
+-- Migration: 004_bookmarks
+-- Description: Create bookmarks table for per-user saved papers
+
+-- +migrate Up
+
+-- Create bookmarks table
+CREATE TABLE IF NOT EXISTS bookmarks (
+    user_id BIGINT NOT NULL,
+    paper_id VARCHAR(64) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, paper_id),
+    INDEX idx_user_created (user_id, created_at, paper_id),
+    INDEX idx_created (created_at),
+    CONSTRAINT fk_bookmarks_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS bookmarks;
@@ -0,0 +1,29 @@
+-- Migration: 005_likes
+-- Description: Create likes and per-paper like counts
+
+-- +migrate Up
+
+-- Create likes table
+CREATE TABLE IF NOT EXISTS likes (
+    user_id BIGINT NOT NULL,
+    paper_id VARCHAR(64) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, paper_id),
+    INDEX idx_user_created (user_id, created_at, paper_id),
+    INDEX idx_created (created_at),
+    CONSTRAINT fk_likes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create paper_like_counts table, maintained with likes so feed pages
+-- read their counts with one indexed lookup. Likes removed by deleting their
+-- user are not seen by any trigger; user.SQLRepository.Delete decrements
+-- the counts before deleting the user
+CREATE TABLE IF NOT EXISTS paper_like_counts (
+    paper_id VARCHAR(64) PRIMARY KEY,
+    like_count INT NOT NULL DEFAULT 0
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS paper_like_counts;
+DROP TABLE IF EXISTS likes;
@@ -0,0 +1,23 @@
+-- Migration: 006_reading_history
+-- Description: Create reading_history table for per-user paper views
+
+-- +migrate Up
+
+-- Create reading_history table
+CREATE TABLE IF NOT EXISTS reading_history (
+    user_id BIGINT NOT NULL,
+    paper_id VARCHAR(64) NOT NULL,
+    first_viewed_at DATETIME NOT NULL,
+    last_viewed_at DATETIME NOT NULL,
+    views INT NOT NULL DEFAULT 1,
+    dwell_seconds INT NOT NULL DEFAULT 0,
+    scroll_depth DOUBLE NOT NULL DEFAULT 0,
+    pdf_page INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, paper_id),
+    INDEX idx_user_last_viewed (user_id, last_viewed_at, paper_id),
+    CONSTRAINT fk_reading_history_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS reading_history;
@@ -0,0 +1,37 @@
+-- Migration: 007_collections
+-- Description: Create collections and collection_items tables for user paper collections
+
+-- +migrate Up
+
+-- Create collections table
+CREATE TABLE IF NOT EXISTS collections (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL,
+    visibility VARCHAR(16) NOT NULL DEFAULT 'private',
+    slug VARCHAR(32) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    UNIQUE KEY uk_slug (slug),
+    INDEX idx_user_updated (user_id, updated_at),
+    INDEX idx_visibility_updated (visibility, updated_at),
+    CONSTRAINT fk_collections_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create collection_items table
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_id BIGINT NOT NULL,
+    paper_id VARCHAR(64) NOT NULL,
+    position INT NOT NULL,
+    note TEXT NOT NULL,
+    added_at DATETIME NOT NULL,
+    PRIMARY KEY (collection_id, paper_id),
+    INDEX idx_collection_position (collection_id, position),
+    CONSTRAINT fk_collection_items_collection FOREIGN KEY (collection_id) REFERENCES collections (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS collection_items;
+DROP TABLE IF EXISTS collections;
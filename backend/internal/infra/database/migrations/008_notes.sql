@@ -0,0 +1,27 @@
+-- Migration: 008_notes
+-- Description: Create notes table for per-user paper notes and highlights
+
+-- +migrate Up
+
+-- Create notes table
+CREATE TABLE IF NOT EXISTS notes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    paper_id VARCHAR(64) NOT NULL,
+    body TEXT NOT NULL,
+    anchor_type VARCHAR(16) NOT NULL DEFAULT '',
+    text_start INT NOT NULL DEFAULT 0,
+    text_end INT NOT NULL DEFAULT 0,
+    quote TEXT NOT NULL,
+    pdf_page INT NOT NULL DEFAULT 0,
+    rects TEXT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    INDEX idx_user_paper (user_id, paper_id, created_at),
+    INDEX idx_user_updated (user_id, updated_at),
+    CONSTRAINT fk_notes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS notes;
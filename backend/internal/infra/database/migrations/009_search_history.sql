@@ -0,0 +1,36 @@
+-- Migration: 009_search_history
+-- Description: Create search_history and saved_searches tables for per-user searches
+
+-- +migrate Up
+
+-- Create search_history table
+CREATE TABLE IF NOT EXISTS search_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    query_key CHAR(64) NOT NULL,
+    params TEXT NOT NULL,
+    result_count INT NOT NULL DEFAULT 0,
+    search_count INT NOT NULL DEFAULT 1,
+    searched_at DATETIME NOT NULL,
+    UNIQUE KEY uk_user_query (user_id, query_key),
+    INDEX idx_user_searched (user_id, searched_at),
+    CONSTRAINT fk_search_history_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create saved_searches table
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    params TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    UNIQUE KEY uk_user_name (user_id, name),
+    INDEX idx_user_created (user_id, created_at),
+    CONSTRAINT fk_saved_searches_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS saved_searches;
+DROP TABLE IF EXISTS search_history;
@@ -0,0 +1,33 @@
+-- Migration: 010_alerts
+-- Description: Create alerts and alert_marks tables for saved search alerts
+
+-- +migrate Up
+
+-- Create alert_marks table (one high-water mark per saved search)
+CREATE TABLE IF NOT EXISTS alert_marks (
+    saved_search_id BIGINT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    high_water DATETIME NOT NULL,
+    checked_at DATETIME NOT NULL,
+    CONSTRAINT fk_alert_marks_search FOREIGN KEY (saved_search_id) REFERENCES saved_searches (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Create alerts table
+CREATE TABLE IF NOT EXISTS alerts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    saved_search_id BIGINT NOT NULL,
+    paper_id VARCHAR(64) NOT NULL,
+    published DATETIME NOT NULL,
+    created_at DATETIME NOT NULL,
+    read_at DATETIME NULL,
+    UNIQUE KEY uk_search_paper (saved_search_id, paper_id),
+    INDEX idx_user_created (user_id, created_at),
+    CONSTRAINT fk_alerts_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_alerts_search FOREIGN KEY (saved_search_id) REFERENCES saved_searches (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS alert_marks;
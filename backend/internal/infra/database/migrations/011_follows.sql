@@ -0,0 +1,21 @@
+-- Migration: 011_follows
+-- Description: Create followed authors and categories
+
+-- +migrate Up
+
+-- Create follows table; follow_key is the normalized author name or
+-- category, name the form the user entered
+CREATE TABLE IF NOT EXISTS follows (
+    user_id BIGINT NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    follow_key VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, kind, follow_key),
+    INDEX idx_user_created (user_id, created_at),
+    CONSTRAINT fk_follows_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS follows;
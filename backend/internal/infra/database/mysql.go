@@ -47,7 +47,9 @@ var _ Connector = (*MySQL)(nil)
 
 // New creates a new MySQL connection.
 func New(cfg Config) (*MySQL, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci",
+	// The session time zone matches the driver's UTC parsing, so times the
+	// database generates (CURRENT_TIMESTAMP) compare with Go times.
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci&time_zone=%%27%%2B00%%3A00%%27",
 		cfg.Username,
 		cfg.Password,
 		cfg.Host,
@@ -0,0 +1,59 @@
+package alert
+
+import (
+	"context"
+	"time"
+)
+
+// Alert is a paper that newly matched one of a user's saved searches.
+type Alert struct {
+	ID        int64
+	UserID    int64
+	SearchID  int64     // Saved search that matched
+	PaperID   string    // Base paper ID, without version
+	Published time.Time // First submission of the paper
+	CreatedAt time.Time
+	ReadAt    *time.Time // Nil while unread
+}
+
+// Mark is the high-water mark of a saved search: papers submitted after
+// it have not been seen by the search yet.
+type Mark struct {
+	SearchID  int64
+	UserID    int64
+	HighWater time.Time // Latest submission seen
+	CheckedAt time.Time // Last time the search was run
+}
+
+// Filter selects a user's alerts.
+type Filter struct {
+	UserID     int64
+	SearchID   int64 // Only alerts of this saved search (0 for all)
+	UnreadOnly bool
+}
+
+// Repository defines the interface for alert persistence.
+type Repository interface {
+	// ListMarks returns the marks of every saved search checked so far.
+	ListMarks(ctx context.Context) ([]*Mark, error)
+
+	// Record adds alerts and saves the mark of the search that produced
+	// them, together. Alerts for a paper the search has already reported
+	// are skipped; the others get their IDs set. Returns the number added.
+	Record(ctx context.Context, mark *Mark, alerts []*Alert) (int, error)
+
+	// List returns a page of a user's alerts, newest first, together with
+	// the total number matching the filter.
+	List(ctx context.Context, filter Filter, offset, limit int) ([]*Alert, int, error)
+
+	// CountUnread returns the number of a user's unread alerts.
+	CountUnread(ctx context.Context, userID int64) (int, error)
+
+	// MarkRead marks a user's unread alerts as read at the given time and
+	// returns how many were marked. A nil ids marks all of them; IDs of
+	// other users' or already read alerts are ignored.
+	MarkRead(ctx context.Context, userID int64, ids []int64, at time.Time) (int, error)
+
+	// DeleteSearch removes the mark and alerts of a saved search.
+	DeleteSearch(ctx context.Context, searchID int64) error
+}
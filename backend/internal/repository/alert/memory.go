@@ -0,0 +1,162 @@
+package alert
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// alertKey identifies a paper reported by a saved search.
+type alertKey struct {
+	searchID int64
+	paperID  string
+}
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	marks    map[int64]Mark // Keyed by saved search ID
+	alerts   map[int64]*Alert
+	reported map[alertKey]bool
+	nextID   int64
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory alert repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		marks:    make(map[int64]Mark),
+		alerts:   make(map[int64]*Alert),
+		reported: make(map[alertKey]bool),
+	}
+}
+
+// ListMarks returns the marks of every saved search checked so far.
+func (r *MemoryRepository) ListMarks(ctx context.Context) ([]*Mark, error) {
+	r.mu.RLock()
+	marks := make([]*Mark, 0, len(r.marks))
+	for _, m := range r.marks {
+		m := m
+		marks = append(marks, &m)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(marks, func(i, j int) bool { return marks[i].SearchID < marks[j].SearchID })
+	return marks, nil
+}
+
+// Record adds alerts and saves the mark of the search that produced them.
+func (r *MemoryRepository) Record(ctx context.Context, mark *Mark, alerts []*Alert) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	added := 0
+	for _, a := range alerts {
+		key := alertKey{searchID: a.SearchID, paperID: a.PaperID}
+		if r.reported[key] {
+			continue
+		}
+		if a.CreatedAt.IsZero() {
+			a.CreatedAt = time.Now()
+		}
+		r.nextID++
+		a.ID = r.nextID
+		stored := *a
+		r.alerts[a.ID] = &stored
+		r.reported[key] = true
+		added++
+	}
+	r.marks[mark.SearchID] = *mark
+	return added, nil
+}
+
+// List returns a page of a user's alerts, newest first.
+func (r *MemoryRepository) List(ctx context.Context, filter Filter, offset, limit int) ([]*Alert, int, error) {
+	r.mu.RLock()
+	var matched []*Alert
+	for _, a := range r.alerts {
+		if a.UserID != filter.UserID ||
+			(filter.SearchID != 0 && a.SearchID != filter.SearchID) ||
+			(filter.UnreadOnly && a.ReadAt != nil) {
+			continue
+		}
+		copied := *a
+		matched = append(matched, &copied)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	total := len(matched)
+	if offset >= total {
+		return []*Alert{}, total, nil
+	}
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return matched[offset:end], total, nil
+}
+
+// CountUnread returns the number of a user's unread alerts.
+func (r *MemoryRepository) CountUnread(ctx context.Context, userID int64) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n := 0
+	for _, a := range r.alerts {
+		if a.UserID == userID && a.ReadAt == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// MarkRead marks a user's unread alerts as read.
+func (r *MemoryRepository) MarkRead(ctx context.Context, userID int64, ids []int64, at time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	marked := 0
+	markRead := func(a *Alert) {
+		if a != nil && a.UserID == userID && a.ReadAt == nil {
+			readAt := at
+			a.ReadAt = &readAt
+			marked++
+		}
+	}
+	if ids == nil {
+		for _, a := range r.alerts {
+			markRead(a)
+		}
+		return marked, nil
+	}
+	for _, id := range ids {
+		markRead(r.alerts[id])
+	}
+	return marked, nil
+}
+
+// DeleteSearch removes the mark and alerts of a saved search.
+func (r *MemoryRepository) DeleteSearch(ctx context.Context, searchID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.marks, searchID)
+	for id, a := range r.alerts {
+		if a.SearchID == searchID {
+			delete(r.alerts, id)
+			delete(r.reported, alertKey{searchID: a.SearchID, paperID: a.PaperID})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+type SQLRepository struct {
+	db database.DB
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based alert repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// alertColumns lists the columns scanned by scanAlert, in order.
+const alertColumns = `id, user_id, saved_search_id, paper_id, published, created_at, read_at`
+
+// ListMarks returns the marks of every saved search checked so far.
+func (r *SQLRepository) ListMarks(ctx context.Context) ([]*Mark, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT saved_search_id, user_id, high_water, checked_at
+		FROM alert_marks
+		ORDER BY saved_search_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert marks: %w", err)
+	}
+	defer rows.Close()
+
+	marks := []*Mark{}
+	for rows.Next() {
+		var m Mark
+		if err := rows.Scan(&m.SearchID, &m.UserID, &m.HighWater, &m.CheckedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan alert mark: %w", err)
+		}
+		marks = append(marks, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate alert marks: %w", err)
+	}
+	return marks, nil
+}
+
+// Record adds alerts and saves the mark of the search that produced them.
+func (r *SQLRepository) Record(ctx context.Context, mark *Mark, alerts []*Alert) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	added := 0
+	for _, a := range alerts {
+		if a.CreatedAt.IsZero() {
+			a.CreatedAt = time.Now()
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT IGNORE INTO alerts (user_id, saved_search_id, paper_id, published, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, a.UserID, a.SearchID, a.PaperID, a.Published, a.CreatedAt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to add alert: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			if err != nil {
+				return 0, fmt.Errorf("failed to add alert: %w", err)
+			}
+			continue // Already reported
+		}
+		if a.ID, err = result.LastInsertId(); err != nil {
+			return 0, fmt.Errorf("failed to get alert ID: %w", err)
+		}
+		added++
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO alert_marks (saved_search_id, user_id, high_water, checked_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			high_water = VALUES(high_water),
+			checked_at = VALUES(checked_at)
+	`, mark.SearchID, mark.UserID, mark.HighWater, mark.CheckedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save alert mark: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit alerts: %w", err)
+	}
+	return added, nil
+}
+
+// List returns a page of a user's alerts, newest first.
+func (r *SQLRepository) List(ctx context.Context, filter Filter, offset, limit int) ([]*Alert, int, error) {
+	where := `user_id = ?`
+	args := []interface{}{filter.UserID}
+	if filter.SearchID != 0 {
+		where += ` AND saved_search_id = ?`
+		args = append(args, filter.SearchID)
+	}
+	if filter.UnreadOnly {
+		where += ` AND read_at IS NULL`
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM alerts WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count alerts: %w", err)
+	}
+	if limit <= 0 {
+		limit = total
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+alertColumns+`
+		FROM alerts
+		WHERE `+where+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []*Alert{}
+	for rows.Next() {
+		a, err := scanAlert(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate alerts: %w", err)
+	}
+	return alerts, total, nil
+}
+
+// CountUnread returns the number of a user's unread alerts.
+func (r *SQLRepository) CountUnread(ctx context.Context, userID int64) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM alerts WHERE user_id = ? AND read_at IS NULL`, userID,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread alerts: %w", err)
+	}
+	return n, nil
+}
+
+// MarkRead marks a user's unread alerts as read.
+func (r *SQLRepository) MarkRead(ctx context.Context, userID int64, ids []int64, at time.Time) (int, error) {
+	query := `UPDATE alerts SET read_at = ? WHERE user_id = ? AND read_at IS NULL`
+	args := []interface{}{at, userID}
+	if ids != nil {
+		if len(ids) == 0 {
+			return 0, nil
+		}
+		query += ` AND id IN (` + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + `)`
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+
+	// read_at always changes from NULL, so the affected rows are the marked ones.
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark alerts read: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark alerts read: %w", err)
+	}
+	return int(n), nil
+}
+
+// DeleteSearch removes the mark and alerts of a saved search.
+func (r *SQLRepository) DeleteSearch(ctx context.Context, searchID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM alerts WHERE saved_search_id = ?`, searchID); err != nil {
+		return fmt.Errorf("failed to delete alerts: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM alert_marks WHERE saved_search_id = ?`, searchID); err != nil {
+		return fmt.Errorf("failed to delete alert mark: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit alert deletion: %w", err)
+	}
+	return nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAlert scans a row selected with alertColumns.
+func scanAlert(row scanner) (*Alert, error) {
+	var a Alert
+	var readAt sql.NullTime
+	err := row.Scan(
+		&a.ID,
+		&a.UserID,
+		&a.SearchID,
+		&a.PaperID,
+		&a.Published,
+		&a.CreatedAt,
+		&readAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if readAt.Valid {
+		a.ReadAt = &readAt.Time
+	}
+	return &a, nil
+}
@@ -0,0 +1,9 @@
+package bookmark
+
+import "errors"
+
+// Common errors for bookmark repository operations.
+var (
+	// ErrBookmarkNotFound is returned when a paper is not bookmarked by the user.
+	ErrBookmarkNotFound = errors.New("bookmark not found")
+)
@@ -0,0 +1,43 @@
+package bookmark
+
+import (
+	"context"
+	"time"
+)
+
+// Bookmark is a paper saved by a user.
+type Bookmark struct {
+	UserID    int64
+	PaperID   string // Base paper ID, without version
+	CreatedAt time.Time
+}
+
+// ListQuery selects a page of a user's bookmarks.
+type ListQuery struct {
+	UserID      int64
+	Offset      int
+	Limit       int
+	OldestFirst bool // Order by bookmark time ascending instead of descending
+}
+
+// Repository defines the interface for bookmark persistence.
+type Repository interface {
+	// Add bookmarks a paper for a user.
+	// If the paper is already bookmarked, the bookmark keeps its original time,
+	// which is written back to b, and Add returns false.
+	Add(ctx context.Context, b *Bookmark) (bool, error)
+
+	// Remove deletes a user's bookmark.
+	// Returns ErrBookmarkNotFound if the paper is not bookmarked.
+	Remove(ctx context.Context, userID int64, paperID string) error
+
+	// List returns a page of a user's bookmarks, ordered by bookmark time
+	// (ties by paper ID), together with the user's total number of bookmarks.
+	List(ctx context.Context, q *ListQuery) ([]*Bookmark, int, error)
+
+	// Find returns the user's bookmarks among the given papers, keyed by paper ID.
+	Find(ctx context.Context, userID int64, paperIDs []string) (map[string]*Bookmark, error)
+
+	// ListSince returns every user's bookmarks made at or after since, oldest first.
+	ListSince(ctx context.Context, since time.Time) ([]*Bookmark, error)
+}
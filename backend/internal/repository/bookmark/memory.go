@@ -0,0 +1,131 @@
+package bookmark
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu        sync.RWMutex
+	bookmarks map[int64]map[string]Bookmark // Keyed by user ID, then paper ID
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory bookmark repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		bookmarks: make(map[int64]map[string]Bookmark),
+	}
+}
+
+// Add bookmarks a paper for a user.
+func (r *MemoryRepository) Add(ctx context.Context, b *Bookmark) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.bookmarks[b.UserID]
+	if !ok {
+		user = make(map[string]Bookmark)
+		r.bookmarks[b.UserID] = user
+	}
+	if existing, found := user[b.PaperID]; found {
+		b.CreatedAt = existing.CreatedAt
+		return false, nil
+	}
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = time.Now()
+	}
+	user[b.PaperID] = *b
+	return true, nil
+}
+
+// Remove deletes a user's bookmark.
+func (r *MemoryRepository) Remove(ctx context.Context, userID int64, paperID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.bookmarks[userID][paperID]; !found {
+		return ErrBookmarkNotFound
+	}
+	delete(r.bookmarks[userID], paperID)
+	return nil
+}
+
+// List returns a page of a user's bookmarks and their total number.
+func (r *MemoryRepository) List(ctx context.Context, q *ListQuery) ([]*Bookmark, int, error) {
+	r.mu.RLock()
+	all := make([]*Bookmark, 0, len(r.bookmarks[q.UserID]))
+	for _, b := range r.bookmarks[q.UserID] {
+		b := b
+		all = append(all, &b)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool {
+		a, b := all[i], all[j]
+		if q.OldestFirst {
+			a, b = b, a
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.PaperID > b.PaperID
+	})
+
+	total := len(all)
+	if q.Offset >= total {
+		return []*Bookmark{}, total, nil
+	}
+	end := total
+	if q.Limit > 0 && q.Offset+q.Limit < end {
+		end = q.Offset + q.Limit
+	}
+	return all[q.Offset:end], total, nil
+}
+
+// ListSince returns every user's bookmarks made at or after since, oldest first.
+func (r *MemoryRepository) ListSince(ctx context.Context, since time.Time) ([]*Bookmark, error) {
+	r.mu.RLock()
+	bookmarks := []*Bookmark{}
+	for _, user := range r.bookmarks {
+		for _, b := range user {
+			if !b.CreatedAt.Before(since) {
+				b := b
+				bookmarks = append(bookmarks, &b)
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(bookmarks, func(i, j int) bool {
+		a, b := bookmarks[i], bookmarks[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if a.PaperID != b.PaperID {
+			return a.PaperID < b.PaperID
+		}
+		return a.UserID < b.UserID
+	})
+	return bookmarks, nil
+}
+
+// Find returns the user's bookmarks among the given papers.
+func (r *MemoryRepository) Find(ctx context.Context, userID int64, paperIDs []string) (map[string]*Bookmark, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := make(map[string]*Bookmark)
+	for _, id := range paperIDs {
+		if b, ok := r.bookmarks[userID][id]; ok {
+			found[id] = &b
+		}
+	}
+	return found, nil
+}
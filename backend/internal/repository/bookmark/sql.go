@@ -0,0 +1,167 @@
+package bookmark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+type SQLRepository struct {
+	db database.Executor
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based bookmark repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// Add bookmarks a paper for a user.
+func (r *SQLRepository) Add(ctx context.Context, b *Bookmark) (bool, error) {
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT IGNORE INTO bookmarks (user_id, paper_id, created_at) VALUES (?, ?, ?)`,
+		b.UserID, b.PaperID, b.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to add bookmark: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n > 0 {
+		return true, nil
+	}
+
+	// Already bookmarked: report the original time.
+	err = r.db.QueryRowContext(ctx,
+		`SELECT created_at FROM bookmarks WHERE user_id = ? AND paper_id = ?`,
+		b.UserID, b.PaperID,
+	).Scan(&b.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to get bookmark: %w", err)
+	}
+	return false, nil
+}
+
+// Remove deletes a user's bookmark.
+func (r *SQLRepository) Remove(ctx context.Context, userID int64, paperID string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM bookmarks WHERE user_id = ? AND paper_id = ?`,
+		userID, paperID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	if n == 0 {
+		return ErrBookmarkNotFound
+	}
+	return nil
+}
+
+// List returns a page of a user's bookmarks and their total number.
+func (r *SQLRepository) List(ctx context.Context, q *ListQuery) ([]*Bookmark, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM bookmarks WHERE user_id = ?`, q.UserID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count bookmarks: %w", err)
+	}
+
+	order := "DESC"
+	if q.OldestFirst {
+		order = "ASC"
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = total
+	}
+	query := fmt.Sprintf(`
+		SELECT user_id, paper_id, created_at
+		FROM bookmarks
+		WHERE user_id = ?
+		ORDER BY created_at %s, paper_id %s
+		LIMIT ? OFFSET ?
+	`, order, order)
+
+	bookmarks, err := r.queryBookmarks(ctx, query, q.UserID, limit, q.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	return bookmarks, total, nil
+}
+
+// Find returns the user's bookmarks among the given papers.
+func (r *SQLRepository) Find(ctx context.Context, userID int64, paperIDs []string) (map[string]*Bookmark, error) {
+	found := make(map[string]*Bookmark)
+	if len(paperIDs) == 0 {
+		return found, nil
+	}
+
+	args := make([]interface{}, 0, len(paperIDs)+1)
+	args = append(args, userID)
+	for _, id := range paperIDs {
+		args = append(args, id)
+	}
+	in := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(paperIDs)), ", ") + ")"
+	query := `
+		SELECT user_id, paper_id, created_at
+		FROM bookmarks
+		WHERE user_id = ? AND paper_id IN ` + in + `
+	`
+
+	bookmarks, err := r.queryBookmarks(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find bookmarks: %w", err)
+	}
+	for _, b := range bookmarks {
+		found[b.PaperID] = b
+	}
+	return found, nil
+}
+
+// ListSince returns every user's bookmarks made at or after since, oldest first.
+func (r *SQLRepository) ListSince(ctx context.Context, since time.Time) ([]*Bookmark, error) {
+	bookmarks, err := r.queryBookmarks(ctx, `
+		SELECT user_id, paper_id, created_at
+		FROM bookmarks
+		WHERE created_at >= ?
+		ORDER BY created_at, paper_id, user_id
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	return bookmarks, nil
+}
+
+// queryBookmarks runs a query selecting user_id, paper_id and created_at.
+func (r *SQLRepository) queryBookmarks(ctx context.Context, query string, args ...interface{}) ([]*Bookmark, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookmarks := []*Bookmark{}
+	for rows.Next() {
+		var b Bookmark
+		if err := rows.Scan(&b.UserID, &b.PaperID, &b.CreatedAt); err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, &b)
+	}
+	return bookmarks, rows.Err()
+}
@@ -0,0 +1,15 @@
+package collection
+
+import "errors"
+
+// Common errors for collection repository operations.
+var (
+	// ErrCollectionNotFound is returned when a collection does not exist.
+	ErrCollectionNotFound = errors.New("collection not found")
+
+	// ErrItemNotFound is returned when a paper is not in a collection.
+	ErrItemNotFound = errors.New("collection item not found")
+
+	// ErrSlugTaken is returned when a share slug is already in use.
+	ErrSlugTaken = errors.New("collection slug already taken")
+)
@@ -0,0 +1,81 @@
+package collection
+
+import (
+	"context"
+	"time"
+)
+
+// PublicVisibility is the visibility of the collections returned by ListPublic.
+const PublicVisibility = "public"
+
+// Collection is a named, ordered list of papers owned by a user.
+type Collection struct {
+	ID          int64
+	UserID      int64
+	Name        string
+	Description string
+	Visibility  string
+	Slug        string // Unique share slug
+	ItemCount   int    // Filled in on read
+	CreatedAt   time.Time
+	UpdatedAt   time.Time // Bumped by any change to the collection or its items
+}
+
+// Item is a paper in a collection.
+type Item struct {
+	CollectionID int64
+	PaperID      string // Base paper ID, without version
+	Position     int    // 1-based position in the collection
+	Note         string
+	AddedAt      time.Time
+}
+
+// Repository defines the interface for collection persistence.
+type Repository interface {
+	// Create stores a new collection and sets its ID and timestamps.
+	// Returns ErrSlugTaken if another collection has the same slug.
+	Create(ctx context.Context, c *Collection) error
+
+	// Get retrieves a collection by ID.
+	// Returns ErrCollectionNotFound if there is none.
+	Get(ctx context.Context, id int64) (*Collection, error)
+
+	// GetBySlug retrieves a collection by its share slug.
+	// Returns ErrCollectionNotFound if there is none.
+	GetBySlug(ctx context.Context, slug string) (*Collection, error)
+
+	// ListByUser returns a user's collections, most recently updated first.
+	ListByUser(ctx context.Context, userID int64) ([]*Collection, error)
+
+	// ListPublic returns a page of public collections, most recently updated
+	// first, together with their total number.
+	ListPublic(ctx context.Context, offset, limit int) ([]*Collection, int, error)
+
+	// Update saves a collection's name, description and visibility.
+	// Returns ErrCollectionNotFound if there is none.
+	Update(ctx context.Context, c *Collection) error
+
+	// Delete removes a collection and its items.
+	// Returns ErrCollectionNotFound if there is none.
+	Delete(ctx context.Context, id int64) error
+
+	// Items returns a collection's items in order.
+	Items(ctx context.Context, collectionID int64) ([]*Item, error)
+
+	// AddItem appends a paper to a collection and reports whether it was
+	// added. If the paper is already in the collection, the stored item is
+	// written back to item.
+	AddItem(ctx context.Context, item *Item) (bool, error)
+
+	// UpdateItem saves an item's note.
+	// Returns ErrItemNotFound if the paper is not in the collection.
+	UpdateItem(ctx context.Context, item *Item) error
+
+	// RemoveItem removes a paper from a collection.
+	// Returns ErrItemNotFound if the paper is not in the collection.
+	RemoveItem(ctx context.Context, collectionID int64, paperID string) error
+
+	// Reorder renumbers a collection's items in the given order. The caller
+	// passes every paper in the collection exactly once.
+	Reorder(ctx context.Context, collectionID int64, paperIDs []string) error
+}
@@ -0,0 +1,258 @@
+package collection
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu          sync.RWMutex
+	collections map[int64]Collection
+	items       map[int64]map[string]Item // Keyed by collection ID, then paper ID
+	nextID      int64
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory collection repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		collections: make(map[int64]Collection),
+		items:       make(map[int64]map[string]Item),
+		nextID:      1,
+	}
+}
+
+// Create stores a new collection.
+func (r *MemoryRepository) Create(ctx context.Context, c *Collection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.collections {
+		if existing.Slug == c.Slug {
+			return ErrSlugTaken
+		}
+	}
+
+	now := time.Now()
+	c.ID = r.nextID
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	c.ItemCount = 0
+	r.collections[c.ID] = *c
+	r.items[c.ID] = make(map[string]Item)
+	r.nextID++
+	return nil
+}
+
+// Get retrieves a collection by ID.
+func (r *MemoryRepository) Get(ctx context.Context, id int64) (*Collection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, found := r.collections[id]
+	if !found {
+		return nil, ErrCollectionNotFound
+	}
+	return r.withCount(c), nil
+}
+
+// GetBySlug retrieves a collection by its share slug.
+func (r *MemoryRepository) GetBySlug(ctx context.Context, slug string) (*Collection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.collections {
+		if c.Slug == slug {
+			return r.withCount(c), nil
+		}
+	}
+	return nil, ErrCollectionNotFound
+}
+
+// ListByUser returns a user's collections, most recently updated first.
+func (r *MemoryRepository) ListByUser(ctx context.Context, userID int64) ([]*Collection, error) {
+	r.mu.RLock()
+	result := []*Collection{}
+	for _, c := range r.collections {
+		if c.UserID == userID {
+			result = append(result, r.withCount(c))
+		}
+	}
+	r.mu.RUnlock()
+
+	sortByUpdated(result)
+	return result, nil
+}
+
+// ListPublic returns a page of public collections and their total number.
+func (r *MemoryRepository) ListPublic(ctx context.Context, offset, limit int) ([]*Collection, int, error) {
+	r.mu.RLock()
+	all := []*Collection{}
+	for _, c := range r.collections {
+		if c.Visibility == PublicVisibility {
+			all = append(all, r.withCount(c))
+		}
+	}
+	r.mu.RUnlock()
+
+	sortByUpdated(all)
+	total := len(all)
+	if offset >= total {
+		return []*Collection{}, total, nil
+	}
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return all[offset:end], total, nil
+}
+
+// Update saves a collection's name, description and visibility.
+func (r *MemoryRepository) Update(ctx context.Context, c *Collection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, found := r.collections[c.ID]
+	if !found {
+		return ErrCollectionNotFound
+	}
+	stored.Name = c.Name
+	stored.Description = c.Description
+	stored.Visibility = c.Visibility
+	stored.UpdatedAt = time.Now()
+	r.collections[c.ID] = stored
+	c.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Delete removes a collection and its items.
+func (r *MemoryRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.collections[id]; !found {
+		return ErrCollectionNotFound
+	}
+	delete(r.collections, id)
+	delete(r.items, id)
+	return nil
+}
+
+// Items returns a collection's items in order.
+func (r *MemoryRepository) Items(ctx context.Context, collectionID int64) ([]*Item, error) {
+	r.mu.RLock()
+	result := make([]*Item, 0, len(r.items[collectionID]))
+	for _, item := range r.items[collectionID] {
+		item := item
+		result = append(result, &item)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Position < result[j].Position })
+	return result, nil
+}
+
+// AddItem appends a paper to a collection.
+func (r *MemoryRepository) AddItem(ctx context.Context, item *Item) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items, ok := r.items[item.CollectionID]
+	if !ok {
+		return false, ErrCollectionNotFound
+	}
+	if existing, found := items[item.PaperID]; found {
+		*item = existing
+		return false, nil
+	}
+
+	position := 0
+	for _, existing := range items {
+		position = max(position, existing.Position)
+	}
+	item.Position = position + 1
+	if item.AddedAt.IsZero() {
+		item.AddedAt = time.Now()
+	}
+	items[item.PaperID] = *item
+	r.touch(item.CollectionID)
+	return true, nil
+}
+
+// UpdateItem saves an item's note.
+func (r *MemoryRepository) UpdateItem(ctx context.Context, item *Item) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, found := r.items[item.CollectionID][item.PaperID]
+	if !found {
+		return ErrItemNotFound
+	}
+	stored.Note = item.Note
+	r.items[item.CollectionID][item.PaperID] = stored
+	*item = stored
+	r.touch(item.CollectionID)
+	return nil
+}
+
+// RemoveItem removes a paper from a collection.
+func (r *MemoryRepository) RemoveItem(ctx context.Context, collectionID int64, paperID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.items[collectionID][paperID]; !found {
+		return ErrItemNotFound
+	}
+	delete(r.items[collectionID], paperID)
+	r.touch(collectionID)
+	return nil
+}
+
+// Reorder renumbers a collection's items in the given order.
+func (r *MemoryRepository) Reorder(ctx context.Context, collectionID int64, paperIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := r.items[collectionID]
+	for i, id := range paperIDs {
+		item, found := items[id]
+		if !found {
+			return ErrItemNotFound
+		}
+		item.Position = i + 1
+		items[id] = item
+	}
+	r.touch(collectionID)
+	return nil
+}
+
+// withCount returns a copy of a collection with its item count.
+// The caller must hold the lock.
+func (r *MemoryRepository) withCount(c Collection) *Collection {
+	c.ItemCount = len(r.items[c.ID])
+	return &c
+}
+
+// touch bumps a collection's update time. The caller must hold the lock.
+func (r *MemoryRepository) touch(id int64) {
+	if c, found := r.collections[id]; found {
+		c.UpdatedAt = time.Now()
+		r.collections[id] = c
+	}
+}
+
+// sortByUpdated sorts collections by update time, newest first, then by ID.
+func sortByUpdated(collections []*Collection) {
+	sort.Slice(collections, func(i, j int) bool {
+		if !collections[i].UpdatedAt.Equal(collections[j].UpdatedAt) {
+			return collections[i].UpdatedAt.After(collections[j].UpdatedAt)
+		}
+		return collections[i].ID > collections[j].ID
+	})
+}
@@ -0,0 +1,395 @@
+package collection
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+type SQLRepository struct {
+	db database.DB
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based collection repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// selectCollections selects the columns scanned by scanCollection.
+const selectCollections = `
+	SELECT c.id, c.user_id, c.name, c.description, c.visibility, c.slug,
+		(SELECT COUNT(*) FROM collection_items i WHERE i.collection_id = c.id),
+		c.created_at, c.updated_at
+	FROM collections c
+`
+
+// Create stores a new collection.
+func (r *SQLRepository) Create(ctx context.Context, c *Collection) error {
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	c.ItemCount = 0
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO collections (user_id, name, description, visibility, slug, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, c.UserID, c.Name, c.Description, c.Visibility, c.Slug, c.CreatedAt, c.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrSlugTaken
+		}
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+	c.ID = id
+	return nil
+}
+
+// Get retrieves a collection by ID.
+func (r *SQLRepository) Get(ctx context.Context, id int64) (*Collection, error) {
+	c, err := scanCollection(r.db.QueryRowContext(ctx, selectCollections+`WHERE c.id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return c, nil
+}
+
+// GetBySlug retrieves a collection by its share slug.
+func (r *SQLRepository) GetBySlug(ctx context.Context, slug string) (*Collection, error) {
+	c, err := scanCollection(r.db.QueryRowContext(ctx, selectCollections+`WHERE c.slug = ?`, slug))
+	if err == sql.ErrNoRows {
+		return nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection by slug: %w", err)
+	}
+	return c, nil
+}
+
+// ListByUser returns a user's collections, most recently updated first.
+func (r *SQLRepository) ListByUser(ctx context.Context, userID int64) ([]*Collection, error) {
+	return r.queryCollections(ctx,
+		selectCollections+`WHERE c.user_id = ? ORDER BY c.updated_at DESC, c.id DESC`, userID)
+}
+
+// ListPublic returns a page of public collections and their total number.
+func (r *SQLRepository) ListPublic(ctx context.Context, offset, limit int) ([]*Collection, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM collections WHERE visibility = ?`, PublicVisibility,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count public collections: %w", err)
+	}
+	if limit <= 0 {
+		limit = total
+	}
+
+	collections, err := r.queryCollections(ctx,
+		selectCollections+`WHERE c.visibility = ? ORDER BY c.updated_at DESC, c.id DESC LIMIT ? OFFSET ?`,
+		PublicVisibility, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return collections, total, nil
+}
+
+// Update saves a collection's name, description and visibility.
+func (r *SQLRepository) Update(ctx context.Context, c *Collection) error {
+	c.UpdatedAt = time.Now()
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE collections SET name = ?, description = ?, visibility = ?, updated_at = ?
+		WHERE id = ?
+	`, c.Name, c.Description, c.Visibility, c.UpdatedAt, c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		// MySQL counts only changed rows, and an identical update within the
+		// same second changes nothing, so check that the collection still exists.
+		_, err := r.Get(ctx, c.ID)
+		return err
+	}
+	return nil
+}
+
+// Delete removes a collection and its items.
+func (r *SQLRepository) Delete(ctx context.Context, id int64) error {
+	// Items are removed by the foreign key's ON DELETE CASCADE.
+	result, err := r.db.ExecContext(ctx, `DELETE FROM collections WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	return requireAffected(result, ErrCollectionNotFound)
+}
+
+// Items returns a collection's items in order.
+func (r *SQLRepository) Items(ctx context.Context, collectionID int64) ([]*Item, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT collection_id, paper_id, position, note, added_at
+		FROM collection_items
+		WHERE collection_id = ?
+		ORDER BY position
+	`, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*Item{}
+	for rows.Next() {
+		var item Item
+		if err := rows.Scan(&item.CollectionID, &item.PaperID, &item.Position, &item.Note, &item.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan collection item: %w", err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate collection items: %w", err)
+	}
+	return items, nil
+}
+
+// AddItem appends a paper to a collection.
+func (r *SQLRepository) AddItem(ctx context.Context, item *Item) (bool, error) {
+	if item.AddedAt.IsZero() {
+		item.AddedAt = time.Now()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the collection row serializes appends, so positions stay unique.
+	var id int64
+	err = tx.QueryRowContext(ctx, `SELECT id FROM collections WHERE id = ? FOR UPDATE`, item.CollectionID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, ErrCollectionNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock collection: %w", err)
+	}
+
+	var existing Item
+	err = tx.QueryRowContext(ctx, `
+		SELECT collection_id, paper_id, position, note, added_at
+		FROM collection_items WHERE collection_id = ? AND paper_id = ?
+	`, item.CollectionID, item.PaperID).Scan(
+		&existing.CollectionID, &existing.PaperID, &existing.Position, &existing.Note, &existing.AddedAt,
+	)
+	if err == nil {
+		*item = existing
+		return false, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to check collection item: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(position), 0) + 1 FROM collection_items WHERE collection_id = ?`,
+		item.CollectionID,
+	).Scan(&item.Position)
+	if err != nil {
+		return false, fmt.Errorf("failed to get next position: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO collection_items (collection_id, paper_id, position, note, added_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, item.CollectionID, item.PaperID, item.Position, item.Note, item.AddedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to add collection item: %w", err)
+	}
+	if err := touch(ctx, tx, item.CollectionID); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit collection item: %w", err)
+	}
+	return true, nil
+}
+
+// UpdateItem saves an item's note.
+func (r *SQLRepository) UpdateItem(ctx context.Context, item *Item) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT position, added_at FROM collection_items
+		WHERE collection_id = ? AND paper_id = ? FOR UPDATE
+	`, item.CollectionID, item.PaperID).Scan(&item.Position, &item.AddedAt)
+	if err == sql.ErrNoRows {
+		return ErrItemNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get collection item: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE collection_items SET note = ? WHERE collection_id = ? AND paper_id = ?`,
+		item.Note, item.CollectionID, item.PaperID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update collection item: %w", err)
+	}
+	if err := touch(ctx, tx, item.CollectionID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit collection item: %w", err)
+	}
+	return nil
+}
+
+// RemoveItem removes a paper from a collection.
+func (r *SQLRepository) RemoveItem(ctx context.Context, collectionID int64, paperID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM collection_items WHERE collection_id = ? AND paper_id = ?`,
+		collectionID, paperID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove collection item: %w", err)
+	}
+	if err := requireAffected(result, ErrItemNotFound); err != nil {
+		return err
+	}
+	if err := touch(ctx, tx, collectionID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit collection item removal: %w", err)
+	}
+	return nil
+}
+
+// Reorder renumbers a collection's items in the given order.
+func (r *SQLRepository) Reorder(ctx context.Context, collectionID int64, paperIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Affected rows are not checked: a paper that keeps its position counts
+	// as unchanged, and the caller has already checked the IDs.
+	for i, id := range paperIDs {
+		_, err := tx.ExecContext(ctx,
+			`UPDATE collection_items SET position = ? WHERE collection_id = ? AND paper_id = ?`,
+			i+1, collectionID, id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to reorder collection items: %w", err)
+		}
+	}
+	if err := touch(ctx, tx, collectionID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit collection order: %w", err)
+	}
+	return nil
+}
+
+// queryCollections runs a query selecting selectCollections columns.
+func (r *SQLRepository) queryCollections(ctx context.Context, query string, args ...interface{}) ([]*Collection, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	defer rows.Close()
+
+	collections := []*Collection{}
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collection: %w", err)
+		}
+		collections = append(collections, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate collections: %w", err)
+	}
+	return collections, nil
+}
+
+// touch bumps a collection's update time within a transaction.
+func touch(ctx context.Context, tx *sql.Tx, id int64) error {
+	_, err := tx.ExecContext(ctx, `UPDATE collections SET updated_at = ? WHERE id = ?`, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to touch collection: %w", err)
+	}
+	return nil
+}
+
+// requireAffected returns notFound if a statement changed no rows.
+func requireAffected(result sql.Result, notFound error) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCollection scans a row selected with selectCollections.
+func scanCollection(row scanner) (*Collection, error) {
+	var c Collection
+	err := row.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Name,
+		&c.Description,
+		&c.Visibility,
+		&c.Slug,
+		&c.ItemCount,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
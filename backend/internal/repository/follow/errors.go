@@ -0,0 +1,9 @@
+package follow
+
+import "errors"
+
+// Common errors for follow repository operations.
+var (
+	// ErrFollowNotFound is returned when a user does not follow an author or category.
+	ErrFollowNotFound = errors.New("follow not found")
+)
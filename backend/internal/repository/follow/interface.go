@@ -0,0 +1,39 @@
+package follow
+
+import (
+	"context"
+	"time"
+)
+
+// Kind is what a user follows.
+type Kind string
+
+// Follow kinds.
+const (
+	KindAuthor   Kind = "author"
+	KindCategory Kind = "category"
+)
+
+// Follow is an author or arXiv category followed by a user.
+type Follow struct {
+	UserID    int64
+	Kind      Kind
+	Key       string // Identifies the name among the user's follows of the kind
+	Name      string // Name as given by the user
+	CreatedAt time.Time
+}
+
+// Repository defines the interface for follow persistence.
+type Repository interface {
+	// Add follows an author or category for a user.
+	// If the user already follows the key, the follow keeps its original
+	// name and time, which are written back to f, and Add returns false.
+	Add(ctx context.Context, f *Follow) (bool, error)
+
+	// Remove unfollows an author or category.
+	// Returns ErrFollowNotFound if the user does not follow the key.
+	Remove(ctx context.Context, userID int64, kind Kind, key string) error
+
+	// List returns all of a user's follows, most recent first.
+	List(ctx context.Context, userID int64) ([]*Follow, error)
+}
@@ -0,0 +1,90 @@
+package follow
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// followKey identifies a follow within a user's follows.
+type followKey struct {
+	kind Kind
+	key  string
+}
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu      sync.RWMutex
+	follows map[int64]map[followKey]Follow // Keyed by user ID, then kind and key
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory follow repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		follows: make(map[int64]map[followKey]Follow),
+	}
+}
+
+// Add follows an author or category for a user.
+func (r *MemoryRepository) Add(ctx context.Context, f *Follow) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.follows[f.UserID]
+	if !ok {
+		user = make(map[followKey]Follow)
+		r.follows[f.UserID] = user
+	}
+	k := followKey{kind: f.Kind, key: f.Key}
+	if existing, found := user[k]; found {
+		f.Name = existing.Name
+		f.CreatedAt = existing.CreatedAt
+		return false, nil
+	}
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = time.Now()
+	}
+	user[k] = *f
+	return true, nil
+}
+
+// Remove unfollows an author or category.
+func (r *MemoryRepository) Remove(ctx context.Context, userID int64, kind Kind, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := followKey{kind: kind, key: key}
+	if _, found := r.follows[userID][k]; !found {
+		return ErrFollowNotFound
+	}
+	delete(r.follows[userID], k)
+	return nil
+}
+
+// List returns all of a user's follows, most recent first.
+func (r *MemoryRepository) List(ctx context.Context, userID int64) ([]*Follow, error) {
+	r.mu.RLock()
+	follows := make([]*Follow, 0, len(r.follows[userID]))
+	for _, f := range r.follows[userID] {
+		f := f
+		follows = append(follows, &f)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(follows, func(i, j int) bool {
+		a, b := follows[i], follows[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Key < b.Key
+	})
+	return follows, nil
+}
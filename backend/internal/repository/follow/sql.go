@@ -0,0 +1,98 @@
+package follow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+type SQLRepository struct {
+	db database.Executor
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based follow repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// Add follows an author or category for a user.
+func (r *SQLRepository) Add(ctx context.Context, f *Follow) (bool, error) {
+	if f.CreatedAt.IsZero() {
+		f.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT IGNORE INTO follows (user_id, kind, follow_key, name, created_at) VALUES (?, ?, ?, ?, ?)`,
+		f.UserID, f.Kind, f.Key, f.Name, f.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to add follow: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n > 0 {
+		return true, nil
+	}
+
+	// Already followed: report the original name and time.
+	err = r.db.QueryRowContext(ctx,
+		`SELECT name, created_at FROM follows WHERE user_id = ? AND kind = ? AND follow_key = ?`,
+		f.UserID, f.Kind, f.Key,
+	).Scan(&f.Name, &f.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to get follow: %w", err)
+	}
+	return false, nil
+}
+
+// Remove unfollows an author or category.
+func (r *SQLRepository) Remove(ctx context.Context, userID int64, kind Kind, key string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM follows WHERE user_id = ? AND kind = ? AND follow_key = ?`,
+		userID, kind, key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove follow: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove follow: %w", err)
+	}
+	if n == 0 {
+		return ErrFollowNotFound
+	}
+	return nil
+}
+
+// List returns all of a user's follows, most recent first.
+func (r *SQLRepository) List(ctx context.Context, userID int64) ([]*Follow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT user_id, kind, follow_key, name, created_at
+		FROM follows
+		WHERE user_id = ?
+		ORDER BY created_at DESC, kind, follow_key
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+	defer rows.Close()
+
+	follows := []*Follow{}
+	for rows.Next() {
+		var f Follow
+		if err := rows.Scan(&f.UserID, &f.Kind, &f.Key, &f.Name, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan follow: %w", err)
+		}
+		follows = append(follows, &f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list follows: %w", err)
+	}
+	return follows, nil
+}
@@ -0,0 +1,9 @@
+package harvest
+
+import "errors"
+
+// Common errors for harvest state repository operations.
+var (
+	// ErrStateNotFound is returned when no state exists for a set and metadata format.
+	ErrStateNotFound = errors.New("harvest state not found")
+)
@@ -0,0 +1,27 @@
+package harvest
+
+import (
+	"context"
+	"time"
+)
+
+// State is the progress of incremental harvesting for one set and metadata format.
+type State struct {
+	Set              string    // OAI-PMH set (e.g., "cs"); empty for the whole repository
+	MetadataPrefix   string    // Metadata format (e.g., "arXiv")
+	Watermark        time.Time // The next incremental run harvests records changed on or after this day
+	ResumptionToken  string    // Token of an unfinished run; empty when the last run completed
+	RunStartedAt     time.Time // Server time at the start of the current or last run
+	RecordsHarvested int64     // Records ingested over all runs
+	UpdatedAt        time.Time
+}
+
+// Repository defines the interface for harvest state persistence.
+type Repository interface {
+	// Get retrieves the state for a set and metadata format.
+	// Returns ErrStateNotFound if nothing has been harvested yet.
+	Get(ctx context.Context, set, metadataPrefix string) (*State, error)
+
+	// Save creates or replaces the state for its set and metadata format.
+	Save(ctx context.Context, state *State) error
+}
@@ -0,0 +1,51 @@
+package harvest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	states map[string]State
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory harvest state repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		states: make(map[string]State),
+	}
+}
+
+// Get retrieves the state for a set and metadata format.
+func (r *MemoryRepository) Get(ctx context.Context, set, metadataPrefix string) (*State, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state, found := r.states[stateKey(set, metadataPrefix)]
+	if !found {
+		return nil, ErrStateNotFound
+	}
+	return &state, nil
+}
+
+// Save creates or replaces the state.
+func (r *MemoryRepository) Save(ctx context.Context, state *State) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state.UpdatedAt = time.Now()
+	r.states[stateKey(state.Set, state.MetadataPrefix)] = *state
+	return nil
+}
+
+// stateKey generates the map key for a set and metadata format.
+func stateKey(set, metadataPrefix string) string {
+	return metadataPrefix + "|" + set
+}
@@ -0,0 +1,93 @@
+package harvest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+type SQLRepository struct {
+	db database.Executor
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based harvest state repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// Get retrieves the state for a set and metadata format.
+func (r *SQLRepository) Get(ctx context.Context, set, metadataPrefix string) (*State, error) {
+	query := `
+		SELECT set_spec, metadata_prefix, watermark, resumption_token, run_started_at, records_harvested, updated_at
+		FROM harvest_state
+		WHERE set_spec = ? AND metadata_prefix = ?
+		LIMIT 1
+	`
+
+	var state State
+	var watermark, runStartedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, set, metadataPrefix).Scan(
+		&state.Set,
+		&state.MetadataPrefix,
+		&watermark,
+		&state.ResumptionToken,
+		&runStartedAt,
+		&state.RecordsHarvested,
+		&state.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrStateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get harvest state: %w", err)
+	}
+
+	state.Watermark = watermark.Time
+	state.RunStartedAt = runStartedAt.Time
+	return &state, nil
+}
+
+// Save creates or replaces the state.
+func (r *SQLRepository) Save(ctx context.Context, state *State) error {
+	query := `
+		INSERT INTO harvest_state
+			(set_spec, metadata_prefix, watermark, resumption_token, run_started_at, records_harvested, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			watermark = VALUES(watermark),
+			resumption_token = VALUES(resumption_token),
+			run_started_at = VALUES(run_started_at),
+			records_harvested = VALUES(records_harvested),
+			updated_at = VALUES(updated_at)
+	`
+
+	state.UpdatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		state.Set,
+		state.MetadataPrefix,
+		nullTime(state.Watermark),
+		state.ResumptionToken,
+		nullTime(state.RunStartedAt),
+		state.RecordsHarvested,
+		state.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save harvest state: %w", err)
+	}
+	return nil
+}
+
+// nullTime maps the zero time to NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,9 @@
+package history
+
+import "errors"
+
+// Common errors for reading history repository operations.
+var (
+	// ErrEntryNotFound is returned when a user has no history for a paper.
+	ErrEntryNotFound = errors.New("history entry not found")
+)
@@ -0,0 +1,43 @@
+package history
+
+import (
+	"context"
+	"time"
+)
+
+// Entry is a user's reading history for one paper.
+type Entry struct {
+	UserID        int64
+	PaperID       string // Base paper ID, without version
+	FirstViewedAt time.Time
+	LastViewedAt  time.Time
+	Views         int     // Reading sessions
+	DwellSeconds  int     // Total time spent reading
+	ScrollDepth   float64 // Furthest scroll position reached, 0 to 1
+	PDFPage       int     // Last PDF page reached (0 if the PDF was not opened)
+}
+
+// Repository defines the interface for reading history persistence.
+type Repository interface {
+	// Get retrieves a user's entry for a paper.
+	// Returns ErrEntryNotFound if the user has not read the paper.
+	Get(ctx context.Context, userID int64, paperID string) (*Entry, error)
+
+	// Save creates or replaces an entry.
+	Save(ctx context.Context, entry *Entry) error
+
+	// List returns a page of a user's entries, most recently viewed first
+	// (ties by paper ID), together with the user's total number of entries.
+	List(ctx context.Context, userID int64, offset, limit int) ([]*Entry, int, error)
+
+	// Seen returns which of the given papers a user has read.
+	// Papers not read are omitted.
+	Seen(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error)
+
+	// Delete removes a user's entry for a paper.
+	// Returns ErrEntryNotFound if there is none.
+	Delete(ctx context.Context, userID int64, paperID string) error
+
+	// Clear removes all of a user's entries and returns how many there were.
+	Clear(ctx context.Context, userID int64) (int, error)
+}
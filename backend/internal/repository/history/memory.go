@@ -0,0 +1,114 @@
+package history
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu      sync.RWMutex
+	entries map[int64]map[string]Entry // Keyed by user ID, then paper ID
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory reading history repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		entries: make(map[int64]map[string]Entry),
+	}
+}
+
+// Get retrieves a user's entry for a paper.
+func (r *MemoryRepository) Get(ctx context.Context, userID int64, paperID string) (*Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, found := r.entries[userID][paperID]
+	if !found {
+		return nil, ErrEntryNotFound
+	}
+	return &entry, nil
+}
+
+// Save creates or replaces an entry.
+func (r *MemoryRepository) Save(ctx context.Context, entry *Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.entries[entry.UserID]
+	if !ok {
+		user = make(map[string]Entry)
+		r.entries[entry.UserID] = user
+	}
+	user[entry.PaperID] = *entry
+	return nil
+}
+
+// List returns a page of a user's entries and their total number.
+func (r *MemoryRepository) List(ctx context.Context, userID int64, offset, limit int) ([]*Entry, int, error) {
+	r.mu.RLock()
+	all := make([]*Entry, 0, len(r.entries[userID]))
+	for _, e := range r.entries[userID] {
+		e := e
+		all = append(all, &e)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].LastViewedAt.Equal(all[j].LastViewedAt) {
+			return all[i].LastViewedAt.After(all[j].LastViewedAt)
+		}
+		return all[i].PaperID > all[j].PaperID
+	})
+
+	total := len(all)
+	if offset >= total {
+		return []*Entry{}, total, nil
+	}
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return all[offset:end], total, nil
+}
+
+// Seen returns which of the given papers a user has read.
+func (r *MemoryRepository) Seen(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	for _, id := range paperIDs {
+		if _, ok := r.entries[userID][id]; ok {
+			seen[id] = true
+		}
+	}
+	return seen, nil
+}
+
+// Delete removes a user's entry for a paper.
+func (r *MemoryRepository) Delete(ctx context.Context, userID int64, paperID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.entries[userID][paperID]; !found {
+		return ErrEntryNotFound
+	}
+	delete(r.entries[userID], paperID)
+	return nil
+}
+
+// Clear removes all of a user's entries.
+func (r *MemoryRepository) Clear(ctx context.Context, userID int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(r.entries[userID])
+	delete(r.entries, userID)
+	return n, nil
+}
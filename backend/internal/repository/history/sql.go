@@ -0,0 +1,202 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+type SQLRepository struct {
+	db database.Executor
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based reading history repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// entryColumns lists the columns scanned by scanEntry, in order.
+const entryColumns = `user_id, paper_id, first_viewed_at, last_viewed_at, views, dwell_seconds, scroll_depth, pdf_page`
+
+// Get retrieves a user's entry for a paper.
+func (r *SQLRepository) Get(ctx context.Context, userID int64, paperID string) (*Entry, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+entryColumns+` FROM reading_history WHERE user_id = ? AND paper_id = ?`,
+		userID, paperID,
+	)
+	entry, err := scanEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrEntryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history entry: %w", err)
+	}
+	return entry, nil
+}
+
+// Save creates or replaces an entry.
+func (r *SQLRepository) Save(ctx context.Context, entry *Entry) error {
+	query := `
+		INSERT INTO reading_history (` + entryColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			first_viewed_at = VALUES(first_viewed_at),
+			last_viewed_at = VALUES(last_viewed_at),
+			views = VALUES(views),
+			dwell_seconds = VALUES(dwell_seconds),
+			scroll_depth = VALUES(scroll_depth),
+			pdf_page = VALUES(pdf_page)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		entry.UserID,
+		entry.PaperID,
+		entry.FirstViewedAt,
+		entry.LastViewedAt,
+		entry.Views,
+		entry.DwellSeconds,
+		entry.ScrollDepth,
+		entry.PDFPage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save history entry: %w", err)
+	}
+	return nil
+}
+
+// List returns a page of a user's entries and their total number.
+func (r *SQLRepository) List(ctx context.Context, userID int64, offset, limit int) ([]*Entry, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM reading_history WHERE user_id = ?`, userID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count history entries: %w", err)
+	}
+	if limit <= 0 {
+		limit = total
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM reading_history
+		WHERE user_id = ?
+		ORDER BY last_viewed_at DESC, paper_id DESC
+		LIMIT ? OFFSET ?
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list history entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*Entry{}
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan history entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate history entries: %w", err)
+	}
+	return entries, total, nil
+}
+
+// Seen returns which of the given papers a user has read.
+func (r *SQLRepository) Seen(ctx context.Context, userID int64, paperIDs []string) (map[string]bool, error) {
+	seen := make(map[string]bool)
+	if len(paperIDs) == 0 {
+		return seen, nil
+	}
+
+	args := make([]interface{}, 0, len(paperIDs)+1)
+	args = append(args, userID)
+	for _, id := range paperIDs {
+		args = append(args, id)
+	}
+	in := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(paperIDs)), ", ") + ")"
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT paper_id FROM reading_history WHERE user_id = ? AND paper_id IN `+in, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query seen papers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan seen paper: %w", err)
+		}
+		seen[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate seen papers: %w", err)
+	}
+	return seen, nil
+}
+
+// Delete removes a user's entry for a paper.
+func (r *SQLRepository) Delete(ctx context.Context, userID int64, paperID string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM reading_history WHERE user_id = ? AND paper_id = ?`,
+		userID, paperID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete history entry: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete history entry: %w", err)
+	}
+	if n == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+// Clear removes all of a user's entries.
+func (r *SQLRepository) Clear(ctx context.Context, userID int64) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM reading_history WHERE user_id = ?`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear history: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear history: %w", err)
+	}
+	return int(n), nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEntry scans a row selected with entryColumns.
+func scanEntry(row scanner) (*Entry, error) {
+	var e Entry
+	err := row.Scan(
+		&e.UserID,
+		&e.PaperID,
+		&e.FirstViewedAt,
+		&e.LastViewedAt,
+		&e.Views,
+		&e.DwellSeconds,
+		&e.ScrollDepth,
+		&e.PDFPage,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,39 @@
+package like
+
+import (
+	"context"
+	"time"
+)
+
+// Like is a user's like of a paper.
+type Like struct {
+	UserID    int64
+	PaperID   string // Base paper ID, without version
+	CreatedAt time.Time
+}
+
+// Repository defines the interface for likes and per-paper like counts.
+// Counts are kept alongside the likes, so reading them for a page of papers
+// costs a single lookup rather than one count per paper.
+type Repository interface {
+	// Add records a like and increments the paper's count.
+	// Returns false, changing nothing, if the user already likes the paper.
+	Add(ctx context.Context, l *Like) (bool, error)
+
+	// Remove deletes a like and decrements the paper's count.
+	// Returns false, changing nothing, if the user does not like the paper.
+	Remove(ctx context.Context, userID int64, paperID string) (bool, error)
+
+	// Counts returns the like counts of the given papers.
+	// Papers without likes are omitted.
+	Counts(ctx context.Context, paperIDs []string) (map[string]int, error)
+
+	// Find returns the given papers that a user likes, keyed by paper ID.
+	Find(ctx context.Context, userID int64, paperIDs []string) (map[string]*Like, error)
+
+	// ListByUser returns a user's most recent likes, newest first.
+	ListByUser(ctx context.Context, userID int64, limit int) ([]*Like, error)
+
+	// ListSince returns every user's likes made at or after since, oldest first.
+	ListSince(ctx context.Context, since time.Time) ([]*Like, error)
+}
@@ -0,0 +1,137 @@
+package like
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	likes  map[int64]map[string]time.Time // Keyed by user ID, then paper ID
+	counts map[string]int                 // Keyed by paper ID
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory like repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		likes:  make(map[int64]map[string]time.Time),
+		counts: make(map[string]int),
+	}
+}
+
+// Add records a like and increments the paper's count.
+func (r *MemoryRepository) Add(ctx context.Context, l *Like) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.likes[l.UserID]
+	if !ok {
+		user = make(map[string]time.Time)
+		r.likes[l.UserID] = user
+	}
+	if _, found := user[l.PaperID]; found {
+		return false, nil
+	}
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+	user[l.PaperID] = l.CreatedAt
+	r.counts[l.PaperID]++
+	return true, nil
+}
+
+// Remove deletes a like and decrements the paper's count.
+func (r *MemoryRepository) Remove(ctx context.Context, userID int64, paperID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.likes[userID][paperID]; !found {
+		return false, nil
+	}
+	delete(r.likes[userID], paperID)
+	if r.counts[paperID]--; r.counts[paperID] <= 0 {
+		delete(r.counts, paperID)
+	}
+	return true, nil
+}
+
+// Counts returns the like counts of the given papers.
+func (r *MemoryRepository) Counts(ctx context.Context, paperIDs []string) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, id := range paperIDs {
+		if n := r.counts[id]; n > 0 {
+			counts[id] = n
+		}
+	}
+	return counts, nil
+}
+
+// Find returns the given papers that a user likes.
+func (r *MemoryRepository) Find(ctx context.Context, userID int64, paperIDs []string) (map[string]*Like, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := make(map[string]*Like)
+	for _, id := range paperIDs {
+		if at, ok := r.likes[userID][id]; ok {
+			found[id] = &Like{UserID: userID, PaperID: id, CreatedAt: at}
+		}
+	}
+	return found, nil
+}
+
+// ListByUser returns a user's most recent likes, newest first.
+func (r *MemoryRepository) ListByUser(ctx context.Context, userID int64, limit int) ([]*Like, error) {
+	r.mu.RLock()
+	likes := make([]*Like, 0, len(r.likes[userID]))
+	for id, at := range r.likes[userID] {
+		likes = append(likes, &Like{UserID: userID, PaperID: id, CreatedAt: at})
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(likes, func(i, j int) bool {
+		if !likes[i].CreatedAt.Equal(likes[j].CreatedAt) {
+			return likes[i].CreatedAt.After(likes[j].CreatedAt)
+		}
+		return likes[i].PaperID > likes[j].PaperID
+	})
+	if limit > 0 && len(likes) > limit {
+		likes = likes[:limit]
+	}
+	return likes, nil
+}
+
+// ListSince returns every user's likes made at or after since, oldest first.
+func (r *MemoryRepository) ListSince(ctx context.Context, since time.Time) ([]*Like, error) {
+	r.mu.RLock()
+	likes := []*Like{}
+	for userID, user := range r.likes {
+		for id, at := range user {
+			if !at.Before(since) {
+				likes = append(likes, &Like{UserID: userID, PaperID: id, CreatedAt: at})
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(likes, func(i, j int) bool {
+		if !likes[i].CreatedAt.Equal(likes[j].CreatedAt) {
+			return likes[i].CreatedAt.Before(likes[j].CreatedAt)
+		}
+		if likes[i].PaperID != likes[j].PaperID {
+			return likes[i].PaperID < likes[j].PaperID
+		}
+		return likes[i].UserID < likes[j].UserID
+	})
+	return likes, nil
+}
@@ -0,0 +1,210 @@
+package like
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+// Like counts are maintained in paper_like_counts in the same transaction
+// as the likes themselves.
+type SQLRepository struct {
+	db database.DB
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based like repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// Add records a like and increments the paper's count.
+func (r *SQLRepository) Add(ctx context.Context, l *Like) (bool, error) {
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT IGNORE INTO likes (user_id, paper_id, created_at) VALUES (?, ?, ?)`,
+		l.UserID, l.PaperID, l.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to add like: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO paper_like_counts (paper_id, like_count) VALUES (?, 1)
+		ON DUPLICATE KEY UPDATE like_count = like_count + 1
+	`, l.PaperID)
+	if err != nil {
+		return false, fmt.Errorf("failed to increment like count: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit like: %w", err)
+	}
+	return true, nil
+}
+
+// Remove deletes a like and decrements the paper's count.
+func (r *SQLRepository) Remove(ctx context.Context, userID int64, paperID string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM likes WHERE user_id = ? AND paper_id = ?`,
+		userID, paperID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove like: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE paper_like_counts SET like_count = like_count - 1 WHERE paper_id = ? AND like_count > 0`,
+		paperID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to decrement like count: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit unlike: %w", err)
+	}
+	return true, nil
+}
+
+// Counts returns the like counts of the given papers.
+func (r *SQLRepository) Counts(ctx context.Context, paperIDs []string) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(paperIDs) == 0 {
+		return counts, nil
+	}
+
+	args := make([]interface{}, len(paperIDs))
+	for i, id := range paperIDs {
+		args[i] = id
+	}
+	in := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(paperIDs)), ", ") + ")"
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT paper_id, like_count FROM paper_like_counts WHERE like_count > 0 AND paper_id IN `+in, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query like counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan like count: %w", err)
+		}
+		counts[id] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate like counts: %w", err)
+	}
+	return counts, nil
+}
+
+// Find returns the given papers that a user likes.
+func (r *SQLRepository) Find(ctx context.Context, userID int64, paperIDs []string) (map[string]*Like, error) {
+	found := make(map[string]*Like)
+	if len(paperIDs) == 0 {
+		return found, nil
+	}
+
+	args := make([]interface{}, 0, len(paperIDs)+1)
+	args = append(args, userID)
+	for _, id := range paperIDs {
+		args = append(args, id)
+	}
+	in := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(paperIDs)), ", ") + ")"
+
+	likes, err := r.queryLikes(ctx,
+		`SELECT user_id, paper_id, created_at FROM likes WHERE user_id = ? AND paper_id IN `+in, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find likes: %w", err)
+	}
+	for _, l := range likes {
+		found[l.PaperID] = l
+	}
+	return found, nil
+}
+
+// ListByUser returns a user's most recent likes, newest first.
+func (r *SQLRepository) ListByUser(ctx context.Context, userID int64, limit int) ([]*Like, error) {
+	query := `
+		SELECT user_id, paper_id, created_at
+		FROM likes
+		WHERE user_id = ?
+		ORDER BY created_at DESC, paper_id DESC
+	`
+	args := []interface{}{userID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	likes, err := r.queryLikes(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list likes: %w", err)
+	}
+	return likes, nil
+}
+
+// ListSince returns every user's likes made at or after since, oldest first.
+func (r *SQLRepository) ListSince(ctx context.Context, since time.Time) ([]*Like, error) {
+	likes, err := r.queryLikes(ctx, `
+		SELECT user_id, paper_id, created_at
+		FROM likes
+		WHERE created_at >= ?
+		ORDER BY created_at, paper_id, user_id
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list likes: %w", err)
+	}
+	return likes, nil
+}
+
+// queryLikes runs a query selecting user_id, paper_id and created_at.
+func (r *SQLRepository) queryLikes(ctx context.Context, query string, args ...interface{}) ([]*Like, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	likes := []*Like{}
+	for rows.Next() {
+		var l Like
+		if err := rows.Scan(&l.UserID, &l.PaperID, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		likes = append(likes, &l)
+	}
+	return likes, rows.Err()
+}
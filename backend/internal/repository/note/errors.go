@@ -0,0 +1,9 @@
+package note
+
+import "errors"
+
+// Common errors for note repository operations.
+var (
+	// ErrNoteNotFound is returned when a note does not exist.
+	ErrNoteNotFound = errors.New("note not found")
+)
@@ -0,0 +1,64 @@
+package note
+
+import (
+	"context"
+	"time"
+)
+
+// Anchor types of a note.
+const (
+	AnchorNone     = ""         // A note on the paper as a whole
+	AnchorAbstract = "abstract" // A range of the abstract text
+	AnchorPDF      = "pdf"      // A region of a PDF page
+)
+
+// Rect is a region of a PDF page, as fractions of the page size measured
+// from the top-left corner.
+type Rect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Note is a user's markdown note or highlight on a paper.
+type Note struct {
+	ID         int64
+	UserID     int64
+	PaperID    string // Base paper ID, without version
+	Body       string // Markdown
+	AnchorType string
+	Start      int    // Abstract anchors: first character, counted in runes
+	End        int    // Abstract anchors: end of the range (exclusive)
+	Quote      string // Abstract anchors: the highlighted text
+	Page       int    // PDF anchors: 1-based page number
+	Rects      []Rect // PDF anchors: highlighted regions of the page
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Repository defines the interface for note persistence.
+type Repository interface {
+	// Create stores a new note and sets its ID and timestamps.
+	Create(ctx context.Context, n *Note) error
+
+	// Get retrieves a note by ID.
+	// Returns ErrNoteNotFound if there is none.
+	Get(ctx context.Context, id int64) (*Note, error)
+
+	// ListByPaper returns a user's notes on a paper, oldest first.
+	ListByPaper(ctx context.Context, userID int64, paperID string) ([]*Note, error)
+
+	// Update saves a note's body and anchor and sets its update time.
+	// Returns ErrNoteNotFound if there is none.
+	Update(ctx context.Context, n *Note) error
+
+	// Delete removes a note.
+	// Returns ErrNoteNotFound if there is none.
+	Delete(ctx context.Context, id int64) error
+
+	// Search returns a page of a user's notes whose body or quote contains
+	// every term, ignoring case, most recently updated first, together with
+	// the total number of matches.
+	Search(ctx context.Context, userID int64, terms []string, offset, limit int) ([]*Note, int, error)
+}
@@ -0,0 +1,161 @@
+package note
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	notes  map[int64]Note
+	nextID int64
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory note repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		notes: make(map[int64]Note),
+	}
+}
+
+// Create stores a new note.
+func (r *MemoryRepository) Create(ctx context.Context, n *Note) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	now := time.Now()
+	n.ID = r.nextID
+	n.CreatedAt = now
+	n.UpdatedAt = now
+	r.notes[n.ID] = copyNote(n)
+	return nil
+}
+
+// Get retrieves a note by ID.
+func (r *MemoryRepository) Get(ctx context.Context, id int64) (*Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n, found := r.notes[id]
+	if !found {
+		return nil, ErrNoteNotFound
+	}
+	n = copyNote(&n)
+	return &n, nil
+}
+
+// ListByPaper returns a user's notes on a paper, oldest first.
+func (r *MemoryRepository) ListByPaper(ctx context.Context, userID int64, paperID string) ([]*Note, error) {
+	r.mu.RLock()
+	notes := []*Note{}
+	for _, n := range r.notes {
+		if n.UserID == userID && n.PaperID == paperID {
+			n = copyNote(&n)
+			notes = append(notes, &n)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(notes, func(i, j int) bool {
+		if !notes[i].CreatedAt.Equal(notes[j].CreatedAt) {
+			return notes[i].CreatedAt.Before(notes[j].CreatedAt)
+		}
+		return notes[i].ID < notes[j].ID
+	})
+	return notes, nil
+}
+
+// Update saves a note's body and anchor.
+func (r *MemoryRepository) Update(ctx context.Context, n *Note) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, found := r.notes[n.ID]
+	if !found {
+		return ErrNoteNotFound
+	}
+	n.UserID = stored.UserID
+	n.PaperID = stored.PaperID
+	n.CreatedAt = stored.CreatedAt
+	n.UpdatedAt = time.Now()
+	r.notes[n.ID] = copyNote(n)
+	return nil
+}
+
+// Delete removes a note.
+func (r *MemoryRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.notes[id]; !found {
+		return ErrNoteNotFound
+	}
+	delete(r.notes, id)
+	return nil
+}
+
+// Search returns a page of a user's notes containing every term.
+func (r *MemoryRepository) Search(ctx context.Context, userID int64, terms []string, offset, limit int) ([]*Note, int, error) {
+	lowered := make([]string, len(terms))
+	for i, term := range terms {
+		lowered[i] = strings.ToLower(term)
+	}
+
+	r.mu.RLock()
+	matches := []*Note{}
+	for _, n := range r.notes {
+		if n.UserID == userID && containsAll(n, lowered) {
+			n = copyNote(&n)
+			matches = append(matches, &n)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].UpdatedAt.Equal(matches[j].UpdatedAt) {
+			return matches[i].UpdatedAt.After(matches[j].UpdatedAt)
+		}
+		return matches[i].ID > matches[j].ID
+	})
+
+	total := len(matches)
+	if offset >= total {
+		return []*Note{}, total, nil
+	}
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return matches[offset:end], total, nil
+}
+
+// containsAll reports whether a note's body or quote contains every
+// lower-cased term.
+func containsAll(n Note, terms []string) bool {
+	body := strings.ToLower(n.Body)
+	quote := strings.ToLower(n.Quote)
+	for _, term := range terms {
+		if !strings.Contains(body, term) && !strings.Contains(quote, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// copyNote returns a copy of a note that shares no slices with it.
+func copyNote(n *Note) Note {
+	c := *n
+	if n.Rects != nil {
+		c.Rects = append([]Rect(nil), n.Rects...)
+	}
+	return c
+}
@@ -0,0 +1,237 @@
+package note
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+type SQLRepository struct {
+	db database.DB
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based note repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// noteColumns lists the columns scanned by scanNote, in order.
+const noteColumns = `id, user_id, paper_id, body, anchor_type, text_start, text_end, quote, pdf_page, rects, created_at, updated_at`
+
+// likeEscaper escapes the LIKE wildcards in a search term.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Create stores a new note.
+func (r *SQLRepository) Create(ctx context.Context, n *Note) error {
+	rects, err := encodeRects(n.Rects)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	n.CreatedAt = now
+	n.UpdatedAt = now
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO notes (user_id, paper_id, body, anchor_type, text_start, text_end, quote, pdf_page, rects, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, n.UserID, n.PaperID, n.Body, n.AnchorType, n.Start, n.End, n.Quote, n.Page, rects, n.CreatedAt, n.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+	n.ID = id
+	return nil
+}
+
+// Get retrieves a note by ID.
+func (r *SQLRepository) Get(ctx context.Context, id int64) (*Note, error) {
+	n, err := scanNote(r.db.QueryRowContext(ctx, `SELECT `+noteColumns+` FROM notes WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNoteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+	return n, nil
+}
+
+// ListByPaper returns a user's notes on a paper, oldest first.
+func (r *SQLRepository) ListByPaper(ctx context.Context, userID int64, paperID string) ([]*Note, error) {
+	return r.queryNotes(ctx, `
+		SELECT `+noteColumns+`
+		FROM notes
+		WHERE user_id = ? AND paper_id = ?
+		ORDER BY created_at, id
+	`, userID, paperID)
+}
+
+// Update saves a note's body and anchor.
+func (r *SQLRepository) Update(ctx context.Context, n *Note) error {
+	rects, err := encodeRects(n.Rects)
+	if err != nil {
+		return err
+	}
+	n.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notes
+		SET body = ?, anchor_type = ?, text_start = ?, text_end = ?, quote = ?, pdf_page = ?, rects = ?, updated_at = ?
+		WHERE id = ?
+	`, n.Body, n.AnchorType, n.Start, n.End, n.Quote, n.Page, rects, n.UpdatedAt, n.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+	if affected == 0 {
+		// Identical updates affect no rows; see collection.SQLRepository.Update.
+		return r.exists(ctx, n.ID)
+	}
+	return nil
+}
+
+// exists returns ErrNoteNotFound if there is no note with the ID.
+func (r *SQLRepository) exists(ctx context.Context, id int64) error {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notes WHERE id = ?`, id).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check note: %w", err)
+	}
+	if count == 0 {
+		return ErrNoteNotFound
+	}
+	return nil
+}
+
+// Delete removes a note.
+func (r *SQLRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM notes WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+	if n == 0 {
+		return ErrNoteNotFound
+	}
+	return nil
+}
+
+// Search returns a page of a user's notes containing every term.
+func (r *SQLRepository) Search(ctx context.Context, userID int64, terms []string, offset, limit int) ([]*Note, int, error) {
+	// The column collation is case-insensitive, so LIKE ignores case.
+	where := "user_id = ?"
+	args := []interface{}{userID}
+	for _, term := range terms {
+		pattern := "%" + likeEscaper.Replace(term) + "%"
+		where += " AND (body LIKE ? OR quote LIKE ?)"
+		args = append(args, pattern, pattern)
+	}
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notes WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count matching notes: %w", err)
+	}
+	if limit <= 0 {
+		limit = total
+	}
+
+	notes, err := r.queryNotes(ctx, `
+		SELECT `+noteColumns+`
+		FROM notes
+		WHERE `+where+`
+		ORDER BY updated_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notes, total, nil
+}
+
+// queryNotes runs a query selecting noteColumns.
+func (r *SQLRepository) queryNotes(ctx context.Context, query string, args ...interface{}) ([]*Note, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := []*Note{}
+	for rows.Next() {
+		n, err := scanNote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
+		}
+		notes = append(notes, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notes: %w", err)
+	}
+	return notes, nil
+}
+
+// encodeRects encodes PDF rectangles for the rects column.
+func encodeRects(rects []Rect) (sql.NullString, error) {
+	if len(rects) == 0 {
+		return sql.NullString{}, nil
+	}
+	encoded, err := json.Marshal(rects)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode note rects: %w", err)
+	}
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanNote scans a row selected with noteColumns.
+func scanNote(row scanner) (*Note, error) {
+	var n Note
+	var rects sql.NullString
+	err := row.Scan(
+		&n.ID,
+		&n.UserID,
+		&n.PaperID,
+		&n.Body,
+		&n.AnchorType,
+		&n.Start,
+		&n.End,
+		&n.Quote,
+		&n.Page,
+		&rects,
+		&n.CreatedAt,
+		&n.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if rects.Valid {
+		if err := json.Unmarshal([]byte(rects.String), &n.Rects); err != nil {
+			return nil, fmt.Errorf("failed to decode note rects: %w", err)
+		}
+	}
+	return &n, nil
+}
@@ -8,8 +8,10 @@ import (
 // Paper represents a paper entity in the repository layer.
 type Paper struct {
 	ID              string
+	Version         int
 	Title           string
 	Authors         []string
+	AuthorDetails   []AuthorDetail
 	Summary         string
 	Published       time.Time
 	Updated         time.Time
@@ -18,18 +20,58 @@ type Paper struct {
 	ArxivURL        string
 	PDFURL          string
 	ImageURL        string
+	DOI             string
+	JournalRef      string
+	Comment         string
+	License         string
+	Versions        []Version // Version history, oldest first (empty if unknown)
+}
+
+// AuthorDetail is an author together with their stated affiliations.
+type AuthorDetail struct {
+	Name         string
+	Affiliations []string
+}
+
+// Version is one submitted version of a paper.
+type Version struct {
+	Version   int
+	Submitted time.Time
+}
+
+// PaperList is a page of papers together with the total number available.
+type PaperList struct {
+	Papers []*Paper
+	Total  int // Total number of papers available (upstream, or matching a Query), not just in this page
+}
+
+// DefaultQueryLimit is the page size used when a Query has no limit.
+const DefaultQueryLimit = 20
+
+// Query selects stored papers. Empty fields do not filter.
+// Results are ordered by Updated, newest first, then by ID.
+type Query struct {
+	Category    string    // Listed in this category (e.g., "cs.AI")
+	Author      string    // Written by an author with this name (case-insensitive)
+	From        time.Time // Updated on or after this time
+	Until       time.Time // Updated on or before this time
+	StoredSince time.Time // Stored or changed on or after this time, by the repository's clock
+	Limit       int       // Page size (DefaultQueryLimit if zero)
+	Offset      int
 }
 
 // Repository defines the interface for paper data access.
 // This abstraction allows for different storage implementations
 // (memory, database, etc.)
 type Repository interface {
-	// GetByCategory retrieves papers by category.
+	// GetByCategory retrieves a cached feed page. The key identifies the
+	// page: a category, or a key derived from the full feed request.
 	// Returns cached papers if available, otherwise returns nil.
-	GetByCategory(ctx context.Context, category string) ([]*Paper, bool)
+	GetByCategory(ctx context.Context, category string) (*PaperList, bool)
 
-	// SaveByCategory stores papers for a category with TTL.
-	SaveByCategory(ctx context.Context, category string, papers []*Paper, ttl time.Duration)
+	// SaveByCategory stores a feed page under its key with TTL, and stores
+	// each of its papers as Save does.
+	SaveByCategory(ctx context.Context, category string, list *PaperList, ttl time.Duration)
 
 	// GetByID retrieves a single paper by ID.
 	GetByID(ctx context.Context, id string) (*Paper, bool)
@@ -37,6 +79,15 @@ type Repository interface {
 	// Save stores a single paper.
 	Save(ctx context.Context, paper *Paper, ttl time.Duration)
 
+	// Upsert stores papers permanently, replacing stored papers with the same ID.
+	// Unlike Save, upserted papers do not expire; it is used for bulk ingestion.
+	Upsert(ctx context.Context, papers []*Paper) error
+
+	// Find lists stored papers matching the query, one page at a time.
+	// Only papers stored permanently (through Upsert, or persisted by a
+	// database-backed implementation) are searched, not cached ones.
+	Find(ctx context.Context, q *Query) (*PaperList, error)
+
 	// InvalidateCategory removes cached papers for a category.
 	InvalidateCategory(ctx context.Context, category string)
 
@@ -3,14 +3,23 @@ package paper
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/rrlian/papertok/backend/internal/infra/cache"
 )
 
 // MemoryRepository implements Repository using in-memory cache.
+// Upserted papers are kept in a separate map that never expires,
+// so they live as long as the process.
 type MemoryRepository struct {
 	cache cache.Cache
+
+	mu       sync.RWMutex
+	stored   map[string]*Paper
+	storedAt map[string]time.Time
 }
 
 // Ensure MemoryRepository implements Repository interface
@@ -19,46 +28,51 @@ var _ Repository = (*MemoryRepository)(nil)
 // NewMemoryRepository creates a new memory-based paper repository.
 func NewMemoryRepository(c cache.Cache) *MemoryRepository {
 	return &MemoryRepository{
-		cache: c,
+		cache:    c,
+		stored:   make(map[string]*Paper),
+		storedAt: make(map[string]time.Time),
 	}
 }
 
 // GetByCategory retrieves papers by category from cache.
-func (r *MemoryRepository) GetByCategory(ctx context.Context, category string) ([]*Paper, bool) {
+func (r *MemoryRepository) GetByCategory(ctx context.Context, category string) (*PaperList, bool) {
 	key := r.categoryKey(category)
 	value, found := r.cache.Get(key)
 	if !found {
 		return nil, false
 	}
 
-	papers, ok := value.([]*Paper)
+	list, ok := value.(*PaperList)
 	if !ok {
 		return nil, false
 	}
 
-	return papers, true
+	return list, true
 }
 
-// SaveByCategory stores papers for a category in cache.
-func (r *MemoryRepository) SaveByCategory(ctx context.Context, category string, papers []*Paper, ttl time.Duration) {
+// SaveByCategory stores papers for a category in cache, and caches each
+// paper by ID.
+func (r *MemoryRepository) SaveByCategory(ctx context.Context, category string, list *PaperList, ttl time.Duration) {
 	key := r.categoryKey(category)
-	r.cache.Set(key, papers, ttl)
+	r.cache.Set(key, list, ttl)
+	for _, paper := range list.Papers {
+		r.Save(ctx, paper, ttl)
+	}
 }
 
 // GetByID retrieves a single paper by ID from cache.
 func (r *MemoryRepository) GetByID(ctx context.Context, id string) (*Paper, bool) {
 	key := r.paperKey(id)
-	value, found := r.cache.Get(key)
-	if !found {
-		return nil, false
+	if value, found := r.cache.Get(key); found {
+		if paper, ok := value.(*Paper); ok {
+			return paper, true
+		}
 	}
 
-	paper, ok := value.(*Paper)
-	if !ok {
-		return nil, false
-	}
-
-	return paper, true
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	paper, found := r.stored[id]
+	return paper, found
 }
 
 // Save stores a single paper in cache.
@@ -67,13 +81,56 @@ func (r *MemoryRepository) Save(ctx context.Context, paper *Paper, ttl time.Dura
 	r.cache.Set(key, paper, ttl)
 }
 
+// Upsert stores papers without expiry.
+func (r *MemoryRepository) Upsert(ctx context.Context, papers []*Paper) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, paper := range papers {
+		r.stored[paper.ID] = paper
+		r.storedAt[paper.ID] = now
+	}
+	return nil
+}
+
+// Find lists upserted papers matching the query.
+func (r *MemoryRepository) Find(ctx context.Context, q *Query) (*PaperList, error) {
+	r.mu.RLock()
+	matched := make([]*Paper, 0)
+	for id, paper := range r.stored {
+		if !q.StoredSince.IsZero() && r.storedAt[id].Before(q.StoredSince) {
+			continue
+		}
+		if matchesQuery(paper, q) {
+			matched = append(matched, paper)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].Updated.Equal(matched[j].Updated) {
+			return matched[i].Updated.After(matched[j].Updated)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	start := min(max(q.Offset, 0), len(matched))
+	end := min(start+limit, len(matched))
+	return &PaperList{Papers: matched[start:end], Total: len(matched)}, nil
+}
+
 // InvalidateCategory removes cached papers for a category.
 func (r *MemoryRepository) InvalidateCategory(ctx context.Context, category string) {
 	key := r.categoryKey(category)
 	r.cache.Delete(key)
 }
 
-// Clear removes all cached papers.
+// Clear removes all cached papers. Upserted papers are kept.
 func (r *MemoryRepository) Clear(ctx context.Context) {
 	r.cache.Clear()
 }
@@ -87,3 +144,30 @@ func (r *MemoryRepository) categoryKey(category string) string {
 func (r *MemoryRepository) paperKey(id string) string {
 	return fmt.Sprintf("papers:id:%s", id)
 }
+
+// matchesQuery reports whether a paper satisfies every filter of the query.
+func matchesQuery(paper *Paper, q *Query) bool {
+	if !q.From.IsZero() && paper.Updated.Before(q.From) {
+		return false
+	}
+	if !q.Until.IsZero() && paper.Updated.After(q.Until) {
+		return false
+	}
+	if q.Category != "" && !containsFold(paper.Categories, q.Category, false) {
+		return false
+	}
+	if q.Author != "" && !containsFold(paper.Authors, q.Author, true) {
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether values contains target, optionally ignoring case.
+func containsFold(values []string, target string, ignoreCase bool) bool {
+	for _, v := range values {
+		if v == target || (ignoreCase && strings.EqualFold(v, target)) {
+			return true
+		}
+	}
+	return false
+}
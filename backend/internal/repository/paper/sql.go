@@ -0,0 +1,521 @@
+package paper
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/cache"
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// paperColumns are the papers columns read by every query, in scan order.
+const paperColumns = `p.id, p.version, p.title, p.summary, p.published, p.updated, p.primary_category,
+	p.arxiv_url, p.pdf_url, p.image_url, p.doi, p.journal_ref, p.comment, p.license`
+
+// maxAuthorNameLength is the length of authors.name.
+const maxAuthorNameLength = 255
+
+// maxRowsPerStatement bounds multi-row statements, keeping large batches well
+// within MySQL's limit of 65535 placeholders.
+const maxRowsPerStatement = 500
+
+// SQLRepository implements Repository using SQL database for papers and
+// an in-memory cache for category pages.
+// Papers passed to Save are persisted as well as cached, so everything
+// shown to users survives a restart.
+type SQLRepository struct {
+	db     database.DB
+	cached *MemoryRepository
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based paper repository.
+func NewSQLRepository(db database.DB, c cache.Cache) *SQLRepository {
+	return &SQLRepository{
+		db:     db,
+		cached: NewMemoryRepository(c),
+	}
+}
+
+// GetByCategory retrieves a cached category page.
+func (r *SQLRepository) GetByCategory(ctx context.Context, category string) (*PaperList, bool) {
+	return r.cached.GetByCategory(ctx, category)
+}
+
+// SaveByCategory caches a category page and its papers, and persists the
+// papers in one transaction. As with Save, a failed write is logged and
+// leaves them cached.
+func (r *SQLRepository) SaveByCategory(ctx context.Context, category string, list *PaperList, ttl time.Duration) {
+	r.cached.SaveByCategory(ctx, category, list, ttl)
+	if err := r.Upsert(ctx, list.Papers); err != nil {
+		log.Printf("Failed to store %d papers of %s: %v", len(list.Papers), category, err)
+	}
+}
+
+// GetByID retrieves a paper from cache, then from the database.
+func (r *SQLRepository) GetByID(ctx context.Context, id string) (*Paper, bool) {
+	if paper, found := r.cached.GetByID(ctx, id); found {
+		return paper, true
+	}
+
+	query := `SELECT ` + paperColumns + ` FROM papers p WHERE p.id = ? LIMIT 1`
+	papers, err := r.queryPapers(ctx, query, id)
+	if err != nil || len(papers) == 0 {
+		return nil, false
+	}
+	return papers[0], true
+}
+
+// Save caches a paper and persists it. The interface has no error return,
+// so a failed write is logged and only leaves the paper cached.
+func (r *SQLRepository) Save(ctx context.Context, paper *Paper, ttl time.Duration) {
+	r.cached.Save(ctx, paper, ttl)
+	if err := r.Upsert(ctx, []*Paper{paper}); err != nil {
+		log.Printf("Failed to store paper %s: %v", paper.ID, err)
+	}
+}
+
+// Upsert inserts or replaces papers in a single transaction. Authors and
+// categories of the whole batch are written with a few multi-row statements.
+// If a paper appears more than once, the last copy wins.
+func (r *SQLRepository) Upsert(ctx context.Context, papers []*Paper) error {
+	papers = lastByID(papers)
+	if len(papers) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, paper := range papers {
+		if err := r.upsertPaper(ctx, tx, paper); err != nil {
+			return err
+		}
+	}
+	if err := r.replaceCategories(ctx, tx, papers); err != nil {
+		return err
+	}
+	if err := r.replaceAuthors(ctx, tx, papers); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit papers: %w", err)
+	}
+	return nil
+}
+
+// Find lists stored papers matching the query.
+func (r *SQLRepository) Find(ctx context.Context, q *Query) (*PaperList, error) {
+	var conditions []string
+	var args []interface{}
+	if q.Category != "" {
+		conditions = append(conditions, "p.id IN (SELECT paper_id FROM paper_categories WHERE category = ?)")
+		args = append(args, q.Category)
+	}
+	if q.Author != "" {
+		conditions = append(conditions, `p.id IN (
+			SELECT pa.paper_id FROM paper_authors pa JOIN authors a ON a.id = pa.author_id WHERE a.name = ?)`)
+		args = append(args, q.Author)
+	}
+	if !q.From.IsZero() {
+		conditions = append(conditions, "p.updated >= ?")
+		args = append(args, q.From)
+	}
+	if !q.Until.IsZero() {
+		conditions = append(conditions, "p.updated <= ?")
+		args = append(args, q.Until)
+	}
+	if !q.StoredSince.IsZero() {
+		conditions = append(conditions, "p.updated_at >= ?")
+		args = append(args, q.StoredSince)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM papers p`+where, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count papers: %w", err)
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	query := `SELECT ` + paperColumns + ` FROM papers p` + where + ` ORDER BY p.updated DESC, p.id LIMIT ? OFFSET ?`
+	papers, err := r.queryPapers(ctx, query, append(args, limit, max(q.Offset, 0))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaperList{Papers: papers, Total: total}, nil
+}
+
+// InvalidateCategory removes a cached category page.
+func (r *SQLRepository) InvalidateCategory(ctx context.Context, category string) {
+	r.cached.InvalidateCategory(ctx, category)
+}
+
+// Clear removes all cached papers. Stored papers are kept.
+func (r *SQLRepository) Clear(ctx context.Context) {
+	r.cached.Clear(ctx)
+}
+
+// upsertPaper writes one paper and its version history.
+// The version history is only replaced when the paper carries one, so a
+// paper fetched from the search API does not erase a harvested history.
+func (r *SQLRepository) upsertPaper(ctx context.Context, tx *sql.Tx, paper *Paper) error {
+	query := `
+		INSERT INTO papers
+			(id, version, title, summary, published, updated, primary_category,
+			 arxiv_url, pdf_url, image_url, doi, journal_ref, comment, license)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			version = GREATEST(version, VALUES(version)),
+			title = VALUES(title),
+			summary = VALUES(summary),
+			published = COALESCE(VALUES(published), published),
+			updated = COALESCE(VALUES(updated), updated),
+			primary_category = VALUES(primary_category),
+			arxiv_url = VALUES(arxiv_url),
+			pdf_url = VALUES(pdf_url),
+			image_url = VALUES(image_url),
+			doi = VALUES(doi),
+			journal_ref = VALUES(journal_ref),
+			comment = VALUES(comment),
+			license = IF(VALUES(license) = '', license, VALUES(license))
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		paper.ID,
+		paper.Version,
+		paper.Title,
+		paper.Summary,
+		nullTime(paper.Published),
+		nullTime(paper.Updated),
+		paper.PrimaryCategory,
+		paper.ArxivURL,
+		paper.PDFURL,
+		paper.ImageURL,
+		paper.DOI,
+		paper.JournalRef,
+		paper.Comment,
+		paper.License,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert paper %s: %w", paper.ID, err)
+	}
+
+	if len(paper.Versions) > 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM paper_versions WHERE paper_id = ?`, paper.ID); err != nil {
+			return fmt.Errorf("failed to delete paper versions: %w", err)
+		}
+		values := make([]string, len(paper.Versions))
+		args := make([]interface{}, 0, 3*len(paper.Versions))
+		for i, v := range paper.Versions {
+			values[i] = "(?, ?, ?)"
+			args = append(args, paper.ID, v.Version, nullTime(v.Submitted))
+		}
+		query := `INSERT INTO paper_versions (paper_id, version, submitted) VALUES ` + strings.Join(values, ", ")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert paper versions: %w", err)
+		}
+	}
+	return nil
+}
+
+// replaceCategories rewrites the categories of papers, keeping their order.
+func (r *SQLRepository) replaceCategories(ctx context.Context, tx *sql.Tx, papers []*Paper) error {
+	if err := deleteByPaper(ctx, tx, "paper_categories", papers); err != nil {
+		return fmt.Errorf("failed to delete paper categories: %w", err)
+	}
+
+	var rows [][]interface{}
+	for _, paper := range papers {
+		seen := make(map[string]bool, len(paper.Categories))
+		for _, category := range paper.Categories {
+			if category == "" || seen[category] {
+				continue
+			}
+			seen[category] = true
+			rows = append(rows, []interface{}{paper.ID, category, len(seen)})
+		}
+	}
+
+	err := execChunked(ctx, tx, rows, func(n int) string {
+		return `INSERT INTO paper_categories (paper_id, category, position) VALUES ` + repeatJoined("(?, ?, ?)", ", ", n)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert paper categories: %w", err)
+	}
+	return nil
+}
+
+// replaceAuthors rewrites the authors of papers, creating unknown authors.
+// Authors are linked by name in the same statement that inserts the links,
+// so the database's case-insensitive name matching applies.
+func (r *SQLRepository) replaceAuthors(ctx context.Context, tx *sql.Tx, papers []*Paper) error {
+	if err := deleteByPaper(ctx, tx, "paper_authors", papers); err != nil {
+		return fmt.Errorf("failed to delete paper authors: %w", err)
+	}
+
+	var names, rows [][]interface{}
+	seen := make(map[string]bool)
+	for _, paper := range papers {
+		details := paper.AuthorDetails
+		if len(details) == 0 {
+			details = make([]AuthorDetail, len(paper.Authors))
+			for i, name := range paper.Authors {
+				details[i] = AuthorDetail{Name: name}
+			}
+		}
+
+		position := 0
+		for _, author := range details {
+			name := truncate(strings.TrimSpace(author.Name), maxAuthorNameLength)
+			if name == "" {
+				continue
+			}
+
+			var affiliations sql.NullString
+			if len(author.Affiliations) > 0 {
+				encoded, err := json.Marshal(author.Affiliations)
+				if err != nil {
+					return fmt.Errorf("failed to encode affiliations: %w", err)
+				}
+				affiliations = sql.NullString{String: string(encoded), Valid: true}
+			}
+
+			position++
+			rows = append(rows, []interface{}{paper.ID, position, affiliations, name})
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, []interface{}{name})
+			}
+		}
+	}
+
+	err := execChunked(ctx, tx, names, func(n int) string {
+		return `INSERT INTO authors (name) VALUES ` + repeatJoined("(?)", ", ", n) + ` ON DUPLICATE KEY UPDATE id = id`
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert authors: %w", err)
+	}
+
+	err = execChunked(ctx, tx, rows, func(n int) string {
+		return `
+			INSERT INTO paper_authors (paper_id, position, affiliations, author_id)
+			SELECT v.paper_id, v.position, v.affiliations, a.id
+			FROM (SELECT ? AS paper_id, ? AS position, ? AS affiliations, ? AS name` +
+			strings.Repeat(` UNION ALL SELECT ?, ?, ?, ?`, n-1) + `) v
+			JOIN authors a ON a.name = v.name`
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert paper authors: %w", err)
+	}
+	return nil
+}
+
+// deleteByPaper removes the rows of table belonging to papers.
+func deleteByPaper(ctx context.Context, tx *sql.Tx, table string, papers []*Paper) error {
+	ids := make([][]interface{}, len(papers))
+	for i, paper := range papers {
+		ids[i] = []interface{}{paper.ID}
+	}
+	return execChunked(ctx, tx, ids, func(n int) string {
+		return `DELETE FROM ` + table + ` WHERE paper_id IN (` + repeatJoined("?", ", ", n) + `)`
+	})
+}
+
+// execChunked runs the statement built by query for each chunk of at most
+// maxRowsPerStatement rows, passing the chunk's values in order.
+func execChunked(ctx context.Context, tx *sql.Tx, rows [][]interface{}, query func(n int) string) error {
+	for start := 0; start < len(rows); start += maxRowsPerStatement {
+		chunk := rows[start:min(start+maxRowsPerStatement, len(rows))]
+		var args []interface{}
+		for _, row := range chunk {
+			args = append(args, row...)
+		}
+		if _, err := tx.ExecContext(ctx, query(len(chunk)), args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repeatJoined returns n copies of s separated by sep.
+func repeatJoined(s, sep string, n int) string {
+	return strings.TrimSuffix(strings.Repeat(s+sep, n), sep)
+}
+
+// lastByID drops all but the last copy of each paper, keeping their order.
+func lastByID(papers []*Paper) []*Paper {
+	last := make(map[string]int, len(papers))
+	for i, paper := range papers {
+		last[paper.ID] = i
+	}
+	if len(last) == len(papers) {
+		return papers
+	}
+	unique := make([]*Paper, 0, len(last))
+	for i, paper := range papers {
+		if last[paper.ID] == i {
+			unique = append(unique, paper)
+		}
+	}
+	return unique
+}
+
+// queryPapers runs a papers query and loads the authors, categories and versions of the result.
+func (r *SQLRepository) queryPapers(ctx context.Context, query string, args ...interface{}) ([]*Paper, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query papers: %w", err)
+	}
+	defer rows.Close()
+
+	papers := make([]*Paper, 0)
+	for rows.Next() {
+		var paper Paper
+		var published, updated sql.NullTime
+		err := rows.Scan(
+			&paper.ID,
+			&paper.Version,
+			&paper.Title,
+			&paper.Summary,
+			&published,
+			&updated,
+			&paper.PrimaryCategory,
+			&paper.ArxivURL,
+			&paper.PDFURL,
+			&paper.ImageURL,
+			&paper.DOI,
+			&paper.JournalRef,
+			&paper.Comment,
+			&paper.License,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan paper: %w", err)
+		}
+		paper.Published = published.Time
+		paper.Updated = updated.Time
+		papers = append(papers, &paper)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate papers: %w", err)
+	}
+
+	if err := r.loadRelations(ctx, papers); err != nil {
+		return nil, err
+	}
+	return papers, nil
+}
+
+// loadRelations fills in authors, categories and versions with one query each.
+func (r *SQLRepository) loadRelations(ctx context.Context, papers []*Paper) error {
+	if len(papers) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*Paper, len(papers))
+	args := make([]interface{}, len(papers))
+	for i, paper := range papers {
+		byID[paper.ID] = paper
+		args[i] = paper.ID
+	}
+	in := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(papers)), ", ") + ")"
+
+	// Categories
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT paper_id, category FROM paper_categories WHERE paper_id IN `+in+` ORDER BY paper_id, position`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query paper categories: %w", err)
+	}
+	for rows.Next() {
+		var paperID, category string
+		if err := rows.Scan(&paperID, &category); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan paper category: %w", err)
+		}
+		byID[paperID].Categories = append(byID[paperID].Categories, category)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate paper categories: %w", err)
+	}
+
+	// Authors
+	rows, err = r.db.QueryContext(ctx, `
+		SELECT pa.paper_id, a.name, pa.affiliations
+		FROM paper_authors pa JOIN authors a ON a.id = pa.author_id
+		WHERE pa.paper_id IN `+in+`
+		ORDER BY pa.paper_id, pa.position`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query paper authors: %w", err)
+	}
+	for rows.Next() {
+		var paperID, name string
+		var affiliations sql.NullString
+		if err := rows.Scan(&paperID, &name, &affiliations); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan paper author: %w", err)
+		}
+		author := AuthorDetail{Name: name}
+		if affiliations.Valid {
+			_ = json.Unmarshal([]byte(affiliations.String), &author.Affiliations)
+		}
+		paper := byID[paperID]
+		paper.Authors = append(paper.Authors, name)
+		paper.AuthorDetails = append(paper.AuthorDetails, author)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate paper authors: %w", err)
+	}
+
+	// Versions
+	rows, err = r.db.QueryContext(ctx,
+		`SELECT paper_id, version, submitted FROM paper_versions WHERE paper_id IN `+in+` ORDER BY paper_id, version`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query paper versions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var paperID string
+		var version Version
+		var submitted sql.NullTime
+		if err := rows.Scan(&paperID, &version.Version, &submitted); err != nil {
+			return fmt.Errorf("failed to scan paper version: %w", err)
+		}
+		version.Submitted = submitted.Time
+		byID[paperID].Versions = append(byID[paperID].Versions, version)
+	}
+	return rows.Err()
+}
+
+// nullTime maps the zero time to NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,86 @@
+package paper
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/rrlian/papertok/backend/internal/infra/cache"
+)
+
+func newMockSQLRepository(t *testing.T) (*SQLRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewSQLRepository(db, cache.NewMemoryCache()), mock
+}
+
+func TestSQLRepository_Upsert_BatchesAuthors(t *testing.T) {
+	// Arrange
+	repo, mock := newMockSQLRepository(t)
+	papers := []*Paper{
+		{ID: "2401.00001", Authors: []string{"Alice", "Bob"}, Categories: []string{"cs.AI"}},
+		{ID: "2401.00002", Authors: []string{"Bob"}, Categories: []string{"cs.CL", "cs.AI", "cs.CL"}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO papers`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO papers`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM paper_categories WHERE paper_id IN (?, ?)`)).
+		WithArgs("2401.00001", "2401.00002").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO paper_categories (paper_id, category, position) VALUES (?, ?, ?), (?, ?, ?), (?, ?, ?)`)).
+		WithArgs("2401.00001", "cs.AI", 1, "2401.00002", "cs.CL", 1, "2401.00002", "cs.AI", 2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM paper_authors WHERE paper_id IN (?, ?)`)).
+		WithArgs("2401.00001", "2401.00002").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO authors (name) VALUES (?), (?) ON DUPLICATE KEY UPDATE`)).
+		WithArgs("Alice", "Bob").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`INSERT INTO paper_authors .* UNION ALL SELECT \?, \?, \?, \? UNION ALL SELECT \?, \?, \?, \?\) v`).
+		WithArgs(
+			"2401.00001", 1, sql.NullString{}, "Alice",
+			"2401.00001", 2, sql.NullString{}, "Bob",
+			"2401.00002", 1, sql.NullString{}, "Bob",
+		).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	// Act
+	err := repo.Upsert(context.Background(), papers)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Expected one statement per table, got: %v", err)
+	}
+}
+
+func TestSQLRepository_Upsert_RollsBackOnError(t *testing.T) {
+	// Arrange
+	repo, mock := newMockSQLRepository(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO papers`).WillReturnError(errors.New("table missing"))
+	mock.ExpectRollback()
+
+	// Act
+	err := repo.Upsert(context.Background(), []*Paper{{ID: "2401.00001"}})
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Expected a rollback, got: %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package searchhistory
+
+import "errors"
+
+// Common errors for search history repository operations.
+var (
+	// ErrEntryNotFound is returned when a history entry does not exist.
+	ErrEntryNotFound = errors.New("search history entry not found")
+
+	// ErrSavedNotFound is returned when a saved search does not exist.
+	ErrSavedNotFound = errors.New("saved search not found")
+
+	// ErrNameTaken is returned when a user already has a saved search with the name.
+	ErrNameTaken = errors.New("saved search name already taken")
+)
@@ -0,0 +1,78 @@
+package searchhistory
+
+import (
+	"context"
+	"time"
+)
+
+// Entry is a search a user has run. Repeats of the same search share an entry.
+type Entry struct {
+	ID          int64
+	UserID      int64
+	Key         string // Identifies the search among the user's entries
+	Params      string // Encoded query and filters, opaque to the repository
+	ResultCount int    // Matches on the latest run
+	SearchCount int    // Times the search was run
+	SearchedAt  time.Time
+}
+
+// SavedSearch is a named search kept by a user.
+type SavedSearch struct {
+	ID        int64
+	UserID    int64
+	Name      string // Unique per user
+	Params    string // Encoded query and filters, opaque to the repository
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Repository defines the interface for search history and saved search persistence.
+type Repository interface {
+	// Record adds a search to a user's history. If the user already has an
+	// entry with the same key, its search count is incremented and its
+	// params, result count and time are replaced. The stored entry is
+	// written back to entry.
+	Record(ctx context.Context, entry *Entry) error
+
+	// GetEntry retrieves a history entry by ID.
+	// Returns ErrEntryNotFound if there is none.
+	GetEntry(ctx context.Context, id int64) (*Entry, error)
+
+	// ListEntries returns a page of a user's history, most recent first,
+	// together with the user's total number of entries.
+	ListEntries(ctx context.Context, userID int64, offset, limit int) ([]*Entry, int, error)
+
+	// DeleteEntry removes a history entry.
+	// Returns ErrEntryNotFound if there is none.
+	DeleteEntry(ctx context.Context, id int64) error
+
+	// ClearEntries removes a user's whole history and returns how many
+	// entries there were.
+	ClearEntries(ctx context.Context, userID int64) (int, error)
+
+	// PruneEntries removes all but a user's keep most recent entries.
+	PruneEntries(ctx context.Context, userID int64, keep int) error
+
+	// CreateSaved stores a new saved search and sets its ID and timestamps.
+	// Returns ErrNameTaken if the user has a saved search with the same name.
+	CreateSaved(ctx context.Context, s *SavedSearch) error
+
+	// GetSaved retrieves a saved search by ID.
+	// Returns ErrSavedNotFound if there is none.
+	GetSaved(ctx context.Context, id int64) (*SavedSearch, error)
+
+	// ListSaved returns a user's saved searches, most recently created first.
+	ListSaved(ctx context.Context, userID int64) ([]*SavedSearch, error)
+
+	// ListAllSaved returns every user's saved searches, oldest first.
+	ListAllSaved(ctx context.Context) ([]*SavedSearch, error)
+
+	// RenameSaved saves a saved search's name and sets its update time.
+	// Returns ErrSavedNotFound if there is none, or ErrNameTaken if the
+	// user has another saved search with the name.
+	RenameSaved(ctx context.Context, s *SavedSearch) error
+
+	// DeleteSaved removes a saved search.
+	// Returns ErrSavedNotFound if there is none.
+	DeleteSaved(ctx context.Context, id int64) error
+}
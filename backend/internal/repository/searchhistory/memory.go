@@ -0,0 +1,252 @@
+package searchhistory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryRepository implements the Repository interface using in-memory storage.
+// This is primarily intended for testing and development.
+type MemoryRepository struct {
+	mu          sync.RWMutex
+	entries     map[int64]Entry
+	saved       map[int64]SavedSearch
+	nextEntryID int64
+	nextSavedID int64
+}
+
+// Ensure MemoryRepository implements Repository interface.
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new in-memory search history repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		entries: make(map[int64]Entry),
+		saved:   make(map[int64]SavedSearch),
+	}
+}
+
+// Record adds a search to a user's history.
+func (r *MemoryRepository) Record(ctx context.Context, entry *Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, e := range r.entries {
+		if e.UserID == entry.UserID && e.Key == entry.Key {
+			e.Params = entry.Params
+			e.ResultCount = entry.ResultCount
+			e.SearchedAt = entry.SearchedAt
+			e.SearchCount++
+			r.entries[id] = e
+			*entry = e
+			return nil
+		}
+	}
+
+	r.nextEntryID++
+	entry.ID = r.nextEntryID
+	entry.SearchCount = 1
+	r.entries[entry.ID] = *entry
+	return nil
+}
+
+// GetEntry retrieves a history entry by ID.
+func (r *MemoryRepository) GetEntry(ctx context.Context, id int64) (*Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, found := r.entries[id]
+	if !found {
+		return nil, ErrEntryNotFound
+	}
+	return &e, nil
+}
+
+// ListEntries returns a page of a user's history and its total size.
+func (r *MemoryRepository) ListEntries(ctx context.Context, userID int64, offset, limit int) ([]*Entry, int, error) {
+	r.mu.RLock()
+	all := r.userEntries(userID)
+	r.mu.RUnlock()
+
+	total := len(all)
+	if offset >= total {
+		return []*Entry{}, total, nil
+	}
+	end := total
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return all[offset:end], total, nil
+}
+
+// DeleteEntry removes a history entry.
+func (r *MemoryRepository) DeleteEntry(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.entries[id]; !found {
+		return ErrEntryNotFound
+	}
+	delete(r.entries, id)
+	return nil
+}
+
+// ClearEntries removes a user's whole history.
+func (r *MemoryRepository) ClearEntries(ctx context.Context, userID int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for id, e := range r.entries {
+		if e.UserID == userID {
+			delete(r.entries, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// PruneEntries removes all but a user's keep most recent entries.
+func (r *MemoryRepository) PruneEntries(ctx context.Context, userID int64, keep int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	all := r.userEntries(userID)
+	if len(all) <= keep {
+		return nil
+	}
+	for _, e := range all[keep:] {
+		delete(r.entries, e.ID)
+	}
+	return nil
+}
+
+// CreateSaved stores a new saved search.
+func (r *MemoryRepository) CreateSaved(ctx context.Context, s *SavedSearch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(s.UserID, s.Name, 0) {
+		return ErrNameTaken
+	}
+	r.nextSavedID++
+	now := time.Now()
+	s.ID = r.nextSavedID
+	s.CreatedAt = now
+	s.UpdatedAt = now
+	r.saved[s.ID] = *s
+	return nil
+}
+
+// GetSaved retrieves a saved search by ID.
+func (r *MemoryRepository) GetSaved(ctx context.Context, id int64) (*SavedSearch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, found := r.saved[id]
+	if !found {
+		return nil, ErrSavedNotFound
+	}
+	return &s, nil
+}
+
+// ListSaved returns a user's saved searches, most recently created first.
+func (r *MemoryRepository) ListSaved(ctx context.Context, userID int64) ([]*SavedSearch, error) {
+	r.mu.RLock()
+	saved := []*SavedSearch{}
+	for _, s := range r.saved {
+		if s.UserID == userID {
+			s := s
+			saved = append(saved, &s)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(saved, func(i, j int) bool {
+		if !saved[i].CreatedAt.Equal(saved[j].CreatedAt) {
+			return saved[i].CreatedAt.After(saved[j].CreatedAt)
+		}
+		return saved[i].ID > saved[j].ID
+	})
+	return saved, nil
+}
+
+// ListAllSaved returns every user's saved searches, oldest first.
+func (r *MemoryRepository) ListAllSaved(ctx context.Context) ([]*SavedSearch, error) {
+	r.mu.RLock()
+	saved := make([]*SavedSearch, 0, len(r.saved))
+	for _, s := range r.saved {
+		s := s
+		saved = append(saved, &s)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(saved, func(i, j int) bool { return saved[i].ID < saved[j].ID })
+	return saved, nil
+}
+
+// RenameSaved saves a saved search's name.
+func (r *MemoryRepository) RenameSaved(ctx context.Context, s *SavedSearch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, found := r.saved[s.ID]
+	if !found {
+		return ErrSavedNotFound
+	}
+	if r.nameTaken(stored.UserID, s.Name, s.ID) {
+		return ErrNameTaken
+	}
+	stored.Name = s.Name
+	stored.UpdatedAt = time.Now()
+	r.saved[s.ID] = stored
+	*s = stored
+	return nil
+}
+
+// DeleteSaved removes a saved search.
+func (r *MemoryRepository) DeleteSaved(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.saved[id]; !found {
+		return ErrSavedNotFound
+	}
+	delete(r.saved, id)
+	return nil
+}
+
+// userEntries returns copies of a user's entries, most recent first.
+// The caller must hold the lock.
+func (r *MemoryRepository) userEntries(userID int64) []*Entry {
+	all := []*Entry{}
+	for _, e := range r.entries {
+		if e.UserID == userID {
+			e := e
+			all = append(all, &e)
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].SearchedAt.Equal(all[j].SearchedAt) {
+			return all[i].SearchedAt.After(all[j].SearchedAt)
+		}
+		return all[i].ID > all[j].ID
+	})
+	return all
+}
+
+// nameTaken reports whether a user has a saved search other than exceptID
+// with the name, ignoring case like the SQL collation. The caller must hold
+// the lock.
+func (r *MemoryRepository) nameTaken(userID int64, name string, exceptID int64) bool {
+	for id, s := range r.saved {
+		if id != exceptID && s.UserID == userID && strings.EqualFold(s.Name, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,327 @@
+package searchhistory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rrlian/papertok/backend/internal/infra/database"
+)
+
+// SQLRepository implements the Repository interface using SQL database.
+type SQLRepository struct {
+	db database.DB
+}
+
+// Ensure SQLRepository implements Repository interface.
+var _ Repository = (*SQLRepository)(nil)
+
+// NewSQLRepository creates a new SQL-based search history repository.
+func NewSQLRepository(db database.DB) *SQLRepository {
+	return &SQLRepository{
+		db: db,
+	}
+}
+
+// entryColumns lists the columns scanned by scanEntry, in order.
+const entryColumns = `id, user_id, query_key, params, result_count, search_count, searched_at`
+
+// savedColumns lists the columns scanned by scanSaved, in order.
+const savedColumns = `id, user_id, name, params, created_at, updated_at`
+
+// Record adds a search to a user's history.
+func (r *SQLRepository) Record(ctx context.Context, entry *Entry) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO search_history (user_id, query_key, params, result_count, search_count, searched_at)
+		VALUES (?, ?, ?, ?, 1, ?)
+		ON DUPLICATE KEY UPDATE
+			params = VALUES(params),
+			result_count = VALUES(result_count),
+			search_count = search_count + 1,
+			searched_at = VALUES(searched_at)
+	`, entry.UserID, entry.Key, entry.Params, entry.ResultCount, entry.SearchedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record search: %w", err)
+	}
+
+	stored, err := scanEntry(r.db.QueryRowContext(ctx,
+		`SELECT `+entryColumns+` FROM search_history WHERE user_id = ? AND query_key = ?`,
+		entry.UserID, entry.Key,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to read recorded search: %w", err)
+	}
+	*entry = *stored
+	return nil
+}
+
+// GetEntry retrieves a history entry by ID.
+func (r *SQLRepository) GetEntry(ctx context.Context, id int64) (*Entry, error) {
+	entry, err := scanEntry(r.db.QueryRowContext(ctx,
+		`SELECT `+entryColumns+` FROM search_history WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrEntryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search history entry: %w", err)
+	}
+	return entry, nil
+}
+
+// ListEntries returns a page of a user's history and its total size.
+func (r *SQLRepository) ListEntries(ctx context.Context, userID int64, offset, limit int) ([]*Entry, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM search_history WHERE user_id = ?`, userID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count search history: %w", err)
+	}
+	if limit <= 0 {
+		limit = total
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+entryColumns+`
+		FROM search_history
+		WHERE user_id = ?
+		ORDER BY searched_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list search history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*Entry{}
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan search history entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate search history: %w", err)
+	}
+	return entries, total, nil
+}
+
+// DeleteEntry removes a history entry.
+func (r *SQLRepository) DeleteEntry(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM search_history WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete search history entry: %w", err)
+	}
+	return requireAffected(result, ErrEntryNotFound)
+}
+
+// ClearEntries removes a user's whole history.
+func (r *SQLRepository) ClearEntries(ctx context.Context, userID int64) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM search_history WHERE user_id = ?`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear search history: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear search history: %w", err)
+	}
+	return int(n), nil
+}
+
+// PruneEntries removes all but a user's keep most recent entries.
+func (r *SQLRepository) PruneEntries(ctx context.Context, userID int64, keep int) error {
+	// MySQL cannot select from the table being deleted from, so the
+	// entries to keep go through a derived table.
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM search_history
+		WHERE user_id = ? AND id NOT IN (
+			SELECT id FROM (
+				SELECT id FROM search_history
+				WHERE user_id = ?
+				ORDER BY searched_at DESC, id DESC
+				LIMIT ?
+			) AS kept
+		)
+	`, userID, userID, keep)
+	if err != nil {
+		return fmt.Errorf("failed to prune search history: %w", err)
+	}
+	return nil
+}
+
+// CreateSaved stores a new saved search.
+func (r *SQLRepository) CreateSaved(ctx context.Context, s *SavedSearch) error {
+	now := time.Now()
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO saved_searches (user_id, name, params, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, s.UserID, s.Name, s.Params, s.CreatedAt, s.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrNameTaken
+		}
+		return fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+	s.ID = id
+	return nil
+}
+
+// GetSaved retrieves a saved search by ID.
+func (r *SQLRepository) GetSaved(ctx context.Context, id int64) (*SavedSearch, error) {
+	s, err := scanSaved(r.db.QueryRowContext(ctx,
+		`SELECT `+savedColumns+` FROM saved_searches WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrSavedNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	return s, nil
+}
+
+// ListSaved returns a user's saved searches, most recently created first.
+func (r *SQLRepository) ListSaved(ctx context.Context, userID int64) ([]*SavedSearch, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+savedColumns+`
+		FROM saved_searches
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	saved := []*SavedSearch{}
+	for rows.Next() {
+		s, err := scanSaved(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved search: %w", err)
+		}
+		saved = append(saved, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate saved searches: %w", err)
+	}
+	return saved, nil
+}
+
+// ListAllSaved returns every user's saved searches, oldest first.
+func (r *SQLRepository) ListAllSaved(ctx context.Context) ([]*SavedSearch, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+savedColumns+`
+		FROM saved_searches
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	saved := []*SavedSearch{}
+	for rows.Next() {
+		s, err := scanSaved(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved search: %w", err)
+		}
+		saved = append(saved, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate saved searches: %w", err)
+	}
+	return saved, nil
+}
+
+// RenameSaved saves a saved search's name.
+func (r *SQLRepository) RenameSaved(ctx context.Context, s *SavedSearch) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE saved_searches SET name = ?, updated_at = ? WHERE id = ?`,
+		s.Name, time.Now(), s.ID,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrNameTaken
+		}
+		return fmt.Errorf("failed to rename saved search: %w", err)
+	}
+
+	// Re-read rather than trusting the affected rows; see collection.SQLRepository.Update.
+	stored, err := r.GetSaved(ctx, s.ID)
+	if err != nil {
+		return err
+	}
+	*s = *stored
+	return nil
+}
+
+// DeleteSaved removes a saved search.
+func (r *SQLRepository) DeleteSaved(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM saved_searches WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	return requireAffected(result, ErrSavedNotFound)
+}
+
+// requireAffected returns notFound if a statement changed no rows.
+func requireAffected(result sql.Result, notFound error) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEntry scans a row selected with entryColumns.
+func scanEntry(row scanner) (*Entry, error) {
+	var e Entry
+	err := row.Scan(
+		&e.ID,
+		&e.UserID,
+		&e.Key,
+		&e.Params,
+		&e.ResultCount,
+		&e.SearchCount,
+		&e.SearchedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// scanSaved scans a row selected with savedColumns.
+func scanSaved(row scanner) (*SavedSearch, error) {
+	var s SavedSearch
+	err := row.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.Name,
+		&s.Params,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -11,7 +11,7 @@ import (
 
 // SQLRepository implements the Repository interface using SQL database.
 type SQLRepository struct {
-	db database.Executor
+	db database.DB
 }
 
 // Ensure SQLRepository implements Repository interface.
@@ -59,6 +59,44 @@ func (r *SQLRepository) Create(ctx context.Context, user *User) error {
 	return nil
 }
 
+// Delete removes a user and, through foreign key cascades, everything they own.
+// MySQL does not fire triggers for cascaded deletes, so the like counts of the
+// papers the user liked are decremented here, in the same transaction.
+func (r *SQLRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE paper_like_counts c
+		JOIN likes l ON l.paper_id = c.paper_id
+		SET c.like_count = c.like_count - 1
+		WHERE l.user_id = ? AND c.like_count > 0
+	`
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to decrement like counts: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrUserNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user deletion: %w", err)
+	}
+	return nil
+}
+
 // FindByEmail retrieves a user by email address.
 func (r *SQLRepository) FindByEmail(ctx context.Context, email string) (*User, error) {
 	query := `